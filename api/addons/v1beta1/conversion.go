@@ -26,30 +26,106 @@ import (
 
 	addonsv1 "sigs.k8s.io/cluster-api/api/addons/v1beta2"
 	clusterv1beta1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
+	utilconversion "sigs.k8s.io/cluster-api/util/conversion"
 )
 
 func (src *ClusterResourceSet) ConvertTo(dstRaw conversion.Hub) error {
 	dst := dstRaw.(*addonsv1.ClusterResourceSet)
 
-	return Convert_v1beta1_ClusterResourceSet_To_v1beta2_ClusterResourceSet(src, dst, nil)
+	if err := Convert_v1beta1_ClusterResourceSet_To_v1beta2_ClusterResourceSet(src, dst, nil); err != nil {
+		return err
+	}
+
+	restored := &addonsv1.ClusterResourceSet{}
+	ok, err := utilconversion.UnmarshalData(src, restored)
+	if err != nil {
+		return err
+	}
+
+	// Recover other values.
+	if ok {
+		dst.Spec.Prune = restored.Spec.Prune
+		dst.Spec.DriftCorrectionIntervalSeconds = restored.Spec.DriftCorrectionIntervalSeconds
+		dst.Spec.TemplateEngine = restored.Spec.TemplateEngine
+		dst.Spec.WaitForHealthy = restored.Spec.WaitForHealthy
+		dst.Spec.DependsOn = restored.Spec.DependsOn
+	}
+
+	return nil
 }
 
 func (dst *ClusterResourceSet) ConvertFrom(srcRaw conversion.Hub) error {
 	src := srcRaw.(*addonsv1.ClusterResourceSet)
 
-	return Convert_v1beta2_ClusterResourceSet_To_v1beta1_ClusterResourceSet(src, dst, nil)
+	if err := Convert_v1beta2_ClusterResourceSet_To_v1beta1_ClusterResourceSet(src, dst, nil); err != nil {
+		return err
+	}
+
+	return utilconversion.MarshalData(src, dst)
 }
 
 func (src *ClusterResourceSetBinding) ConvertTo(dstRaw conversion.Hub) error {
 	dst := dstRaw.(*addonsv1.ClusterResourceSetBinding)
 
-	return Convert_v1beta1_ClusterResourceSetBinding_To_v1beta2_ClusterResourceSetBinding(src, dst, nil)
+	if err := Convert_v1beta1_ClusterResourceSetBinding_To_v1beta2_ClusterResourceSetBinding(src, dst, nil); err != nil {
+		return err
+	}
+
+	restored := &addonsv1.ClusterResourceSetBinding{}
+	ok, err := utilconversion.UnmarshalData(src, restored)
+	if err != nil {
+		return err
+	}
+
+	// Recover other values.
+	if ok {
+		RestoreResourceBindingObjects(restored.Spec.Bindings, dst.Spec.Bindings)
+		dst.Status = restored.Status
+	}
+
+	return nil
 }
 
 func (dst *ClusterResourceSetBinding) ConvertFrom(srcRaw conversion.Hub) error {
 	src := srcRaw.(*addonsv1.ClusterResourceSetBinding)
 
-	return Convert_v1beta2_ClusterResourceSetBinding_To_v1beta1_ClusterResourceSetBinding(src, dst, nil)
+	if err := Convert_v1beta2_ClusterResourceSetBinding_To_v1beta1_ClusterResourceSetBinding(src, dst, nil); err != nil {
+		return err
+	}
+
+	return utilconversion.MarshalData(src, dst)
+}
+
+// RestoreResourceBindingObjects restores the objects, the drift and the health of the resources in dst from the restored bindings.
+// Note: objects are restored only for bindings and resources that still exist in dst.
+// Note: RestoreResourceBindingObjects is also used by the conversions of older API versions.
+func RestoreResourceBindingObjects(restored, dst []addonsv1.ResourceSetBinding) {
+	for i := range dst {
+		for _, restoredBinding := range restored {
+			if restoredBinding.ClusterResourceSetName != dst[i].ClusterResourceSetName {
+				continue
+			}
+			for j := range dst[i].Resources {
+				if restoredResource := restoredBinding.GetResource(dst[i].Resources[j].ResourceRef); restoredResource != nil {
+					dst[i].Resources[j].Objects = restoredResource.Objects
+					dst[i].Resources[j].DriftedObjects = restoredResource.DriftedObjects
+					dst[i].Resources[j].LastDriftCheckTime = restoredResource.LastDriftCheckTime
+					dst[i].Resources[j].Healthy = restoredResource.Healthy
+					dst[i].Resources[j].UnhealthyObjects = restoredResource.UnhealthyObjects
+				}
+			}
+		}
+	}
+}
+
+func Convert_v1beta2_ClusterResourceSetBinding_To_v1beta1_ClusterResourceSetBinding(in *addonsv1.ClusterResourceSetBinding, out *ClusterResourceSetBinding, s apimachineryconversion.Scope) error {
+	// Status does not exist in v1beta1.
+	return autoConvert_v1beta2_ClusterResourceSetBinding_To_v1beta1_ClusterResourceSetBinding(in, out, s)
+}
+
+func Convert_v1beta2_ClusterResourceSetSpec_To_v1beta1_ClusterResourceSetSpec(in *addonsv1.ClusterResourceSetSpec, out *ClusterResourceSetSpec, s apimachineryconversion.Scope) error {
+	// Prune, DriftCorrectionIntervalSeconds, TemplateEngine, WaitForHealthy and DependsOn do not exist in v1beta1.
+	return autoConvert_v1beta2_ClusterResourceSetSpec_To_v1beta1_ClusterResourceSetSpec(in, out, s)
 }
 
 func Convert_v1beta2_ClusterResourceSetStatus_To_v1beta1_ClusterResourceSetStatus(in *addonsv1.ClusterResourceSetStatus, out *ClusterResourceSetStatus, s apimachineryconversion.Scope) error {
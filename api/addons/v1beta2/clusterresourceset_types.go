@@ -39,6 +39,18 @@ const (
 	// ClusterResourceSetResourcesAppliedWrongSecretTypeReason is the reason used when the Secret's type in the resource list is not supported.
 	ClusterResourceSetResourcesAppliedWrongSecretTypeReason = "WrongSecretType"
 
+	// ClusterResourceSetResourcesAppliedWaitingForDependenciesReason is the reason used when the ClusterResourceSets in dependsOn
+	// are not applied yet to one of the matching clusters.
+	ClusterResourceSetResourcesAppliedWaitingForDependenciesReason = "WaitingForDependencies"
+
+	// ClusterResourceSetResourcesAppliedInvalidDependenciesReason is the reason used when the ClusterResourceSets in dependsOn
+	// can never be applied, e.g. because they do not exist or because ClusterResourceSets depend on each other in a cycle.
+	ClusterResourceSetResourcesAppliedInvalidDependenciesReason = "InvalidDependencies"
+
+	// ClusterResourceSetResourcesAppliedWaitingForHealthyReason is the reason used when the objects applied to one of the
+	// matching clusters are not healthy yet and waitForHealthy is enabled.
+	ClusterResourceSetResourcesAppliedWaitingForHealthyReason = "WaitingForHealthy"
+
 	// ClusterResourceSetResourcesAppliedInternalErrorReason surfaces unexpected failures when reconciling a ClusterResourceSet.
 	ClusterResourceSetResourcesAppliedInternalErrorReason = clusterv1.InternalErrorReason
 )
@@ -71,8 +83,69 @@ type ClusterResourceSetSpec struct {
 	// +kubebuilder:validation:Enum=ApplyOnce;Reconcile
 	// +optional
 	Strategy string `json:"strategy,omitempty"`
+
+	// prune defines if objects applied to a Cluster by this ClusterResourceSet should be deleted from the Cluster
+	// when they are removed from the referenced ConfigMaps/Secrets or when the reference to the ConfigMap/Secret
+	// is removed from resources.
+	// Applied objects are tracked in the ClusterResourceSetBinding of the Cluster.
+	// prune can only be set if strategy is Reconcile. Defaults to false.
+	// +optional
+	Prune *bool `json:"prune,omitempty"`
+
+	// driftCorrectionIntervalSeconds is the interval at which objects applied to a Cluster by this ClusterResourceSet
+	// are checked for drift, i.e. for changes or deletions in the Cluster; drifted objects are reported in the
+	// ClusterResourceSetBinding of the Cluster and re-applied, forcing ownership of the fields managed by this ClusterResourceSet.
+	// If not set, drift is neither checked nor corrected, and applying objects fails if fields managed by this
+	// ClusterResourceSet have been taken over by other field managers.
+	// driftCorrectionIntervalSeconds can only be set if strategy is Reconcile.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	DriftCorrectionIntervalSeconds *int32 `json:"driftCorrectionIntervalSeconds,omitempty"`
+
+	// templateEngine defines the engine used to render the data of the referenced ConfigMaps/Secrets with values
+	// from the Cluster before applying it to the Cluster, so the same ClusterResourceSet can be used for many Clusters.
+	// If not set, the data of the referenced ConfigMaps/Secrets is applied verbatim.
+	// The only supported engine is GoTemplate; templates are rendered with sprig functions and the same variables
+	// available in ClusterClass inline patches, e.g. {{ .builtin.cluster.name }}, {{ .builtin.cluster.metadata.labels }},
+	// {{ .builtin.cluster.network.pods }} or variables from Cluster.spec.topology.variables.
+	// Rendering fails if a template refers to a variable that is not defined for the Cluster.
+	// +optional
+	// +kubebuilder:validation:Enum=GoTemplate
+	TemplateEngine ClusterResourceSetTemplateEngine `json:"templateEngine,omitempty"`
+
+	// waitForHealthy when true makes the ClusterResourceSet check the health of the objects applied to the Cluster,
+	// i.e. that Deployments and DaemonSets are available and that CustomResourceDefinitions are established.
+	// The health of the objects is surfaced in the ClusterResourceSetBinding of the Cluster and in the
+	// ResourcesHealthy condition of the Cluster; the ResourcesApplied condition of the ClusterResourceSet
+	// is true only once the objects applied to all the matching Clusters are healthy.
+	// Note: applied in the ClusterResourceSetBinding only tracks if the objects have been applied, independent of their health.
+	// Defaults to false.
+	// +optional
+	WaitForHealthy *bool `json:"waitForHealthy,omitempty"`
+
+	// dependsOn is a list of names of ClusterResourceSets in the same namespace that must be applied to a Cluster
+	// before this ClusterResourceSet is applied to the same Cluster, e.g. to apply CustomResourceDefinitions
+	// before the corresponding custom resources.
+	// If a ClusterResourceSet in dependsOn has waitForHealthy enabled, the objects it applies to the Cluster
+	// must also be healthy.
+	// ClusterResourceSets in dependsOn that do not match the Cluster are ignored.
+	// ClusterResourceSets in dependsOn must exist and must not depend on each other in a cycle.
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=100
+	// +kubebuilder:validation:items:MinLength=1
+	// +kubebuilder:validation:items:MaxLength=253
+	DependsOn []string `json:"dependsOn,omitempty"`
 }
 
+// ClusterResourceSetTemplateEngine is a string representation of a ClusterResourceSet template engine.
+type ClusterResourceSetTemplateEngine string
+
+const (
+	// ClusterResourceSetTemplateEngineGoTemplate renders the data of the resources of a ClusterResourceSet as Go templates.
+	ClusterResourceSetTemplateEngineGoTemplate ClusterResourceSetTemplateEngine = "GoTemplate"
+)
+
 // ClusterResourceSetResourceKind is a string representation of a ClusterResourceSet resource kind.
 type ClusterResourceSetResourceKind string
 
@@ -104,7 +177,7 @@ const (
 	// ClusterResourceSet controller after being created if not specified by user.
 	ClusterResourceSetStrategyApplyOnce ClusterResourceSetStrategy = "ApplyOnce"
 	// ClusterResourceSetStrategyReconcile reapplies the resources managed by a ClusterResourceSet
-	// if their normalized hash changes.
+	// if their normalized hash changes, using server-side apply with a field manager dedicated to the ClusterResourceSet.
 	ClusterResourceSetStrategyReconcile ClusterResourceSetStrategy = "Reconcile"
 )
 
@@ -23,6 +23,21 @@ import (
 	"k8s.io/utils/ptr"
 )
 
+// ClusterResourceSetBinding's Drifted condition and corresponding reasons.
+const (
+	// ClusterResourceSetBindingDriftedCondition surfaces whether objects applied to the Cluster by ClusterResourceSets
+	// with strategy Reconcile have been changed or deleted in the Cluster after being applied.
+	ClusterResourceSetBindingDriftedCondition = "Drifted"
+
+	// ClusterResourceSetBindingDriftedReason surfaces when at least one object applied to the Cluster by a
+	// ClusterResourceSet has been changed or deleted in the Cluster.
+	ClusterResourceSetBindingDriftedReason = "Drifted"
+
+	// ClusterResourceSetBindingNotDriftedReason surfaces when no object applied to the Cluster by ClusterResourceSets
+	// has been changed or deleted in the Cluster.
+	ClusterResourceSetBindingNotDriftedReason = "NotDrifted"
+)
+
 // ResourceBinding shows the status of a resource that belongs to a ClusterResourceSet matched by the owner cluster of the ClusterResourceSetBinding object.
 type ResourceBinding struct {
 	// ResourceRef specifies a resource.
@@ -42,6 +57,66 @@ type ResourceBinding struct {
 	// applied is to track if a resource is applied to the cluster or not.
 	// +required
 	Applied *bool `json:"applied,omitempty"`
+
+	// objects is the list of objects applied to the cluster from this resource.
+	// objects is only tracked if prune is enabled in the ClusterResourceSet.
+	// +optional
+	// +listType=atomic
+	// +kubebuilder:validation:MaxItems=1000
+	Objects []ResourceBindingObject `json:"objects,omitempty"`
+
+	// driftedObjects is the list of objects applied to the cluster from this resource that have been changed
+	// or deleted in the cluster after being applied.
+	// driftedObjects is only tracked if driftCorrectionIntervalSeconds is set in the ClusterResourceSet.
+	// +optional
+	// +listType=atomic
+	// +kubebuilder:validation:MaxItems=1000
+	DriftedObjects []ResourceBindingObject `json:"driftedObjects,omitempty"`
+
+	// lastDriftCheckTime identifies when the objects applied to the cluster from this resource were last checked for drift.
+	// lastDriftCheckTime is only tracked if driftCorrectionIntervalSeconds is set in the ClusterResourceSet.
+	// +optional
+	LastDriftCheckTime metav1.Time `json:"lastDriftCheckTime,omitempty,omitzero"`
+
+	// healthy is true if all the Deployments, DaemonSets and CustomResourceDefinitions applied to the cluster
+	// from this resource are healthy.
+	// healthy is only tracked if waitForHealthy is enabled in the ClusterResourceSet.
+	// +optional
+	Healthy *bool `json:"healthy,omitempty"`
+
+	// unhealthyObjects is the list of objects applied to the cluster from this resource that are not healthy.
+	// unhealthyObjects is only tracked if waitForHealthy is enabled in the ClusterResourceSet.
+	// +optional
+	// +listType=atomic
+	// +kubebuilder:validation:MaxItems=1000
+	UnhealthyObjects []ResourceBindingObject `json:"unhealthyObjects,omitempty"`
+}
+
+// ResourceBindingObject is a reference to an object applied to the cluster.
+type ResourceBindingObject struct {
+	// apiVersion of the object.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=317
+	APIVersion string `json:"apiVersion,omitempty"`
+
+	// kind of the object.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	Kind string `json:"kind,omitempty"`
+
+	// namespace of the object, it is empty for cluster-scoped objects.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	Namespace string `json:"namespace,omitempty"`
+
+	// name of the object.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Name string `json:"name,omitempty"`
 }
 
 // ResourceSetBinding keeps info on all of the resources in a ClusterResourceSet.
@@ -87,6 +162,16 @@ func (r *ResourceSetBinding) SetBinding(resourceBinding ResourceBinding) {
 	r.Resources = append(r.Resources, resourceBinding)
 }
 
+// RemoveResource removes the ResourceBinding for a resource ref if present.
+func (r *ResourceSetBinding) RemoveResource(resourceRef ResourceRef) {
+	for i := range r.Resources {
+		if reflect.DeepEqual(r.Resources[i].ResourceRef, resourceRef) {
+			r.Resources = append(r.Resources[:i], r.Resources[i+1:]...)
+			return
+		}
+	}
+}
+
 // GetOrCreateBinding returns the ResourceSetBinding for a given ClusterResourceSet if exists,
 // otherwise creates one and updates ClusterResourceSet with it.
 func (c *ClusterResourceSetBinding) GetOrCreateBinding(clusterResourceSet *ClusterResourceSet) *ResourceSetBinding {
@@ -113,8 +198,10 @@ func (c *ClusterResourceSetBinding) RemoveBinding(clusterResourceSet *ClusterRes
 
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:path=clusterresourcesetbindings,scope=Namespaced,categories=cluster-api
+// +kubebuilder:subresource:status
 // +kubebuilder:storageversion
 // +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.clusterName",description="Cluster"
+// +kubebuilder:printcolumn:name="Drifted",type="string",JSONPath=`.status.conditions[?(@.type=="Drifted")].status`,description="Objects changed in the Cluster after being applied",priority=10
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Time duration since creation of ClusterResourceSetBinding"
 
 // ClusterResourceSetBinding lists all matching ClusterResourceSets with the cluster it belongs to.
@@ -127,6 +214,9 @@ type ClusterResourceSetBinding struct {
 	// spec is the desired state of ClusterResourceSetBinding.
 	// +required
 	Spec ClusterResourceSetBindingSpec `json:"spec,omitempty,omitzero"`
+	// status is the observed state of ClusterResourceSetBinding.
+	// +optional
+	Status ClusterResourceSetBindingStatus `json:"status,omitempty,omitzero"`
 }
 
 // ClusterResourceSetBindingSpec defines the desired state of ClusterResourceSetBinding.
@@ -144,6 +234,28 @@ type ClusterResourceSetBindingSpec struct {
 	ClusterName string `json:"clusterName,omitempty"`
 }
 
+// ClusterResourceSetBindingStatus defines the observed state of ClusterResourceSetBinding.
+// +kubebuilder:validation:MinProperties=1
+type ClusterResourceSetBindingStatus struct {
+	// conditions represents the observations of a ClusterResourceSetBinding's current state.
+	// Known condition types are Drifted.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +kubebuilder:validation:MaxItems=32
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// GetConditions returns the set of conditions for this object.
+func (c *ClusterResourceSetBinding) GetConditions() []metav1.Condition {
+	return c.Status.Conditions
+}
+
+// SetConditions sets conditions for an API object.
+func (c *ClusterResourceSetBinding) SetConditions(conditions []metav1.Condition) {
+	c.Status.Conditions = conditions
+}
+
 // +kubebuilder:object:root=true
 
 // ClusterResourceSetBindingList contains a list of ClusterResourceSetBinding.
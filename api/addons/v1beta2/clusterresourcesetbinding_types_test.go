@@ -217,3 +217,54 @@ func TestSetResourceBinding(t *testing.T) {
 		})
 	}
 }
+
+func TestRemoveResource(t *testing.T) {
+	resourceRef1 := ResourceRef{
+		Name: "resource1",
+		Kind: "Secret",
+	}
+	resourceRef2 := ResourceRef{
+		Name: "resource2",
+		Kind: "ConfigMap",
+	}
+
+	tests := []struct {
+		name              string
+		resourceRef       ResourceRef
+		expectedResources []ResourceRef
+	}{
+		{
+			name:              "should remove the resource binding if it exists",
+			resourceRef:       resourceRef1,
+			expectedResources: []ResourceRef{resourceRef2},
+		},
+		{
+			name: "should be a no-op if the resource binding does not exist",
+			resourceRef: ResourceRef{
+				Name: "notExist",
+				Kind: "Secret",
+			},
+			expectedResources: []ResourceRef{resourceRef1, resourceRef2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			resourceSetBinding := &ResourceSetBinding{
+				ClusterResourceSetName: "test-clusterResourceSet",
+				Resources: []ResourceBinding{
+					{ResourceRef: resourceRef1, Applied: ptr.To(true)},
+					{ResourceRef: resourceRef2, Applied: ptr.To(true)},
+				},
+			}
+			resourceSetBinding.RemoveResource(tt.resourceRef)
+
+			resources := []ResourceRef{}
+			for _, r := range resourceSetBinding.Resources {
+				resources = append(resources, r.ResourceRef)
+			}
+			g.Expect(resources).To(Equal(tt.expectedResources))
+		})
+	}
+}
@@ -38,4 +38,16 @@ const (
 
 	// WrongSecretTypeV1Beta1Reason (Severity=Warning) documents at least one of the Secret's type in the resource list is not supported.
 	WrongSecretTypeV1Beta1Reason = "WrongSecretType"
+
+	// WaitingForDependenciesV1Beta1Reason (Severity=Info) documents the ClusterResourceSets in dependsOn are not applied yet
+	// to one of the matching clusters.
+	WaitingForDependenciesV1Beta1Reason = "WaitingForDependencies"
+
+	// InvalidDependenciesV1Beta1Reason (Severity=Warning) documents the ClusterResourceSets in dependsOn can never be applied,
+	// e.g. because they do not exist or because ClusterResourceSets depend on each other in a cycle.
+	InvalidDependenciesV1Beta1Reason = "InvalidDependencies"
+
+	// WaitingForHealthyV1Beta1Reason (Severity=Info) documents the objects applied to one of the matching clusters
+	// are not healthy yet and waitForHealthy is enabled.
+	WaitingForHealthyV1Beta1Reason = "WaitingForHealthy"
 )
@@ -59,6 +59,7 @@ func (in *ClusterResourceSetBinding) DeepCopyInto(out *ClusterResourceSetBinding
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterResourceSetBinding.
@@ -133,6 +134,28 @@ func (in *ClusterResourceSetBindingSpec) DeepCopy() *ClusterResourceSetBindingSp
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterResourceSetBindingStatus) DeepCopyInto(out *ClusterResourceSetBindingStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterResourceSetBindingStatus.
+func (in *ClusterResourceSetBindingStatus) DeepCopy() *ClusterResourceSetBindingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterResourceSetBindingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterResourceSetDeprecatedStatus) DeepCopyInto(out *ClusterResourceSetDeprecatedStatus) {
 	*out = *in
@@ -194,6 +217,26 @@ func (in *ClusterResourceSetSpec) DeepCopyInto(out *ClusterResourceSetSpec) {
 		*out = make([]ResourceRef, len(*in))
 		copy(*out, *in)
 	}
+	if in.Prune != nil {
+		in, out := &in.Prune, &out.Prune
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DriftCorrectionIntervalSeconds != nil {
+		in, out := &in.DriftCorrectionIntervalSeconds, &out.DriftCorrectionIntervalSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.WaitForHealthy != nil {
+		in, out := &in.WaitForHealthy, &out.WaitForHealthy
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterResourceSetSpec.
@@ -265,6 +308,27 @@ func (in *ResourceBinding) DeepCopyInto(out *ResourceBinding) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Objects != nil {
+		in, out := &in.Objects, &out.Objects
+		*out = make([]ResourceBindingObject, len(*in))
+		copy(*out, *in)
+	}
+	if in.DriftedObjects != nil {
+		in, out := &in.DriftedObjects, &out.DriftedObjects
+		*out = make([]ResourceBindingObject, len(*in))
+		copy(*out, *in)
+	}
+	in.LastDriftCheckTime.DeepCopyInto(&out.LastDriftCheckTime)
+	if in.Healthy != nil {
+		in, out := &in.Healthy, &out.Healthy
+		*out = new(bool)
+		**out = **in
+	}
+	if in.UnhealthyObjects != nil {
+		in, out := &in.UnhealthyObjects, &out.UnhealthyObjects
+		*out = make([]ResourceBindingObject, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceBinding.
@@ -277,6 +341,21 @@ func (in *ResourceBinding) DeepCopy() *ResourceBinding {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceBindingObject) DeepCopyInto(out *ResourceBindingObject) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceBindingObject.
+func (in *ResourceBindingObject) DeepCopy() *ResourceBindingObject {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceBindingObject)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceRef) DeepCopyInto(out *ResourceRef) {
 	*out = *in
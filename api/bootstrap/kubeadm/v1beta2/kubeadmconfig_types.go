@@ -39,6 +39,14 @@ const (
 	Ignition Format = "ignition"
 )
 
+const (
+	// EtcdRestoreAnnotation is the annotation the KubeadmControlPlane controller sets on the KubeadmConfig of the Machine
+	// restoring etcd from a snapshot; the annotation value is the name of the snapshot.
+	// KubeadmConfigs with this annotation are bootstrapped using kubeadm init, even if the control plane of the
+	// Cluster is already initialized.
+	EtcdRestoreAnnotation = "bootstrap.cluster.x-k8s.io/etcd-restore"
+)
+
 var (
 	cannotUseWithIgnition                            = fmt.Sprintf("not supported when spec.format is set to: %q", Ignition)
 	conflictingFileSourceMsg                         = "only one of content or contentFrom may be specified for a single file"
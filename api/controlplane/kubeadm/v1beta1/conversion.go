@@ -78,6 +78,12 @@ func (src *KubeadmControlPlane) ConvertTo(dstRaw conversion.Hub) error {
 	// Recover other values
 	if ok {
 		bootstrapv1beta1.RestoreKubeadmConfigSpec(&restored.Spec.KubeadmConfigSpec, &dst.Spec.KubeadmConfigSpec)
+
+		dst.Spec.Etcd = restored.Spec.Etcd
+		dst.Status.Etcd = restored.Status.Etcd
+		dst.Spec.CARotation = restored.Spec.CARotation
+		dst.Status.CARotation = restored.Status.CARotation
+		dst.Spec.Rollout.Before.CertificatesRenewalStrategy = restored.Spec.Rollout.Before.CertificatesRenewalStrategy
 	}
 
 	if src.Spec.RemediationStrategy != nil {
@@ -134,6 +140,8 @@ func (src *KubeadmControlPlaneTemplate) ConvertTo(dstRaw conversion.Hub) error {
 	// Recover other values
 	if ok {
 		bootstrapv1beta1.RestoreKubeadmConfigSpec(&restored.Spec.Template.Spec.KubeadmConfigSpec, &dst.Spec.Template.Spec.KubeadmConfigSpec)
+
+		dst.Spec.Template.Spec.Rollout.Before.CertificatesRenewalStrategy = restored.Spec.Template.Spec.Rollout.Before.CertificatesRenewalStrategy
 	}
 
 	if src.Spec.Template.Spec.RemediationStrategy != nil {
@@ -35,6 +35,20 @@ const (
 	RollingUpdateStrategyType KubeadmControlPlaneRolloutStrategyType = "RollingUpdate"
 )
 
+// KubeadmControlPlaneCertificatesRenewalStrategy defines how certificates of control plane Machines are renewed
+// when they are about to expire.
+// +kubebuilder:validation:Enum=Rollout;InPlace
+type KubeadmControlPlaneCertificatesRenewalStrategy string
+
+const (
+	// RolloutCertificatesRenewalStrategy renews certificates by replacing control plane Machines with new ones.
+	RolloutCertificatesRenewalStrategy KubeadmControlPlaneCertificatesRenewalStrategy = "Rollout"
+
+	// InPlaceCertificatesRenewalStrategy renews certificates in-place, without replacing control plane Machines,
+	// via the extension implementing the UpdateMachine hook.
+	InPlaceCertificatesRenewalStrategy KubeadmControlPlaneCertificatesRenewalStrategy = "InPlace"
+)
+
 const (
 	// KubeadmControlPlaneFinalizer is the finalizer applied to KubeadmControlPlane resources
 	// by its managing controller.
@@ -65,6 +79,29 @@ const (
 	// ensure it runs last (thus ensuring that kubelet is still working while other pre-terminate hooks run).
 	PreTerminateHookCleanupAnnotation = clusterv1.PreTerminateDeleteHookAnnotationPrefix + "/kcp-cleanup"
 
+	// EtcdSnapshotLabel is the label KCP sets on Secrets storing etcd snapshots when using the Secret
+	// snapshot storage type; the label value is the name of the KubeadmControlPlane.
+	EtcdSnapshotLabel = "controlplane.cluster.x-k8s.io/etcd-snapshot"
+
+	// EtcdRestoreConfirmedAnnotation is the annotation users must set on the KubeadmControlPlane to confirm that KCP
+	// can delete all the control plane Machines to restore etcd from a snapshot, skipping drain, wait for volume detach
+	// and the KCP pre-terminate hook; the annotation value must be the name of the snapshot in spec.etcd.restore.
+	EtcdRestoreConfirmedAnnotation = "controlplane.cluster.x-k8s.io/etcd-restore-confirmed"
+
+	// RenewCertificatesAnnotation is the annotation KCP sets on the KubeadmConfig of a Machine being updated in-place
+	// to signal to the extension implementing the UpdateMachine hook that the certificates of the Machine are about to
+	// expire and must be renewed, e.g. by running `kubeadm certs renew all` and restarting control plane components.
+	// In the CanUpdateMachine request the annotation is set only on the desired KubeadmConfig, and the extension
+	// must patch it into the current KubeadmConfig to report that it can renew certificates.
+	RenewCertificatesAnnotation = "controlplane.cluster.x-k8s.io/renew-certificates"
+
+	// DefaultEtcdSnapshotRetention defines the default number of etcd snapshots to keep in the storage.
+	DefaultEtcdSnapshotRetention = int32(3)
+
+	// DefaultEtcdMinFragmentationPercent defines the default minimum percentage of the database size of an etcd member
+	// not in use which triggers defragmentation of the member.
+	DefaultEtcdMinFragmentationPercent = int32(50)
+
 	// DefaultMinHealthyPeriodSeconds defines the default minimum period before we consider a remediation on a
 	// machine unrelated from the previous remediation.
 	DefaultMinHealthyPeriodSeconds = int32(60 * 60)
@@ -244,6 +281,54 @@ const (
 	KubeadmControlPlaneNotRollingOutReason = clusterv1.NotRollingOutReason
 )
 
+// KubeadmControlPlane's CARotating condition and corresponding reasons.
+const (
+	// KubeadmControlPlaneCARotatingCondition is true while the certificate authorities of the cluster are being rotated.
+	// Note: The current phase of the rotation and the Machines that must be rolled out before moving to the next phase
+	// surface in the condition message, as well as the MachinePools blocking the rotation and the time to wait
+	// for service account tokens to be refreshed before removing the old service account key.
+	KubeadmControlPlaneCARotatingCondition = "CARotating"
+
+	// KubeadmControlPlaneCARotatingReason surfaces when the certificate authorities of the cluster are being rotated.
+	KubeadmControlPlaneCARotatingReason = "Rotating"
+
+	// KubeadmControlPlaneCANotRotatingReason surfaces when the certificate authorities of the cluster are not being rotated.
+	KubeadmControlPlaneCANotRotatingReason = "NotRotating"
+
+	// KubeadmControlPlaneCARotatingInternalErrorReason surfaces unexpected failures when rotating the certificate authorities.
+	KubeadmControlPlaneCARotatingInternalErrorReason = clusterv1.InternalErrorReason
+)
+
+// KubeadmControlPlane's EtcdSnapshotSucceeded condition and corresponding reasons.
+const (
+	// KubeadmControlPlaneEtcdSnapshotSucceededCondition is true if the last etcd snapshot has been taken and stored successfully.
+	// Note: This condition is set only if etcd snapshots are enabled; failures taking etcd snapshots do not block other operations.
+	KubeadmControlPlaneEtcdSnapshotSucceededCondition = "EtcdSnapshotSucceeded"
+
+	// KubeadmControlPlaneEtcdSnapshotSucceededReason surfaces when the last etcd snapshot has been taken and stored successfully.
+	KubeadmControlPlaneEtcdSnapshotSucceededReason = "Succeeded"
+
+	// KubeadmControlPlaneEtcdSnapshotFailedReason surfaces when taking or storing the last etcd snapshot, or
+	// deleting snapshots exceeding retention, failed.
+	KubeadmControlPlaneEtcdSnapshotFailedReason = "Failed"
+)
+
+// KubeadmControlPlane's EtcdDefragmentationSucceeded condition and corresponding reasons.
+const (
+	// KubeadmControlPlaneEtcdDefragmentationSucceededCondition is true if the last check of the database size of etcd members,
+	// and the defragmentation of the members exceeding the threshold, if any, succeeded.
+	// Note: This condition is set only if etcd defragmentation is enabled; failures defragmenting etcd members do not block other operations.
+	KubeadmControlPlaneEtcdDefragmentationSucceededCondition = "EtcdDefragmentationSucceeded"
+
+	// KubeadmControlPlaneEtcdDefragmentationSucceededReason surfaces when the last check of the database size of etcd members,
+	// and the defragmentation of the members exceeding the threshold, if any, succeeded.
+	KubeadmControlPlaneEtcdDefragmentationSucceededReason = "Succeeded"
+
+	// KubeadmControlPlaneEtcdDefragmentationFailedReason surfaces when checking the database size of etcd members,
+	// or defragmenting a member, failed.
+	KubeadmControlPlaneEtcdDefragmentationFailedReason = "Failed"
+)
+
 // KubeadmControlPlane's ScalingUp condition and corresponding reasons.
 const (
 	// KubeadmControlPlaneScalingUpCondition is true if actual replicas < desired replicas.
@@ -449,6 +534,16 @@ type KubeadmControlPlaneSpec struct {
 	// InfraMachines & KubeadmConfigs will use the same name as the corresponding Machines.
 	// +optional
 	MachineNaming MachineNamingSpec `json:"machineNaming,omitempty,omitzero"`
+
+	// etcd allows to configure how KCP operates the etcd cluster, e.g. taking periodic snapshots, restoring from a snapshot or defragmenting members.
+	// NOTE: etcd can only be set when using stacked etcd (kubeadmConfigSpec.clusterConfiguration.etcd.external not set).
+	// +optional
+	Etcd KubeadmControlPlaneEtcdSpec `json:"etcd,omitempty,omitzero"`
+
+	// caRotation allows to rotate the certificate authorities of the cluster, i.e. the cluster CA, the etcd CA,
+	// the front-proxy CA and the service account keys.
+	// +optional
+	CARotation KubeadmControlPlaneCARotationSpec `json:"caRotation,omitempty,omitzero"`
 }
 
 // KubeadmControlPlaneMachineTemplate defines the template for Machines
@@ -550,6 +645,15 @@ type KubeadmControlPlaneRolloutBeforeSpec struct {
 	// +optional
 	// +kubebuilder:validation:Minimum=7
 	CertificatesExpiryDays int32 `json:"certificatesExpiryDays,omitempty"`
+
+	// certificatesRenewalStrategy defines how certificates expiring within certificatesExpiryDays are renewed.
+	// When set to Rollout, Machines are replaced with new ones.
+	// When set to InPlace, certificates are renewed without replacing Machines, via the extension implementing
+	// the UpdateMachine hook; this requires the InPlaceUpdates feature gate. If the Machine cannot be updated
+	// in-place, e.g. because no CanUpdateMachine extension is registered, it is replaced with a new one.
+	// If not set, this field defaults to Rollout.
+	// +optional
+	CertificatesRenewalStrategy KubeadmControlPlaneCertificatesRenewalStrategy `json:"certificatesRenewalStrategy,omitempty"`
 }
 
 // KubeadmControlPlaneRolloutStrategy describes how to replace existing machines
@@ -648,12 +752,178 @@ type MachineNamingSpec struct {
 	Template string `json:"template,omitempty"`
 }
 
+// KubeadmControlPlaneEtcdSpec allows to configure how KCP operates the etcd cluster.
+// +kubebuilder:validation:MinProperties=1
+type KubeadmControlPlaneEtcdSpec struct {
+	// snapshot allows to configure periodic snapshots of the etcd cluster.
+	// +optional
+	Snapshot KubeadmControlPlaneEtcdSnapshotSpec `json:"snapshot,omitempty,omitzero"`
+
+	// restore allows to restore the etcd cluster from a snapshot, e.g. when etcd quorum is lost.
+	// When restore is set, KCP deletes all the control plane Machines and creates a single new Machine,
+	// which restores etcd from the snapshot before running kubeadm init; after etcd has been restored,
+	// the control plane is scaled up to the desired number of replicas.
+	// Snapshots are read from the storage defined in snapshot.storage.
+	// NOTE: restore should be removed after the restore completed; to restore the same snapshot again, restore must be
+	// removed and then set again.
+	// +optional
+	Restore KubeadmControlPlaneEtcdRestoreSpec `json:"restore,omitempty,omitzero"`
+
+	// defragmentation allows to configure periodic defragmentation of etcd members.
+	// When defragmentation is set, KCP also recovers etcd members from NOSPACE alarms, by compacting
+	// the etcd key space, defragmenting the members and then disarming the alarms.
+	// +optional
+	Defragmentation KubeadmControlPlaneEtcdDefragmentationSpec `json:"defragmentation,omitempty,omitzero"`
+}
+
+// KubeadmControlPlaneEtcdDefragmentationSpec allows to configure periodic defragmentation of etcd members.
+type KubeadmControlPlaneEtcdDefragmentationSpec struct {
+	// intervalSeconds is the interval between two checks of the database size of etcd members.
+	// When a check finds members with fragmentation exceeding minFragmentationPercent, they are defragmented
+	// one at a time.
+	// +required
+	// +kubebuilder:validation:Minimum=300
+	IntervalSeconds int32 `json:"intervalSeconds,omitempty"`
+
+	// minFragmentationPercent is the minimum percentage of the database size of an etcd member not in use
+	// which triggers defragmentation of the member.
+	// If not set, this value is defaulted to 50.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	MinFragmentationPercent *int32 `json:"minFragmentationPercent,omitempty"`
+}
+
+// KubeadmControlPlaneEtcdRestoreSpec allows to restore the etcd cluster from a snapshot.
+type KubeadmControlPlaneEtcdRestoreSpec struct {
+	// snapshotName is the name of the snapshot to restore etcd from, e.g. the name of the snapshot in status.etcd.lastSnapshot.
+	// The restore starts only after the controlplane.cluster.x-k8s.io/etcd-restore-confirmed annotation is set on the
+	// KubeadmControlPlane with the name of the snapshot as value.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	SnapshotName string `json:"snapshotName,omitempty"`
+}
+
+// KubeadmControlPlaneEtcdSnapshotSpec allows to configure periodic snapshots of the etcd cluster.
+type KubeadmControlPlaneEtcdSnapshotSpec struct {
+	// intervalSeconds is the interval between two etcd snapshots.
+	// +required
+	// +kubebuilder:validation:Minimum=300
+	IntervalSeconds int32 `json:"intervalSeconds,omitempty"`
+
+	// retention is the number of snapshots to keep in the storage; when a new snapshot is taken,
+	// the oldest snapshots exceeding retention are deleted.
+	// If not set, this value is defaulted to 3.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	Retention *int32 `json:"retention,omitempty"`
+
+	// storage defines where etcd snapshots are stored.
+	// +required
+	Storage KubeadmControlPlaneEtcdSnapshotStorage `json:"storage,omitempty,omitzero"`
+}
+
+// KubeadmControlPlaneEtcdSnapshotStorageType defines the type of storage for etcd snapshots.
+// +kubebuilder:validation:Enum=Secret;S3;PersistentVolumeClaim
+type KubeadmControlPlaneEtcdSnapshotStorageType string
+
+const (
+	// SecretEtcdSnapshotStorageType stores etcd snapshots in Secrets in the namespace of the KubeadmControlPlane.
+	// NOTE: Snapshots are compressed, but a Secret can't be bigger than 1MiB, so this storage type can be used
+	// only for small clusters, e.g. for development and test environments.
+	SecretEtcdSnapshotStorageType KubeadmControlPlaneEtcdSnapshotStorageType = "Secret"
+
+	// S3EtcdSnapshotStorageType stores etcd snapshots in a bucket of an S3-compatible object storage.
+	S3EtcdSnapshotStorageType KubeadmControlPlaneEtcdSnapshotStorageType = "S3"
+
+	// PersistentVolumeClaimEtcdSnapshotStorageType stores etcd snapshots in a PersistentVolumeClaim of the management
+	// cluster, in a directory named after the namespace of the KubeadmControlPlane.
+	// NOTE: The PersistentVolumeClaim must be mounted into the KubeadmControlPlane controller, with the mount path
+	// set via the --etcd-snapshot-volume-path flag.
+	PersistentVolumeClaimEtcdSnapshotStorageType KubeadmControlPlaneEtcdSnapshotStorageType = "PersistentVolumeClaim"
+)
+
+// KubeadmControlPlaneEtcdSnapshotStorage defines where etcd snapshots are stored.
+type KubeadmControlPlaneEtcdSnapshotStorage struct {
+	// type is the type of storage for etcd snapshots, one of Secret, S3 or PersistentVolumeClaim.
+	// +required
+	Type KubeadmControlPlaneEtcdSnapshotStorageType `json:"type,omitempty"`
+
+	// s3 defines the S3-compatible object storage where etcd snapshots are stored.
+	// s3 is required when type is S3.
+	// +optional
+	S3 KubeadmControlPlaneEtcdSnapshotS3Storage `json:"s3,omitempty,omitzero"`
+}
+
+// KubeadmControlPlaneEtcdSnapshotS3Storage defines an S3-compatible object storage.
+type KubeadmControlPlaneEtcdSnapshotS3Storage struct {
+	// endpoint is the URL of the S3-compatible object storage, e.g. https://s3.eu-west-1.amazonaws.com.
+	// Buckets are addressed using path-style URLs, e.g. https://s3.eu-west-1.amazonaws.com/my-bucket.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=512
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// bucket is the name of the bucket where etcd snapshots are stored.
+	// +required
+	// +kubebuilder:validation:MinLength=3
+	// +kubebuilder:validation:MaxLength=63
+	Bucket string `json:"bucket,omitempty"`
+
+	// prefix is prepended to the key of etcd snapshots in the bucket.
+	// Snapshots are stored with key <prefix>/<namespace>/<snapshot name>.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	Prefix string `json:"prefix,omitempty"`
+
+	// region of the bucket.
+	// If not set, this value is defaulted to us-east-1.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	Region string `json:"region,omitempty"`
+
+	// credentialsSecretName is the name of a Secret in the namespace of the KubeadmControlPlane
+	// with the accessKeyID and secretAccessKey keys used to authenticate to the object storage.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	CredentialsSecretName string `json:"credentialsSecretName,omitempty"`
+
+	// caSecretName is the name of a Secret in the namespace of the KubeadmControlPlane with the ca.crt key
+	// containing the PEM-encoded certificate authorities used to verify the certificate of the object storage,
+	// in addition to the system certificate authorities.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	CASecretName string `json:"caSecretName,omitempty"`
+}
+
+// KubeadmControlPlaneCARotationSpec allows to rotate the certificate authorities of the cluster.
+type KubeadmControlPlaneCARotationSpec struct {
+	// rotateAfter triggers a rotation of the certificate authorities when it is in the past and
+	// after the start time of the last rotation.
+	// The rotation happens in multiple phases: first the new certificate authorities are added to the ones trusted
+	// by the cluster, then they are used to sign certificates, and finally the old certificate authorities are removed.
+	// All the Machines of the Cluster, including worker Machines, must be rolled out in every phase; KCP rolls out
+	// control plane Machines, while worker Machines must be rolled out by the user, e.g. by setting rollout.after
+	// on MachineDeployments.
+	// Example: In the YAML the time can be specified in the RFC3339 format.
+	// To specify the rotateAfter target as March 9, 2023, at 9 am UTC
+	// use "2023-03-09T09:00:00Z".
+	// +required
+	RotateAfter metav1.Time `json:"rotateAfter,omitempty,omitzero"`
+}
+
 // KubeadmControlPlaneStatus defines the observed state of KubeadmControlPlane.
 // +kubebuilder:validation:MinProperties=1
 type KubeadmControlPlaneStatus struct {
 	// conditions represents the observations of a KubeadmControlPlane's current state.
 	// Known condition types are Available, CertificatesAvailable, EtcdClusterAvailable, MachinesReady, MachinesUpToDate,
-	// ScalingUp, ScalingDown, Remediating, Deleting, Paused.
+	// ScalingUp, ScalingDown, Remediating, CARotating, EtcdSnapshotSucceeded, EtcdDefragmentationSucceeded, Deleting, Paused.
 	// +optional
 	// +listType=map
 	// +listMapKey=type
@@ -708,6 +978,14 @@ type KubeadmControlPlaneStatus struct {
 	// +optional
 	LastRemediation LastRemediationStatus `json:"lastRemediation,omitempty,omitzero"`
 
+	// etcd provides observations of the etcd cluster operated by KCP.
+	// +optional
+	Etcd KubeadmControlPlaneEtcdStatus `json:"etcd,omitempty,omitzero"`
+
+	// caRotation stores info about the rotation of the certificate authorities requested in spec.caRotation.
+	// +optional
+	CARotation KubeadmControlPlaneCARotationStatus `json:"caRotation,omitempty,omitzero"`
+
 	// deprecated groups all the status fields that are deprecated and will be removed when all the nested field are removed.
 	// +optional
 	Deprecated *KubeadmControlPlaneDeprecatedStatus `json:"deprecated,omitempty"`
@@ -788,6 +1066,138 @@ type KubeadmControlPlaneV1Beta1DeprecatedStatus struct {
 	UnavailableReplicas int32 `json:"unavailableReplicas"` //nolint:kubeapilinter // field will be removed when v1beta1 is removed
 }
 
+// KubeadmControlPlaneEtcdStatus provides observations of the etcd cluster operated by KCP.
+// +kubebuilder:validation:MinProperties=1
+type KubeadmControlPlaneEtcdStatus struct {
+	// lastSnapshot stores info about the last etcd snapshot successfully taken.
+	// +optional
+	LastSnapshot EtcdSnapshotStatus `json:"lastSnapshot,omitempty,omitzero"`
+
+	// restore stores info about the restore of the etcd cluster from a snapshot requested in spec.etcd.restore.
+	// +optional
+	Restore EtcdRestoreStatus `json:"restore,omitempty,omitzero"`
+
+	// lastDefragmentationCheckTime is when KCP last checked if etcd members had to be defragmented.
+	// It is represented in RFC3339 form and is in UTC.
+	// +optional
+	LastDefragmentationCheckTime metav1.Time `json:"lastDefragmentationCheckTime,omitempty,omitzero"`
+
+	// members stores info about etcd members, e.g. the size of their database.
+	// NOTE: members are reported only when spec.etcd.defragmentation is set.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=32
+	Members []EtcdMemberStatus `json:"members,omitempty"`
+}
+
+// KubeadmControlPlaneCARotationPhase is a phase of the rotation of the certificate authorities.
+// +kubebuilder:validation:Enum=TrustNewCertificateAuthorities;SignWithNewCertificateAuthorities;RemoveOldCertificateAuthorities;Completed
+type KubeadmControlPlaneCARotationPhase string
+
+const (
+	// TrustNewCertificateAuthoritiesCARotationPhase is the phase where new certificate authorities are added to the ones
+	// trusted by the cluster, while certificates are still signed by the old certificate authorities.
+	TrustNewCertificateAuthoritiesCARotationPhase KubeadmControlPlaneCARotationPhase = "TrustNewCertificateAuthorities"
+
+	// SignWithNewCertificateAuthoritiesCARotationPhase is the phase where certificates are signed by the new certificate
+	// authorities, while the old certificate authorities are still trusted by the cluster.
+	SignWithNewCertificateAuthoritiesCARotationPhase KubeadmControlPlaneCARotationPhase = "SignWithNewCertificateAuthorities"
+
+	// RemoveOldCertificateAuthoritiesCARotationPhase is the phase where the old certificate authorities are removed
+	// from the ones trusted by the cluster.
+	RemoveOldCertificateAuthoritiesCARotationPhase KubeadmControlPlaneCARotationPhase = "RemoveOldCertificateAuthorities"
+
+	// CompletedCARotationPhase is the phase of a completed rotation.
+	CompletedCARotationPhase KubeadmControlPlaneCARotationPhase = "Completed"
+)
+
+// KubeadmControlPlaneCARotationStatus stores info about the rotation of the certificate authorities.
+type KubeadmControlPlaneCARotationStatus struct {
+	// phase is the current phase of the rotation.
+	// +required
+	Phase KubeadmControlPlaneCARotationPhase `json:"phase,omitempty"`
+
+	// startTime is when the rotation started. It is represented in RFC3339 form and is in UTC.
+	// +required
+	StartTime metav1.Time `json:"startTime,omitempty,omitzero"`
+
+	// phaseStartTime is when the current phase of the rotation started; Machines created before this time must be
+	// rolled out before moving to the next phase. It is represented in RFC3339 form and is in UTC.
+	// +required
+	PhaseStartTime metav1.Time `json:"phaseStartTime,omitempty,omitzero"`
+
+	// completionTime is when the rotation completed. It is represented in RFC3339 form and is in UTC.
+	// +optional
+	CompletionTime metav1.Time `json:"completionTime,omitempty,omitzero"`
+}
+
+// EtcdMemberStatus stores info about an etcd member.
+type EtcdMemberStatus struct {
+	// name is the name of the etcd member, which is also the name of the Node hosting the member.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Name string `json:"name,omitempty"`
+
+	// dbSizeBytes is the size of the database of the etcd member.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	DBSizeBytes *int64 `json:"dbSizeBytes,omitempty"`
+
+	// dbSizeInUseBytes is the size of the database of the etcd member logically in use.
+	// The difference with dbSizeBytes can be reclaimed by defragmenting the member.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	DBSizeInUseBytes *int64 `json:"dbSizeInUseBytes,omitempty"`
+
+	// lastDefragmentationTime is when the etcd member has been last defragmented by KCP.
+	// It is represented in RFC3339 form and is in UTC.
+	// +optional
+	LastDefragmentationTime metav1.Time `json:"lastDefragmentationTime,omitempty,omitzero"`
+}
+
+// EtcdRestoreStatus stores info about the restore of the etcd cluster from a snapshot.
+type EtcdRestoreStatus struct {
+	// snapshotName is the name of the snapshot etcd is restored from.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	SnapshotName string `json:"snapshotName,omitempty"`
+
+	// machine is the name of the Machine restoring etcd from the snapshot.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Machine string `json:"machine,omitempty"`
+
+	// startTime is when the restore started. It is represented in RFC3339 form and is in UTC.
+	// +required
+	StartTime metav1.Time `json:"startTime,omitempty,omitzero"`
+
+	// completionTime is when the restore completed. It is represented in RFC3339 form and is in UTC.
+	// +optional
+	CompletionTime metav1.Time `json:"completionTime,omitempty,omitzero"`
+}
+
+// EtcdSnapshotStatus stores info about an etcd snapshot.
+type EtcdSnapshotStatus struct {
+	// name is the name of the snapshot in the storage.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Name string `json:"name,omitempty"`
+
+	// time is when the snapshot has been taken. It is represented in RFC3339 form and is in UTC.
+	// +required
+	Time metav1.Time `json:"time,omitempty,omitzero"`
+
+	// sizeBytes is the size of the snapshot, before compression.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	SizeBytes *int64 `json:"sizeBytes,omitempty"`
+}
+
 // LastRemediationStatus  stores info about last remediation performed.
 // NOTE: if for any reason information about last remediation are lost, RetryCount is going to restart from 0 and thus
 // more remediations than expected might happen.
@@ -27,6 +27,70 @@ import (
 	corev1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdMemberStatus) DeepCopyInto(out *EtcdMemberStatus) {
+	*out = *in
+	if in.DBSizeBytes != nil {
+		in, out := &in.DBSizeBytes, &out.DBSizeBytes
+		*out = new(int64)
+		**out = **in
+	}
+	if in.DBSizeInUseBytes != nil {
+		in, out := &in.DBSizeInUseBytes, &out.DBSizeInUseBytes
+		*out = new(int64)
+		**out = **in
+	}
+	in.LastDefragmentationTime.DeepCopyInto(&out.LastDefragmentationTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdMemberStatus.
+func (in *EtcdMemberStatus) DeepCopy() *EtcdMemberStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdMemberStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdRestoreStatus) DeepCopyInto(out *EtcdRestoreStatus) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	in.CompletionTime.DeepCopyInto(&out.CompletionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdRestoreStatus.
+func (in *EtcdRestoreStatus) DeepCopy() *EtcdRestoreStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdRestoreStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdSnapshotStatus) DeepCopyInto(out *EtcdSnapshotStatus) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	if in.SizeBytes != nil {
+		in, out := &in.SizeBytes, &out.SizeBytes
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdSnapshotStatus.
+func (in *EtcdSnapshotStatus) DeepCopy() *EtcdSnapshotStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdSnapshotStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubeadmControlPlane) DeepCopyInto(out *KubeadmControlPlane) {
 	*out = *in
@@ -54,6 +118,40 @@ func (in *KubeadmControlPlane) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeadmControlPlaneCARotationSpec) DeepCopyInto(out *KubeadmControlPlaneCARotationSpec) {
+	*out = *in
+	in.RotateAfter.DeepCopyInto(&out.RotateAfter)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmControlPlaneCARotationSpec.
+func (in *KubeadmControlPlaneCARotationSpec) DeepCopy() *KubeadmControlPlaneCARotationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeadmControlPlaneCARotationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeadmControlPlaneCARotationStatus) DeepCopyInto(out *KubeadmControlPlaneCARotationStatus) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	in.PhaseStartTime.DeepCopyInto(&out.PhaseStartTime)
+	in.CompletionTime.DeepCopyInto(&out.CompletionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmControlPlaneCARotationStatus.
+func (in *KubeadmControlPlaneCARotationStatus) DeepCopy() *KubeadmControlPlaneCARotationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeadmControlPlaneCARotationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubeadmControlPlaneDeprecatedStatus) DeepCopyInto(out *KubeadmControlPlaneDeprecatedStatus) {
 	*out = *in
@@ -74,6 +172,136 @@ func (in *KubeadmControlPlaneDeprecatedStatus) DeepCopy() *KubeadmControlPlaneDe
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeadmControlPlaneEtcdDefragmentationSpec) DeepCopyInto(out *KubeadmControlPlaneEtcdDefragmentationSpec) {
+	*out = *in
+	if in.MinFragmentationPercent != nil {
+		in, out := &in.MinFragmentationPercent, &out.MinFragmentationPercent
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmControlPlaneEtcdDefragmentationSpec.
+func (in *KubeadmControlPlaneEtcdDefragmentationSpec) DeepCopy() *KubeadmControlPlaneEtcdDefragmentationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeadmControlPlaneEtcdDefragmentationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeadmControlPlaneEtcdRestoreSpec) DeepCopyInto(out *KubeadmControlPlaneEtcdRestoreSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmControlPlaneEtcdRestoreSpec.
+func (in *KubeadmControlPlaneEtcdRestoreSpec) DeepCopy() *KubeadmControlPlaneEtcdRestoreSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeadmControlPlaneEtcdRestoreSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeadmControlPlaneEtcdSnapshotS3Storage) DeepCopyInto(out *KubeadmControlPlaneEtcdSnapshotS3Storage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmControlPlaneEtcdSnapshotS3Storage.
+func (in *KubeadmControlPlaneEtcdSnapshotS3Storage) DeepCopy() *KubeadmControlPlaneEtcdSnapshotS3Storage {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeadmControlPlaneEtcdSnapshotS3Storage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeadmControlPlaneEtcdSnapshotSpec) DeepCopyInto(out *KubeadmControlPlaneEtcdSnapshotSpec) {
+	*out = *in
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(int32)
+		**out = **in
+	}
+	out.Storage = in.Storage
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmControlPlaneEtcdSnapshotSpec.
+func (in *KubeadmControlPlaneEtcdSnapshotSpec) DeepCopy() *KubeadmControlPlaneEtcdSnapshotSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeadmControlPlaneEtcdSnapshotSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeadmControlPlaneEtcdSnapshotStorage) DeepCopyInto(out *KubeadmControlPlaneEtcdSnapshotStorage) {
+	*out = *in
+	out.S3 = in.S3
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmControlPlaneEtcdSnapshotStorage.
+func (in *KubeadmControlPlaneEtcdSnapshotStorage) DeepCopy() *KubeadmControlPlaneEtcdSnapshotStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeadmControlPlaneEtcdSnapshotStorage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeadmControlPlaneEtcdSpec) DeepCopyInto(out *KubeadmControlPlaneEtcdSpec) {
+	*out = *in
+	in.Snapshot.DeepCopyInto(&out.Snapshot)
+	out.Restore = in.Restore
+	in.Defragmentation.DeepCopyInto(&out.Defragmentation)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmControlPlaneEtcdSpec.
+func (in *KubeadmControlPlaneEtcdSpec) DeepCopy() *KubeadmControlPlaneEtcdSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeadmControlPlaneEtcdSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeadmControlPlaneEtcdStatus) DeepCopyInto(out *KubeadmControlPlaneEtcdStatus) {
+	*out = *in
+	in.LastSnapshot.DeepCopyInto(&out.LastSnapshot)
+	in.Restore.DeepCopyInto(&out.Restore)
+	in.LastDefragmentationCheckTime.DeepCopyInto(&out.LastDefragmentationCheckTime)
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]EtcdMemberStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmControlPlaneEtcdStatus.
+func (in *KubeadmControlPlaneEtcdStatus) DeepCopy() *KubeadmControlPlaneEtcdStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeadmControlPlaneEtcdStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubeadmControlPlaneInitializationStatus) DeepCopyInto(out *KubeadmControlPlaneInitializationStatus) {
 	*out = *in
@@ -307,6 +535,8 @@ func (in *KubeadmControlPlaneSpec) DeepCopyInto(out *KubeadmControlPlaneSpec) {
 	in.Rollout.DeepCopyInto(&out.Rollout)
 	in.Remediation.DeepCopyInto(&out.Remediation)
 	out.MachineNaming = in.MachineNaming
+	in.Etcd.DeepCopyInto(&out.Etcd)
+	in.CARotation.DeepCopyInto(&out.CARotation)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmControlPlaneSpec.
@@ -351,6 +581,8 @@ func (in *KubeadmControlPlaneStatus) DeepCopyInto(out *KubeadmControlPlaneStatus
 		**out = **in
 	}
 	in.LastRemediation.DeepCopyInto(&out.LastRemediation)
+	in.Etcd.DeepCopyInto(&out.Etcd)
+	in.CARotation.DeepCopyInto(&out.CARotation)
 	if in.Deprecated != nil {
 		in, out := &in.Deprecated, &out.Deprecated
 		*out = new(KubeadmControlPlaneDeprecatedStatus)
@@ -409,6 +409,9 @@ func (src *Machine) ConvertTo(dstRaw conversion.Hub) error {
 	if ok {
 		dst.Spec.MinReadySeconds = restored.Spec.MinReadySeconds
 		dst.Spec.Taints = restored.Spec.Taints
+		if dst.Status.Deletion != nil && restored.Status.Deletion != nil {
+			dst.Status.Deletion.NodeDrainGroups = restored.Status.Deletion.NodeDrainGroups
+		}
 		// Restore the phase, this also means that any client using v1beta1 during a round-trip
 		// won't be able to write the Phase field. But that's okay as the only client writing the Phase
 		// field should be the Machine controller.
@@ -508,6 +511,7 @@ func (src *MachineDeployment) ConvertTo(dstRaw conversion.Hub) error {
 	// Recover other values
 	if ok {
 		dst.Spec.Template.Spec.Taints = restored.Spec.Template.Spec.Taints
+		dst.Spec.Rollout.Strategy.Canary = restored.Spec.Rollout.Strategy.Canary
 	}
 
 	return nil
@@ -624,12 +628,34 @@ func (dst *MachinePool) ConvertFrom(srcRaw conversion.Hub) error {
 
 func (src *MachineDrainRule) ConvertTo(dstRaw conversion.Hub) error {
 	dst := dstRaw.(*clusterv1.MachineDrainRule)
-	return Convert_v1beta1_MachineDrainRule_To_v1beta2_MachineDrainRule(src, dst, nil)
+
+	if err := Convert_v1beta1_MachineDrainRule_To_v1beta2_MachineDrainRule(src, dst, nil); err != nil {
+		return err
+	}
+
+	restored := &clusterv1.MachineDrainRule{}
+	ok, err := utilconversion.UnmarshalData(src, restored)
+	if err != nil {
+		return err
+	}
+
+	// Recover other values.
+	if ok {
+		dst.Spec.Drain.Timeout = restored.Spec.Drain.Timeout
+		dst.Spec.Drain.EvictionFallback = restored.Spec.Drain.EvictionFallback
+	}
+
+	return nil
 }
 
 func (dst *MachineDrainRule) ConvertFrom(srcRaw conversion.Hub) error {
 	src := srcRaw.(*clusterv1.MachineDrainRule)
-	return Convert_v1beta2_MachineDrainRule_To_v1beta1_MachineDrainRule(src, dst, nil)
+
+	if err := Convert_v1beta2_MachineDrainRule_To_v1beta1_MachineDrainRule(src, dst, nil); err != nil {
+		return err
+	}
+
+	return utilconversion.MarshalData(src, dst)
 }
 
 func Convert_v1beta2_ClusterClass_To_v1beta1_ClusterClass(in *clusterv1.ClusterClass, out *ClusterClass, s apimachineryconversion.Scope) error {
@@ -2440,6 +2466,10 @@ func Convert_v1beta1_MachineDeletionStatus_To_v1beta2_MachineDeletionStatus(in *
 	return nil
 }
 
+func Convert_v1beta2_MachineDrainRuleDrainConfig_To_v1beta1_MachineDrainRuleDrainConfig(in *clusterv1.MachineDrainRuleDrainConfig, out *MachineDrainRuleDrainConfig, s apimachineryconversion.Scope) error {
+	return autoConvert_v1beta2_MachineDrainRuleDrainConfig_To_v1beta1_MachineDrainRuleDrainConfig(in, out, s)
+}
+
 func Convert_v1beta2_MachineDeletionStatus_To_v1beta1_MachineDeletionStatus(in *clusterv1.MachineDeletionStatus, out *MachineDeletionStatus, _ apimachineryconversion.Scope) error {
 	if !reflect.DeepEqual(in.NodeDrainStartTime, metav1.Time{}) {
 		out.NodeDrainStartTime = ptr.To(in.NodeDrainStartTime)
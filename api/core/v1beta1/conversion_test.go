@@ -90,6 +90,10 @@ func TestFuzzyConversion(t *testing.T) {
 		Spoke:       &MachinePool{},
 		FuzzerFuncs: []fuzzer.FuzzerFuncs{MachinePoolFuzzFuncs},
 	}))
+	t.Run("for MachineDrainRule", utilconversion.FuzzTestFunc(utilconversion.FuzzTestFuncInput{
+		Hub:   &clusterv1.MachineDrainRule{},
+		Spoke: &MachineDrainRule{},
+	}))
 }
 
 func ClusterFuzzFuncs(_ runtimeserializer.CodecFactory) []interface{} {
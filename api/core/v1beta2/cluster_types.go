@@ -348,6 +348,23 @@ const (
 	ClusterRemoteConnectionProbeSucceededReason = "ProbeSucceeded"
 )
 
+// Cluster's ResourcesHealthy condition and corresponding reasons.
+const (
+	// ClusterResourcesHealthyCondition is true if the Deployments, DaemonSets and CustomResourceDefinitions applied to the
+	// Cluster by ClusterResourceSets with waitForHealthy set are healthy.
+	// Note: This condition is set by the ClusterResourceSet controller only if at least one ClusterResourceSet
+	// with waitForHealthy set is applied to the Cluster.
+	ClusterResourcesHealthyCondition = "ResourcesHealthy"
+
+	// ClusterResourcesHealthyReason surfaces when all the objects applied to the Cluster by ClusterResourceSets
+	// with waitForHealthy set are healthy.
+	ClusterResourcesHealthyReason = "Healthy"
+
+	// ClusterResourcesNotHealthyReason surfaces when at least one of the objects applied to the Cluster by
+	// ClusterResourceSets with waitForHealthy set is not healthy.
+	ClusterResourcesNotHealthyReason = "NotHealthy"
+)
+
 // Cluster's RollingOut condition and corresponding reasons.
 const (
 	// ClusterRollingOutCondition is the summary of `RollingOut` conditions from ControlPlane, MachineDeployments
@@ -1074,7 +1091,7 @@ type MachineDeploymentTopologyHealthCheckRemediationTriggerIf struct {
 // +kubebuilder:validation:MinProperties=1
 type MachineDeploymentTopologyMachineDeletionSpec struct {
 	// order defines the order in which Machines are deleted when downscaling.
-	// Defaults to "Random".  Valid values are "Random, "Newest", "Oldest"
+	// Defaults to "Random".  Valid values are "Random, "Newest", "Oldest", "LeastDisruptive", "External"
 	// +optional
 	Order MachineSetDeletionOrder `json:"order,omitempty"`
 
@@ -1113,7 +1130,9 @@ type MachineDeploymentTopologyRolloutSpec struct {
 type MachineDeploymentTopologyRolloutStrategy struct {
 	// type of rollout. Allowed values are RollingUpdate and OnDelete.
 	// Default is RollingUpdate.
+	// NOTE: The Canary rollout strategy is not supported for MachineDeployments in a managed topology.
 	// +required
+	// +kubebuilder:validation:Enum=RollingUpdate;OnDelete
 	Type MachineDeploymentRolloutStrategyType `json:"type,omitempty"`
 
 	// rollingUpdate is the rolling update config params. Present only if
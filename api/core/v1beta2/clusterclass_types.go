@@ -628,7 +628,7 @@ type MachineDeploymentClassHealthCheckRemediationTriggerIf struct {
 // +kubebuilder:validation:MinProperties=1
 type MachineDeploymentClassMachineDeletionSpec struct {
 	// order defines the order in which Machines are deleted when downscaling.
-	// Defaults to "Random".  Valid values are "Random, "Newest", "Oldest"
+	// Defaults to "Random".  Valid values are "Random, "Newest", "Oldest", "LeastDisruptive", "External"
 	// +optional
 	Order MachineSetDeletionOrder `json:"order,omitempty"`
 
@@ -687,7 +687,9 @@ type MachineDeploymentClassRolloutSpec struct {
 type MachineDeploymentClassRolloutStrategy struct {
 	// type of rollout. Allowed values are RollingUpdate and OnDelete.
 	// Default is RollingUpdate.
+	// NOTE: The Canary rollout strategy is not supported for MachineDeployments in a managed topology.
 	// +required
+	// +kubebuilder:validation:Enum=RollingUpdate;OnDelete
 	Type MachineDeploymentRolloutStrategyType `json:"type,omitempty"`
 
 	// rollingUpdate is the rolling update config params. Present only if
@@ -257,6 +257,13 @@ const (
 	// The preflight check is only run if the Cluster has a managed topology, a ControlPlane is used (controlPlaneRef
 	// must exist in the Cluster), the ControlPlane has a version and the MachineSet has a version.
 	MachineSetPreflightCheckControlPlaneVersionSkew MachineSetPreflightCheck = "ControlPlaneVersionSkew"
+
+	// MachineSetPreflightCheckBeforeMachineCreateHook is the name of the preflight check
+	// that calls the BeforeMachineCreate hook of the Runtime Extensions registered for it, so that
+	// Runtime Extensions can block the creation of Machines, e.g. because of quota, IPAM capacity or change-freeze windows.
+	// The preflight check is only run if the RuntimeSDK feature gate is enabled. Contrary to the other preflight
+	// checks, it does not require a ControlPlane.
+	MachineSetPreflightCheckBeforeMachineCreateHook MachineSetPreflightCheck = "BeforeMachineCreateHook"
 )
 
 // NodeOutdatedRevisionTaint can be added to Nodes at rolling updates in general triggered by updating MachineDeployment
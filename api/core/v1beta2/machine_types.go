@@ -733,6 +733,26 @@ type MachineDeletionStatus struct {
 	// Only present when the Machine has a deletionTimestamp and waiting for volume detachments had been started.
 	// +optional
 	WaitForNodeVolumeDetachStartTime metav1.Time `json:"waitForNodeVolumeDetachStartTime,omitempty,omitzero"`
+
+	// nodeDrainGroups contains the times when the drain of groups of Pods with the same drain order started
+	// and is used to determine if the timeouts of MachineDrainRules are exceeded.
+	// Only groups of Pods to which a MachineDrainRule with a timeout applies are tracked.
+	// +optional
+	// +listType=map
+	// +listMapKey=order
+	// +kubebuilder:validation:MaxItems=100
+	NodeDrainGroups []MachineNodeDrainGroup `json:"nodeDrainGroups,omitempty"`
+}
+
+// MachineNodeDrainGroup is the drain state of a group of Pods with the same drain order.
+type MachineNodeDrainGroup struct {
+	// order is the drain order of the group of Pods.
+	// +required
+	Order *int32 `json:"order,omitempty"`
+
+	// startTime is the time when the drain of the group of Pods started.
+	// +required
+	StartTime metav1.Time `json:"startTime,omitempty,omitzero"`
 }
 
 // SetTypedPhase sets the Phase field to the string representation of MachinePhase.
@@ -32,7 +32,7 @@ const (
 )
 
 // MachineDeploymentRolloutStrategyType defines the type of MachineDeployment rollout strategies.
-// +kubebuilder:validation:Enum=RollingUpdate;OnDelete
+// +kubebuilder:validation:Enum=RollingUpdate;OnDelete;Canary
 type MachineDeploymentRolloutStrategyType string
 
 const (
@@ -43,6 +43,11 @@ const (
 	// OnDeleteMachineDeploymentStrategyType replaces old MachineSets when the deletion of the associated machines are completed.
 	OnDeleteMachineDeploymentStrategyType MachineDeploymentRolloutStrategyType = "OnDelete"
 
+	// CanaryMachineDeploymentStrategyType replaces the old MachineSet by new one using rolling update, but
+	// it first rolls out a limited number of canary machines and then proceeds in steps, each of them gated by an analysis
+	// of the machines already rolled out.
+	CanaryMachineDeploymentStrategyType MachineDeploymentRolloutStrategyType = "Canary"
+
 	// RevisionAnnotation is the revision annotation of a machine deployment's machine sets which records its rollout sequence.
 	RevisionAnnotation = "machinedeployment.clusters.x-k8s.io/revision"
 
@@ -315,15 +320,20 @@ type MachineDeploymentRolloutSpec struct {
 // with new ones.
 // +kubebuilder:validation:MinProperties=1
 type MachineDeploymentRolloutStrategy struct {
-	// type of rollout. Allowed values are RollingUpdate and OnDelete.
+	// type of rollout. Allowed values are RollingUpdate, OnDelete and Canary.
 	// Default is RollingUpdate.
 	// +required
 	Type MachineDeploymentRolloutStrategyType `json:"type,omitempty"`
 
 	// rollingUpdate is the rolling update config params. Present only if
-	// type = RollingUpdate.
+	// type = RollingUpdate or type = Canary.
 	// +optional
 	RollingUpdate MachineDeploymentRolloutStrategyRollingUpdate `json:"rollingUpdate,omitempty,omitzero"`
+
+	// canary is the canary config params. Present only if
+	// type = Canary.
+	// +optional
+	Canary MachineDeploymentRolloutStrategyCanary `json:"canary,omitempty,omitzero"`
 }
 
 // MachineDeploymentRolloutStrategyRollingUpdate is used to control the desired behavior of rolling update.
@@ -361,6 +371,56 @@ type MachineDeploymentRolloutStrategyRollingUpdate struct {
 	MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty"`
 }
 
+// MachineDeploymentRolloutStrategyCanary is used to control the desired behavior of a canary rollout.
+//
+// A canary rollout first replaces old machines with the given number of canary machines, then it pauses until
+// all the new machines pass the analysis; after that it proceeds in steps, each one scaling up the new MachineSet
+// to the given percentage of desired machines, and pausing again until all the new machines pass the analysis.
+// Within each step, machines are replaced according to the rollingUpdate config params.
+// +kubebuilder:validation:MinProperties=1
+type MachineDeploymentRolloutStrategyCanary struct {
+	// replicas is the number of canary machines to roll out before pausing for the first analysis.
+	// Defaults to 1.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// steps is the list of percentages of desired machines the new MachineSet is scaled up to
+	// after the canary machines passed the analysis; steps must be in increasing order.
+	// The rollout always completes with a final step at 100%.
+	// Example: when this is set to [25, 50], the rollout pauses at the canary machines, then at 25%
+	// and then at 50% of desired machines before rolling out all the remaining machines.
+	// +optional
+	// +listType=atomic
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=10
+	// +kubebuilder:validation:items:Minimum=1
+	// +kubebuilder:validation:items:Maximum=100
+	Steps []int32 `json:"steps,omitempty"`
+
+	// analysis defines the gate that must be passed by the new machines before the rollout proceeds to the next step.
+	// +optional
+	Analysis MachineDeploymentRolloutStrategyCanaryAnalysis `json:"analysis,omitempty,omitzero"`
+}
+
+// MachineDeploymentRolloutStrategyCanaryAnalysis defines the gate that must be passed by the new machines
+// before a canary rollout proceeds to the next step.
+// NOTE: A new machine always has to be available in order to pass the analysis.
+// +kubebuilder:validation:MinProperties=1
+type MachineDeploymentRolloutStrategyCanaryAnalysis struct {
+	// conditions is a list of Machine condition types that must be true on a new machine
+	// for the machine to pass the analysis, e.g. conditions set by an external controller
+	// after checking workloads running on the machine.
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=32
+	// +kubebuilder:validation:items:Pattern=`^([a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*/)?(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])$`
+	// +kubebuilder:validation:items:MinLength=1
+	// +kubebuilder:validation:items:MaxLength=316
+	Conditions []string `json:"conditions,omitempty"`
+}
+
 // MachineDeploymentRemediationSpec controls how unhealthy Machines are remediated.
 // +kubebuilder:validation:MinProperties=1
 type MachineDeploymentRemediationSpec struct {
@@ -414,7 +474,7 @@ type MachineNamingSpec struct {
 // +kubebuilder:validation:MinProperties=1
 type MachineDeploymentDeletionSpec struct {
 	// order defines the order in which Machines are deleted when downscaling.
-	// Defaults to "Random".  Valid values are "Random, "Newest", "Oldest"
+	// Defaults to "Random".  Valid values are "Random, "Newest", "Oldest", "LeastDisruptive", "External"
 	// +optional
 	Order MachineSetDeletionOrder `json:"order,omitempty"`
 }
@@ -43,6 +43,24 @@ const (
 	MachineDrainRuleDrainBehaviorWaitCompleted MachineDrainRuleDrainBehavior = "WaitCompleted"
 )
 
+// MachineDrainRuleDrainTimeoutBehavior defines the behavior after the drain timeout of a MachineDrainRule is reached.
+// Can be either "Skip", "Drain", or "Delete".
+// +kubebuilder:validation:Enum=Skip;Drain;Delete
+type MachineDrainRuleDrainTimeoutBehavior string
+
+const (
+	// MachineDrainRuleDrainTimeoutBehaviorSkip means the Pods are skipped for the rest of the drain
+	// after the timeout is reached.
+	MachineDrainRuleDrainTimeoutBehaviorSkip MachineDrainRuleDrainTimeoutBehavior = "Skip"
+
+	// MachineDrainRuleDrainTimeoutBehaviorDrain means the Pods are drained after the timeout is reached.
+	MachineDrainRuleDrainTimeoutBehaviorDrain MachineDrainRuleDrainTimeoutBehavior = "Drain"
+
+	// MachineDrainRuleDrainTimeoutBehaviorDelete means the Pods are deleted after the timeout is reached.
+	// Note: Deleting a Pod does not respect PodDisruptionBudgets.
+	MachineDrainRuleDrainTimeoutBehaviorDelete MachineDrainRuleDrainTimeoutBehavior = "Delete"
+)
+
 // MachineDrainRuleSpec defines the spec of a MachineDrainRule.
 type MachineDrainRuleSpec struct {
 	// drain configures if and how Pods are drained.
@@ -138,6 +156,56 @@ type MachineDrainRuleDrainConfig struct {
 	// Valid values for order are from -2147483648 to 2147483647 (inclusive).
 	// +optional
 	Order *int32 `json:"order,omitempty"`
+
+	// timeout configures how long to wait for the Pods to which this MachineDrainRule applies
+	// and what happens with them after that.
+	// The timeout starts when Cluster API starts to drain the group of Pods with the order of this MachineDrainRule,
+	// so a single group of stuck Pods doesn't block the drain until the nodeDrainTimeoutSeconds of the Machine is reached.
+	// timeout can only be set if behavior is set to "Drain" or "WaitCompleted".
+	// +optional
+	Timeout MachineDrainRuleDrainTimeout `json:"timeout,omitempty,omitzero"`
+
+	// evictionFallback configures a fallback for Pods for which eviction is blocked by PodDisruptionBudgets,
+	// e.g. because of misconfigured PodDisruptionBudgets: after a deadline, the Pods are deleted instead of being evicted.
+	// evictionFallback can only be set if behavior is set to "Drain".
+	// +optional
+	EvictionFallback MachineDrainRuleEvictionFallback `json:"evictionFallback,omitempty,omitzero"`
+}
+
+// MachineDrainRuleDrainTimeout configures how long to wait for Pods during drain and what happens with them after that.
+type MachineDrainRuleDrainTimeout struct {
+	// seconds is the maximum time in seconds to wait for the Pods.
+	// +required
+	// +kubebuilder:validation:Minimum=1
+	Seconds *int32 `json:"seconds,omitempty"`
+
+	// behavior defines what happens with the Pods after the timeout is reached.
+	// Can be either "Skip", "Drain", or "Delete".
+	// "Skip" means that the Pods will be skipped for the rest of the drain, e.g. Pods still waiting
+	// to be removed from the Node after eviction won't block the drain anymore.
+	// "Drain" means that the Pods will be evicted, it can only be used if behavior of the MachineDrainRule
+	// is set to "WaitCompleted".
+	// "Delete" means that the Pods will be deleted instead of being evicted, this does not respect
+	// PodDisruptionBudgets.
+	// +required
+	Behavior MachineDrainRuleDrainTimeoutBehavior `json:"behavior,omitempty"`
+}
+
+// MachineDrainRuleEvictionFallback configures a fallback for Pods for which eviction is blocked by PodDisruptionBudgets.
+type MachineDrainRuleEvictionFallback struct {
+	// deleteAfterSeconds is the time in seconds after which Pods for which eviction is blocked by
+	// PodDisruptionBudgets are deleted.
+	// The time starts when the eviction of a Pod is blocked by PodDisruptionBudgets for the first time.
+	// Note: The time is tracked in memory, so it starts again if the Machine controller is restarted.
+	// +required
+	// +kubebuilder:validation:Minimum=1
+	DeleteAfterSeconds *int32 `json:"deleteAfterSeconds,omitempty"`
+
+	// gracePeriodSeconds is the grace period in seconds used when deleting Pods.
+	// If not set, the terminationGracePeriodSeconds of the Pods is used.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	GracePeriodSeconds *int32 `json:"gracePeriodSeconds,omitempty"`
 }
 
 // MachineDrainRuleMachineSelector defines to which Machines this MachineDrainRule should be applied.
@@ -113,7 +113,7 @@ type MachineSetSpec struct {
 // +kubebuilder:validation:MinProperties=1
 type MachineSetDeletionSpec struct {
 	// order defines the order in which Machines are deleted when downscaling.
-	// Defaults to "Random".  Valid values are "Random, "Newest", "Oldest"
+	// Defaults to "Random".  Valid values are "Random, "Newest", "Oldest", "LeastDisruptive", "External"
 	// +optional
 	Order MachineSetDeletionOrder `json:"order,omitempty"`
 }
@@ -271,7 +271,7 @@ type MachineTemplateSpec struct {
 
 // MachineSetDeletionOrder defines how priority is assigned to nodes to delete when
 // downscaling a MachineSet. Defaults to "Random".
-// +kubebuilder:validation:Enum=Random;Newest;Oldest
+// +kubebuilder:validation:Enum=Random;Newest;Oldest;LeastDisruptive;External
 type MachineSetDeletionOrder string
 
 const (
@@ -295,6 +295,25 @@ const (
 	// or NodeHealthy type of Status.Conditions is not true).
 	// It then prioritizes the oldest Machines for deletion based on the Machine's CreationTimestamp.
 	OldestMachineSetDeletionOrder MachineSetDeletionOrder = "Oldest"
+
+	// LeastDisruptiveMachineSetDeletionOrder prioritizes both Machines that have the annotation
+	// "cluster.x-k8s.io/delete-machine=yes" and Machines that are unhealthy
+	// (Status.FailureReason or Status.FailureMessage are set to a non-empty value
+	// or NodeHealthy type of Status.Conditions is not true).
+	// It then prioritizes for deletion the Machines whose Node is hosting the fewest Pods protected
+	// by a PodDisruptionBudget, then the fewest Pods using local storage and finally the fewest Pods.
+	// Note: DaemonSet Pods, static Pods and Pods skipped during drain are not considered.
+	LeastDisruptiveMachineSetDeletionOrder MachineSetDeletionOrder = "LeastDisruptive"
+
+	// ExternalMachineSetDeletionOrder prioritizes both Machines that have the annotation
+	// "cluster.x-k8s.io/delete-machine=yes" and Machines that are unhealthy
+	// (Status.FailureReason or Status.FailureMessage are set to a non-empty value
+	// or NodeHealthy type of Status.Conditions is not true).
+	// It then prioritizes Machines for deletion based on the ranking returned by the
+	// RankMachinesForDeletion Runtime Extension.
+	// Note: This requires the RuntimeSDK feature gate to be enabled and exactly one
+	// RankMachinesForDeletion Runtime Extension to be registered.
+	ExternalMachineSetDeletionOrder MachineSetDeletionOrder = "External"
 )
 
 // MachineSetStatus defines the observed state of MachineSet.
@@ -1499,6 +1499,13 @@ func (in *MachineDeletionStatus) DeepCopyInto(out *MachineDeletionStatus) {
 	*out = *in
 	in.NodeDrainStartTime.DeepCopyInto(&out.NodeDrainStartTime)
 	in.WaitForNodeVolumeDetachStartTime.DeepCopyInto(&out.WaitForNodeVolumeDetachStartTime)
+	if in.NodeDrainGroups != nil {
+		in, out := &in.NodeDrainGroups, &out.NodeDrainGroups
+		*out = make([]MachineNodeDrainGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineDeletionStatus.
@@ -1905,6 +1912,7 @@ func (in *MachineDeploymentRolloutSpec) DeepCopy() *MachineDeploymentRolloutSpec
 func (in *MachineDeploymentRolloutStrategy) DeepCopyInto(out *MachineDeploymentRolloutStrategy) {
 	*out = *in
 	in.RollingUpdate.DeepCopyInto(&out.RollingUpdate)
+	in.Canary.DeepCopyInto(&out.Canary)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineDeploymentRolloutStrategy.
@@ -1917,6 +1925,52 @@ func (in *MachineDeploymentRolloutStrategy) DeepCopy() *MachineDeploymentRollout
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineDeploymentRolloutStrategyCanary) DeepCopyInto(out *MachineDeploymentRolloutStrategyCanary) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	in.Analysis.DeepCopyInto(&out.Analysis)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineDeploymentRolloutStrategyCanary.
+func (in *MachineDeploymentRolloutStrategyCanary) DeepCopy() *MachineDeploymentRolloutStrategyCanary {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineDeploymentRolloutStrategyCanary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineDeploymentRolloutStrategyCanaryAnalysis) DeepCopyInto(out *MachineDeploymentRolloutStrategyCanaryAnalysis) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineDeploymentRolloutStrategyCanaryAnalysis.
+func (in *MachineDeploymentRolloutStrategyCanaryAnalysis) DeepCopy() *MachineDeploymentRolloutStrategyCanaryAnalysis {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineDeploymentRolloutStrategyCanaryAnalysis)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MachineDeploymentRolloutStrategyRollingUpdate) DeepCopyInto(out *MachineDeploymentRolloutStrategyRollingUpdate) {
 	*out = *in
@@ -2338,6 +2392,8 @@ func (in *MachineDrainRuleDrainConfig) DeepCopyInto(out *MachineDrainRuleDrainCo
 		*out = new(int32)
 		**out = **in
 	}
+	in.Timeout.DeepCopyInto(&out.Timeout)
+	in.EvictionFallback.DeepCopyInto(&out.EvictionFallback)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineDrainRuleDrainConfig.
@@ -2350,6 +2406,51 @@ func (in *MachineDrainRuleDrainConfig) DeepCopy() *MachineDrainRuleDrainConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineDrainRuleDrainTimeout) DeepCopyInto(out *MachineDrainRuleDrainTimeout) {
+	*out = *in
+	if in.Seconds != nil {
+		in, out := &in.Seconds, &out.Seconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineDrainRuleDrainTimeout.
+func (in *MachineDrainRuleDrainTimeout) DeepCopy() *MachineDrainRuleDrainTimeout {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineDrainRuleDrainTimeout)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineDrainRuleEvictionFallback) DeepCopyInto(out *MachineDrainRuleEvictionFallback) {
+	*out = *in
+	if in.DeleteAfterSeconds != nil {
+		in, out := &in.DeleteAfterSeconds, &out.DeleteAfterSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.GracePeriodSeconds != nil {
+		in, out := &in.GracePeriodSeconds, &out.GracePeriodSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineDrainRuleEvictionFallback.
+func (in *MachineDrainRuleEvictionFallback) DeepCopy() *MachineDrainRuleEvictionFallback {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineDrainRuleEvictionFallback)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MachineDrainRuleList) DeepCopyInto(out *MachineDrainRuleList) {
 	*out = *in
@@ -2786,6 +2887,27 @@ func (in *MachineNamingSpec) DeepCopy() *MachineNamingSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineNodeDrainGroup) DeepCopyInto(out *MachineNodeDrainGroup) {
+	*out = *in
+	if in.Order != nil {
+		in, out := &in.Order, &out.Order
+		*out = new(int32)
+		**out = **in
+	}
+	in.StartTime.DeepCopyInto(&out.StartTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineNodeDrainGroup.
+func (in *MachineNodeDrainGroup) DeepCopy() *MachineNodeDrainGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineNodeDrainGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MachineNodeReference) DeepCopyInto(out *MachineNodeReference) {
 	*out = *in
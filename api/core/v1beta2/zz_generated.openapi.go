@@ -103,6 +103,8 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDeploymentRemediationSpec":                         schema_cluster_api_api_core_v1beta2_MachineDeploymentRemediationSpec(ref),
 		"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDeploymentRolloutSpec":                             schema_cluster_api_api_core_v1beta2_MachineDeploymentRolloutSpec(ref),
 		"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDeploymentRolloutStrategy":                         schema_cluster_api_api_core_v1beta2_MachineDeploymentRolloutStrategy(ref),
+		"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDeploymentRolloutStrategyCanary":                   schema_cluster_api_api_core_v1beta2_MachineDeploymentRolloutStrategyCanary(ref),
+		"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDeploymentRolloutStrategyCanaryAnalysis":           schema_cluster_api_api_core_v1beta2_MachineDeploymentRolloutStrategyCanaryAnalysis(ref),
 		"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDeploymentRolloutStrategyRollingUpdate":            schema_cluster_api_api_core_v1beta2_MachineDeploymentRolloutStrategyRollingUpdate(ref),
 		"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDeploymentSpec":                                    schema_cluster_api_api_core_v1beta2_MachineDeploymentSpec(ref),
 		"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDeploymentStatus":                                  schema_cluster_api_api_core_v1beta2_MachineDeploymentStatus(ref),
@@ -120,6 +122,8 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDeprecatedStatus":                                  schema_cluster_api_api_core_v1beta2_MachineDeprecatedStatus(ref),
 		"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDrainRule":                                         schema_cluster_api_api_core_v1beta2_MachineDrainRule(ref),
 		"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDrainRuleDrainConfig":                              schema_cluster_api_api_core_v1beta2_MachineDrainRuleDrainConfig(ref),
+		"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDrainRuleDrainTimeout":                             schema_cluster_api_api_core_v1beta2_MachineDrainRuleDrainTimeout(ref),
+		"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDrainRuleEvictionFallback":                         schema_cluster_api_api_core_v1beta2_MachineDrainRuleEvictionFallback(ref),
 		"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDrainRuleList":                                     schema_cluster_api_api_core_v1beta2_MachineDrainRuleList(ref),
 		"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDrainRuleMachineSelector":                          schema_cluster_api_api_core_v1beta2_MachineDrainRuleMachineSelector(ref),
 		"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDrainRulePodSelector":                              schema_cluster_api_api_core_v1beta2_MachineDrainRulePodSelector(ref),
@@ -137,6 +141,7 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineInitializationStatus":                              schema_cluster_api_api_core_v1beta2_MachineInitializationStatus(ref),
 		"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineList":                                              schema_cluster_api_api_core_v1beta2_MachineList(ref),
 		"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineNamingSpec":                                        schema_cluster_api_api_core_v1beta2_MachineNamingSpec(ref),
+		"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineNodeDrainGroup":                                    schema_cluster_api_api_core_v1beta2_MachineNodeDrainGroup(ref),
 		"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineNodeReference":                                     schema_cluster_api_api_core_v1beta2_MachineNodeReference(ref),
 		"sigs.k8s.io/cluster-api/api/core/v1beta2.MachinePool":                                              schema_cluster_api_api_core_v1beta2_MachinePool(ref),
 		"sigs.k8s.io/cluster-api/api/core/v1beta2.MachinePoolClass":                                         schema_cluster_api_api_core_v1beta2_MachinePoolClass(ref),
@@ -2779,11 +2784,33 @@ func schema_cluster_api_api_core_v1beta2_MachineDeletionStatus(ref common.Refere
 							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
 						},
 					},
+					"nodeDrainGroups": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"order",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "nodeDrainGroups contains the times when the drain of groups of Pods with the same drain order started and is used to determine if the timeouts of MachineDrainRules are exceeded. Only groups of Pods to which a MachineDrainRule with a timeout applies are tracked.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("sigs.k8s.io/cluster-api/api/core/v1beta2.MachineNodeDrainGroup"),
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time", "sigs.k8s.io/cluster-api/api/core/v1beta2.MachineNodeDrainGroup"},
 	}
 }
 
@@ -3153,7 +3180,7 @@ func schema_cluster_api_api_core_v1beta2_MachineDeploymentClassMachineDeletionSp
 				Properties: map[string]spec.Schema{
 					"order": {
 						SchemaProps: spec.SchemaProps{
-							Description: "order defines the order in which Machines are deleted when downscaling. Defaults to \"Random\".  Valid values are \"Random, \"Newest\", \"Oldest\"",
+							Description: "order defines the order in which Machines are deleted when downscaling. Defaults to \"Random\".  Valid values are \"Random, \"Newest\", \"Oldest\", \"LeastDisruptive\", \"External\"",
 							Type:        []string{"string"},
 							Format:      "",
 						},
@@ -3236,7 +3263,7 @@ func schema_cluster_api_api_core_v1beta2_MachineDeploymentClassRolloutStrategy(r
 				Properties: map[string]spec.Schema{
 					"type": {
 						SchemaProps: spec.SchemaProps{
-							Description: "type of rollout. Allowed values are RollingUpdate and OnDelete. Default is RollingUpdate.",
+							Description: "type of rollout. Allowed values are RollingUpdate and OnDelete. Default is RollingUpdate. NOTE: The Canary rollout strategy is not supported for MachineDeployments in a managed topology.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
@@ -3293,7 +3320,7 @@ func schema_cluster_api_api_core_v1beta2_MachineDeploymentDeletionSpec(ref commo
 				Properties: map[string]spec.Schema{
 					"order": {
 						SchemaProps: spec.SchemaProps{
-							Description: "order defines the order in which Machines are deleted when downscaling. Defaults to \"Random\".  Valid values are \"Random, \"Newest\", \"Oldest\"",
+							Description: "order defines the order in which Machines are deleted when downscaling. Defaults to \"Random\".  Valid values are \"Random, \"Newest\", \"Oldest\", \"LeastDisruptive\", \"External\"",
 							Type:        []string{"string"},
 							Format:      "",
 						},
@@ -3434,24 +3461,113 @@ func schema_cluster_api_api_core_v1beta2_MachineDeploymentRolloutStrategy(ref co
 				Properties: map[string]spec.Schema{
 					"type": {
 						SchemaProps: spec.SchemaProps{
-							Description: "type of rollout. Allowed values are RollingUpdate and OnDelete. Default is RollingUpdate.",
+							Description: "type of rollout. Allowed values are RollingUpdate, OnDelete and Canary. Default is RollingUpdate.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
 					"rollingUpdate": {
 						SchemaProps: spec.SchemaProps{
-							Description: "rollingUpdate is the rolling update config params. Present only if type = RollingUpdate.",
+							Description: "rollingUpdate is the rolling update config params. Present only if type = RollingUpdate or type = Canary.",
 							Default:     map[string]interface{}{},
 							Ref:         ref("sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDeploymentRolloutStrategyRollingUpdate"),
 						},
 					},
+					"canary": {
+						SchemaProps: spec.SchemaProps{
+							Description: "canary is the canary config params. Present only if type = Canary.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDeploymentRolloutStrategyCanary"),
+						},
+					},
 				},
 				Required: []string{"type"},
 			},
 		},
 		Dependencies: []string{
-			"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDeploymentRolloutStrategyRollingUpdate"},
+			"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDeploymentRolloutStrategyCanary", "sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDeploymentRolloutStrategyRollingUpdate"},
+	}
+}
+
+func schema_cluster_api_api_core_v1beta2_MachineDeploymentRolloutStrategyCanary(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "MachineDeploymentRolloutStrategyCanary is used to control the desired behavior of a canary rollout.\n\nA canary rollout first replaces old machines with the given number of canary machines, then it pauses until all the new machines pass the analysis; after that it proceeds in steps, each one scaling up the new MachineSet to the given percentage of desired machines, and pausing again until all the new machines pass the analysis. Within each step, machines are replaced according to the rollingUpdate config params.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"replicas": {
+						SchemaProps: spec.SchemaProps{
+							Description: "replicas is the number of canary machines to roll out before pausing for the first analysis. Defaults to 1.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"steps": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "atomic",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "steps is the list of percentages of desired machines the new MachineSet is scaled up to after the canary machines passed the analysis; steps must be in increasing order. The rollout always completes with a final step at 100%. Example: when this is set to [25, 50], the rollout pauses at the canary machines, then at 25% and then at 50% of desired machines before rolling out all the remaining machines.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: 0,
+										Type:    []string{"integer"},
+										Format:  "int32",
+									},
+								},
+							},
+						},
+					},
+					"analysis": {
+						SchemaProps: spec.SchemaProps{
+							Description: "analysis defines the gate that must be passed by the new machines before the rollout proceeds to the next step.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDeploymentRolloutStrategyCanaryAnalysis"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDeploymentRolloutStrategyCanaryAnalysis"},
+	}
+}
+
+func schema_cluster_api_api_core_v1beta2_MachineDeploymentRolloutStrategyCanaryAnalysis(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "MachineDeploymentRolloutStrategyCanaryAnalysis defines the gate that must be passed by the new machines before a canary rollout proceeds to the next step. NOTE: A new machine always has to be available in order to pass the analysis.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"conditions": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "set",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "conditions is a list of Machine condition types that must be true on a new machine for the machine to pass the analysis, e.g. conditions set by an external controller after checking workloads running on the machine.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 }
 
@@ -3929,7 +4045,7 @@ func schema_cluster_api_api_core_v1beta2_MachineDeploymentTopologyMachineDeletio
 				Properties: map[string]spec.Schema{
 					"order": {
 						SchemaProps: spec.SchemaProps{
-							Description: "order defines the order in which Machines are deleted when downscaling. Defaults to \"Random\".  Valid values are \"Random, \"Newest\", \"Oldest\"",
+							Description: "order defines the order in which Machines are deleted when downscaling. Defaults to \"Random\".  Valid values are \"Random, \"Newest\", \"Oldest\", \"LeastDisruptive\", \"External\"",
 							Type:        []string{"string"},
 							Format:      "",
 						},
@@ -3992,7 +4108,7 @@ func schema_cluster_api_api_core_v1beta2_MachineDeploymentTopologyRolloutStrateg
 				Properties: map[string]spec.Schema{
 					"type": {
 						SchemaProps: spec.SchemaProps{
-							Description: "type of rollout. Allowed values are RollingUpdate and OnDelete. Default is RollingUpdate.",
+							Description: "type of rollout. Allowed values are RollingUpdate and OnDelete. Default is RollingUpdate. NOTE: The Canary rollout strategy is not supported for MachineDeployments in a managed topology.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
@@ -4224,10 +4340,82 @@ func schema_cluster_api_api_core_v1beta2_MachineDrainRuleDrainConfig(ref common.
 							Format:      "int32",
 						},
 					},
+					"timeout": {
+						SchemaProps: spec.SchemaProps{
+							Description: "timeout configures how long to wait for the Pods to which this MachineDrainRule applies and what happens with them after that. The timeout starts when Cluster API starts to drain the group of Pods with the order of this MachineDrainRule, so a single group of stuck Pods doesn't block the drain until the nodeDrainTimeoutSeconds of the Machine is reached. timeout can only be set if behavior is set to \"Drain\" or \"WaitCompleted\".",
+							Default:     map[string]interface{}{},
+							Ref:         ref("sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDrainRuleDrainTimeout"),
+						},
+					},
+					"evictionFallback": {
+						SchemaProps: spec.SchemaProps{
+							Description: "evictionFallback configures a fallback for Pods for which eviction is blocked by PodDisruptionBudgets, e.g. because of misconfigured PodDisruptionBudgets: after a deadline, the Pods are deleted instead of being evicted. evictionFallback can only be set if behavior is set to \"Drain\".",
+							Default:     map[string]interface{}{},
+							Ref:         ref("sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDrainRuleEvictionFallback"),
+						},
+					},
 				},
 				Required: []string{"behavior"},
 			},
 		},
+		Dependencies: []string{
+			"sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDrainRuleDrainTimeout", "sigs.k8s.io/cluster-api/api/core/v1beta2.MachineDrainRuleEvictionFallback"},
+	}
+}
+
+func schema_cluster_api_api_core_v1beta2_MachineDrainRuleDrainTimeout(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "MachineDrainRuleDrainTimeout configures how long to wait for Pods during drain and what happens with them after that.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"seconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "seconds is the maximum time in seconds to wait for the Pods.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"behavior": {
+						SchemaProps: spec.SchemaProps{
+							Description: "behavior defines what happens with the Pods after the timeout is reached. Can be either \"Skip\", \"Drain\", or \"Delete\". \"Skip\" means that the Pods will be skipped for the rest of the drain, e.g. Pods still waiting to be removed from the Node after eviction won't block the drain anymore. \"Drain\" means that the Pods will be evicted, it can only be used if behavior of the MachineDrainRule is set to \"WaitCompleted\". \"Delete\" means that the Pods will be deleted instead of being evicted, this does not respect PodDisruptionBudgets.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"seconds", "behavior"},
+			},
+		},
+	}
+}
+
+func schema_cluster_api_api_core_v1beta2_MachineDrainRuleEvictionFallback(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "MachineDrainRuleEvictionFallback configures a fallback for Pods for which eviction is blocked by PodDisruptionBudgets.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"deleteAfterSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "deleteAfterSeconds is the time in seconds after which Pods for which eviction is blocked by PodDisruptionBudgets are deleted. The time starts when the eviction of a Pod is blocked by PodDisruptionBudgets for the first time. Note: The time is tracked in memory, so it starts again if the Machine controller is restarted.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"gracePeriodSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "gracePeriodSeconds is the grace period in seconds used when deleting Pods. If not set, the terminationGracePeriodSeconds of the Pods is used.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+				Required: []string{"deleteAfterSeconds"},
+			},
+		},
 	}
 }
 
@@ -4934,6 +5122,35 @@ func schema_cluster_api_api_core_v1beta2_MachineNamingSpec(ref common.ReferenceC
 	}
 }
 
+func schema_cluster_api_api_core_v1beta2_MachineNodeDrainGroup(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "MachineNodeDrainGroup is the drain state of a group of Pods with the same drain order.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"order": {
+						SchemaProps: spec.SchemaProps{
+							Description: "order is the drain order of the group of Pods.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"startTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "startTime is the time when the drain of the group of Pods started.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+				},
+				Required: []string{"order", "startTime"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
 func schema_cluster_api_api_core_v1beta2_MachineNodeReference(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -5786,7 +6003,7 @@ func schema_cluster_api_api_core_v1beta2_MachineSetDeletionSpec(ref common.Refer
 				Properties: map[string]spec.Schema{
 					"order": {
 						SchemaProps: spec.SchemaProps{
-							Description: "order defines the order in which Machines are deleted when downscaling. Defaults to \"Random\".  Valid values are \"Random, \"Newest\", \"Oldest\"",
+							Description: "order defines the order in which Machines are deleted when downscaling. Defaults to \"Random\".  Valid values are \"Random, \"Newest\", \"Oldest\", \"LeastDisruptive\", \"External\"",
 							Type:        []string{"string"},
 							Format:      "",
 						},
@@ -0,0 +1,150 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	runtimecatalog "sigs.k8s.io/cluster-api/exp/runtime/catalog"
+)
+
+// RankMachinesForDeletionRequest is the request of the RankMachinesForDeletion hook.
+// +kubebuilder:object:root=true
+type RankMachinesForDeletionRequest struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// CommonRequest contains fields common to all request types.
+	CommonRequest `json:",inline"`
+
+	// machineSet is the full MachineSet object.
+	// +required
+	MachineSet clusterv1.MachineSet `json:"machineSet,omitempty,omitzero"`
+
+	// machines is the list of Machines of the MachineSet that are candidates for deletion.
+	// +optional
+	// +listType=atomic
+	// +kubebuilder:validation:MaxItems=10000
+	Machines []clusterv1.Machine `json:"machines,omitempty"`
+}
+
+var _ ResponseObject = &RankMachinesForDeletionResponse{}
+
+// RankMachinesForDeletionResponse is the response of the RankMachinesForDeletion hook.
+// +kubebuilder:object:root=true
+type RankMachinesForDeletionResponse struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// CommonResponse contains Status and Message fields common to all response types.
+	CommonResponse `json:",inline"`
+
+	// machineNames is the list of names of the Machines ranked for deletion, the Machine that
+	// should be deleted first goes first.
+	// Machines not included in the list are deleted after all the Machines included in the list.
+	// +optional
+	// +listType=atomic
+	// +kubebuilder:validation:MaxItems=10000
+	// +kubebuilder:validation:items:MinLength=1
+	// +kubebuilder:validation:items:MaxLength=253
+	MachineNames []string `json:"machineNames,omitempty"`
+}
+
+// RankMachinesForDeletion is the hook that will be called to rank the Machines of a MachineSet
+// using the External deletion order.
+func RankMachinesForDeletion(*RankMachinesForDeletionRequest, *RankMachinesForDeletionResponse) {}
+
+func init() {
+	catalogBuilder.RegisterHook(RankMachinesForDeletion, &runtimecatalog.HookMeta{
+		Tags:    []string{"MachineSet Hooks"},
+		Summary: "Cluster API Runtime will call this hook to rank the Machines of a MachineSet for deletion",
+		Description: "Cluster API Runtime will call this hook when a MachineSet with deletion order External has to pick " +
+			"the Machines to delete, e.g. when scaling down. " +
+			"The request contains the MachineSet and the Machines that are candidates for deletion. " +
+			"Extensions should return the names of the Machines ranked for deletion, the Machine that should be deleted first goes first.\n" +
+			"\n" +
+			"Notes:\n" +
+			"- Machines that are already deleting, that have the delete-machine annotation, that are updating in-place or that are unhealthy " +
+			"are always deleted before the Machines ranked by the extension\n" +
+			"- Machines not included in the response are deleted after all the Machines included in the response\n" +
+			"- Only one extension can be registered for this hook\n",
+	})
+}
+
+// BeforeMachineCreateOperation is the operation the Machines of a MachineSet are created for.
+type BeforeMachineCreateOperation string
+
+const (
+	// BeforeMachineCreateOperationScaleUp is used when Machines are created because the MachineSet is scaling up.
+	BeforeMachineCreateOperationScaleUp BeforeMachineCreateOperation = "ScaleUp"
+
+	// BeforeMachineCreateOperationRemediation is used when unhealthy Machines are remediated, i.e. they are
+	// deleted and then replaced by new Machines.
+	BeforeMachineCreateOperationRemediation BeforeMachineCreateOperation = "Remediation"
+)
+
+// BeforeMachineCreateRequest is the request of the BeforeMachineCreate hook.
+// +kubebuilder:object:root=true
+type BeforeMachineCreateRequest struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// CommonRequest contains fields common to all request types.
+	CommonRequest `json:",inline"`
+
+	// cluster is the full Cluster object the MachineSet belongs to.
+	// +required
+	Cluster clusterv1.Cluster `json:"cluster,omitempty,omitzero"`
+
+	// machineSet is the full MachineSet object.
+	// +required
+	MachineSet clusterv1.MachineSet `json:"machineSet,omitempty,omitzero"`
+
+	// operation is the operation the Machines are going to be created for.
+	// +required
+	// +kubebuilder:validation:Enum=ScaleUp;Remediation
+	Operation BeforeMachineCreateOperation `json:"operation,omitempty"`
+}
+
+var _ RetryResponseObject = &BeforeMachineCreateResponse{}
+
+// BeforeMachineCreateResponse is the response of the BeforeMachineCreate hook.
+// +kubebuilder:object:root=true
+type BeforeMachineCreateResponse struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// CommonRetryResponse contains Status, Message and RetryAfterSeconds fields.
+	CommonRetryResponse `json:",inline"`
+}
+
+// BeforeMachineCreate is the hook that will be called before a MachineSet creates Machines.
+func BeforeMachineCreate(*BeforeMachineCreateRequest, *BeforeMachineCreateResponse) {}
+
+func init() {
+	catalogBuilder.RegisterHook(BeforeMachineCreate, &runtimecatalog.HookMeta{
+		Tags:    []string{"MachineSet Hooks"},
+		Summary: "Cluster API Runtime will call this hook before a MachineSet creates Machines",
+		Description: "Cluster API Runtime will call this hook as a MachineSet preflight check before creating Machines, " +
+			"i.e. when scaling up or when replacing unhealthy Machines during remediation. " +
+			"The request contains the Cluster, the MachineSet and the operation the Machines are going to be created for. " +
+			"Extensions can block the creation of Machines, e.g. because of quota, IPAM capacity or change-freeze windows.\n" +
+			"\n" +
+			"Notes:\n" +
+			"- This hook is called only if the MachineSetPreflightChecks feature gate is enabled and the \"BeforeMachineCreateHook\" preflight check is enabled\n" +
+			"- If RetryAfterSeconds is set to a non-zero value, the creation of Machines is blocked and the message of the response " +
+			"is surfaced in the conditions of the MachineSet; the hook is called again when the preflight checks are re-evaluated\n" +
+			"- All the extensions registered for this hook are called, the creation of Machines is blocked if any of them returns a non-zero RetryAfterSeconds\n",
+	})
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+
+	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	"sigs.k8s.io/cluster-api/util/secret"
+)
+
+const (
+	// defaultKubeadmPatchesDirectory is the directory where the kubeadm patches for the certificate authorities
+	// rotation are written when the KubeadmConfig does not define a patches directory.
+	defaultKubeadmPatchesDirectory = "/etc/kubernetes/patches"
+
+	// caRotationKubeadmPatchSuffix is the suffix of the kubeadm patches for the certificate authorities rotation.
+	caRotationKubeadmPatchSuffix = "-ca-rotation"
+)
+
+// caRotationKubeadmPatches defines, for each control plane component, the flags which must point to the file
+// containing all the certificate authorities trusted while the cluster CA is being rotated.
+var caRotationKubeadmPatches = []struct {
+	target string
+	flags  []string
+}{
+	{target: "kube-apiserver", flags: []string{"client-ca-file"}},
+	{target: "kube-controller-manager", flags: []string{"client-ca-file", "root-ca-file"}},
+}
+
+// jsonPatchOperation is an operation of a JSON patch.
+type jsonPatchOperation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path"`
+	Value string `json:"value"`
+}
+
+// trustRotatingCertificateAuthorities configures the kubelet and the control plane components of a control plane
+// Machine to trust all the certificate authorities trusted by the cluster CA while it is being rotated.
+// This is required because the cluster CA file on the Machine contains only the certificate authority used for signing,
+// as required by the CSR signer of kube-controller-manager, while the trusted ones are in a separate file.
+// nodeRegistration and patches are changed in place, so callers must pass a copy of the KubeadmConfig spec;
+// the returned files are the kubeadm patches to be written on the Machine.
+// NOTE: kubeadm patches are used because kubeadm join reads the ClusterConfiguration from the cluster, so the
+// control plane components extra args can't be changed per Machine.
+func trustRotatingCertificateAuthorities(certificates secret.Certificates, nodeRegistration *bootstrapv1.NodeRegistrationOptions, patches *bootstrapv1.Patches) ([]bootstrapv1.File, error) {
+	clusterCA := certificates.GetByPurpose(secret.ClusterCA)
+	if clusterCA == nil || clusterCA.TrustedCertFile == "" || !clusterCA.IsRotating() {
+		return nil, nil
+	}
+
+	setKubeletExtraArg(nodeRegistration, "client-ca-file", clusterCA.TrustedCertFile)
+
+	if patches.Directory == "" {
+		patches.Directory = defaultKubeadmPatchesDirectory
+	}
+	files := make([]bootstrapv1.File, 0, len(caRotationKubeadmPatches))
+	for _, p := range caRotationKubeadmPatches {
+		operations := make([]jsonPatchOperation, 0, len(p.flags))
+		for _, flag := range p.flags {
+			// Note: The flag is appended to the command of the static Pod, so it overrides the one set by kubeadm.
+			operations = append(operations, jsonPatchOperation{
+				Op:    "add",
+				Path:  "/spec/containers/0/command/-",
+				Value: fmt.Sprintf("--%s=%s", flag, clusterCA.TrustedCertFile),
+			})
+		}
+		content, err := json.Marshal(operations)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to marshal kubeadm patch for %s", p.target)
+		}
+		files = append(files, bootstrapv1.File{
+			Path:        path.Join(patches.Directory, fmt.Sprintf("%s%s+json.json", p.target, caRotationKubeadmPatchSuffix)),
+			Owner:       "root:root",
+			Permissions: "0640",
+			Content:     string(content),
+		})
+	}
+	return files, nil
+}
+
+// setKubeletExtraArg sets a kubelet extra arg, overriding the value set by users if any.
+func setKubeletExtraArg(nodeRegistration *bootstrapv1.NodeRegistrationOptions, arg, value string) {
+	for i := range nodeRegistration.KubeletExtraArgs {
+		if nodeRegistration.KubeletExtraArgs[i].Name == arg {
+			nodeRegistration.KubeletExtraArgs[i].Value = ptr.To(value)
+			return
+		}
+	}
+	nodeRegistration.KubeletExtraArgs = append(nodeRegistration.KubeletExtraArgs, bootstrapv1.Arg{Name: arg, Value: ptr.To(value)})
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	"sigs.k8s.io/cluster-api/util/secret"
+)
+
+func TestTrustRotatingCertificateAuthorities(t *testing.T) {
+	tests := []struct {
+		name                 string
+		rotating             bool
+		joinConfiguration    bootstrapv1.JoinConfiguration
+		wantKubeletExtraArgs []bootstrapv1.Arg
+		wantPatchesDirectory string
+		wantFiles            []bootstrapv1.File
+	}{
+		{
+			name:     "does nothing if the cluster CA is not being rotated",
+			rotating: false,
+		},
+		{
+			name:     "trusts the rotating certificate authorities",
+			rotating: true,
+			joinConfiguration: bootstrapv1.JoinConfiguration{
+				NodeRegistration: bootstrapv1.NodeRegistrationOptions{
+					KubeletExtraArgs: []bootstrapv1.Arg{{Name: "v", Value: ptr.To("2")}},
+				},
+			},
+			wantKubeletExtraArgs: []bootstrapv1.Arg{
+				{Name: "v", Value: ptr.To("2")},
+				{Name: "client-ca-file", Value: ptr.To("/etc/kubernetes/pki/ca-bundle.crt")},
+			},
+			wantPatchesDirectory: "/etc/kubernetes/patches",
+			wantFiles: []bootstrapv1.File{
+				{
+					Path:        "/etc/kubernetes/patches/kube-apiserver-ca-rotation+json.json",
+					Owner:       "root:root",
+					Permissions: "0640",
+					Content:     `[{"op":"add","path":"/spec/containers/0/command/-","value":"--client-ca-file=/etc/kubernetes/pki/ca-bundle.crt"}]`,
+				},
+				{
+					Path:        "/etc/kubernetes/patches/kube-controller-manager-ca-rotation+json.json",
+					Owner:       "root:root",
+					Permissions: "0640",
+					Content: `[{"op":"add","path":"/spec/containers/0/command/-","value":"--client-ca-file=/etc/kubernetes/pki/ca-bundle.crt"},` +
+						`{"op":"add","path":"/spec/containers/0/command/-","value":"--root-ca-file=/etc/kubernetes/pki/ca-bundle.crt"}]`,
+				},
+			},
+		},
+		{
+			name:     "respects the patches directory and overrides the kubelet client-ca-file arg set by users",
+			rotating: true,
+			joinConfiguration: bootstrapv1.JoinConfiguration{
+				NodeRegistration: bootstrapv1.NodeRegistrationOptions{
+					KubeletExtraArgs: []bootstrapv1.Arg{{Name: "client-ca-file", Value: ptr.To("/etc/kubernetes/pki/ca.crt")}},
+				},
+				Patches: bootstrapv1.Patches{
+					Directory: "/tmp/patches",
+				},
+			},
+			wantKubeletExtraArgs: []bootstrapv1.Arg{
+				{Name: "client-ca-file", Value: ptr.To("/etc/kubernetes/pki/ca-bundle.crt")},
+			},
+			wantPatchesDirectory: "/tmp/patches",
+			wantFiles: []bootstrapv1.File{
+				{
+					Path:        "/tmp/patches/kube-apiserver-ca-rotation+json.json",
+					Owner:       "root:root",
+					Permissions: "0640",
+					Content:     `[{"op":"add","path":"/spec/containers/0/command/-","value":"--client-ca-file=/etc/kubernetes/pki/ca-bundle.crt"}]`,
+				},
+				{
+					Path:        "/tmp/patches/kube-controller-manager-ca-rotation+json.json",
+					Owner:       "root:root",
+					Permissions: "0640",
+					Content: `[{"op":"add","path":"/spec/containers/0/command/-","value":"--client-ca-file=/etc/kubernetes/pki/ca-bundle.crt"},` +
+						`{"op":"add","path":"/spec/containers/0/command/-","value":"--root-ca-file=/etc/kubernetes/pki/ca-bundle.crt"}]`,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			certificates := secret.NewControlPlaneJoinCerts(&bootstrapv1.ClusterConfiguration{})
+			g.Expect(certificates.Generate()).To(Succeed())
+			for _, c := range certificates {
+				c.Secret = c.AsSecret(client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: "test"}, metav1.OwnerReference{})
+			}
+			if tt.rotating {
+				_, err := certificates.GetByPurpose(secret.ClusterCA).AddNextCertificateAuthority()
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+
+			joinConfiguration := tt.joinConfiguration.DeepCopy()
+			files, err := trustRotatingCertificateAuthorities(certificates, &joinConfiguration.NodeRegistration, &joinConfiguration.Patches)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(files).To(Equal(tt.wantFiles))
+			g.Expect(joinConfiguration.NodeRegistration.KubeletExtraArgs).To(Equal(tt.wantKubeletExtraArgs))
+			g.Expect(joinConfiguration.Patches.Directory).To(Equal(tt.wantPatchesDirectory))
+		})
+	}
+}
@@ -347,6 +347,13 @@ func (r *KubeadmConfigReconciler) reconcile(ctx context.Context, scope *Scope, c
 		return r.handleClusterNotInitialized(ctx, scope)
 	}
 
+	// The Machine restoring etcd from a snapshot is bootstrapped using kubeadm init, like the first control plane Machine.
+	// Note: The etcd data is restored by commands the KubeadmControlPlane controller adds to the KubeadmConfig.
+	if _, ok := config.Annotations[bootstrapv1.EtcdRestoreAnnotation]; ok && configOwner.IsControlPlaneMachine() {
+		log.Info("Generating BootstrapData for the control plane Machine restoring etcd from a snapshot")
+		return r.handleClusterNotInitialized(ctx, scope)
+	}
+
 	// Every other case it's a join scenario
 	// Nb. in this case ClusterConfiguration and InitConfiguration should not be defined by users, but in case of misconfigurations, CABPK simply ignore them
 
@@ -867,7 +874,17 @@ func (r *KubeadmConfigReconciler) joinControlplane(ctx context.Context, scope *S
 		return ctrl.Result{}, errors.Wrapf(err, "failed to parse kubernetes version %q", kubernetesVersion)
 	}
 
-	joinData, err := kubeadmtypes.MarshalJoinConfigurationForVersion(&scope.Config.Spec.JoinConfiguration, parsedVersion)
+	// If the cluster CA is being rotated, configure the joining control plane to trust all the certificate authorities
+	// trusted by the cluster CA.
+	// Note: Changes are applied to a copy of the JoinConfiguration, so they are not persisted in the KubeadmConfig.
+	joinConfiguration := scope.Config.Spec.JoinConfiguration.DeepCopy()
+	caRotationFiles, err := trustRotatingCertificateAuthorities(certificates, &joinConfiguration.NodeRegistration, &joinConfiguration.Patches)
+	if err != nil {
+		scope.Error(err, "Failed to configure trusted certificate authorities")
+		return ctrl.Result{}, err
+	}
+
+	joinData, err := kubeadmtypes.MarshalJoinConfigurationForVersion(joinConfiguration, parsedVersion)
 	if err != nil {
 		scope.Error(err, "Failed to marshal join configuration")
 		return ctrl.Result{}, err
@@ -916,6 +933,7 @@ func (r *KubeadmConfigReconciler) joinControlplane(ctx context.Context, scope *S
 		}
 		files = append(files, *kubeconfig)
 	}
+	files = append(files, caRotationFiles...)
 
 	controlPlaneJoinInput := &cloudinit.ControlPlaneJoinInput{
 		JoinConfiguration: joinData,
@@ -1292,7 +1310,7 @@ func (r *KubeadmConfigReconciler) reconcileDiscoveryFile(ctx context.Context, cl
 			log.Error(err, "Unable to set Cluster CA for Discovery.File.KubeConfig")
 			return ctrl.Result{}, err
 		}
-		cfg.Cluster.CertificateAuthorityData = clusterCA.KeyPair.Cert
+		cfg.Cluster.CertificateAuthorityData = clusterCA.TrustedCertificates()
 		log.V(3).Info("Altering JoinConfiguration.Discovery.File.KubeConfig.Cluster.CertificateAuthorityData")
 	}
 
@@ -543,6 +543,56 @@ func TestKubeadmConfigReconciler_Reconcile_GenerateCloudConfigData(t *testing.T)
 	g.Expect(err).ToNot(HaveOccurred())
 }
 
+// The control plane Machine restoring etcd from a snapshot is bootstrapped using kubeadm init, even if the control plane is already initialized.
+func TestKubeadmConfigReconciler_Reconcile_GenerateCloudConfigDataForEtcdRestore(t *testing.T) {
+	g := NewWithT(t)
+
+	configName := "control-plane-etcd-restore-cfg"
+	cluster := builder.Cluster(metav1.NamespaceDefault, "cluster").Build()
+	cluster.Spec.ControlPlaneEndpoint = clusterv1.APIEndpoint{Host: "validhost", Port: 6443}
+	cluster.Status.Initialization.InfrastructureProvisioned = ptr.To(true)
+	cluster.Status.Conditions = []metav1.Condition{{Type: clusterv1.ClusterControlPlaneInitializedCondition, Status: metav1.ConditionTrue}}
+
+	controlPlaneRestoreMachine := newControlPlaneMachine(cluster, "control-plane-etcd-restore-machine")
+	controlPlaneRestoreConfig := newControlPlaneInitKubeadmConfig(controlPlaneRestoreMachine.Namespace, configName)
+	controlPlaneRestoreConfig.Annotations = map[string]string{bootstrapv1.EtcdRestoreAnnotation: "kcp-etcd-snapshot-20260101100000"}
+	addKubeadmConfigToMachine(controlPlaneRestoreConfig, controlPlaneRestoreMachine)
+
+	objects := []client.Object{
+		cluster,
+		controlPlaneRestoreMachine,
+		controlPlaneRestoreConfig,
+	}
+	objects = append(objects, createSecrets(t, cluster, controlPlaneRestoreConfig)...)
+
+	myclient := fake.NewClientBuilder().WithObjects(objects...).WithStatusSubresource(&bootstrapv1.KubeadmConfig{}).Build()
+
+	k := &KubeadmConfigReconciler{
+		Client:              myclient,
+		SecretCachingClient: myclient,
+		ClusterCache:        clustercache.NewFakeClusterCache(myclient, client.ObjectKey{Name: cluster.Name, Namespace: cluster.Namespace}),
+		KubeadmInitLock:     &myInitLocker{},
+	}
+
+	request := ctrl.Request{
+		NamespacedName: client.ObjectKey{
+			Namespace: metav1.NamespaceDefault,
+			Name:      configName,
+		},
+	}
+	result, err := k.Reconcile(ctx, request)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result.RequeueAfter).To(Equal(time.Duration(0)))
+
+	cfg, err := getKubeadmConfig(myclient, configName, metav1.NamespaceDefault)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(ptr.Deref(cfg.Status.Initialization.DataSecretCreated, false)).To(BeTrue())
+
+	s := &corev1.Secret{}
+	g.Expect(myclient.Get(ctx, client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: configName}, s)).To(Succeed())
+	g.Expect(string(s.Data["value"])).To(ContainSubstring("kubeadm init --config /run/kubeadm/kubeadm.yaml"))
+}
+
 // If a control plane has no JoinConfiguration, then we will create a default and no error will occur.
 func TestKubeadmConfigReconciler_Reconcile_ErrorIfJoiningControlPlaneHasInvalidConfiguration(t *testing.T) {
 	g := NewWithT(t)
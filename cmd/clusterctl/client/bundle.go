@@ -0,0 +1,634 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/yaml"
+
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/repository"
+	yamlprocessor "sigs.k8s.io/cluster-api/cmd/clusterctl/client/yamlprocessor"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/scheme"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/util"
+	logf "sigs.k8s.io/cluster-api/cmd/clusterctl/log"
+	utilyaml "sigs.k8s.io/cluster-api/util/yaml"
+)
+
+const (
+	// bundleManifestFile is the name of the file describing the content of a bundle.
+	bundleManifestFile = "bundle.yaml"
+
+	// bundleImagesFile is the name of the file listing the container images required by the content of a bundle.
+	bundleImagesFile = "images.txt"
+
+	// bundleURLScheme is the scheme used for the URL of providers read from a bundle.
+	bundleURLScheme = "bundle"
+
+	certManagerProviderName = "cert-manager"
+)
+
+// CreateBundleOptions carries the options supported by CreateBundle.
+type CreateBundleOptions struct {
+	// CoreProvider version (e.g. cluster-api:v1.1.5) to add to the bundle. If unspecified, the
+	// cluster-api core provider's latest release is used.
+	CoreProvider string
+
+	// BootstrapProviders and versions (e.g. kubeadm:v1.1.5) to add to the bundle.
+	// If unspecified, the kubeadm bootstrap provider's latest release is used.
+	BootstrapProviders []string
+
+	// ControlPlaneProviders and versions (e.g. kubeadm:v1.1.5) to add to the bundle.
+	// If unspecified, the kubeadm control plane provider latest release is used.
+	ControlPlaneProviders []string
+
+	// InfrastructureProviders and versions (e.g. aws:v0.5.0) to add to the bundle.
+	InfrastructureProviders []string
+
+	// IPAMProviders and versions (e.g. infoblox:v0.0.1) to add to the bundle.
+	IPAMProviders []string
+
+	// RuntimeExtensionProviders and versions (e.g. test:v0.0.1) to add to the bundle.
+	RuntimeExtensionProviders []string
+
+	// AddonProviders and versions (e.g. helm:v0.1.0) to add to the bundle.
+	AddonProviders []string
+
+	// Flavors of the cluster templates to add to the bundle; the default cluster template of each
+	// infrastructure provider is always added, if it exists.
+	Flavors []string
+
+	// ClusterClasses to add to the bundle, read from the infrastructure providers.
+	ClusterClasses []string
+
+	// Path of the bundle file to create.
+	Path string
+}
+
+// bundleManifest describes the content of a bundle.
+type bundleManifest struct {
+	Providers   []bundleProvider   `json:"providers"`
+	CertManager *bundleCertManager `json:"certManager,omitempty"`
+}
+
+// bundleProvider describes a provider included in a bundle.
+type bundleProvider struct {
+	Name           string                    `json:"name"`
+	Type           clusterctlv1.ProviderType `json:"type"`
+	Version        string                    `json:"version"`
+	ComponentsPath string                    `json:"componentsPath"`
+}
+
+// bundleCertManager describes the cert-manager release included in a bundle.
+type bundleCertManager struct {
+	Version        string `json:"version"`
+	ComponentsPath string `json:"componentsPath"`
+}
+
+// bundle is the in-memory representation of a bundle.
+type bundle struct {
+	manifest bundleManifest
+	images   sets.Set[string]
+	files    map[string][]byte
+}
+
+func newBundle() *bundle {
+	return &bundle{
+		images: sets.Set[string]{},
+		files:  map[string][]byte{},
+	}
+}
+
+// CreateBundle creates a bundle with the provider components, metadata, cluster templates and ClusterClasses
+// required for initializing a management cluster without access to the provider repositories.
+func (c *clusterctlClient) CreateBundle(ctx context.Context, options CreateBundleOptions) error {
+	log := logf.Log
+
+	if options.Path == "" {
+		return errors.New("invalid arguments: please provide the path of the bundle file to create")
+	}
+
+	// Add the same default providers used when initializing a management cluster for the first time.
+	if options.CoreProvider == "" {
+		options.CoreProvider = config.ClusterAPIProviderName
+	}
+	if len(options.BootstrapProviders) == 0 {
+		options.BootstrapProviders = append(options.BootstrapProviders, config.KubeadmBootstrapProviderName)
+	}
+	if len(options.ControlPlaneProviders) == 0 {
+		options.ControlPlaneProviders = append(options.ControlPlaneProviders, config.KubeadmControlPlaneProviderName)
+	}
+
+	b := newBundle()
+	missingFlavors := sets.New[string](options.Flavors...)
+	missingClusterClasses := sets.New[string](options.ClusterClasses...)
+
+	providersByType := []struct {
+		providerType clusterctlv1.ProviderType
+		providers    []string
+	}{
+		{clusterctlv1.CoreProviderType, []string{options.CoreProvider}},
+		{clusterctlv1.BootstrapProviderType, options.BootstrapProviders},
+		{clusterctlv1.ControlPlaneProviderType, options.ControlPlaneProviders},
+		{clusterctlv1.InfrastructureProviderType, options.InfrastructureProviders},
+		{clusterctlv1.IPAMProviderType, options.IPAMProviders},
+		{clusterctlv1.RuntimeExtensionProviderType, options.RuntimeExtensionProviders},
+		{clusterctlv1.AddonProviderType, options.AddonProviders},
+	}
+	for _, p := range providersByType {
+		for _, provider := range p.providers {
+			// It is possible to opt-out from bootstrap/control-plane providers using '-' as a provider name (NoopProvider).
+			if provider == NoopProvider {
+				if p.providerType == clusterctlv1.CoreProviderType {
+					return errors.New("the '-' value can not be used for the core provider")
+				}
+				continue
+			}
+
+			log.Info("Fetching", "provider", provider, "type", p.providerType)
+			if err := c.addProviderToBundle(ctx, b, provider, p.providerType, options, missingFlavors, missingClusterClasses); err != nil {
+				return errors.Wrapf(err, "failed to add the %q provider to the bundle", provider)
+			}
+		}
+	}
+
+	if missingFlavors.Len() > 0 {
+		return errors.Errorf("failed to find the cluster templates for flavors %s in any of the infrastructure providers", strings.Join(sets.List(missingFlavors), ", "))
+	}
+	if missingClusterClasses.Len() > 0 {
+		return errors.Errorf("failed to find the ClusterClasses %s in any of the infrastructure providers", strings.Join(sets.List(missingClusterClasses), ", "))
+	}
+
+	log.Info("Fetching", "provider", certManagerProviderName)
+	if err := c.addCertManagerToBundle(ctx, b); err != nil {
+		return errors.Wrap(err, "failed to add cert-manager to the bundle")
+	}
+
+	if err := b.write(options.Path); err != nil {
+		return errors.Wrapf(err, "failed to write bundle %q", options.Path)
+	}
+	log.Info("Bundle created", "path", options.Path, "images", b.images.Len())
+	return nil
+}
+
+// addProviderToBundle adds the components, the metadata and, for infrastructure providers, the requested cluster
+// templates and ClusterClasses of a provider to the bundle.
+func (c *clusterctlClient) addProviderToBundle(ctx context.Context, b *bundle, provider string, providerType clusterctlv1.ProviderType, options CreateBundleOptions, missingFlavors, missingClusterClasses sets.Set[string]) error {
+	name, version, err := parseProviderName(provider)
+	if err != nil {
+		return err
+	}
+
+	providerConfig, err := c.configClient.Providers().Get(name, providerType)
+	if err != nil {
+		return err
+	}
+
+	repositoryClient, err := c.repositoryClientFactory(ctx, RepositoryClientFactoryInput{Provider: providerConfig})
+	if err != nil {
+		return err
+	}
+
+	if version == "" {
+		version = repositoryClient.DefaultVersion()
+	}
+
+	// Gets the components without processing variables, so they will be processed when reading them from the bundle.
+	componentsOptions := repository.ComponentsOptions{Version: version, SkipTemplateProcess: true}
+	rawComponents, err := repositoryClient.Components().Raw(ctx, componentsOptions)
+	if err != nil {
+		return err
+	}
+	components, err := repositoryClient.Components().Get(ctx, componentsOptions)
+	if err != nil {
+		return err
+	}
+	if components.Type() != providerType {
+		return errors.Errorf("can't use %q provider as an %q, it is a %q", provider, providerType, components.Type())
+	}
+	version = components.Version()
+	b.images.Insert(components.Images()...)
+
+	metadata, err := repositoryClient.Metadata(version).Get(ctx)
+	if err != nil {
+		return err
+	}
+	rawMetadata, err := encodeMetadata(metadata)
+	if err != nil {
+		return err
+	}
+
+	componentsPath := path.Base(providerConfig.URL())
+	providerPath := path.Join(providerConfig.ManifestLabel(), version)
+	b.files[path.Join(providerPath, componentsPath)] = rawComponents
+	b.files[path.Join(providerPath, "metadata.yaml")] = rawMetadata
+
+	if providerType == clusterctlv1.InfrastructureProviderType {
+		processor := yamlprocessor.NewSimpleProcessor()
+
+		// The default cluster template is optional.
+		// NOTE: Only files not published by the provider are skipped; any other error, e.g. a network or an
+		// authentication error, is returned so it does not surface as a missing file or an incomplete bundle.
+		template, err := repository.GetRawTemplate(ctx, repositoryClient.Templates(version), "")
+		if err != nil && !repository.IsNotFound(err) {
+			return errors.Wrap(err, "failed to get the default cluster template")
+		}
+		if err == nil {
+			b.files[path.Join(providerPath, processor.GetTemplateName(version, ""))] = template
+		}
+
+		for _, flavor := range options.Flavors {
+			template, err := repository.GetRawTemplate(ctx, repositoryClient.Templates(version), flavor)
+			if err != nil {
+				if repository.IsNotFound(err) {
+					continue
+				}
+				return errors.Wrapf(err, "failed to get the cluster template for flavor %q", flavor)
+			}
+			b.files[path.Join(providerPath, processor.GetTemplateName(version, flavor))] = template
+			missingFlavors.Delete(flavor)
+		}
+
+		for _, clusterClass := range options.ClusterClasses {
+			template, err := repository.GetRawClusterClass(ctx, repositoryClient.ClusterClasses(version), clusterClass)
+			if err != nil {
+				if repository.IsNotFound(err) {
+					continue
+				}
+				return errors.Wrapf(err, "failed to get the ClusterClass %q", clusterClass)
+			}
+			b.files[path.Join(providerPath, processor.GetClusterClassTemplateName(version, clusterClass))] = template
+			missingClusterClasses.Delete(clusterClass)
+		}
+	}
+
+	b.manifest.Providers = append(b.manifest.Providers, bundleProvider{
+		Name:           providerConfig.Name(),
+		Type:           providerType,
+		Version:        version,
+		ComponentsPath: componentsPath,
+	})
+	return nil
+}
+
+// addCertManagerToBundle adds the cert-manager components to the bundle.
+func (c *clusterctlClient) addCertManagerToBundle(ctx context.Context, b *bundle) error {
+	certManagerConfig, err := c.configClient.CertManager().Get()
+	if err != nil {
+		return err
+	}
+
+	// Given that cert manager components yaml are stored in a repository like providers components yaml,
+	// we are using the same machinery to retrieve the file by using a fake provider object using
+	// the cert manager repository url.
+	certManagerFakeProvider := config.NewProvider(certManagerProviderName, certManagerConfig.URL(), "")
+	repositoryClient, err := c.repositoryClientFactory(ctx, RepositoryClientFactoryInput{Provider: certManagerFakeProvider})
+	if err != nil {
+		return err
+	}
+
+	rawComponents, err := repositoryClient.Components().Raw(ctx, repository.ComponentsOptions{Version: certManagerConfig.Version()})
+	if err != nil {
+		return err
+	}
+
+	objs, err := utilyaml.ToUnstructured(rawComponents)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse yaml for cert-manager manifest")
+	}
+	objs, err = util.FixImages(objs, func(image string) (string, error) {
+		return c.configClient.ImageMeta().AlterImage(config.CertManagerImageComponent, image)
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to apply image override to the cert-manager manifest")
+	}
+	images, err := util.InspectImages(objs)
+	if err != nil {
+		return err
+	}
+	b.images.Insert(images...)
+
+	componentsPath := path.Base(certManagerConfig.URL())
+	b.files[path.Join(certManagerProviderName, certManagerConfig.Version(), componentsPath)] = rawComponents
+	b.manifest.CertManager = &bundleCertManager{
+		Version:        certManagerConfig.Version(),
+		ComponentsPath: componentsPath,
+	}
+	return nil
+}
+
+// write writes the bundle to a tar.gz file.
+func (b *bundle) write(bundlePath string) error {
+	manifest, err := yaml.Marshal(b.manifest)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the bundle manifest")
+	}
+
+	images := sets.List(b.images)
+	files := map[string][]byte{
+		bundleManifestFile: manifest,
+		bundleImagesFile:   []byte(strings.Join(images, "\n") + "\n"),
+	}
+	for name, content := range b.files {
+		files[name] = content
+	}
+
+	// Sort the file names so the bundle content is deterministic.
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	buf := &bytes.Buffer{}
+	gzipWriter := gzip.NewWriter(buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for _, name := range names {
+		if err := tarWriter.WriteHeader(&tar.Header{
+			Name:     name,
+			Mode:     0o644,
+			Size:     int64(len(files[name])),
+			Typeflag: tar.TypeReg,
+		}); err != nil {
+			return err
+		}
+		if _, err := tarWriter.Write(files[name]); err != nil {
+			return err
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		return err
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return err
+	}
+
+	return os.WriteFile(bundlePath, buf.Bytes(), 0o600)
+}
+
+// loadBundle reads a bundle from a tar.gz file.
+func loadBundle(bundlePath string) (*bundle, error) {
+	f, err := os.Open(bundlePath) //nolint:gosec // The path is provided by the user.
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open bundle %q", bundlePath)
+	}
+	defer f.Close()
+
+	gzipReader, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read bundle %q", bundlePath)
+	}
+	defer gzipReader.Close()
+
+	b := newBundle()
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read bundle %q", bundlePath)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		content, err := io.ReadAll(tarReader)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read %q from bundle %q", header.Name, bundlePath)
+		}
+		b.files[path.Clean(header.Name)] = content
+	}
+
+	manifest, ok := b.files[bundleManifestFile]
+	if !ok {
+		return nil, errors.Errorf("invalid bundle %q: %s is missing", bundlePath, bundleManifestFile)
+	}
+	if err := yaml.Unmarshal(manifest, &b.manifest); err != nil {
+		return nil, errors.Wrapf(err, "invalid bundle %q: failed to parse %s", bundlePath, bundleManifestFile)
+	}
+	delete(b.files, bundleManifestFile)
+	delete(b.files, bundleImagesFile)
+
+	return b, nil
+}
+
+// getProvider returns the provider with the given name and type included in the bundle, if any.
+func (b *bundle) getProvider(name string, providerType clusterctlv1.ProviderType) (bundleProvider, bool) {
+	for _, p := range b.manifest.Providers {
+		if p.Name == name && p.Type == providerType {
+			return p, true
+		}
+	}
+	return bundleProvider{}, false
+}
+
+// repository returns a repository serving the files included in the bundle for a provider.
+func (b *bundle) repository(provider config.Provider) (repository.Repository, error) {
+	var version, componentsPath string
+	switch {
+	case provider.Name() == certManagerProviderName && provider.Type() == clusterctlv1.ProviderTypeUnknown:
+		if b.manifest.CertManager == nil {
+			return nil, errors.New("cert-manager is not included in the bundle")
+		}
+		version, componentsPath = b.manifest.CertManager.Version, b.manifest.CertManager.ComponentsPath
+	default:
+		p, ok := b.getProvider(provider.Name(), provider.Type())
+		if !ok {
+			return nil, errors.Errorf("provider %q of type %q is not included in the bundle", provider.Name(), provider.Type())
+		}
+		version, componentsPath = p.Version, p.ComponentsPath
+	}
+
+	repo := repository.NewMemoryRepository().
+		WithPaths("", componentsPath).
+		WithDefaultVersion(version)
+
+	prefix := provider.ManifestLabel() + "/"
+	for name, content := range b.files {
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		fileVersion, filePath, ok := strings.Cut(strings.TrimPrefix(name, prefix), "/")
+		if !ok {
+			continue
+		}
+		repo.WithFile(fileVersion, filePath, content)
+	}
+	return repo, nil
+}
+
+// url returns the URL used for a provider read from the bundle.
+func (b *bundle) url(manifestLabel, version, componentsPath string) string {
+	return fmt.Sprintf("%s://%s", bundleURLScheme, path.Join(manifestLabel, version, componentsPath))
+}
+
+// InjectBundle allows to read providers and cert-manager from a bundle created with CreateBundle instead of
+// reading them from the provider repositories.
+func InjectBundle(bundlePath string) Option {
+	return func(c *clusterctlClient) {
+		c.bundlePath = bundlePath
+	}
+}
+
+// bundleConfigClient wraps a config.Client so the configuration of the providers and of cert-manager
+// included in a bundle point to the bundle.
+type bundleConfigClient struct {
+	config.Client
+	bundle *bundle
+}
+
+var _ config.Client = &bundleConfigClient{}
+
+func (c *bundleConfigClient) Providers() config.ProvidersClient {
+	return &bundleProvidersClient{
+		ProvidersClient: c.Client.Providers(),
+		bundle:          c.bundle,
+	}
+}
+
+func (c *bundleConfigClient) CertManager() config.CertManagerClient {
+	return &bundleCertManagerClient{
+		CertManagerClient: c.Client.CertManager(),
+		bundle:            c.bundle,
+	}
+}
+
+// bundleProvidersClient implements config.ProvidersClient for providers included in a bundle.
+type bundleProvidersClient struct {
+	config.ProvidersClient
+	bundle *bundle
+}
+
+// List returns all the provider configurations, with the providers included in the bundle pointing to the bundle.
+func (p *bundleProvidersClient) List() ([]config.Provider, error) {
+	providers, err := p.ProvidersClient.List()
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]config.Provider, 0, len(providers))
+	for _, provider := range providers {
+		if _, ok := p.bundle.getProvider(provider.Name(), provider.Type()); ok {
+			continue
+		}
+		ret = append(ret, provider)
+	}
+	for _, bp := range p.bundle.manifest.Providers {
+		ret = append(ret, p.toProvider(bp))
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Less(ret[j])
+	})
+	return ret, nil
+}
+
+// Get returns the configuration for the provider with a given name/type; providers included in the bundle point to the bundle.
+func (p *bundleProvidersClient) Get(name string, providerType clusterctlv1.ProviderType) (config.Provider, error) {
+	if bp, ok := p.bundle.getProvider(name, providerType); ok {
+		return p.toProvider(bp), nil
+	}
+	return p.ProvidersClient.Get(name, providerType)
+}
+
+func (p *bundleProvidersClient) toProvider(bp bundleProvider) config.Provider {
+	manifestLabel := clusterctlv1.ManifestLabel(bp.Name, bp.Type)
+	return config.NewProvider(bp.Name, p.bundle.url(manifestLabel, bp.Version, bp.ComponentsPath), bp.Type)
+}
+
+// bundleCertManagerClient implements config.CertManagerClient for the cert-manager release included in a bundle.
+type bundleCertManagerClient struct {
+	config.CertManagerClient
+	bundle *bundle
+}
+
+// Get returns the cert-manager configuration pointing to the bundle.
+func (p *bundleCertManagerClient) Get() (config.CertManager, error) {
+	certManagerConfig, err := p.CertManagerClient.Get()
+	if err != nil {
+		return nil, err
+	}
+	if p.bundle.manifest.CertManager == nil {
+		return certManagerConfig, nil
+	}
+
+	cm := p.bundle.manifest.CertManager
+	return config.NewCertManager(p.bundle.url(certManagerProviderName, cm.Version, cm.ComponentsPath), cm.Version, certManagerConfig.Timeout()), nil
+}
+
+// bundleRepositoryFactory is a RepositoryClientFactory func that reads providers from a bundle.
+func bundleRepositoryFactory(b *bundle, configClient config.Client) RepositoryClientFactory {
+	return func(ctx context.Context, input RepositoryClientFactoryInput) (repository.Client, error) {
+		repo, err := b.repository(input.Provider)
+		if err != nil {
+			return nil, err
+		}
+		return repository.New(
+			ctx,
+			input.Provider,
+			configClient,
+			repository.InjectRepository(repo),
+			repository.InjectYamlProcessor(input.Processor),
+		)
+	}
+}
+
+// clusterRepositoryFactory is a cluster.RepositoryClientFactory func that reads cert-manager and the providers included
+// in a bundle from the bundle; other providers, e.g. providers already installed in the management cluster, are read
+// from the provider repositories.
+func (b *bundle) clusterRepositoryFactory(ctx context.Context, provider config.Provider, configClient config.Client, options ...repository.Option) (repository.Client, error) {
+	if !strings.HasPrefix(provider.URL(), bundleURLScheme+"://") {
+		return repository.New(ctx, provider, configClient, options...)
+	}
+
+	repo, err := b.repository(provider)
+	if err != nil {
+		return nil, err
+	}
+	return repository.New(ctx, provider, configClient, append(options, repository.InjectRepository(repo))...)
+}
+
+// encodeMetadata encodes metadata to yaml.
+func encodeMetadata(metadata *clusterctlv1.Metadata) ([]byte, error) {
+	codecs := serializer.NewCodecFactory(scheme.Scheme)
+
+	info, match := runtime.SerializerInfoForMediaType(codecs.SupportedMediaTypes(), runtime.ContentTypeYAML)
+	if !match {
+		return nil, errors.New("failed to get SerializerInfo for application/yaml")
+	}
+
+	metadata = metadata.DeepCopy()
+	metadata.SetGroupVersionKind(clusterctlv1.GroupVersion.WithKind("Metadata"))
+
+	encoder := codecs.EncoderForVersion(info.Serializer, clusterctlv1.GroupVersion)
+	return runtime.Encode(encoder, metadata)
+}
@@ -0,0 +1,239 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/repository"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+)
+
+// fakeClusterWithCertManagerRepository returns a clusterctl client for an empty management cluster,
+// with a repository for cert-manager in addition to the repositories for capi, bootstrap and infra providers.
+func fakeClusterWithCertManagerRepository() *fakeClient {
+	client := fakeEmptyCluster()
+
+	certManagerConfig, _ := client.configClient.CertManager().Get()
+	certManagerRepository := newFakeRepository(ctx, config.NewProvider("cert-manager", certManagerConfig.URL(), ""), client.configClient).
+		WithPaths("root", "cert-manager.yaml").
+		WithFile(certManagerConfig.Version(), "cert-manager.yaml", infraComponentsYAML("cert-manager"))
+	client.repositories[certManagerRepository.ManifestLabel()] = certManagerRepository
+
+	return client
+}
+
+func Test_clusterctlClient_CreateBundle(t *testing.T) {
+	tests := []struct {
+		name    string
+		options CreateBundleOptions
+		noPath  bool
+		wantErr bool
+	}{
+		{
+			name: "creates a bundle with default providers",
+			options: CreateBundleOptions{
+				InfrastructureProviders: []string{"infra"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "creates a bundle with provider versions",
+			options: CreateBundleOptions{
+				CoreProvider:            "cluster-api:v1.1.0",
+				InfrastructureProviders: []string{"infra:v3.1.0"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "fails if the path is empty",
+			options: CreateBundleOptions{
+				InfrastructureProviders: []string{"infra"},
+			},
+			noPath:  true,
+			wantErr: true,
+		},
+		{
+			name: "fails if a provider does not exist",
+			options: CreateBundleOptions{
+				InfrastructureProviders: []string{"does-not-exist"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails if a flavor does not exist",
+			options: CreateBundleOptions{
+				InfrastructureProviders: []string{"infra"},
+				Flavors:                 []string{"does-not-exist"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			client := fakeClusterWithCertManagerRepository()
+
+			if !tt.noPath {
+				tt.options.Path = filepath.Join(t.TempDir(), "bundle.tar.gz")
+			}
+
+			err := client.CreateBundle(ctx, tt.options)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+
+			b, err := loadBundle(tt.options.Path)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(b.manifest.Providers).To(HaveLen(4))
+			g.Expect(b.manifest.CertManager).ToNot(BeNil())
+		})
+	}
+}
+
+func Test_clusterctlClient_InjectBundle(t *testing.T) {
+	g := NewWithT(t)
+
+	bundlePath := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	err := fakeClusterWithCertManagerRepository().CreateBundle(ctx, CreateBundleOptions{
+		InfrastructureProviders: []string{"infra:v3.0.0"},
+		Path:                    bundlePath,
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// Creates a client reading providers from the bundle, with a configuration where repositories are not reachable.
+	configClient := fakeConfig(
+		[]config.Provider{capiProviderConfig, bootstrapProviderConfig, controlPlaneProviderConfig, infraProviderConfig, infraCompatibleProviderConfig},
+		map[string]string{"SOME_VARIABLE": "value"},
+	)
+	client, err := newClusterctlClient(ctx, "fake-config",
+		InjectConfig(configClient),
+		InjectBundle(bundlePath),
+		InjectCurrentContractVersion(currentContractVersion),
+	)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// Providers included in the bundle are read from the bundle.
+	components, err := client.GetProviderComponents(ctx, "infra", clusterctlv1.InfrastructureProviderType, ComponentsOptions{})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(components.Version()).To(Equal("v3.0.0"))
+	g.Expect(components.Images()).To(ConsistOf("registry.k8s.io/cluster-api-aws/cluster-api-aws-controller:v0.5.3"))
+
+	template, err := client.GetClusterTemplate(ctx, GetClusterTemplateOptions{
+		ProviderRepositorySource: &ProviderRepositorySourceOptions{InfrastructureProvider: "infra:v3.0.0"},
+		ClusterName:              "test",
+		TargetNamespace:          "ns1",
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(template.Objs()).To(HaveLen(1))
+
+	// Versions not included in the bundle can't be read.
+	_, err = client.GetProviderComponents(ctx, "infra:v3.1.0", clusterctlv1.InfrastructureProviderType, ComponentsOptions{})
+	g.Expect(err).To(HaveOccurred())
+
+	// Providers not included in the bundle can't be read.
+	_, err = client.GetProviderComponents(ctx, "infra-compatible", clusterctlv1.InfrastructureProviderType, ComponentsOptions{})
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring("is not included in the bundle"))
+
+	// cert-manager is read from the bundle.
+	certManagerConfig, err := client.configClient.CertManager().Get()
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(certManagerConfig.URL()).To(HavePrefix("bundle://cert-manager/"))
+}
+
+func Test_clusterctlClient_InitFromBundle(t *testing.T) {
+	g := NewWithT(t)
+
+	bundlePath := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	err := fakeClusterWithCertManagerRepository().CreateBundle(ctx, CreateBundleOptions{
+		InfrastructureProviders: []string{"infra:v3.0.0"},
+		Path:                    bundlePath,
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	b, err := loadBundle(bundlePath)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// Creates a client reading providers from the bundle, with a configuration where repositories are not reachable
+	// and without repositories injected in the clusterctl client nor in the management cluster client, so
+	// init must read everything from the bundle.
+	configClient := fakeConfig(
+		[]config.Provider{capiProviderConfig, bootstrapProviderConfig, controlPlaneProviderConfig, infraProviderConfig},
+		map[string]string{"SOME_VARIABLE": "value"},
+	)
+	kubeconfig := cluster.Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"}
+	mgmtCluster := &fakeClusterClient{
+		kubeconfig:   kubeconfig,
+		fakeProxy:    test.NewFakeProxy(),
+		repositories: map[string]repository.Client{},
+		certManager:  newFakeCertManagerClient(nil, nil),
+	}
+	bundleConfigClient := &bundleConfigClient{Client: configClient, bundle: b}
+	mgmtCluster.internalclient = cluster.New(kubeconfig, bundleConfigClient,
+		cluster.InjectProxy(mgmtCluster.fakeProxy),
+		cluster.InjectPollImmediateWaiter(func(context.Context, time.Duration, time.Duration, wait.ConditionWithContextFunc) error {
+			return nil
+		}),
+		cluster.InjectRepositoryFactory(b.clusterRepositoryFactory),
+		cluster.InjectCurrentContractVersion(currentContractVersion),
+		cluster.InjectGetCompatibleContractVersionsFunc(getCompatibleContractVersions),
+	)
+
+	client, err := newClusterctlClient(ctx, "fake-config",
+		InjectConfig(configClient),
+		InjectBundle(bundlePath),
+		InjectClusterClientFactory(func(ClusterClientFactoryInput) (cluster.Client, error) {
+			return mgmtCluster, nil
+		}),
+		InjectCurrentContractVersion(currentContractVersion),
+	)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	got, err := client.Init(ctx, InitOptions{
+		Kubeconfig:              Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+		InfrastructureProviders: []string{"infra"},
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	g.Expect(got).To(HaveLen(4))
+	for _, c := range got {
+		g.Expect(c.URL()).To(HavePrefix("bundle://"))
+	}
+
+	// Providers are installed in the management cluster.
+	providerList, err := mgmtCluster.ProviderInventory().List(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(providerList.Items).To(HaveLen(4))
+	for _, p := range providerList.Items {
+		if p.GetName() == "infrastructure-infra" {
+			g.Expect(p.Version).To(Equal("v3.0.0"))
+		}
+	}
+}
@@ -47,6 +47,10 @@ type Client interface {
 	// InitImages returns the list of images required for executing the init command.
 	InitImages(ctx context.Context, options InitOptions) ([]string, error)
 
+	// CreateBundle creates a bundle with the providers required for initializing a management cluster without
+	// access to the provider repositories.
+	CreateBundle(ctx context.Context, options CreateBundleOptions) error
+
 	// GetClusterTemplate returns a workload cluster template.
 	GetClusterTemplate(ctx context.Context, options GetClusterTemplateOptions) (Template, error)
 
@@ -75,6 +79,10 @@ type Client interface {
 	// DescribeCluster returns the object tree representing the status of a Cluster API cluster.
 	DescribeCluster(ctx context.Context, options DescribeClusterOptions) (*tree.ObjectTree, error)
 
+	// DiffCluster computes the desired state of a Cluster with a managed topology and returns the differences
+	// with the objects existing in the management cluster.
+	DiffCluster(ctx context.Context, options DiffClusterOptions) (*cluster.TopologyDiffOutput, error)
+
 	// AlphaClient is an Interface for alpha features in clusterctl
 	AlphaClient
 }
@@ -87,6 +95,8 @@ type AlphaClient interface {
 	RolloutPause(ctx context.Context, options RolloutPauseOptions) error
 	// RolloutResume provides rollout resume of paused cluster-api resources
 	RolloutResume(ctx context.Context, options RolloutResumeOptions) error
+	// DrainPlan returns what draining the Node of a Machine would do, without evicting or deleting any Pod.
+	DrainPlan(ctx context.Context, options DrainPlanOptions) (*cluster.MachineDrainPlanOutput, error)
 }
 
 // YamlPrinter exposes methods that prints the processed template and
@@ -107,6 +117,7 @@ type clusterctlClient struct {
 	alphaClient                   alpha.Client
 	currentContractVersion        string
 	getCompatibleContractVersions func(string) sets.Set[string]
+	bundlePath                    string
 }
 
 // RepositoryClientFactoryInput represents the inputs required by the factory.
@@ -188,6 +199,21 @@ func newClusterctlClient(ctx context.Context, path string, options ...Option) (*
 		client.configClient = c
 	}
 
+	// if there is an injected bundle, read providers and cert-manager from the bundle instead of
+	// reading them from the provider repositories.
+	var clusterOptions []cluster.Option
+	if client.bundlePath != "" {
+		b, err := loadBundle(client.bundlePath)
+		if err != nil {
+			return nil, err
+		}
+		client.configClient = &bundleConfigClient{Client: client.configClient, bundle: b}
+		if client.repositoryClientFactory == nil {
+			client.repositoryClientFactory = bundleRepositoryFactory(b, client.configClient)
+		}
+		clusterOptions = append(clusterOptions, cluster.InjectRepositoryFactory(b.clusterRepositoryFactory))
+	}
+
 	// if there is an injected RepositoryFactory, use it, otherwise use a default one.
 	if client.repositoryClientFactory == nil {
 		client.repositoryClientFactory = defaultRepositoryFactory(client.configClient)
@@ -195,7 +221,7 @@ func newClusterctlClient(ctx context.Context, path string, options ...Option) (*
 
 	// if there is an injected ClusterFactory, use it, otherwise use a default one.
 	if client.clusterClientFactory == nil {
-		client.clusterClientFactory = defaultClusterFactory(client.configClient, client.currentContractVersion, client.getCompatibleContractVersions)
+		client.clusterClientFactory = defaultClusterFactory(client.configClient, client.currentContractVersion, client.getCompatibleContractVersions, clusterOptions...)
 	}
 
 	// if there is an injected alphaClient, use it, otherwise use a default one.
@@ -220,15 +246,17 @@ func defaultRepositoryFactory(configClient config.Client) RepositoryClientFactor
 }
 
 // defaultClusterFactory is a ClusterClientFactory func the uses the default client provided by the cluster low level library.
-func defaultClusterFactory(configClient config.Client, currentContractVersion string, getCompatibleContractVersions func(string) sets.Set[string]) ClusterClientFactory {
+func defaultClusterFactory(configClient config.Client, currentContractVersion string, getCompatibleContractVersions func(string) sets.Set[string], options ...cluster.Option) ClusterClientFactory {
 	return func(input ClusterClientFactoryInput) (cluster.Client, error) {
 		return cluster.New(
 			// Kubeconfig is a type alias to cluster.Kubeconfig
 			cluster.Kubeconfig(input.Kubeconfig),
 			configClient,
-			cluster.InjectYamlProcessor(input.Processor),
-			cluster.InjectCurrentContractVersion(currentContractVersion),
-			cluster.InjectGetCompatibleContractVersionsFunc(getCompatibleContractVersions),
+			append([]cluster.Option{
+				cluster.InjectYamlProcessor(input.Processor),
+				cluster.InjectCurrentContractVersion(currentContractVersion),
+				cluster.InjectGetCompatibleContractVersionsFunc(getCompatibleContractVersions),
+			}, options...)...,
 		), nil
 	}
 }
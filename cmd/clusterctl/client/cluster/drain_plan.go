@@ -0,0 +1,181 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/internal/controllers/machine/drain"
+	"sigs.k8s.io/cluster-api/util"
+)
+
+// MachineDrainPlanInput defines the input for the DrainPlan function.
+type MachineDrainPlanInput struct {
+	// Namespace of the Machine.
+	Namespace string
+
+	// MachineName is the name of the Machine.
+	MachineName string
+}
+
+// MachineDrainPlanOutput defines the output of the DrainPlan function.
+type MachineDrainPlanOutput struct {
+	// Machine is the Machine the drain plan has been computed for.
+	Machine *clusterv1.Machine
+
+	// NodeName is the name of the Node of the Machine.
+	NodeName string
+
+	// Groups contains the groups of Pods with the same drain order that have to go away before the Node
+	// is considered completely drained, in the order in which they are drained.
+	Groups []MachineDrainPlanGroup
+
+	// PodsSkipped contains the Pods that are not drained, e.g. DaemonSet Pods, static Pods and Pods
+	// skipped via drain label or MachineDrainRules.
+	PodsSkipped []MachineDrainPlanPod
+}
+
+// MachineDrainPlanGroup is a group of Pods with the same drain order.
+type MachineDrainPlanGroup struct {
+	// Order is the drain order of the Pods of the group.
+	Order int32
+
+	// Pods contains the Pods of the group.
+	Pods []MachineDrainPlanPod
+}
+
+// MachineDrainPlanPod describes how a Pod is handled when draining the Node of a Machine.
+type MachineDrainPlanPod struct {
+	// Pod is a reference to the Pod.
+	Pod corev1.ObjectReference
+
+	// DrainBehavior is the drain behavior of the Pod, it is either "Drain", "WaitCompleted" or "Skip".
+	DrainBehavior clusterv1.MachineDrainRuleDrainBehavior
+
+	// Terminating is true if the Pod already has a deletionTimestamp.
+	Terminating bool
+
+	// Warning contains a warning about the Pod, e.g. if the Pod is using local storage.
+	Warning string
+
+	// BlockingPodDisruptionBudgets contains the names of the PodDisruptionBudgets that currently
+	// do not allow any disruption of the Pod, i.e. an eviction of the Pod would be blocked right now.
+	BlockingPodDisruptionBudgets []string
+}
+
+// MachineClient has methods to work with Machines.
+type MachineClient interface {
+	// DrainPlan computes what draining the Node of a Machine would do using the same code of the Machine controller,
+	// i.e. which Pods are drained in which order, which Pods are skipped, which Pods are waited for completion and
+	// which Pods can't be evicted right now because of PodDisruptionBudgets.
+	// NOTE: DrainPlan never changes objects in the management cluster or in the workload cluster.
+	DrainPlan(ctx context.Context, in *MachineDrainPlanInput) (*MachineDrainPlanOutput, error)
+}
+
+// machineClient implements MachineClient.
+type machineClient struct {
+	proxy Proxy
+
+	// getWorkloadClusterClient returns a client for a workload cluster, it can be overridden in tests.
+	getWorkloadClusterClient func(ctx context.Context, c client.Client, cluster client.ObjectKey) (client.Client, error)
+}
+
+// ensure machineClient implements MachineClient.
+var _ MachineClient = &machineClient{}
+
+// newMachineClient returns a MachineClient.
+func newMachineClient(proxy Proxy) *machineClient {
+	return &machineClient{
+		proxy: proxy,
+		getWorkloadClusterClient: func(ctx context.Context, c client.Client, cluster client.ObjectKey) (client.Client, error) {
+			return (&workloadClusterCache{client: c}).GetClient(ctx, cluster)
+		},
+	}
+}
+
+func (m *machineClient) DrainPlan(ctx context.Context, in *MachineDrainPlanInput) (*MachineDrainPlanOutput, error) {
+	c, err := m.proxy.NewClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	machine := &clusterv1.Machine{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: in.Namespace, Name: in.MachineName}, machine); err != nil {
+		return nil, errors.Wrapf(err, "failed to get Machine %s", klog.KRef(in.Namespace, in.MachineName))
+	}
+	if !machine.Status.NodeRef.IsDefined() {
+		return nil, errors.Errorf("failed to compute drain plan: Machine %s has no Node", klog.KObj(machine))
+	}
+	nodeName := machine.Status.NodeRef.Name
+
+	cluster, err := util.GetClusterByName(ctx, c, machine.Namespace, machine.Spec.ClusterName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get Cluster %s", klog.KRef(machine.Namespace, machine.Spec.ClusterName))
+	}
+
+	remoteClient, err := m.getWorkloadClusterClient(ctx, c, client.ObjectKeyFromObject(cluster))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get client for Cluster %s", klog.KObj(cluster))
+	}
+
+	drainer := &drain.Helper{
+		Client:             c,
+		RemoteClient:       remoteClient,
+		GracePeriodSeconds: -1,
+	}
+	plan, err := drainer.GetPlan(ctx, cluster, machine, nodeName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to compute drain plan for Node %s", nodeName)
+	}
+
+	out := &MachineDrainPlanOutput{
+		Machine:     machine,
+		NodeName:    nodeName,
+		PodsSkipped: toMachineDrainPlanPods(plan.PodsSkipped),
+	}
+	for _, group := range plan.Groups {
+		out.Groups = append(out.Groups, MachineDrainPlanGroup{
+			Order: group.Order,
+			Pods:  toMachineDrainPlanPods(group.Pods),
+		})
+	}
+	return out, nil
+}
+
+func toMachineDrainPlanPods(planPods []drain.PlanPod) []MachineDrainPlanPod {
+	var pods []MachineDrainPlanPod
+	for _, p := range planPods {
+		pods = append(pods, MachineDrainPlanPod{
+			Pod: corev1.ObjectReference{
+				Kind:      "Pod",
+				Namespace: p.Pod.Namespace,
+				Name:      p.Pod.Name,
+			},
+			DrainBehavior:                p.DrainBehavior,
+			Terminating:                  !p.Pod.DeletionTimestamp.IsZero(),
+			Warning:                      p.Warning,
+			BlockingPodDisruptionBudgets: p.BlockingPodDisruptionBudgets,
+		})
+	}
+	return pods
+}
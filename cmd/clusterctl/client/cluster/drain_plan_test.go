@@ -0,0 +1,176 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+)
+
+func Test_machineClient_DrainPlan(t *testing.T) {
+	g := NewWithT(t)
+
+	ctx := context.Background()
+
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns1",
+			Name:      "cluster1",
+		},
+	}
+	machine := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns1",
+			Name:      "machine1",
+		},
+		Spec: clusterv1.MachineSpec{
+			ClusterName: "cluster1",
+		},
+		Status: clusterv1.MachineStatus{
+			NodeRef: clusterv1.MachineNodeReference{
+				Name: "node1",
+			},
+		},
+	}
+	machineWithoutNode := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns1",
+			Name:      "machine2",
+		},
+		Spec: clusterv1.MachineSpec{
+			ClusterName: "cluster1",
+		},
+	}
+	mdr := &clusterv1.MachineDrainRule{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns1",
+			Name:      "mdr-drain-later",
+		},
+		Spec: clusterv1.MachineDrainRuleSpec{
+			Drain: clusterv1.MachineDrainRuleDrainConfig{
+				Behavior: clusterv1.MachineDrainRuleDrainBehaviorDrain,
+				Order:    ptr.To[int32](10),
+			},
+			Pods: []clusterv1.MachineDrainRulePodSelector{
+				{
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"app": "database"},
+					},
+				},
+			},
+		},
+	}
+
+	pod := func(name string, podLabels map[string]string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "app",
+				Name:      name,
+				Labels:    podLabels,
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						Kind:       "ReplicaSet",
+						Controller: ptr.To(true),
+					},
+				},
+			},
+			Spec: corev1.PodSpec{
+				NodeName: "node1",
+			},
+		}
+	}
+	remoteClient := fake.NewClientBuilder().
+		WithObjects(
+			&corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "app",
+					Labels: map[string]string{"kubernetes.io/metadata.name": "app"},
+				},
+			},
+			pod("frontend", map[string]string{"app": "frontend"}),
+			pod("database", map[string]string{"app": "database"}),
+			pod("monitoring", map[string]string{clusterv1.PodDrainLabel: "skip"}),
+			&policyv1.PodDisruptionBudget{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "app",
+					Name:      "frontend",
+				},
+				Spec: policyv1.PodDisruptionBudgetSpec{
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"app": "frontend"},
+					},
+				},
+			},
+		).
+		WithIndex(&corev1.Pod{}, "spec.nodeName", func(o client.Object) []string {
+			return []string{o.(*corev1.Pod).Spec.NodeName}
+		}).
+		Build()
+
+	proxy := test.NewFakeProxy().WithObjs(cluster, machine, machineWithoutNode, mdr)
+	machineClient := newMachineClient(proxy)
+	machineClient.getWorkloadClusterClient = func(_ context.Context, _ client.Client, key client.ObjectKey) (client.Client, error) {
+		g.Expect(key).To(Equal(client.ObjectKeyFromObject(cluster)))
+		return remoteClient, nil
+	}
+
+	out, err := machineClient.DrainPlan(ctx, &MachineDrainPlanInput{Namespace: "ns1", MachineName: "machine1"})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(out.NodeName).To(Equal("node1"))
+	g.Expect(out.Groups).To(BeComparableTo([]MachineDrainPlanGroup{
+		{
+			Order: 0,
+			Pods: []MachineDrainPlanPod{
+				{
+					Pod:                          corev1.ObjectReference{Kind: "Pod", Namespace: "app", Name: "frontend"},
+					DrainBehavior:                clusterv1.MachineDrainRuleDrainBehaviorDrain,
+					BlockingPodDisruptionBudgets: []string{"frontend"},
+				},
+			},
+		},
+		{
+			Order: 10,
+			Pods: []MachineDrainPlanPod{
+				{
+					Pod:           corev1.ObjectReference{Kind: "Pod", Namespace: "app", Name: "database"},
+					DrainBehavior: clusterv1.MachineDrainRuleDrainBehaviorDrain,
+				},
+			},
+		},
+	}))
+	g.Expect(out.PodsSkipped).To(BeComparableTo([]MachineDrainPlanPod{
+		{
+			Pod:           corev1.ObjectReference{Kind: "Pod", Namespace: "app", Name: "monitoring"},
+			DrainBehavior: clusterv1.MachineDrainRuleDrainBehaviorSkip,
+		},
+	}))
+
+	// DrainPlan fails for a Machine without a Node.
+	_, err = machineClient.DrainPlan(ctx, &MachineDrainPlanInput{Namespace: "ns1", MachineName: "machine2"})
+	g.Expect(err).To(MatchError(ContainSubstring("has no Node")))
+}
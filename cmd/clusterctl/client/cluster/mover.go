@@ -56,11 +56,35 @@ type ObjectMover interface {
 	// Move moves all the Cluster API objects existing in a namespace (or from all the namespaces if empty) to a target management cluster.
 	Move(ctx context.Context, namespace string, toCluster Client, dryRun bool, mutators ...ResourceMutatorFunc) error
 
+	// MoveWithJournal moves all the Cluster API objects existing in a namespace (or from all the namespaces if empty) to a target management cluster,
+	// recording the progress of the operation in a journal file, so an interrupted move can be resumed or rolled back.
+	MoveWithJournal(ctx context.Context, namespace string, toCluster Client, journalPath string, mutators ...ResourceMutatorFunc) error
+
+	// ResumeMove resumes an interrupted move using the journal file recorded by MoveWithJournal.
+	ResumeMove(ctx context.Context, toCluster Client, journalPath string, mutators ...ResourceMutatorFunc) error
+
+	// RollbackMove rolls back an interrupted move using the journal file recorded by MoveWithJournal, moving all the objects back
+	// to the source management cluster.
+	RollbackMove(ctx context.Context, toCluster Client, journalPath string) error
+
 	// ToDirectory writes all the Cluster API objects existing in a namespace (or from all the namespaces if empty) to a target directory.
 	ToDirectory(ctx context.Context, namespace string, directory string) error
 
 	// FromDirectory reads all the Cluster API objects existing in a configured directory to a target management cluster.
 	FromDirectory(ctx context.Context, toCluster Client, directory string) error
+
+	// ToArchive writes all the Cluster API objects existing in a namespace (or from all the namespaces if empty) to a tar.gz archive,
+	// together with a manifest with the checksum of each file. If recipients are provided, Secrets are encrypted so they can be read
+	// only with the private key corresponding to one of the recipients public keys.
+	ToArchive(ctx context.Context, namespace string, archivePath string, recipients []string) error
+
+	// FromArchive verifies a tar.gz archive written by ToArchive, and then reads all the Cluster API objects in it to a target
+	// management cluster. The identity private key is required if the archive contains encrypted Secrets.
+	FromArchive(ctx context.Context, toCluster Client, archivePath string, identity string) error
+
+	// SelectClusters limits Move, MoveWithJournal, ToDirectory and ToArchive to the given Clusters in the namespace and to the objects they depend on,
+	// leaving other Clusters in the same namespace untouched.
+	SelectClusters(clusterNames ...string)
 }
 
 // objectMover implements the ObjectMover interface.
@@ -68,6 +92,9 @@ type objectMover struct {
 	fromProxy             Proxy
 	fromProviderInventory InventoryClient
 	dryRun                bool
+	journalPath           string
+	journal               *moveJournal
+	clusterNames          []string
 }
 
 // ensure objectMover implements the ObjectMover interface.
@@ -104,6 +131,196 @@ func (o *objectMover) Move(ctx context.Context, namespace string, toCluster Clie
 	return o.move(ctx, objectGraph, proxy, mutators...)
 }
 
+func (o *objectMover) MoveWithJournal(ctx context.Context, namespace string, toCluster Client, journalPath string, mutators ...ResourceMutatorFunc) error {
+	if _, err := os.Stat(journalPath); err == nil {
+		return errors.Errorf("move journal %q already exists: resume or rollback the interrupted move, or delete the journal if it is stale", journalPath)
+	}
+
+	o.journalPath = journalPath
+	return o.Move(ctx, namespace, toCluster, false, mutators...)
+}
+
+func (o *objectMover) ResumeMove(ctx context.Context, toCluster Client, journalPath string, mutators ...ResourceMutatorFunc) error {
+	log := logf.Log
+	log.Info("Resuming move...")
+
+	journal, err := loadMoveJournal(journalPath)
+	if err != nil {
+		return err
+	}
+	if journal.Phase == moveJournalPhaseRollingBack {
+		return errors.Errorf("move journal %q records a move being rolled back, it can only be rolled back", journalPath)
+	}
+
+	// checks that all the required providers in place in the target cluster.
+	if err := o.checkTargetProviders(ctx, toCluster.ProviderInventory()); err != nil {
+		return errors.Wrap(err, "failed to check providers in target cluster")
+	}
+
+	// Rebuild the move sequence from the journal, because objects might already be deleted from the source cluster.
+	o.journal = journal
+	moveSequence := journal.moveSequence()
+	clusters := moveSequence.getClusters()
+	clusterClasses := moveSequence.getClusterClasses()
+
+	log.Info("Resuming move of Cluster API objects", "Clusters", len(clusters), "ClusterClasses", len(clusterClasses), "Phase", journal.Phase)
+
+	// If objects were not yet deleted from the source cluster, ensure it is paused, because the move might
+	// have been interrupted while pausing it.
+	if journal.Phase == moveJournalPhaseCreating {
+		log.V(1).Info("Pausing the source cluster")
+		if err := setClusterPause(ctx, o.fromProxy, clusters, true, false); err != nil {
+			return err
+		}
+
+		log.V(1).Info("Pausing the source ClusterClasses")
+		if err := setClusterClassPause(ctx, o.fromProxy, clusterClasses, true, false); err != nil {
+			return errors.Wrap(err, "error pausing ClusterClasses")
+		}
+	}
+
+	return o.moveObjects(ctx, moveSequence, clusters, clusterClasses, toCluster.Proxy(), mutators...)
+}
+
+func (o *objectMover) RollbackMove(ctx context.Context, toCluster Client, journalPath string) error {
+	log := logf.Log
+	log.Info("Rolling back move...")
+
+	journal, err := loadMoveJournal(journalPath)
+	if err != nil {
+		return err
+	}
+
+	// Build both the move sequence, with nodes referring to objects in the source cluster, and the rollback sequence,
+	// with nodes referring to objects in the target cluster.
+	moveSequence := journal.moveSequence()
+	rollbackSequence := journal.rollbackSequence()
+	restoreNamespace := journal.restoreNamespace()
+
+	if err := journal.setPhase(moveJournalPhaseRollingBack); err != nil {
+		return err
+	}
+
+	// Rolling back is a move from the target cluster to the source cluster, limited to the objects recorded in the journal.
+	toProxy := toCluster.Proxy()
+	rollbackMover := newObjectMover(toProxy, toCluster.ProviderInventory())
+
+	// Sets the pause field on the Clusters and ClusterClasses already created in the target cluster, because the move
+	// might have been interrupted while resuming them.
+	var targetClusters, targetClusterClasses []*node
+	for _, n := range rollbackSequence.getClusters() {
+		if journal.isCreated(n) {
+			targetClusters = append(targetClusters, n)
+		}
+	}
+	for _, n := range rollbackSequence.getClusterClasses() {
+		if journal.isCreated(n) {
+			targetClusterClasses = append(targetClusterClasses, n)
+		}
+	}
+	log.V(1).Info("Pausing the target cluster")
+	if err := setClusterPause(ctx, toProxy, targetClusters, true, false); err != nil {
+		return err
+	}
+	log.V(1).Info("Pausing the target ClusterClasses")
+	if err := setClusterClassPause(ctx, toProxy, targetClusterClasses, true, false); err != nil {
+		return errors.Wrap(err, "error pausing ClusterClasses")
+	}
+
+	// Create again in the source cluster the objects already deleted from it, group by group, ensuring all the ownerReferences are re-created.
+	// NOTE: Deletions are saved in the journal in batches, so a move interrupted while deleting objects from the source cluster
+	// might have deleted objects not recorded as deleted yet; given that objects are deleted only after being created in the
+	// target cluster, all the objects created in the target cluster and missing from the source cluster are restored as well.
+	log.Info("Restoring objects deleted from the source cluster")
+	for groupIndex := range len(rollbackSequence.groups) {
+		group := moveGroup{}
+		for _, n := range rollbackSequence.getGroup(groupIndex) {
+			if journal.isDeleted(n) {
+				group = append(group, n)
+				continue
+			}
+			if journal.isCreated(n) {
+				exists, err := o.existsInSource(ctx, journal.sourceIdentity(n))
+				if err != nil {
+					return err
+				}
+				if !exists {
+					group = append(group, n)
+				}
+			}
+		}
+		if err := rollbackMover.createGroup(ctx, group, o.fromProxy, restoreNamespace); err != nil {
+			return err
+		}
+		for _, n := range group {
+			if err := journal.recordRestored(n); err != nil {
+				return err
+			}
+		}
+		if err := journal.flush(); err != nil {
+			return err
+		}
+	}
+
+	// Delete from the target cluster all the objects created by the move, group by group in reverse order.
+	log.Info("Deleting objects from the target cluster")
+	for groupIndex := len(rollbackSequence.groups) - 1; groupIndex >= 0; groupIndex-- {
+		group := moveGroup{}
+		for _, n := range rollbackSequence.getGroup(groupIndex) {
+			if journal.isCreated(n) {
+				group = append(group, n)
+			}
+		}
+		if err := rollbackMover.deleteGroup(ctx, group); err != nil {
+			return err
+		}
+		for _, n := range group {
+			if err := journal.recordRemoved(n); err != nil {
+				return err
+			}
+		}
+		if err := journal.flush(); err != nil {
+			return err
+		}
+	}
+
+	// Resume the ClusterClasses and reset the pause field on the Clusters in the source cluster, so the controllers start reconciling them again.
+	log.V(1).Info("Resuming the source ClusterClasses")
+	if err := setClusterClassPause(ctx, o.fromProxy, moveSequence.getClusterClasses(), false, false); err != nil {
+		return errors.Wrap(err, "error resuming ClusterClasses")
+	}
+
+	log.V(1).Info("Resuming the source cluster")
+	if err := setClusterPause(ctx, o.fromProxy, moveSequence.getClusters(), false, false); err != nil {
+		return err
+	}
+
+	return journal.remove()
+}
+
+// existsInSource returns true if the object with the given identity exists in the source management cluster.
+func (o *objectMover) existsInSource(ctx context.Context, identity corev1.ObjectReference) (bool, error) {
+	cFrom, err := o.fromProxy.NewClient(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(identity.APIVersion)
+	obj.SetKind(identity.Kind)
+	if err := cFrom.Get(ctx, client.ObjectKey{Namespace: identity.Namespace, Name: identity.Name}, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "error reading %q %s/%s", identity.GroupVersionKind(), identity.Namespace, identity.Name)
+	}
+	return true, nil
+}
+
+func (o *objectMover) SelectClusters(clusterNames ...string) {
+	o.clusterNames = clusterNames
+}
+
 func (o *objectMover) ToDirectory(ctx context.Context, namespace string, directory string) error {
 	log := logf.Log
 	log.Info("Moving to directory...")
@@ -203,6 +420,11 @@ func (o *objectMover) getObjectGraph(ctx context.Context, namespace string) (*ob
 		return nil, errors.Wrap(err, "failed to discover the object graph")
 	}
 
+	// If required, prune the object graph to the selected Clusters and to the objects they depend on.
+	if err := objectGraph.selectClusters(o.clusterNames); err != nil {
+		return nil, err
+	}
+
 	// Checks if Cluster API has already completed the provisioning of the infrastructure for the objects involved in the move/toDirectory operation.
 	// This is required because if the infrastructure is provisioned, then we can reasonably assume that the objects we are moving/backing up are
 	// not currently waiting for long-running reconciliation loops, and so we can safely rely on the pause field on the Cluster object
@@ -353,6 +575,22 @@ func (o *objectMover) move(ctx context.Context, graph *objectGraph, toProxy Prox
 
 	log.Info("Moving Cluster API objects", "ClusterClasses", len(clusterClasses))
 
+	// Define the move sequence by processing the ownerReference chain, so we ensure that a Kubernetes object is moved only after its owners.
+	// The sequence is bases on object graph nodes, each one representing a Kubernetes object; nodes are grouped, so bulk of nodes can be moved in parallel. e.g.
+	// - All the Clusters should be moved first (group 1, processed in parallel)
+	// - All the MachineDeployments should be moved second (group 1, processed in parallel)
+	// - then all the MachineSets, then all the Machines, etc.
+	moveSequence := getMoveSequence(graph)
+
+	// If required, record the move sequence in a journal before changing anything, so the move can be resumed or rolled back if interrupted.
+	if o.journalPath != "" && !o.dryRun {
+		o.journal = newMoveJournal(o.journalPath, moveSequence)
+		if err := o.journal.save(); err != nil {
+			return err
+		}
+		log.Info("Recording the move progress", "Journal", o.journalPath)
+	}
+
 	// Sets the pause field on the Cluster object in the source management cluster, so the controllers stop reconciling it.
 	log.V(1).Info("Pausing the source cluster")
 	if err := setClusterPause(ctx, o.fromProxy, clusters, true, o.dryRun); err != nil {
@@ -381,14 +619,19 @@ func (o *objectMover) move(ctx context.Context, graph *objectGraph, toProxy Prox
 	// - namespace will be ensured to exist before creating the resource.
 	// - If it's done here, we might create a namespace that can end up unused on target cluster (due to mutators).
 
-	// Define the move sequence by processing the ownerReference chain, so we ensure that a Kubernetes object is moved only after its owners.
-	// The sequence is bases on object graph nodes, each one representing a Kubernetes object; nodes are grouped, so bulk of nodes can be moved in parallel. e.g.
-	// - All the Clusters should be moved first (group 1, processed in parallel)
-	// - All the MachineDeployments should be moved second (group 1, processed in parallel)
-	// - then all the MachineSets, then all the Machines, etc.
-	moveSequence := getMoveSequence(graph)
+	return o.moveObjects(ctx, moveSequence, clusters, clusterClasses, toProxy, mutators...)
+}
+
+// moveObjects creates the objects in the move sequence in the target management cluster, deletes them from the source management cluster
+// and then resumes the Clusters and the ClusterClasses in the target management cluster.
+// NOTE: If the move is recorded in a journal, objects already created/deleted according to the journal are skipped.
+func (o *objectMover) moveObjects(ctx context.Context, moveSequence *moveSequence, clusters, clusterClasses []*node, toProxy Proxy, mutators ...ResourceMutatorFunc) error {
+	log := logf.Log
 
 	// Create all objects group by group, ensuring all the ownerReferences are re-created.
+	if err := o.journal.setPhase(moveJournalPhaseCreating); err != nil {
+		return err
+	}
 	log.Info("Creating objects in the target cluster")
 	for groupIndex := range len(moveSequence.groups) {
 		if err := o.createGroup(ctx, moveSequence.getGroup(groupIndex), toProxy, mutators...); err != nil {
@@ -401,6 +644,9 @@ func (o *objectMover) move(ctx context.Context, graph *objectGraph, toProxy Prox
 	// mutators affecting non metadata fields are no-op after this point.
 
 	// Delete all objects group by group in reverse order.
+	if err := o.journal.setPhase(moveJournalPhaseDeleting); err != nil {
+		return err
+	}
 	log.Info("Deleting objects from the source cluster")
 	for groupIndex := len(moveSequence.groups) - 1; groupIndex >= 0; groupIndex-- {
 		if err := o.deleteGroup(ctx, moveSequence.getGroup(groupIndex)); err != nil {
@@ -409,14 +655,34 @@ func (o *objectMover) move(ctx context.Context, graph *objectGraph, toProxy Prox
 	}
 
 	// Resume the ClusterClasses in the target management cluster, so the controllers start reconciling it.
+	if err := o.journal.setPhase(moveJournalPhaseResuming); err != nil {
+		return err
+	}
 	log.V(1).Info("Resuming the target ClusterClasses")
 	if err := setClusterClassPause(ctx, toProxy, clusterClasses, false, o.dryRun, mutators...); err != nil {
 		return errors.Wrap(err, "error resuming ClusterClasses")
 	}
 
+	// Resume the ClusterClasses that are not deleted from the source management cluster, because they are still used there by other Clusters.
+	sourceClusterClasses := []*node{}
+	for _, clusterClass := range clusterClasses {
+		if clusterClass.shouldNotDelete {
+			sourceClusterClasses = append(sourceClusterClasses, clusterClass)
+		}
+	}
+	log.V(1).Info("Resuming the source ClusterClasses not deleted")
+	if err := setClusterClassPause(ctx, o.fromProxy, sourceClusterClasses, false, o.dryRun); err != nil {
+		return errors.Wrap(err, "error resuming ClusterClasses")
+	}
+
 	// Reset the pause field on the Cluster object in the target management cluster, so the controllers start reconciling it.
 	log.V(1).Info("Resuming the target cluster")
-	return setClusterPause(ctx, toProxy, clusters, false, o.dryRun, mutators...)
+	if err := setClusterPause(ctx, toProxy, clusters, false, o.dryRun, mutators...); err != nil {
+		return err
+	}
+
+	// The move is completed, so the journal is not required anymore.
+	return o.journal.remove()
 }
 
 func (o *objectMover) toDirectory(ctx context.Context, graph *objectGraph, directory string) error {
@@ -941,6 +1207,11 @@ func (o *objectMover) createGroup(ctx context.Context, group moveGroup, toProxy
 	// Nb. This prevents us from making repetitive (and expensive) calls in listing all namespaces to ensure a namespace exists before creating a resource.
 	existingNamespaces := sets.New[string]()
 	for _, nodeToCreate := range group {
+		// If the object was already created by an interrupted move, skip it.
+		if o.journal.isCreated(nodeToCreate) {
+			continue
+		}
+
 		// Creates the Kubernetes object corresponding to the nodeToCreate.
 		// Nb. The operation is wrapped in a retry loop to make move more resilient to unexpected conditions.
 		err := retryWithExponentialBackoff(ctx, createTargetObjectBackoff, func(ctx context.Context) error {
@@ -951,6 +1222,11 @@ func (o *objectMover) createGroup(ctx context.Context, group moveGroup, toProxy
 		}
 	}
 
+	// Save the objects created in the group, if any, in the journal.
+	if err := o.journal.flush(); err != nil {
+		errList = append(errList, err)
+	}
+
 	if len(errList) > 0 {
 		return kerrors.NewAggregate(errList)
 	}
@@ -1099,7 +1375,7 @@ func (o *objectMover) createTargetObject(ctx context.Context, nodeToCreate *node
 		return errors.Wrap(err, "error patching the managed fields")
 	}
 
-	return nil
+	return o.journal.recordCreated(nodeToCreate, obj)
 }
 
 func (o *objectMover) backupTargetObject(ctx context.Context, nodeToCreate *node, directory string) error {
@@ -1241,6 +1517,11 @@ func (o *objectMover) deleteGroup(ctx context.Context, group moveGroup) error {
 	for i := range group {
 		nodeToDelete := group[i]
 
+		// If the object was already deleted by an interrupted move, skip it.
+		if o.journal.isDeleted(nodeToDelete) {
+			continue
+		}
+
 		// Delete the Kubernetes object corresponding to the current node.
 		// Nb. The operation is wrapped in a retry loop to make move more resilient to unexpected conditions.
 		err := retryWithExponentialBackoff(ctx, deleteSourceObjectBackoff, func(ctx context.Context) error {
@@ -1248,7 +1529,16 @@ func (o *objectMover) deleteGroup(ctx context.Context, group moveGroup) error {
 		})
 		if err != nil {
 			errList = append(errList, err)
+			continue
 		}
+		if err := o.journal.recordDeleted(nodeToDelete); err != nil {
+			errList = append(errList, err)
+		}
+	}
+
+	// Save the objects deleted in the group, if any, in the journal.
+	if err := o.journal.flush(); err != nil {
+		errList = append(errList, err)
 	}
 
 	return kerrors.NewAggregate(errList)
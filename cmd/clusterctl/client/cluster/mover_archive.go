@@ -0,0 +1,538 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	logf "sigs.k8s.io/cluster-api/cmd/clusterctl/log"
+)
+
+const (
+	// archiveManifestFile is the name of the file describing the content of an archive.
+	archiveManifestFile = "manifest.yaml"
+
+	// archiveVersion is the version of the archive format.
+	archiveVersion = "v1"
+
+	// archiveEncryptedFileSuffix is the suffix added to the name of the encrypted files in an archive.
+	archiveEncryptedFileSuffix = ".enc"
+
+	// archiveKeyInfo is the info used when deriving the key wrapping the data key for a recipient.
+	archiveKeyInfo = "clusterctl archive v1"
+)
+
+// archiveManifest describes the content of an archive.
+type archiveManifest struct {
+	// Version of the archive format.
+	Version string `json:"version"`
+
+	// Files included in the archive, with their checksum.
+	Files []archiveFile `json:"files"`
+
+	// Encryption describes how Secrets in the archive are encrypted, if they are.
+	Encryption *archiveEncryption `json:"encryption,omitempty"`
+}
+
+// archiveFile describes a file included in an archive.
+type archiveFile struct {
+	// Name of the file.
+	Name string `json:"name"`
+
+	// SHA256 is the checksum of the file content, as stored in the archive.
+	SHA256 string `json:"sha256"`
+
+	// Encrypted is true if the file content is encrypted.
+	Encrypted bool `json:"encrypted,omitempty"`
+}
+
+// archiveEncryption describes how Secrets in an archive are encrypted.
+// Secrets are encrypted with a random data key using AES-256-GCM; the data key is then wrapped for each recipient
+// using a key derived from an X25519 key exchange between an ephemeral key and the recipient key.
+type archiveEncryption struct {
+	Recipients []archiveRecipient `json:"recipients"`
+}
+
+// archiveRecipient records the data key wrapped for a recipient.
+type archiveRecipient struct {
+	// Fingerprint is the SHA256 of the recipient public key.
+	Fingerprint string `json:"fingerprint"`
+
+	// EphemeralKey is the public key used for the key exchange with the recipient.
+	EphemeralKey string `json:"ephemeralKey"`
+
+	// WrappedKey is the data key encrypted for the recipient.
+	WrappedKey string `json:"wrappedKey"`
+}
+
+func (o *objectMover) ToArchive(ctx context.Context, namespace string, archivePath string, recipients []string) error {
+	log := logf.Log
+	log.Info("Moving to archive...")
+
+	recipientKeys, err := loadArchiveRecipients(recipients)
+	if err != nil {
+		return err
+	}
+
+	objectGraph, err := o.getObjectGraph(ctx, namespace)
+	if err != nil {
+		return errors.Wrap(err, "failed to get object graph")
+	}
+
+	// Write the objects to a temporary directory, then pack them into the archive.
+	directory, err := os.MkdirTemp("", "clusterctl-archive")
+	if err != nil {
+		return errors.Wrap(err, "failed to create a temporary directory")
+	}
+	defer os.RemoveAll(directory)
+
+	if err := o.toDirectory(ctx, objectGraph, directory); err != nil {
+		return err
+	}
+
+	log.Info("Writing archive", "Path", archivePath, "Encrypted", len(recipientKeys) > 0)
+	return writeArchive(directory, archivePath, recipientKeys)
+}
+
+func (o *objectMover) FromArchive(ctx context.Context, toCluster Client, archivePath string, identity string) error {
+	log := logf.Log
+	log.Info("Moving from archive...")
+
+	var identityKey *ecdh.PrivateKey
+	if identity != "" {
+		var err error
+		if identityKey, err = loadArchiveIdentity(identity); err != nil {
+			return err
+		}
+	}
+
+	// Verify and unpack the archive into a temporary directory, then restore the objects from there.
+	directory, err := os.MkdirTemp("", "clusterctl-archive")
+	if err != nil {
+		return errors.Wrap(err, "failed to create a temporary directory")
+	}
+	defer os.RemoveAll(directory)
+
+	if err := readArchive(archivePath, directory, identityKey); err != nil {
+		return err
+	}
+
+	return o.FromDirectory(ctx, toCluster, directory)
+}
+
+// writeArchive packs all the files in a directory into a tar.gz archive, encrypting Secrets if recipients are provided.
+func writeArchive(directory, archivePath string, recipients []*ecdh.PublicKey) error {
+	entries, err := os.ReadDir(directory)
+	if err != nil {
+		return err
+	}
+
+	var dataKey []byte
+	manifest := archiveManifest{Version: archiveVersion}
+	if len(recipients) > 0 {
+		dataKey = make([]byte, 32)
+		if _, err := rand.Read(dataKey); err != nil {
+			return errors.Wrap(err, "failed to generate the data key")
+		}
+		manifest.Encryption = &archiveEncryption{}
+		for _, recipient := range recipients {
+			r, err := wrapArchiveDataKey(dataKey, recipient)
+			if err != nil {
+				return err
+			}
+			manifest.Encryption.Recipients = append(manifest.Encryption.Recipients, r)
+		}
+	}
+
+	files := map[string][]byte{}
+	for _, entry := range entries {
+		content, err := os.ReadFile(filepath.Join(directory, entry.Name()))
+		if err != nil {
+			return err
+		}
+
+		name := entry.Name()
+		encrypted := false
+		if dataKey != nil && isSecretFile(content) {
+			if content, err = encryptArchiveFile(dataKey, name, content); err != nil {
+				return err
+			}
+			name += archiveEncryptedFileSuffix
+			encrypted = true
+		}
+
+		checksum := sha256.Sum256(content)
+		manifest.Files = append(manifest.Files, archiveFile{
+			Name:      name,
+			SHA256:    hex.EncodeToString(checksum[:]),
+			Encrypted: encrypted,
+		})
+		files[name] = content
+	}
+	sort.Slice(manifest.Files, func(i, j int) bool {
+		return manifest.Files[i].Name < manifest.Files[j].Name
+	})
+
+	manifestContent, err := yaml.Marshal(manifest)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the archive manifest")
+	}
+
+	buf := &bytes.Buffer{}
+	gzipWriter := gzip.NewWriter(buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+	writeFile := func(name string, content []byte) error {
+		if err := tarWriter.WriteHeader(&tar.Header{
+			Name:     name,
+			Mode:     0o600,
+			Size:     int64(len(content)),
+			Typeflag: tar.TypeReg,
+		}); err != nil {
+			return err
+		}
+		_, err := tarWriter.Write(content)
+		return err
+	}
+	if err := writeFile(archiveManifestFile, manifestContent); err != nil {
+		return err
+	}
+	for _, f := range manifest.Files {
+		if err := writeFile(f.Name, files[f.Name]); err != nil {
+			return err
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		return err
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return err
+	}
+
+	return os.WriteFile(archivePath, buf.Bytes(), 0o600)
+}
+
+// readArchive verifies the content of a tar.gz archive against its manifest, and then unpacks it into a directory,
+// decrypting Secrets with the given identity.
+// NOTE: Nothing is written to the directory if the archive fails verification.
+func readArchive(archivePath, directory string, identity *ecdh.PrivateKey) error {
+	f, err := os.Open(archivePath) //nolint:gosec // The path is provided by the user.
+	if err != nil {
+		return errors.Wrapf(err, "failed to open archive %q", archivePath)
+	}
+	defer f.Close()
+
+	gzipReader, err := gzip.NewReader(f)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read archive %q", archivePath)
+	}
+	defer gzipReader.Close()
+
+	files := map[string][]byte{}
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to read archive %q", archivePath)
+		}
+		if header.Typeflag != tar.TypeReg {
+			return errors.Errorf("invalid archive %q: %s is not a regular file", archivePath, header.Name)
+		}
+		content, err := io.ReadAll(tarReader)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read %q from archive %q", header.Name, archivePath)
+		}
+		files[header.Name] = content
+	}
+
+	manifestContent, ok := files[archiveManifestFile]
+	if !ok {
+		return errors.Errorf("invalid archive %q: %s is missing", archivePath, archiveManifestFile)
+	}
+	delete(files, archiveManifestFile)
+
+	manifest := archiveManifest{}
+	if err := yaml.Unmarshal(manifestContent, &manifest); err != nil {
+		return errors.Wrapf(err, "invalid archive %q: failed to parse %s", archivePath, archiveManifestFile)
+	}
+	if manifest.Version != archiveVersion {
+		return errors.Errorf("invalid archive %q: version %q is not supported", archivePath, manifest.Version)
+	}
+
+	// Verify all the files in the archive are listed in the manifest, and that their checksum matches.
+	for _, file := range manifest.Files {
+		content, ok := files[file.Name]
+		if !ok {
+			return errors.Errorf("invalid archive %q: %s is missing", archivePath, file.Name)
+		}
+		checksum := sha256.Sum256(content)
+		if hex.EncodeToString(checksum[:]) != file.SHA256 {
+			return errors.Errorf("invalid archive %q: checksum of %s does not match", archivePath, file.Name)
+		}
+		// Only plain file names are allowed, so files can't be written outside of the target directory.
+		if file.Name != path.Base(file.Name) || file.Name == "." || file.Name == ".." {
+			return errors.Errorf("invalid archive %q: invalid file name %s", archivePath, file.Name)
+		}
+	}
+	if len(files) != len(manifest.Files) {
+		return errors.Errorf("invalid archive %q: it contains files not listed in %s", archivePath, archiveManifestFile)
+	}
+
+	// Unwrap the data key, if required.
+	var dataKey []byte
+	for _, file := range manifest.Files {
+		if !file.Encrypted || dataKey != nil {
+			continue
+		}
+		if identity == nil {
+			return errors.Errorf("archive %q contains encrypted Secrets, an identity is required for reading it", archivePath)
+		}
+		if manifest.Encryption == nil {
+			return errors.Errorf("invalid archive %q: encryption information are missing", archivePath)
+		}
+		if dataKey, err = unwrapArchiveDataKey(manifest.Encryption.Recipients, identity); err != nil {
+			return errors.Wrapf(err, "failed to read archive %q", archivePath)
+		}
+	}
+
+	// Decrypt all the files before writing any of them, so nothing is written if the archive can't be decrypted.
+	plainFiles := map[string][]byte{}
+	for _, file := range manifest.Files {
+		name := file.Name
+		content := files[name]
+		if file.Encrypted {
+			name = strings.TrimSuffix(name, archiveEncryptedFileSuffix)
+			if content, err = decryptArchiveFile(dataKey, name, content); err != nil {
+				return errors.Wrapf(err, "failed to decrypt %s from archive %q", file.Name, archivePath)
+			}
+		}
+		plainFiles[name] = content
+	}
+
+	for name, content := range plainFiles {
+		if err := os.WriteFile(filepath.Join(directory, name), content, 0o600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isSecretFile returns true if a file contains a Secret.
+func isSecretFile(content []byte) bool {
+	typeMeta := metav1.TypeMeta{}
+	if err := json.Unmarshal(content, &typeMeta); err != nil {
+		return false
+	}
+	return typeMeta.GroupVersionKind() == corev1.SchemeGroupVersion.WithKind("Secret")
+}
+
+// wrapArchiveDataKey encrypts the data key for a recipient.
+func wrapArchiveDataKey(dataKey []byte, recipient *ecdh.PublicKey) (archiveRecipient, error) {
+	ephemeral, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return archiveRecipient{}, errors.Wrap(err, "failed to generate the ephemeral key")
+	}
+	sharedSecret, err := ephemeral.ECDH(recipient)
+	if err != nil {
+		return archiveRecipient{}, errors.Wrap(err, "failed to compute the shared secret")
+	}
+	wrappingKey, err := deriveArchiveWrappingKey(sharedSecret, ephemeral.PublicKey(), recipient)
+	if err != nil {
+		return archiveRecipient{}, err
+	}
+	wrappedKey, err := sealArchiveData(wrappingKey, dataKey, nil)
+	if err != nil {
+		return archiveRecipient{}, err
+	}
+	return archiveRecipient{
+		Fingerprint:  archiveKeyFingerprint(recipient),
+		EphemeralKey: base64.StdEncoding.EncodeToString(ephemeral.PublicKey().Bytes()),
+		WrappedKey:   base64.StdEncoding.EncodeToString(wrappedKey),
+	}, nil
+}
+
+// unwrapArchiveDataKey decrypts the data key wrapped for the recipient corresponding to the identity.
+func unwrapArchiveDataKey(recipients []archiveRecipient, identity *ecdh.PrivateKey) ([]byte, error) {
+	fingerprint := archiveKeyFingerprint(identity.PublicKey())
+	for _, r := range recipients {
+		if r.Fingerprint != fingerprint {
+			continue
+		}
+		ephemeralKey, err := base64.StdEncoding.DecodeString(r.EphemeralKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid ephemeral key")
+		}
+		ephemeral, err := ecdh.X25519().NewPublicKey(ephemeralKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid ephemeral key")
+		}
+		wrappedKey, err := base64.StdEncoding.DecodeString(r.WrappedKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid wrapped key")
+		}
+		sharedSecret, err := identity.ECDH(ephemeral)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to compute the shared secret")
+		}
+		wrappingKey, err := deriveArchiveWrappingKey(sharedSecret, ephemeral, identity.PublicKey())
+		if err != nil {
+			return nil, err
+		}
+		dataKey, err := openArchiveData(wrappingKey, wrappedKey, nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to unwrap the data key")
+		}
+		return dataKey, nil
+	}
+	return nil, errors.New("the identity is not a recipient of the archive")
+}
+
+// deriveArchiveWrappingKey derives the key wrapping the data key from the X25519 shared secret between the ephemeral key and the recipient key.
+// NOTE: The ephemeral public key and the recipient public key are used as salt, so the wrapping key is bound to both.
+func deriveArchiveWrappingKey(sharedSecret []byte, ephemeral, recipient *ecdh.PublicKey) ([]byte, error) {
+	salt := append(append([]byte{}, ephemeral.Bytes()...), recipient.Bytes()...)
+	return hkdf.Key(sha256.New, sharedSecret, salt, archiveKeyInfo, 32)
+}
+
+// encryptArchiveFile encrypts the content of a file with the data key; the file name is authenticated too,
+// so encrypted files can't be swapped.
+func encryptArchiveFile(dataKey []byte, name string, content []byte) ([]byte, error) {
+	return sealArchiveData(dataKey, content, []byte(name))
+}
+
+// decryptArchiveFile decrypts the content of a file encrypted with encryptArchiveFile.
+func decryptArchiveFile(dataKey []byte, name string, content []byte) ([]byte, error) {
+	return openArchiveData(dataKey, content, []byte(name))
+}
+
+// sealArchiveData encrypts plaintext with AES-256-GCM, prepending the random nonce to the ciphertext.
+func sealArchiveData(key, plaintext, additionalData []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, errors.Wrap(err, "failed to generate nonce")
+	}
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+// openArchiveData decrypts a ciphertext produced by sealArchiveData.
+func openArchiveData(key, ciphertext, additionalData []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+	return aead.Open(nil, ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():], additionalData)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// archiveKeyFingerprint returns the fingerprint of a public key.
+func archiveKeyFingerprint(key *ecdh.PublicKey) string {
+	checksum := sha256.Sum256(key.Bytes())
+	return hex.EncodeToString(checksum[:])
+}
+
+// loadArchiveRecipients reads the X25519 public keys of the recipients of an archive from PEM files,
+// e.g. generated with `openssl pkey -in key.pem -pubout -out key.pub`.
+func loadArchiveRecipients(paths []string) ([]*ecdh.PublicKey, error) {
+	keys := []*ecdh.PublicKey{}
+	for _, p := range paths {
+		block, err := readPEMFile(p)
+		if err != nil {
+			return nil, err
+		}
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse public key %q", p)
+		}
+		ecdhKey, ok := key.(*ecdh.PublicKey)
+		if !ok || ecdhKey.Curve() != ecdh.X25519() {
+			return nil, errors.Errorf("public key %q is not a X25519 key", p)
+		}
+		keys = append(keys, ecdhKey)
+	}
+	return keys, nil
+}
+
+// loadArchiveIdentity reads the X25519 private key used to decrypt an archive from a PEM file,
+// e.g. generated with `openssl genpkey -algorithm X25519 -out key.pem`.
+func loadArchiveIdentity(p string) (*ecdh.PrivateKey, error) {
+	block, err := readPEMFile(p)
+	if err != nil {
+		return nil, err
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse private key %q", p)
+	}
+	ecdhKey, ok := key.(*ecdh.PrivateKey)
+	if !ok || ecdhKey.Curve() != ecdh.X25519() {
+		return nil, errors.Errorf("private key %q is not a X25519 key", p)
+	}
+	return ecdhKey, nil
+}
+
+func readPEMFile(p string) (*pem.Block, error) {
+	content, err := os.ReadFile(p) //nolint:gosec // The path is provided by the user.
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read key %q", p)
+	}
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, errors.Errorf("key %q is not PEM encoded", p)
+	}
+	return block, nil
+}
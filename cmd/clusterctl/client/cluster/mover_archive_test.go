@@ -0,0 +1,293 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ecdh"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+)
+
+func Test_objectMover_archive(t *testing.T) {
+	// NB. we are testing that the objects saved to an archive are the same read from it, using the same set of backupRestoreTests.
+	for _, tt := range backupRestoreTests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			ctx := context.Background()
+
+			// Create an objectGraph bound a source cluster with all the CRDs for the types involved in the test.
+			graph := getObjectGraphWithObjs(tt.fields.objs)
+
+			// Get all the types to be considered for discovery
+			g.Expect(graph.getDiscoveryTypes(ctx)).To(Succeed())
+
+			// trigger discovery the content of the source cluster
+			g.Expect(graph.Discovery(ctx, "")).To(Succeed())
+
+			mover := objectMover{
+				fromProxy: graph.proxy,
+			}
+
+			dir := t.TempDir()
+			g.Expect(mover.toDirectory(ctx, graph, dir)).To(Succeed())
+
+			publicKeyPath, privateKeyPath := writeArchiveTestKeys(g, t.TempDir())
+			recipients, err := loadArchiveRecipients([]string{publicKeyPath})
+			g.Expect(err).ToNot(HaveOccurred())
+			identity, err := loadArchiveIdentity(privateKeyPath)
+			g.Expect(err).ToNot(HaveOccurred())
+
+			archivePath := filepath.Join(t.TempDir(), "backup.tar.gz")
+			g.Expect(writeArchive(dir, archivePath, recipients)).To(Succeed())
+
+			// Secrets are encrypted in the archive.
+			files := readArchiveTestFiles(g, archivePath)
+			for name, content := range files {
+				if name == archiveManifestFile {
+					continue
+				}
+				g.Expect(isSecretFile(content)).To(BeFalse())
+			}
+
+			restoreDir := t.TempDir()
+			g.Expect(readArchive(archivePath, restoreDir, identity)).To(Succeed())
+
+			wantObjs, err := mover.filesToObjs(dir)
+			g.Expect(err).ToNot(HaveOccurred())
+			gotObjs, err := mover.filesToObjs(restoreDir)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(gotObjs).To(ConsistOf(wantObjs))
+		})
+	}
+}
+
+func Test_readArchive(t *testing.T) {
+	tests := []struct {
+		name       string
+		encrypt    bool
+		noIdentity bool
+		otherKey   bool
+		tamper     func(files map[string][]byte)
+		wantErr    bool
+	}{
+		{
+			name:    "reads a plain archive",
+			encrypt: false,
+			wantErr: false,
+		},
+		{
+			name:    "reads an encrypted archive",
+			encrypt: true,
+			wantErr: false,
+		},
+		{
+			name:       "fails to read an encrypted archive without identity",
+			encrypt:    true,
+			noIdentity: true,
+			wantErr:    true,
+		},
+		{
+			name:     "fails to read an encrypted archive with an identity not included in the recipients",
+			encrypt:  true,
+			otherKey: true,
+			wantErr:  true,
+		},
+		{
+			name: "fails if the checksum of a file does not match",
+			tamper: func(files map[string][]byte) {
+				files["Cluster_ns1_foo.yaml"] = append(files["Cluster_ns1_foo.yaml"], ' ')
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails if a file is missing",
+			tamper: func(files map[string][]byte) {
+				delete(files, "Cluster_ns1_foo.yaml")
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails if a file is not listed in the manifest",
+			tamper: func(files map[string][]byte) {
+				files["Secret_ns1_injected.yaml"] = []byte("{}")
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails if the manifest is missing",
+			tamper: func(files map[string][]byte) {
+				delete(files, archiveManifestFile)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "fails if an encrypted Secret is swapped with another one",
+			encrypt: true,
+			tamper: func(files map[string][]byte) {
+				// NB. checksums are updated by rewriting the manifest, so only encryption can detect the swap.
+				files["Secret_ns1_foo-ca.yaml.enc"], files["Secret_ns1_foo-kubeconfig.yaml.enc"] = files["Secret_ns1_foo-kubeconfig.yaml.enc"], files["Secret_ns1_foo-ca.yaml.enc"]
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			ctx := context.Background()
+
+			graph := getObjectGraphWithObjs(test.NewFakeCluster("ns1", "foo").Objs())
+			g.Expect(graph.getDiscoveryTypes(ctx)).To(Succeed())
+			g.Expect(graph.Discovery(ctx, "")).To(Succeed())
+
+			mover := objectMover{
+				fromProxy: graph.proxy,
+			}
+			dir := t.TempDir()
+			g.Expect(mover.toDirectory(ctx, graph, dir)).To(Succeed())
+
+			keysDir := t.TempDir()
+			publicKeyPath, privateKeyPath := writeArchiveTestKeys(g, keysDir)
+			if tt.otherKey {
+				_, privateKeyPath = writeArchiveTestKeys(g, t.TempDir())
+			}
+
+			var recipients []*ecdh.PublicKey
+			if tt.encrypt {
+				var err error
+				recipients, err = loadArchiveRecipients([]string{publicKeyPath})
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+			var identity *ecdh.PrivateKey
+			if !tt.noIdentity {
+				var err error
+				identity, err = loadArchiveIdentity(privateKeyPath)
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+
+			archivePath := filepath.Join(t.TempDir(), "backup.tar.gz")
+			g.Expect(writeArchive(dir, archivePath, recipients)).To(Succeed())
+
+			if tt.tamper != nil {
+				files := readArchiveTestFiles(g, archivePath)
+				tt.tamper(files)
+				if tt.encrypt {
+					// Rewrite the manifest with the checksums of the swapped files.
+					manifest := archiveManifest{}
+					g.Expect(yaml.Unmarshal(files[archiveManifestFile], &manifest)).To(Succeed())
+					for i := range manifest.Files {
+						checksum := sha256.Sum256(files[manifest.Files[i].Name])
+						manifest.Files[i].SHA256 = hex.EncodeToString(checksum[:])
+					}
+					manifestContent, err := yaml.Marshal(manifest)
+					g.Expect(err).ToNot(HaveOccurred())
+					files[archiveManifestFile] = manifestContent
+				}
+				writeArchiveTestFiles(g, archivePath, files)
+			}
+
+			restoreDir := t.TempDir()
+			err := readArchive(archivePath, restoreDir, identity)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+
+				// Nothing is restored if the archive is not valid.
+				entries, err := os.ReadDir(restoreDir)
+				g.Expect(err).ToNot(HaveOccurred())
+				g.Expect(entries).To(BeEmpty())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+
+			wantObjs, err := mover.filesToObjs(dir)
+			g.Expect(err).ToNot(HaveOccurred())
+			gotObjs, err := mover.filesToObjs(restoreDir)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(gotObjs).To(ConsistOf(wantObjs))
+		})
+	}
+}
+
+// writeArchiveTestKeys writes a X25519 key pair in PEM format, like the one generated by openssl.
+func writeArchiveTestKeys(g *WithT, dir string) (string, string) {
+	key, err := ecdh.X25519().GenerateKey(rand.Reader)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	publicKey, err := x509.MarshalPKIXPublicKey(key.PublicKey())
+	g.Expect(err).ToNot(HaveOccurred())
+	privateKey, err := x509.MarshalPKCS8PrivateKey(key)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	publicKeyPath := filepath.Join(dir, "key.pub")
+	privateKeyPath := filepath.Join(dir, "key.pem")
+	g.Expect(os.WriteFile(publicKeyPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKey}), 0o600)).To(Succeed())
+	g.Expect(os.WriteFile(privateKeyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateKey}), 0o600)).To(Succeed())
+	return publicKeyPath, privateKeyPath
+}
+
+func readArchiveTestFiles(g *WithT, archivePath string) map[string][]byte {
+	data, err := os.ReadFile(archivePath) //nolint:gosec // The path is created by the test.
+	g.Expect(err).ToNot(HaveOccurred())
+
+	gzipReader, err := gzip.NewReader(bytes.NewReader(data))
+	g.Expect(err).ToNot(HaveOccurred())
+
+	files := map[string][]byte{}
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		g.Expect(err).ToNot(HaveOccurred())
+		content, err := io.ReadAll(tarReader)
+		g.Expect(err).ToNot(HaveOccurred())
+		files[header.Name] = content
+	}
+	return files
+}
+
+func writeArchiveTestFiles(g *WithT, archivePath string, files map[string][]byte) {
+	buf := &bytes.Buffer{}
+	gzipWriter := gzip.NewWriter(buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for name, content := range files {
+		g.Expect(tarWriter.WriteHeader(&tar.Header{Name: name, Mode: 0o600, Size: int64(len(content)), Typeflag: tar.TypeReg})).To(Succeed())
+		_, err := tarWriter.Write(content)
+		g.Expect(err).ToNot(HaveOccurred())
+	}
+	g.Expect(tarWriter.Close()).To(Succeed())
+	g.Expect(gzipWriter.Close()).To(Succeed())
+	g.Expect(os.WriteFile(archivePath, buf.Bytes(), 0o600)).To(Succeed())
+}
@@ -0,0 +1,419 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/yaml"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+)
+
+// moveJournalPhase defines the phase of a move operation recorded in a move journal.
+type moveJournalPhase string
+
+const (
+	// moveJournalPhaseCreating is the phase where objects are created in the target management cluster.
+	moveJournalPhaseCreating = moveJournalPhase("Creating")
+
+	// moveJournalPhaseDeleting is the phase where objects are deleted from the source management cluster.
+	moveJournalPhaseDeleting = moveJournalPhase("Deleting")
+
+	// moveJournalPhaseResuming is the phase where Clusters and ClusterClasses are resumed in the target management cluster.
+	moveJournalPhaseResuming = moveJournalPhase("Resuming")
+
+	// moveJournalPhaseRollingBack is the phase where a move operation is rolled back.
+	moveJournalPhaseRollingBack = moveJournalPhase("RollingBack")
+)
+
+// moveJournalSaveBatchSize is the number of objects recorded as created/deleted in a move journal before saving it.
+const moveJournalSaveBatchSize = 50
+
+// moveJournal records the move sequence and the progress of a move operation, so a move interrupted
+// halfway can be resumed or rolled back without discovering the object graph again (objects might
+// already be deleted from the source management cluster).
+type moveJournal struct {
+	// Phase of the move operation.
+	Phase moveJournalPhase `json:"phase"`
+
+	// Groups of the move sequence, in the order they are created in the target management cluster.
+	Groups [][]*moveJournalEntry `json:"groups"`
+
+	path    string
+	entries map[*node]*moveJournalEntry
+
+	// unsaved is the number of objects recorded since the journal has been saved last time.
+	unsaved int
+}
+
+// moveJournalEntry records a node of the move sequence and the progress of the move operation for it.
+type moveJournalEntry struct {
+	// Identity of the object in the source management cluster.
+	Identity corev1.ObjectReference `json:"identity"`
+
+	// Owners of the object in the source management cluster.
+	Owners []moveJournalOwner `json:"owners,omitempty"`
+
+	// IsGlobal, IsGlobalHierarchy and ShouldNotDelete record the corresponding node flags, that determine
+	// how the object is handled when it is created and deleted.
+	IsGlobal          bool `json:"isGlobal,omitempty"`
+	IsGlobalHierarchy bool `json:"isGlobalHierarchy,omitempty"`
+	ShouldNotDelete   bool `json:"shouldNotDelete,omitempty"`
+
+	// Created is true if the object has been created in the target management cluster.
+	Created bool `json:"created,omitempty"`
+
+	// TargetNamespace is the namespace of the object in the target management cluster; it can be different from the
+	// namespace in the source management cluster if mutators are used.
+	TargetNamespace string `json:"targetNamespace,omitempty"`
+
+	// TargetUID is the UID of the object in the target management cluster.
+	TargetUID types.UID `json:"targetUID,omitempty"`
+
+	// Deleted is true if the object has been deleted from the source management cluster.
+	Deleted bool `json:"deleted,omitempty"`
+}
+
+// moveJournalOwner records an OwnerReference of a node of the move sequence.
+type moveJournalOwner struct {
+	Identity           corev1.ObjectReference `json:"identity"`
+	Controller         *bool                  `json:"controller,omitempty"`
+	BlockOwnerDeletion *bool                  `json:"blockOwnerDeletion,omitempty"`
+}
+
+// newMoveJournal returns a move journal for the given move sequence.
+func newMoveJournal(path string, sequence *moveSequence) *moveJournal {
+	j := &moveJournal{
+		Phase:   moveJournalPhaseCreating,
+		path:    path,
+		entries: map[*node]*moveJournalEntry{},
+	}
+	for _, group := range sequence.groups {
+		journalGroup := make([]*moveJournalEntry, 0, len(group))
+		for _, n := range group {
+			entry := &moveJournalEntry{
+				Identity:          n.identity,
+				IsGlobal:          n.isGlobal,
+				IsGlobalHierarchy: n.isGlobalHierarchy,
+				ShouldNotDelete:   n.shouldNotDelete,
+			}
+			for owner, attributes := range n.owners {
+				entry.Owners = append(entry.Owners, moveJournalOwner{
+					Identity:           owner.identity,
+					Controller:         attributes.Controller,
+					BlockOwnerDeletion: attributes.BlockOwnerDeletion,
+				})
+			}
+			journalGroup = append(journalGroup, entry)
+			j.entries[n] = entry
+		}
+		j.Groups = append(j.Groups, journalGroup)
+	}
+	return j
+}
+
+// loadMoveJournal reads a move journal from a file.
+func loadMoveJournal(path string) (*moveJournal, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // The path is provided by the user.
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read move journal %q", path)
+	}
+
+	j := &moveJournal{}
+	if err := yaml.Unmarshal(data, j); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse move journal %q", path)
+	}
+	j.path = path
+	j.entries = map[*node]*moveJournalEntry{}
+	return j, nil
+}
+
+// save writes the move journal to its file.
+// NOTE: The file is replaced atomically, so the journal is never left in an inconsistent state.
+func (j *moveJournal) save() error {
+	if j == nil {
+		return nil
+	}
+
+	data, err := yaml.Marshal(j)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal move journal")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(j.path), 0o700); err != nil {
+		return errors.Wrapf(err, "failed to create the directory for move journal %q", j.path)
+	}
+	tmpPath := j.path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o600); err != nil {
+		return errors.Wrapf(err, "failed to write move journal %q", j.path)
+	}
+	if err := os.Rename(tmpPath, j.path); err != nil {
+		return errors.Wrapf(err, "failed to write move journal %q", j.path)
+	}
+	j.unsaved = 0
+	return nil
+}
+
+// recorded saves the move journal every moveJournalSaveBatchSize objects recorded, so the journal is not rewritten
+// after each object when moving many objects.
+// NOTE: Objects recorded but not yet saved when a move is interrupted are created/deleted again when the move is resumed,
+// which is safe because both operations are idempotent; when the move is rolled back instead, objects deleted from the
+// source cluster but not yet saved as deleted are detected because they are missing from the source cluster (see RollbackMove).
+// Callers must call flush at the end of each group of the move sequence.
+func (j *moveJournal) recorded() error {
+	j.unsaved++
+	if j.unsaved < moveJournalSaveBatchSize {
+		return nil
+	}
+	return j.save()
+}
+
+// flush saves the move journal if there are objects recorded since the journal has been saved last time.
+func (j *moveJournal) flush() error {
+	if j == nil || j.unsaved == 0 {
+		return nil
+	}
+	return j.save()
+}
+
+// remove deletes the move journal file; it should be called when the move operation is completed.
+func (j *moveJournal) remove() error {
+	if j == nil {
+		return nil
+	}
+
+	if err := os.Remove(j.path); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "failed to delete move journal %q", j.path)
+	}
+	return nil
+}
+
+// setPhase sets the phase of the move operation and saves the journal.
+func (j *moveJournal) setPhase(phase moveJournalPhase) error {
+	if j == nil {
+		return nil
+	}
+
+	j.Phase = phase
+	return j.save()
+}
+
+// isCreated returns true if the object corresponding to the node has already been created in the target management cluster.
+func (j *moveJournal) isCreated(n *node) bool {
+	if j == nil {
+		return false
+	}
+	entry, ok := j.entries[n]
+	return ok && entry.Created
+}
+
+// isDeleted returns true if the object corresponding to the node has already been deleted from the source management cluster.
+func (j *moveJournal) isDeleted(n *node) bool {
+	if j == nil {
+		return false
+	}
+	entry, ok := j.entries[n]
+	return ok && entry.Deleted
+}
+
+// sourceIdentity returns the identity in the source management cluster of the object corresponding to the node.
+func (j *moveJournal) sourceIdentity(n *node) corev1.ObjectReference {
+	if entry, ok := j.entries[n]; ok {
+		return entry.Identity
+	}
+	return n.identity
+}
+
+// recordCreated records that the object corresponding to the node has been created in the target management cluster.
+func (j *moveJournal) recordCreated(n *node, obj *unstructured.Unstructured) error {
+	if j == nil {
+		return nil
+	}
+	entry, ok := j.entries[n]
+	if !ok {
+		return nil
+	}
+
+	entry.Created = true
+	entry.TargetNamespace = obj.GetNamespace()
+	entry.TargetUID = obj.GetUID()
+	return j.recorded()
+}
+
+// recordDeleted records that the object corresponding to the node has been deleted from the source management cluster.
+func (j *moveJournal) recordDeleted(n *node) error {
+	if j == nil {
+		return nil
+	}
+	entry, ok := j.entries[n]
+	if !ok {
+		return nil
+	}
+
+	entry.Deleted = true
+	return j.recorded()
+}
+
+// moveSequence rebuilds the move sequence recorded in the journal.
+// NOTE: Nodes for objects already created in the target management cluster get the UID of the target object
+// as newUID, so OwnerReferences of the objects still to be created can be rebuilt.
+func (j *moveJournal) moveSequence() *moveSequence {
+	return j.buildSequence(func(entry *moveJournalEntry) *node {
+		n := newMoveJournalNode(entry)
+		n.identity = entry.Identity
+		if entry.Created {
+			n.newUID = entry.TargetUID
+		}
+		return n
+	})
+}
+
+// rollbackSequence builds a move sequence for moving back to the source management cluster the objects
+// recorded in the journal; nodes in this sequence refer to the objects in the target management cluster.
+// NOTE: Nodes for objects still existing in the source management cluster get the UID of the source object
+// as newUID, so OwnerReferences of the objects to be moved back can be rebuilt.
+func (j *moveJournal) rollbackSequence() *moveSequence {
+	return j.buildSequence(func(entry *moveJournalEntry) *node {
+		identity := entry.Identity
+		if entry.Created {
+			identity.Namespace = entry.TargetNamespace
+			identity.UID = entry.TargetUID
+		}
+		n := newMoveJournalNode(entry)
+		n.identity = identity
+		if !entry.Deleted {
+			n.newUID = entry.Identity.UID
+		}
+		return n
+	})
+}
+
+func newMoveJournalNode(entry *moveJournalEntry) *node {
+	return &node{
+		owners:            map[*node]ownerReferenceAttributes{},
+		softOwners:        map[*node]empty{},
+		tenant:            map[*node]empty{},
+		isGlobal:          entry.IsGlobal,
+		isGlobalHierarchy: entry.IsGlobalHierarchy,
+		shouldNotDelete:   entry.ShouldNotDelete,
+	}
+}
+
+// buildSequence builds a move sequence from the journal, using newNode to create the node for each journal entry.
+func (j *moveJournal) buildSequence(newNode func(entry *moveJournalEntry) *node) *moveSequence {
+	sequence := &moveSequence{
+		groups:   []moveGroup{},
+		nodesMap: map[*node]empty{},
+	}
+
+	uidToNode := map[types.UID]*node{}
+	for _, journalGroup := range j.Groups {
+		group := moveGroup{}
+		for _, entry := range journalGroup {
+			n := newNode(entry)
+			for _, owner := range entry.Owners {
+				// Owners are always in a previous group of the move sequence; owners not included in the move sequence
+				// are represented by a virtual node, like discovery does for owners not observed as concrete objects.
+				ownerNode, ok := uidToNode[owner.Identity.UID]
+				if !ok {
+					ownerNode = &node{identity: owner.Identity, virtual: true}
+				}
+				n.owners[ownerNode] = ownerReferenceAttributes{
+					Controller:         owner.Controller,
+					BlockOwnerDeletion: owner.BlockOwnerDeletion,
+				}
+			}
+			uidToNode[entry.Identity.UID] = n
+			j.entries[n] = entry
+			group = append(group, n)
+		}
+		sequence.addGroup(group)
+	}
+	return sequence
+}
+
+// recordRestored records that the object corresponding to the node has been moved back to the source management cluster.
+func (j *moveJournal) recordRestored(n *node) error {
+	entry, ok := j.entries[n]
+	if !ok {
+		return nil
+	}
+
+	entry.Deleted = false
+	return j.recorded()
+}
+
+// recordRemoved records that the object corresponding to the node has been deleted from the target management cluster.
+func (j *moveJournal) recordRemoved(n *node) error {
+	entry, ok := j.entries[n]
+	if !ok {
+		return nil
+	}
+
+	entry.Created = false
+	entry.TargetNamespace = ""
+	entry.TargetUID = ""
+	return j.recorded()
+}
+
+// restoreNamespace returns a mutator that sets the namespace of the objects moved back to the source management cluster
+// to the namespace they had before the move.
+func (j *moveJournal) restoreNamespace() ResourceMutatorFunc {
+	namespaces := map[string]string{}
+	for _, journalGroup := range j.Groups {
+		for _, entry := range journalGroup {
+			if entry.Created {
+				namespaces[fmt.Sprintf("%s/%s/%s", entry.Identity.GroupVersionKind().GroupKind(), entry.TargetNamespace, entry.Identity.Name)] = entry.Identity.Namespace
+			}
+		}
+	}
+	return func(u *unstructured.Unstructured) error {
+		if namespace, ok := namespaces[fmt.Sprintf("%s/%s/%s", u.GroupVersionKind().GroupKind(), u.GetNamespace(), u.GetName())]; ok {
+			u.SetNamespace(namespace)
+		}
+		return nil
+	}
+}
+
+// getClusters returns the nodes referring to Clusters in a move sequence.
+func (s *moveSequence) getClusters() []*node {
+	return s.getNodesByGroupKind(clusterv1.GroupVersion.WithKind("Cluster").GroupKind().String())
+}
+
+// getClusterClasses returns the nodes referring to ClusterClasses in a move sequence.
+func (s *moveSequence) getClusterClasses() []*node {
+	return s.getNodesByGroupKind(clusterv1.GroupVersion.WithKind("ClusterClass").GroupKind().String())
+}
+
+func (s *moveSequence) getNodesByGroupKind(groupKind string) []*node {
+	nodes := []*node{}
+	for _, group := range s.groups {
+		for _, n := range group {
+			if n.identity.GroupVersionKind().GroupKind().String() == groupKind {
+				nodes = append(nodes, n)
+			}
+		}
+	}
+	return nodes
+}
@@ -0,0 +1,319 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+)
+
+func Test_moveJournal_saveAndLoad(t *testing.T) {
+	// NB. we are testing that the move sequence recorded in the journal is the same computed from the object graph, using the same set of moveTests.
+	for _, tt := range moveTests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			ctx := context.Background()
+
+			// Create an objectGraph bound a source cluster with all the CRDs for the types involved in the test.
+			graph := getObjectGraphWithObjs(tt.fields.objs)
+
+			// Get all the types to be considered for discovery
+			g.Expect(graph.getDiscoveryTypes(ctx)).To(Succeed())
+
+			// trigger discovery the content of the source cluster
+			g.Expect(graph.Discovery(ctx, "")).To(Succeed())
+
+			journalPath := filepath.Join(t.TempDir(), "move-journal.yaml")
+			journal := newMoveJournal(journalPath, getMoveSequence(graph))
+			g.Expect(journal.save()).To(Succeed())
+
+			loaded, err := loadMoveJournal(journalPath)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(loaded.Phase).To(Equal(moveJournalPhaseCreating))
+
+			moveSequence := loaded.moveSequence()
+			g.Expect(moveSequence.groups).To(HaveLen(len(tt.wantMoveGroups)))
+
+			for i, gotGroup := range moveSequence.groups {
+				wantGroup := tt.wantMoveGroups[i]
+				gotNodes := []string{}
+				for _, node := range gotGroup {
+					gotNodes = append(gotNodes, string(node.identity.UID))
+
+					// owners are preserved.
+					graphNode := graph.uidToNode[node.identity.UID]
+					g.Expect(node.owners).To(HaveLen(len(graphNode.owners)))
+					for owner := range node.owners {
+						g.Expect(graphNode.owners).To(HaveKey(graph.uidToNode[owner.identity.UID]))
+					}
+				}
+
+				g.Expect(gotNodes).To(ConsistOf(wantGroup))
+			}
+		})
+	}
+}
+
+func Test_moveJournal_batchedSave(t *testing.T) {
+	g := NewWithT(t)
+
+	graph := getMoveJournalTestGraph(g)
+	moveSequence := getMoveSequence(graph)
+	n := moveSequence.getGroup(0)[0]
+	obj := &unstructured.Unstructured{}
+	obj.SetNamespace("ns2")
+	obj.SetUID("target-uid")
+
+	journalPath := filepath.Join(t.TempDir(), "move-journal.yaml")
+	journal := newMoveJournal(journalPath, moveSequence)
+	g.Expect(journal.save()).To(Succeed())
+
+	isCreatedOnDisk := func() bool {
+		loaded, err := loadMoveJournal(journalPath)
+		g.Expect(err).ToNot(HaveOccurred())
+		return loaded.moveSequence().getGroup(0)[0].newUID == "target-uid"
+	}
+
+	// Objects recorded are not saved until flush is called.
+	g.Expect(journal.recordCreated(n, obj)).To(Succeed())
+	g.Expect(journal.isCreated(n)).To(BeTrue())
+	g.Expect(isCreatedOnDisk()).To(BeFalse())
+
+	g.Expect(journal.flush()).To(Succeed())
+	g.Expect(isCreatedOnDisk()).To(BeTrue())
+	g.Expect(journal.unsaved).To(Equal(0))
+
+	// Objects recorded are saved every moveJournalSaveBatchSize objects.
+	g.Expect(journal.recordRemoved(n)).To(Succeed())
+	for range moveJournalSaveBatchSize - 2 {
+		g.Expect(journal.recordDeleted(n)).To(Succeed())
+	}
+	g.Expect(isCreatedOnDisk()).To(BeTrue())
+	g.Expect(journal.recordDeleted(n)).To(Succeed())
+	g.Expect(isCreatedOnDisk()).To(BeFalse())
+	g.Expect(journal.unsaved).To(Equal(0))
+}
+
+func Test_objectMover_move_withJournal(t *testing.T) {
+	g := NewWithT(t)
+
+	ctx := context.Background()
+
+	graph := getMoveJournalTestGraph(g)
+	toProxy := getFakeProxyWithCRDs()
+
+	journalPath := filepath.Join(t.TempDir(), "move-journal.yaml")
+	mover := objectMover{
+		fromProxy:   graph.proxy,
+		journalPath: journalPath,
+	}
+	g.Expect(mover.move(ctx, graph, toProxy)).To(Succeed())
+
+	// The journal is removed when the move completes.
+	_, err := os.Stat(journalPath)
+	g.Expect(os.IsNotExist(err)).To(BeTrue())
+
+	for _, node := range graph.uidToNode {
+		g.Expect(getMoveJournalTestObject(ctx, g, graph.proxy, node)).To(BeNil())
+		g.Expect(getMoveJournalTestObject(ctx, g, toProxy, node)).ToNot(BeNil())
+	}
+}
+
+func Test_objectMover_resumeMove(t *testing.T) {
+	g := NewWithT(t)
+
+	ctx := context.Background()
+
+	graph := getMoveJournalTestGraph(g)
+	toProxy := getFakeProxyWithCRDs()
+
+	// Simulate a move interrupted after creating the first group in the target cluster.
+	journalPath := filepath.Join(t.TempDir(), "move-journal.yaml")
+	moveSequence := getMoveSequence(graph)
+	mover := objectMover{
+		fromProxy: graph.proxy,
+		journal:   newMoveJournal(journalPath, moveSequence),
+	}
+	g.Expect(mover.journal.save()).To(Succeed())
+	g.Expect(mover.createGroup(ctx, moveSequence.getGroup(0), toProxy)).To(Succeed())
+
+	cluster := getMoveJournalTestObject(ctx, g, toProxy, moveSequence.getGroup(0)[0])
+	g.Expect(cluster).ToNot(BeNil())
+	g.Expect(cluster.GetUID()).ToNot(BeEmpty())
+
+	// Resume the move from the journal.
+	journal, err := loadMoveJournal(journalPath)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	resumeMover := objectMover{
+		fromProxy: graph.proxy,
+		journal:   journal,
+	}
+	resumeSequence := journal.moveSequence()
+	g.Expect(resumeMover.moveObjects(ctx, resumeSequence, resumeSequence.getClusters(), resumeSequence.getClusterClasses(), toProxy)).To(Succeed())
+
+	// The journal is removed when the move completes.
+	_, err = os.Stat(journalPath)
+	g.Expect(os.IsNotExist(err)).To(BeTrue())
+
+	for _, node := range graph.uidToNode {
+		g.Expect(getMoveJournalTestObject(ctx, g, graph.proxy, node)).To(BeNil())
+
+		obj := getMoveJournalTestObject(ctx, g, toProxy, node)
+		g.Expect(obj).ToNot(BeNil())
+
+		// Objects created when resuming are owned by the Cluster created before the move was interrupted.
+		for _, ownerRef := range obj.GetOwnerReferences() {
+			if ownerRef.Kind == "Cluster" {
+				g.Expect(ownerRef.UID).To(Equal(cluster.GetUID()))
+			}
+		}
+	}
+}
+
+func Test_objectMover_rollbackMove(t *testing.T) {
+	g := NewWithT(t)
+
+	ctx := context.Background()
+
+	graph := getMoveJournalTestGraph(g)
+	toProxy := getFakeProxyWithCRDs()
+
+	// Simulate a move interrupted after creating all the groups in the target cluster and deleting the last group from the source cluster.
+	journalPath := filepath.Join(t.TempDir(), "move-journal.yaml")
+	moveSequence := getMoveSequence(graph)
+	mover := objectMover{
+		fromProxy: graph.proxy,
+		journal:   newMoveJournal(journalPath, moveSequence),
+	}
+	g.Expect(mover.journal.save()).To(Succeed())
+	for groupIndex := range len(moveSequence.groups) {
+		g.Expect(mover.createGroup(ctx, moveSequence.getGroup(groupIndex), toProxy)).To(Succeed())
+	}
+	g.Expect(mover.journal.setPhase(moveJournalPhaseDeleting)).To(Succeed())
+	g.Expect(mover.deleteGroup(ctx, moveSequence.getGroup(len(moveSequence.groups)-1))).To(Succeed())
+
+	// Rollback the move.
+	configClient, err := config.New(ctx, "", config.InjectReader(test.NewFakeReader()))
+	g.Expect(err).ToNot(HaveOccurred())
+	toCluster := New(Kubeconfig{}, configClient, InjectProxy(toProxy))
+
+	g.Expect(mover.RollbackMove(ctx, toCluster, journalPath)).To(Succeed())
+
+	// The journal is removed when the rollback completes.
+	_, err = os.Stat(journalPath)
+	g.Expect(os.IsNotExist(err)).To(BeTrue())
+
+	for _, node := range graph.uidToNode {
+		g.Expect(getMoveJournalTestObject(ctx, g, toProxy, node)).To(BeNil())
+
+		obj := getMoveJournalTestObject(ctx, g, graph.proxy, node)
+		g.Expect(obj).ToNot(BeNil())
+
+		// Objects restored in the source cluster are owned by the Cluster that was never deleted from it.
+		for _, ownerRef := range obj.GetOwnerReferences() {
+			if ownerRef.Kind == "Cluster" {
+				g.Expect(string(ownerRef.UID)).To(Equal(clusterv1.GroupVersion.String() + ", Kind=Cluster, ns1/foo"))
+			}
+		}
+	}
+}
+
+func Test_objectMover_rollbackMove_interruptedInTheMiddleOfABatch(t *testing.T) {
+	g := NewWithT(t)
+
+	ctx := context.Background()
+
+	graph := getMoveJournalTestGraph(g)
+	toProxy := getFakeProxyWithCRDs()
+
+	// Simulate a move interrupted while deleting objects from the source cluster, before the deletions are saved in the journal.
+	journalPath := filepath.Join(t.TempDir(), "move-journal.yaml")
+	moveSequence := getMoveSequence(graph)
+	mover := objectMover{
+		fromProxy: graph.proxy,
+		journal:   newMoveJournal(journalPath, moveSequence),
+	}
+	g.Expect(mover.journal.save()).To(Succeed())
+	for groupIndex := range len(moveSequence.groups) {
+		g.Expect(mover.createGroup(ctx, moveSequence.getGroup(groupIndex), toProxy)).To(Succeed())
+	}
+	g.Expect(mover.journal.setPhase(moveJournalPhaseDeleting)).To(Succeed())
+	for groupIndex := len(moveSequence.groups) - 1; groupIndex >= 0; groupIndex-- {
+		for _, n := range moveSequence.getGroup(groupIndex) {
+			g.Expect(mover.deleteSourceObject(ctx, n)).To(Succeed())
+			g.Expect(mover.journal.recordDeleted(n)).To(Succeed())
+		}
+	}
+	g.Expect(mover.journal.unsaved).To(BeNumerically(">", 0))
+
+	loaded, err := loadMoveJournal(journalPath)
+	g.Expect(err).ToNot(HaveOccurred())
+	for _, n := range loaded.moveSequence().getGroup(0) {
+		g.Expect(loaded.isDeleted(n)).To(BeFalse())
+	}
+
+	// Rollback the move.
+	configClient, err := config.New(ctx, "", config.InjectReader(test.NewFakeReader()))
+	g.Expect(err).ToNot(HaveOccurred())
+	toCluster := New(Kubeconfig{}, configClient, InjectProxy(toProxy))
+
+	g.Expect(mover.RollbackMove(ctx, toCluster, journalPath)).To(Succeed())
+
+	// Objects deleted but not saved as deleted in the journal are restored in the source cluster before being deleted from the target cluster.
+	for _, node := range graph.uidToNode {
+		g.Expect(getMoveJournalTestObject(ctx, g, toProxy, node)).To(BeNil())
+		g.Expect(getMoveJournalTestObject(ctx, g, graph.proxy, node)).ToNot(BeNil())
+	}
+}
+
+func getMoveJournalTestGraph(g *WithT) *objectGraph {
+	ctx := context.Background()
+
+	graph := getObjectGraphWithObjs(test.NewFakeCluster("ns1", "foo").Objs())
+	g.Expect(graph.getDiscoveryTypes(ctx)).To(Succeed())
+	g.Expect(graph.Discovery(ctx, "")).To(Succeed())
+	return graph
+}
+
+// getMoveJournalTestObject returns the object corresponding to a node in a cluster, or nil if it does not exist.
+func getMoveJournalTestObject(ctx context.Context, g *WithT, proxy Proxy, node *node) *unstructured.Unstructured {
+	c, err := proxy.NewClient(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(node.identity.APIVersion)
+	obj.SetKind(node.identity.Kind)
+	err = c.Get(ctx, client.ObjectKey{Namespace: node.identity.Namespace, Name: node.identity.Name}, obj)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	g.Expect(err).ToNot(HaveOccurred())
+	return obj
+}
@@ -1369,6 +1369,53 @@ func Test_objectMover_move(t *testing.T) {
 	}
 }
 
+func Test_objectMover_move_selectClusters(t *testing.T) {
+	g := NewWithT(t)
+
+	ctx := context.Background()
+
+	// Create an objectGraph bound a source cluster with two Clusters sharing a ClusterClass.
+	objs := test.NewFakeClusterClass("ns1", "class1").Objs()
+	objs = append(objs, test.NewFakeCluster("ns1", "foo1").WithTopologyClass("class1").Objs()...)
+	objs = append(objs, test.NewFakeCluster("ns1", "foo2").WithTopologyClass("class1").Objs()...)
+	graph := getObjectGraphWithObjs(deduplicateObjects(objs))
+
+	g.Expect(graph.getDiscoveryTypes(ctx)).To(Succeed())
+	g.Expect(graph.Discovery(ctx, "ns1")).To(Succeed())
+
+	// Select the first Cluster only.
+	g.Expect(graph.selectClusters([]string{"foo1"})).To(Succeed())
+
+	// gets a fakeProxy to an empty cluster with all the required CRDs
+	toProxy := getFakeProxyWithCRDs()
+
+	// Run move
+	mover := objectMover{
+		fromProxy: graph.proxy,
+	}
+	g.Expect(mover.move(ctx, graph, toProxy)).To(Succeed())
+
+	csFrom, err := graph.proxy.NewClient(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	csTo, err := toProxy.NewClient(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// The selected Cluster is moved.
+	g.Expect(apierrors.IsNotFound(csFrom.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "foo1"}, &clusterv1.Cluster{}))).To(BeTrue())
+	g.Expect(csTo.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "foo1"}, &clusterv1.Cluster{})).To(Succeed())
+
+	// The other Cluster is not moved.
+	g.Expect(csFrom.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "foo2"}, &clusterv1.Cluster{})).To(Succeed())
+	g.Expect(apierrors.IsNotFound(csTo.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "foo2"}, &clusterv1.Cluster{}))).To(BeTrue())
+
+	// The shared ClusterClass is copied to the target cluster, and it is kept not paused in the source cluster.
+	classFrom := &clusterv1.ClusterClass{}
+	g.Expect(csFrom.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "class1"}, classFrom)).To(Succeed())
+	g.Expect(classFrom.Annotations).ToNot(HaveKey(clusterv1.PausedAnnotation))
+	g.Expect(csTo.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "class1"}, &clusterv1.ClusterClass{})).To(Succeed())
+}
+
 func Test_objectMover_move_with_Mutator(t *testing.T) {
 	// NB. we are testing the move and move sequence using the same set of moveTests, but checking the results at different stages of the move process
 	// we use same mutator function for all tests and validate outcome based on input.
@@ -138,6 +138,16 @@ func (n *node) isSoftOwnedBy(other *node) bool {
 	return ok
 }
 
+// hasTenantIn returns true if one of the tenants of the node is included in the given set of nodes.
+func (n *node) hasTenantIn(nodes map[*node]empty) bool {
+	for tenant := range n.tenant {
+		if _, ok := nodes[tenant]; ok {
+			return true
+		}
+	}
+	return false
+}
+
 func (n *node) getFilename() string {
 	return n.identity.Kind + "_" + n.identity.Namespace + "_" + n.identity.Name + ".yaml"
 }
@@ -852,3 +862,148 @@ func (o *objectGraph) setShouldNotDeleteHierarchy(node *node) {
 		}
 	}
 }
+
+// selectClusters prunes the object graph to the given Clusters and to the objects they depend on, e.g. their ClusterClass or
+// the ClusterResourceSets they are bound to, so other Clusters in the same namespace are not affected by a move operation.
+// Objects required by the selected Clusters that are also used by other Clusters are marked as should not be deleted.
+func (o *objectGraph) selectClusters(clusterNames []string) error {
+	if len(clusterNames) == 0 {
+		return nil
+	}
+
+	selected := map[*node]empty{}
+	for _, name := range clusterNames {
+		found := false
+		for _, cluster := range o.getClusters() {
+			if cluster.identity.Name == name {
+				selected[cluster] = empty{}
+				found = true
+			}
+		}
+		if !found {
+			return errors.Errorf("failed to select Cluster %q: Cluster not found", name)
+		}
+	}
+
+	others := map[*node]empty{}
+	for _, cluster := range o.getClusters() {
+		if _, ok := selected[cluster]; !ok {
+			others[cluster] = empty{}
+		}
+	}
+
+	// Select the objects belonging to the selected Clusters and, transitively, all their owners.
+	keep := map[*node]empty{}
+	var keepWithOwners func(n *node) error
+	keepWithOwners = func(n *node) error {
+		if _, ok := keep[n]; ok {
+			return nil
+		}
+		keep[n] = empty{}
+		owners := []*node{}
+		for owner := range n.owners {
+			owners = append(owners, owner)
+		}
+		for owner := range n.softOwners {
+			owners = append(owners, owner)
+		}
+		for _, owner := range owners {
+			// An object owned by objects belonging only to other Clusters can't be moved without affecting those Clusters.
+			if !owner.hasTenantIn(selected) && owner.hasTenantIn(others) {
+				return errors.Errorf("failed to select Clusters: %s is shared with Clusters not selected (it is owned by %s)", n.identityStr(), owner.identityStr())
+			}
+			if err := keepWithOwners(owner); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for _, n := range o.getNodes() {
+		if n.hasTenantIn(selected) {
+			if err := keepWithOwners(n); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Select also the objects belonging to the selected owners, e.g. the templates of a ClusterClass or the ConfigMaps of a ClusterResourceSet,
+	// unless they belong to other Clusters.
+	for {
+		added := false
+		for _, n := range o.getMoveNodes() {
+			if _, ok := keep[n]; ok || n.hasTenantIn(others) || !n.hasTenantIn(keep) {
+				continue
+			}
+			if err := keepWithOwners(n); err != nil {
+				return err
+			}
+			added = true
+		}
+		if !added {
+			break
+		}
+	}
+
+	// Select also the objects not belonging to any Cluster, e.g. global identities with their credentials or objects labeled for force move,
+	// because they might be used by the selected Clusters via references not tracked in the object graph.
+	// NOTE: Those objects might be used by Clusters not selected too, so they should not be deleted.
+	shared := map[*node]empty{}
+	for _, n := range o.getMoveNodes() {
+		if _, ok := keep[n]; ok {
+			continue
+		}
+		if n.isGlobalHierarchy || len(n.tenant) == 0 {
+			keep[n] = empty{}
+			shared[n] = empty{}
+		}
+	}
+
+	// Objects required by the selected Clusters that are also used by objects not being moved (e.g. a ClusterClass used by other Clusters)
+	// should not be deleted; this applies also to the objects linked to them, e.g. the templates of the ClusterClass.
+	for n := range keep {
+		if n.hasTenantIn(selected) {
+			// Objects belonging both to selected Clusters and to Clusters not selected, e.g. Secrets soft-owned by more Clusters.
+			if n.hasTenantIn(others) {
+				shared[n] = empty{}
+			}
+			continue
+		}
+		for _, other := range o.getMoveNodes() {
+			if _, ok := keep[other]; ok {
+				continue
+			}
+			if other.isOwnedBy(n) || other.isSoftOwnedBy(n) {
+				setSharedHierarchy(n, keep, selected, shared)
+				break
+			}
+		}
+	}
+	for n := range shared {
+		n.shouldNotDelete = true
+	}
+
+	// Prune the object graph.
+	for uid, n := range o.uidToNode {
+		if _, ok := keep[n]; !ok {
+			delete(o.uidToNode, uid)
+		}
+	}
+	return nil
+}
+
+// setSharedHierarchy adds to the shared set a node and all the nodes linked to it via ownership, in both directions,
+// that are not belonging to the selected Clusters.
+func setSharedHierarchy(n *node, keep, selected, shared map[*node]empty) {
+	if _, ok := shared[n]; ok {
+		return
+	}
+	shared[n] = empty{}
+	for other := range keep {
+		if other.hasTenantIn(selected) {
+			continue
+		}
+		if other.isOwnedBy(n) || other.isSoftOwnedBy(n) || n.isOwnedBy(other) || n.isSoftOwnedBy(other) {
+			setSharedHierarchy(other, keep, selected, shared)
+		}
+	}
+}
@@ -2763,6 +2763,227 @@ func Test_objectGraph_setGlobalIdentityTenants(t *testing.T) {
 	}
 }
 
+func Test_objectGraph_selectClusters(t *testing.T) {
+	type fields struct {
+		objs []client.Object
+	}
+	tests := []struct {
+		name         string
+		fields       fields
+		clusterNames []string
+		wantNodes    map[string]bool // wantNodes is a map[node.UID] --> shouldNotDelete
+		wantErr      bool
+	}{
+		{
+			name: "No Clusters selected",
+			fields: fields{
+				objs: test.NewFakeCluster("ns1", "foo").Objs(),
+			},
+			clusterNames: nil,
+			wantNodes: map[string]bool{
+				clusterv1.GroupVersion.String() + ", Kind=Cluster, ns1/foo":                                    false,
+				"/v1, Kind=Secret, ns1/foo-ca":                                                                 false,
+				"/v1, Kind=Secret, ns1/foo-kubeconfig":                                                         false,
+				clusterv1.GroupVersionInfrastructure.String() + ", Kind=GenericInfrastructureCluster, ns1/foo": false,
+			},
+		},
+		{
+			name: "One of two Clusters selected",
+			fields: fields{
+				objs: func() []client.Object {
+					objs := []client.Object{}
+					objs = append(objs, test.NewFakeCluster("ns1", "foo").Objs()...)
+					objs = append(objs, test.NewFakeCluster("ns1", "bar").Objs()...)
+					return objs
+				}(),
+			},
+			clusterNames: []string{"foo"},
+			wantNodes: map[string]bool{
+				clusterv1.GroupVersion.String() + ", Kind=Cluster, ns1/foo":                                    false,
+				"/v1, Kind=Secret, ns1/foo-ca":                                                                 false,
+				"/v1, Kind=Secret, ns1/foo-kubeconfig":                                                         false,
+				clusterv1.GroupVersionInfrastructure.String() + ", Kind=GenericInfrastructureCluster, ns1/foo": false,
+			},
+		},
+		{
+			name: "One of two Clusters with two ClusterClasses selected",
+			fields: fields{
+				objs: func() []client.Object {
+					objs := test.NewFakeClusterClass("ns1", "class1").Objs()
+					objs = append(objs, test.NewFakeClusterClass("ns1", "class2").Objs()...)
+					objs = append(objs, test.NewFakeCluster("ns1", "foo1").WithTopologyClass("class1").Objs()...)
+					objs = append(objs, test.NewFakeCluster("ns1", "foo2").WithTopologyClass("class2").Objs()...)
+					return deduplicateObjects(objs)
+				}(),
+			},
+			clusterNames: []string{"foo1"},
+			wantNodes: map[string]bool{
+				clusterv1.GroupVersion.String() + ", Kind=ClusterClass, ns1/class1":                                       false,
+				clusterv1.GroupVersionInfrastructure.String() + ", Kind=GenericInfrastructureClusterTemplate, ns1/class1": false,
+				clusterv1.GroupVersionControlPlane.String() + ", Kind=GenericControlPlaneTemplate, ns1/class1":            false,
+				clusterv1.GroupVersion.String() + ", Kind=Cluster, ns1/foo1":                                              false,
+				"/v1, Kind=Secret, ns1/foo1-ca":         false,
+				"/v1, Kind=Secret, ns1/foo1-kubeconfig": false,
+				clusterv1.GroupVersionInfrastructure.String() + ", Kind=GenericInfrastructureCluster, ns1/foo1": false,
+			},
+		},
+		{
+			name: "One of two Clusters sharing one ClusterClass selected",
+			fields: fields{
+				objs: func() []client.Object {
+					objs := test.NewFakeClusterClass("ns1", "class1").Objs()
+					objs = append(objs, test.NewFakeCluster("ns1", "foo1").WithTopologyClass("class1").Objs()...)
+					objs = append(objs, test.NewFakeCluster("ns1", "foo2").WithTopologyClass("class1").Objs()...)
+					return deduplicateObjects(objs)
+				}(),
+			},
+			clusterNames: []string{"foo1"},
+			wantNodes: map[string]bool{
+				// The ClusterClass and its templates are still used by foo2, so they should not be deleted.
+				clusterv1.GroupVersion.String() + ", Kind=ClusterClass, ns1/class1":                                       true,
+				clusterv1.GroupVersionInfrastructure.String() + ", Kind=GenericInfrastructureClusterTemplate, ns1/class1": true,
+				clusterv1.GroupVersionControlPlane.String() + ", Kind=GenericControlPlaneTemplate, ns1/class1":            true,
+				clusterv1.GroupVersion.String() + ", Kind=Cluster, ns1/foo1":                                              false,
+				"/v1, Kind=Secret, ns1/foo1-ca":         false,
+				"/v1, Kind=Secret, ns1/foo1-kubeconfig": false,
+				clusterv1.GroupVersionInfrastructure.String() + ", Kind=GenericInfrastructureCluster, ns1/foo1": false,
+			},
+		},
+		{
+			name: "One of two Clusters sharing one ClusterResourceSet selected",
+			fields: fields{
+				objs: func() []client.Object {
+					objs := []client.Object{}
+					objs = append(objs, test.NewFakeCluster("ns1", "cluster1").Objs()...)
+					objs = append(objs, test.NewFakeCluster("ns1", "cluster2").Objs()...)
+
+					objs = append(objs, test.NewFakeClusterResourceSet("ns1", "crs1").
+						WithSecret("resource-s1").
+						WithConfigMap("resource-c1").
+						ApplyToCluster(test.SelectClusterObj(objs, "ns1", "cluster1")).
+						ApplyToCluster(test.SelectClusterObj(objs, "ns1", "cluster2")).
+						Objs()...)
+
+					return objs
+				}(),
+			},
+			clusterNames: []string{"cluster1"},
+			wantNodes: map[string]bool{
+				clusterv1.GroupVersion.String() + ", Kind=Cluster, ns1/cluster1":                                    false,
+				"/v1, Kind=Secret, ns1/cluster1-ca":                                                                 false,
+				"/v1, Kind=Secret, ns1/cluster1-kubeconfig":                                                         false,
+				clusterv1.GroupVersionInfrastructure.String() + ", Kind=GenericInfrastructureCluster, ns1/cluster1": false,
+				addonsv1.GroupVersion.String() + ", Kind=ClusterResourceSetBinding, ns1/cluster1":                   false,
+				// The ClusterResourceSet and its resources are still used by cluster2, so they should not be deleted.
+				addonsv1.GroupVersion.String() + ", Kind=ClusterResourceSet, ns1/crs1": true,
+				"/v1, Kind=Secret, ns1/resource-s1":                                    true,
+				"/v1, Kind=ConfigMap, ns1/resource-c1":                                 true,
+			},
+		},
+		{
+			name: "One of two Clusters sharing a global identity selected",
+			fields: fields{
+				objs: func() []client.Object {
+					objs := test.NewFakeClusterInfrastructureIdentity("infra1-identity").
+						WithSecretIn("ns1").
+						Objs()
+					objs = append(objs, test.NewFakeCluster("ns1", "foo").Objs()...)
+					objs = append(objs, test.NewFakeCluster("ns1", "bar").Objs()...)
+					return objs
+				}(),
+			},
+			clusterNames: []string{"foo"},
+			wantNodes: map[string]bool{
+				clusterv1.GroupVersion.String() + ", Kind=Cluster, ns1/foo":                                    false,
+				"/v1, Kind=Secret, ns1/foo-ca":                                                                 false,
+				"/v1, Kind=Secret, ns1/foo-kubeconfig":                                                         false,
+				clusterv1.GroupVersionInfrastructure.String() + ", Kind=GenericInfrastructureCluster, ns1/foo": false,
+				// The global identity and its credentials might be used by both the Clusters, so they are moved but not deleted.
+				clusterv1.GroupVersionInfrastructure.String() + ", Kind=GenericClusterInfrastructureIdentity, infra1-identity": true,
+				"/v1, Kind=Secret, ns1/infra1-identity-credentials":                                                            true,
+			},
+		},
+		{
+			name: "One of two Clusters sharing an object labeled for force move selected",
+			fields: fields{
+				objs: func() []client.Object {
+					objs := test.NewFakeExternalObject("ns1", "externalObject1").Objs()
+					objs = append(objs, test.NewFakeCluster("ns1", "foo").Objs()...)
+					objs = append(objs, test.NewFakeCluster("ns1", "bar").Objs()...)
+					return objs
+				}(),
+			},
+			clusterNames: []string{"foo"},
+			wantNodes: map[string]bool{
+				clusterv1.GroupVersion.String() + ", Kind=Cluster, ns1/foo":                                    false,
+				"/v1, Kind=Secret, ns1/foo-ca":                                                                 false,
+				"/v1, Kind=Secret, ns1/foo-kubeconfig":                                                         false,
+				clusterv1.GroupVersionInfrastructure.String() + ", Kind=GenericInfrastructureCluster, ns1/foo": false,
+				"external.cluster.x-k8s.io/v1beta2, Kind=GenericExternalObject, ns1/externalObject1":           true,
+			},
+		},
+		{
+			name: "Fails if a Cluster requires objects belonging to Clusters not selected",
+			fields: fields{
+				objs: func() []client.Object {
+					sharedInfrastructureTemplate := test.NewFakeInfrastructureTemplate("shared")
+
+					objs := []client.Object{
+						sharedInfrastructureTemplate,
+					}
+					objs = append(objs, test.NewFakeCluster("ns1", "cluster1").
+						WithMachineSets(test.NewFakeMachineSet("cluster1-ms1").WithInfrastructureTemplate(sharedInfrastructureTemplate)).
+						Objs()...)
+					objs = append(objs, test.NewFakeCluster("ns1", "cluster2").
+						WithMachineSets(test.NewFakeMachineSet("cluster2-ms1").WithInfrastructureTemplate(sharedInfrastructureTemplate)).
+						Objs()...)
+					return objs
+				}(),
+			},
+			clusterNames: []string{"cluster1"},
+			wantErr:      true,
+		},
+		{
+			name: "Fails if a Cluster does not exist",
+			fields: fields{
+				objs: test.NewFakeCluster("ns1", "foo").Objs(),
+			},
+			clusterNames: []string{"bar"},
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			ctx := context.Background()
+
+			// Create an objectGraph bound to a source cluster with all the CRDs for the types involved in the test.
+			graph := getObjectGraphWithObjs(tt.fields.objs)
+
+			// Get all the types to be considered for discovery
+			g.Expect(graph.getDiscoveryTypes(ctx)).To(Succeed())
+
+			// trigger discovery the content of the source cluster
+			// NOTE: Discovery is not limited to ns1, because the fake client does not return global objects when listing objects in a namespace.
+			g.Expect(graph.Discovery(ctx, "")).To(Succeed())
+
+			err := graph.selectClusters(tt.clusterNames)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+
+			gotNodes := map[string]bool{}
+			for _, node := range graph.getMoveNodes() {
+				gotNodes[string(node.identity.UID)] = node.shouldNotDelete
+			}
+			g.Expect(gotNodes).To(Equal(tt.wantNodes))
+		})
+	}
+}
+
 func deduplicateObjects(objs []client.Object) []client.Object {
 	res := []client.Object{}
 	uniqueObjectKeys := sets.Set[string]{}
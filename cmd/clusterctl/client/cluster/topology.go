@@ -0,0 +1,442 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"crypto/rsa"
+	"sort"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/scheme"
+	"sigs.k8s.io/cluster-api/controllers/clustercache"
+	"sigs.k8s.io/cluster-api/controllers/remote"
+	"sigs.k8s.io/cluster-api/exp/topology/scope"
+	"sigs.k8s.io/cluster-api/internal/contract"
+	clustertopologycontroller "sigs.k8s.io/cluster-api/internal/controllers/topology/cluster"
+	"sigs.k8s.io/cluster-api/internal/controllers/topology/cluster/structuredmerge"
+	"sigs.k8s.io/cluster-api/internal/util/ssa"
+	"sigs.k8s.io/cluster-api/util"
+)
+
+// TopologyDiffOperation defines the operation the topology controller is going to perform on an object.
+type TopologyDiffOperation string
+
+const (
+	// TopologyDiffCreate is used for objects that do not exist yet.
+	TopologyDiffCreate TopologyDiffOperation = "Create"
+
+	// TopologyDiffUpdate is used for objects that are going to be patched in place.
+	TopologyDiffUpdate TopologyDiffOperation = "Update"
+
+	// TopologyDiffRotate is used for templates that are going to be replaced by a new template, because
+	// according to Cluster API operational practices templates are never changed in place.
+	TopologyDiffRotate TopologyDiffOperation = "Rotate"
+
+	// TopologyDiffDelete is used for objects that are not part of the desired state anymore.
+	TopologyDiffDelete TopologyDiffOperation = "Delete"
+)
+
+// TopologyDiffInput defines the input for the Diff function.
+type TopologyDiffInput struct {
+	// Namespace of the Cluster.
+	Namespace string
+
+	// ClusterName is the name of the Cluster with a managed topology.
+	ClusterName string
+}
+
+// TopologyDiffOutput defines the output of the Diff function.
+type TopologyDiffOutput struct {
+	// Cluster is the Cluster the diff has been computed for.
+	Cluster *clusterv1.Cluster
+
+	// Objects is the list of objects the topology controller is going to change, in the order they are reconciled.
+	// Objects without changes are not included.
+	Objects []TopologyObjectDiff
+}
+
+// TopologyObjectDiff defines the changes to a single object.
+type TopologyObjectDiff struct {
+	// Object is a reference to the object.
+	Object corev1.ObjectReference
+
+	// Operation is the operation the topology controller is going to perform on the object.
+	Operation TopologyDiffOperation
+
+	// Changes is the JSON merge patch computed by running server side apply in dry run mode against the current object.
+	// It is empty for objects to be created or deleted.
+	Changes []byte
+
+	// Desired is the desired state of the object. It is nil for objects to be deleted.
+	Desired *unstructured.Unstructured
+}
+
+// TopologyClient has methods to work with ClusterClass and managed topologies.
+type TopologyClient interface {
+	// Diff computes the desired state of a Cluster with a managed topology using the same code of the topology controller,
+	// and returns the differences with the objects in the management cluster, including the changes server side apply
+	// would make to existing objects.
+	// NOTE: Diff never changes objects in the management cluster.
+	Diff(ctx context.Context, in *TopologyDiffInput) (*TopologyDiffOutput, error)
+}
+
+// topologyClient implements TopologyClient.
+type topologyClient struct {
+	proxy Proxy
+}
+
+// ensure topologyClient implements TopologyClient.
+var _ TopologyClient = &topologyClient{}
+
+// newTopologyClient returns a TopologyClient.
+func newTopologyClient(proxy Proxy) *topologyClient {
+	return &topologyClient{
+		proxy: proxy,
+	}
+}
+
+func (t *topologyClient) Diff(ctx context.Context, in *TopologyDiffInput) (*TopologyDiffOutput, error) {
+	c, err := t.proxy.NewClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// NOTE: all the write operations are executed in dry run mode, so the management cluster is never changed.
+	c = client.NewDryRunClient(c)
+
+	cluster := &clusterv1.Cluster{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: in.Namespace, Name: in.ClusterName}, cluster); err != nil {
+		return nil, errors.Wrapf(err, "failed to get Cluster %s", klog.KRef(in.Namespace, in.ClusterName))
+	}
+
+	// Runtime Extensions can't be called from clusterctl, so the desired state can't be computed for Clusters
+	// using a ClusterClass that relies on them.
+	if err := checkClusterClassRuntimeExtensions(ctx, c, cluster); err != nil {
+		return nil, err
+	}
+
+	reconciler := &clustertopologycontroller.Reconciler{
+		Client:       c,
+		APIReader:    c,
+		ClusterCache: &workloadClusterCache{client: c},
+	}
+	if err := reconciler.SetupForDryRun(); err != nil {
+		return nil, err
+	}
+
+	s, err := reconciler.ComputeDesiredState(ctx, cluster)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to compute the desired state of Cluster %s", klog.KObj(cluster))
+	}
+
+	differ := &topologyDiffer{
+		client:   c,
+		ssaCache: ssa.NewCache("clusterctl/topology"),
+		out: &TopologyDiffOutput{
+			Cluster: cluster,
+		},
+	}
+	if err := differ.diff(ctx, s); err != nil {
+		return nil, err
+	}
+	return differ.out, nil
+}
+
+// checkClusterClassRuntimeExtensions returns an error if the ClusterClass of a Cluster uses external patches or an upgrade plan extension.
+// NOTE: Without this check, external patches would be silently skipped and the computed diff would not match what the topology controller does.
+func checkClusterClassRuntimeExtensions(ctx context.Context, c client.Reader, cluster *clusterv1.Cluster) error {
+	if !cluster.Spec.Topology.IsDefined() {
+		// Clusters without a managed topology are reported by ComputeDesiredState.
+		return nil
+	}
+
+	clusterClass := &clusterv1.ClusterClass{}
+	if err := c.Get(ctx, cluster.GetClassKey(), clusterClass); err != nil {
+		return errors.Wrapf(err, "failed to get ClusterClass %s", cluster.GetClassKey())
+	}
+
+	for _, patch := range clusterClass.Spec.Patches {
+		if patch.External != nil {
+			return errors.Errorf("ClusterClass %s uses the external patch %q: external patches are not supported by clusterctl", klog.KObj(clusterClass), patch.Name)
+		}
+	}
+	if clusterClass.Spec.Upgrade.External.GenerateUpgradePlanExtension != "" {
+		return errors.Errorf("ClusterClass %s uses the upgrade plan extension %q: upgrade plan extensions are not supported by clusterctl", klog.KObj(clusterClass), clusterClass.Spec.Upgrade.External.GenerateUpgradePlanExtension)
+	}
+	return nil
+}
+
+// topologyDiffer computes the diff between the current and the desired state of a Cluster topology.
+type topologyDiffer struct {
+	client   client.Client
+	ssaCache ssa.Cache
+	out      *TopologyDiffOutput
+}
+
+func (d *topologyDiffer) diff(ctx context.Context, s *scope.Scope) error {
+	// Diff the InfrastructureCluster.
+	ignorePaths, err := contract.InfrastructureCluster().IgnorePaths(s.Desired.InfrastructureCluster)
+	if err != nil {
+		return errors.Wrap(err, "failed to calculate ignore paths")
+	}
+	if err := d.diffObject(ctx, s.Current.InfrastructureCluster, s.Desired.InfrastructureCluster, ignorePaths...); err != nil {
+		return err
+	}
+
+	// Diff the ControlPlane, its MachineHealthCheck and its InfrastructureMachineTemplate.
+	if err := d.diffObject(ctx, s.Current.ControlPlane.MachineHealthCheck, s.Desired.ControlPlane.MachineHealthCheck); err != nil {
+		return err
+	}
+	if s.Blueprint.HasControlPlaneInfrastructureMachine() {
+		if err := d.diffTemplate(ctx, s.Current.ControlPlane.InfrastructureMachineTemplate, s.Desired.ControlPlane.InfrastructureMachineTemplate); err != nil {
+			return err
+		}
+	}
+	ignorePaths, err = contract.ControlPlane().IgnorePaths(s.Desired.ControlPlane.Object)
+	if err != nil {
+		return errors.Wrap(err, "failed to calculate ignore paths")
+	}
+	if err := d.diffObject(ctx, s.Current.ControlPlane.Object, s.Desired.ControlPlane.Object, ignorePaths...); err != nil {
+		return err
+	}
+
+	// Diff the Cluster.
+	if err := d.diffObject(ctx, s.Current.Cluster, s.Desired.Cluster); err != nil {
+		return err
+	}
+
+	// Diff the MachineDeployments and the referenced objects.
+	for _, name := range sortedKeys(s.Current.MachineDeployments, s.Desired.MachineDeployments) {
+		current, desired := s.Current.MachineDeployments[name], s.Desired.MachineDeployments[name]
+		switch {
+		case desired == nil:
+			if err := d.diffObject(ctx, current.MachineHealthCheck, nil); err != nil {
+				return err
+			}
+			if err := d.diffObject(ctx, current.Object, nil); err != nil {
+				return err
+			}
+		case current == nil:
+			current = &scope.MachineDeploymentState{}
+			fallthrough
+		default:
+			if err := d.diffObject(ctx, current.MachineHealthCheck, desired.MachineHealthCheck); err != nil {
+				return err
+			}
+			if err := d.diffTemplate(ctx, current.InfrastructureMachineTemplate, desired.InfrastructureMachineTemplate); err != nil {
+				return err
+			}
+			if err := d.diffTemplate(ctx, current.BootstrapTemplate, desired.BootstrapTemplate); err != nil {
+				return err
+			}
+			if err := d.diffObject(ctx, current.Object, desired.Object); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Diff the MachinePools and the referenced objects.
+	for _, name := range sortedKeys(s.Current.MachinePools, s.Desired.MachinePools) {
+		current, desired := s.Current.MachinePools[name], s.Desired.MachinePools[name]
+		switch {
+		case desired == nil:
+			if err := d.diffObject(ctx, current.Object, nil); err != nil {
+				return err
+			}
+		case current == nil:
+			current = &scope.MachinePoolState{}
+			fallthrough
+		default:
+			if err := d.diffObject(ctx, current.InfrastructureMachinePoolObject, desired.InfrastructureMachinePoolObject); err != nil {
+				return err
+			}
+			if err := d.diffObject(ctx, current.BootstrapObject, desired.BootstrapObject); err != nil {
+				return err
+			}
+			if err := d.diffObject(ctx, current.Object, desired.Object); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// diffObject adds to the output the changes required to move an object from the current to the desired state.
+func (d *topologyDiffer) diffObject(ctx context.Context, current, desired client.Object, ignorePaths ...contract.Path) error {
+	return d.diffReferencedObject(ctx, current, desired, false, ignorePaths)
+}
+
+// diffTemplate adds to the output the changes required to move a template from the current to the desired state;
+// when the spec of a template changes, the template is rotated.
+func (d *topologyDiffer) diffTemplate(ctx context.Context, current, desired client.Object) error {
+	return d.diffReferencedObject(ctx, current, desired, true, nil)
+}
+
+func (d *topologyDiffer) diffReferencedObject(ctx context.Context, current, desired client.Object, isTemplate bool, ignorePaths []contract.Path) error {
+	if util.IsNil(desired) {
+		if util.IsNil(current) {
+			return nil
+		}
+		ref, err := d.objectReference(current)
+		if err != nil {
+			return err
+		}
+		d.out.Objects = append(d.out.Objects, TopologyObjectDiff{Object: ref, Operation: TopologyDiffDelete})
+		return nil
+	}
+
+	desiredUnstructured, err := d.toUnstructured(desired)
+	if err != nil {
+		return err
+	}
+	ref, err := d.objectReference(desiredUnstructured)
+	if err != nil {
+		return err
+	}
+
+	if util.IsNil(current) {
+		d.out.Objects = append(d.out.Objects, TopologyObjectDiff{Object: ref, Operation: TopologyDiffCreate, Desired: desiredUnstructured})
+		return nil
+	}
+
+	patchHelper, err := structuredmerge.NewServerSidePatchHelper(ctx, current, desired, d.client, d.ssaCache, structuredmerge.IgnorePaths(ignorePaths))
+	if err != nil {
+		return errors.Wrapf(err, "failed to compute changes for %s %s", ref.Kind, klog.KRef(ref.Namespace, ref.Name))
+	}
+	if !patchHelper.HasChanges() {
+		return nil
+	}
+
+	operation := TopologyDiffUpdate
+	if isTemplate && patchHelper.HasSpecChanges() {
+		operation = TopologyDiffRotate
+	}
+	d.out.Objects = append(d.out.Objects, TopologyObjectDiff{Object: ref, Operation: operation, Changes: patchHelper.Changes(), Desired: desiredUnstructured})
+	return nil
+}
+
+func (d *topologyDiffer) toUnstructured(obj client.Object) (*unstructured.Unstructured, error) {
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		return u.DeepCopy(), nil
+	}
+
+	gvk, err := apiutil.GVKForObject(obj, d.client.Scheme())
+	if err != nil {
+		return nil, err
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to convert %s to Unstructured", gvk.Kind)
+	}
+	u := &unstructured.Unstructured{Object: content}
+	u.SetGroupVersionKind(gvk)
+	return u, nil
+}
+
+func (d *topologyDiffer) objectReference(obj client.Object) (corev1.ObjectReference, error) {
+	gvk, err := apiutil.GVKForObject(obj, d.client.Scheme())
+	if err != nil {
+		return corev1.ObjectReference{}, err
+	}
+	return corev1.ObjectReference{
+		APIVersion: gvk.GroupVersion().String(),
+		Kind:       gvk.Kind,
+		Namespace:  obj.GetNamespace(),
+		Name:       obj.GetName(),
+	}, nil
+}
+
+// sortedKeys returns the sorted union of the keys of the current and desired state maps.
+func sortedKeys[C, D any](current map[string]C, desired map[string]D) []string {
+	keys := []string{}
+	for k := range current {
+		keys = append(keys, k)
+	}
+	for k := range desired {
+		if _, ok := current[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// workloadClusterCache implements clustercache.ClusterCache for computing the desired state of a Cluster topology,
+// by creating clients for the workload clusters using their kubeconfig secret.
+// NOTE: Only the methods required to read objects from workload clusters are supported; clients are created in dry run mode,
+// so workload clusters are never changed.
+type workloadClusterCache struct {
+	client client.Client
+}
+
+// ensure workloadClusterCache implements clustercache.ClusterCache.
+var _ clustercache.ClusterCache = &workloadClusterCache{}
+
+func (w *workloadClusterCache) GetClient(ctx context.Context, cluster client.ObjectKey) (client.Client, error) {
+	return w.GetUncachedClient(ctx, cluster)
+}
+
+func (w *workloadClusterCache) GetReader(ctx context.Context, cluster client.ObjectKey) (client.Reader, error) {
+	return w.GetUncachedClient(ctx, cluster)
+}
+
+func (w *workloadClusterCache) GetUncachedClient(ctx context.Context, cluster client.ObjectKey) (client.Client, error) {
+	restConfig, err := w.GetRESTConfig(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+	c, err := client.New(restConfig, client.Options{Scheme: scheme.Scheme})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create client for Cluster %s", klog.KRef(cluster.Namespace, cluster.Name))
+	}
+	return client.NewDryRunClient(c), nil
+}
+
+func (w *workloadClusterCache) GetRESTConfig(ctx context.Context, cluster client.ObjectKey) (*rest.Config, error) {
+	return remote.RESTConfig(ctx, "clusterctl", w.client, cluster)
+}
+
+func (w *workloadClusterCache) GetClientCertificatePrivateKey(_ context.Context, _ client.ObjectKey) (*rsa.PrivateKey, error) {
+	return nil, errors.New("getting the client certificate private key is not supported by clusterctl")
+}
+
+func (w *workloadClusterCache) Watch(_ context.Context, _ client.ObjectKey, _ clustercache.Watcher) error {
+	return errors.New("watching workload clusters is not supported by clusterctl")
+}
+
+func (w *workloadClusterCache) GetHealthCheckingState(_ context.Context, _ client.ObjectKey) clustercache.HealthCheckingState {
+	return clustercache.HealthCheckingState{}
+}
+
+func (w *workloadClusterCache) GetClusterSource(_ string, _ func(ctx context.Context, cluster client.Object) []ctrl.Request, _ ...clustercache.GetClusterSourceOption) source.Source {
+	return source.Func(func(context.Context, workqueue.TypedRateLimitingInterface[reconcile.Request]) error {
+		return errors.New("watching workload clusters is not supported by clusterctl")
+	})
+}
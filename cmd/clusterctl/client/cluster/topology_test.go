@@ -0,0 +1,132 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+	"sigs.k8s.io/cluster-api/util/test/builder"
+)
+
+func Test_topologyClient_Diff(t *testing.T) {
+	g := NewWithT(t)
+
+	ctx := context.Background()
+
+	infrastructureClusterTemplate := builder.TestInfrastructureClusterTemplate("ns1", "infra-cluster-template").Build()
+	controlPlaneTemplate := builder.TestControlPlaneTemplate("ns1", "control-plane-template").Build()
+	clusterClass := builder.ClusterClass("ns1", "class").
+		WithInfrastructureClusterTemplate(infrastructureClusterTemplate).
+		WithControlPlaneTemplate(controlPlaneTemplate).
+		WithConditions(metav1.Condition{Type: clusterv1.ClusterClassVariablesReadyCondition, Status: metav1.ConditionTrue}).
+		Build()
+	cluster := builder.Cluster("ns1", "cluster1").
+		WithTopology(builder.ClusterTopology().
+			WithClass("class").
+			WithVersion("v1.33.0").
+			Build()).
+		Build()
+
+	proxy := test.NewFakeProxy().WithObjs(
+		builder.TestInfrastructureClusterCRD.DeepCopy(),
+		builder.TestInfrastructureClusterTemplateCRD.DeepCopy(),
+		builder.TestControlPlaneCRD.DeepCopy(),
+		builder.TestControlPlaneTemplateCRD.DeepCopy(),
+		infrastructureClusterTemplate,
+		controlPlaneTemplate,
+		clusterClass,
+		cluster,
+	)
+	topologyClient := newTopologyClient(proxy)
+
+	// A Cluster without InfrastructureCluster and ControlPlane.
+	out, err := topologyClient.Diff(ctx, &TopologyDiffInput{Namespace: "ns1", ClusterName: "cluster1"})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(getTopologyDiffOperations(out)).To(Equal([]string{
+		"Create TestInfrastructureCluster",
+		"Create TestControlPlane",
+		"Update Cluster",
+	}))
+
+	// Diff never changes objects in the management cluster.
+	c, err := proxy.NewClient(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	currentCluster := &clusterv1.Cluster{}
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(cluster), currentCluster)).To(Succeed())
+	g.Expect(currentCluster.Spec.InfrastructureRef.IsDefined()).To(BeFalse())
+
+	// Create the desired objects like the topology controller does.
+	for _, objDiff := range out.Objects {
+		g.Expect(c.Patch(ctx, objDiff.Desired, client.Apply, client.FieldOwner("capi-topology"), client.ForceOwnership)).To(Succeed())
+	}
+	controlPlane := out.Objects[1].Desired
+
+	out, err = topologyClient.Diff(ctx, &TopologyDiffInput{Namespace: "ns1", ClusterName: "cluster1"})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(out.Objects).To(BeEmpty())
+
+	// Change the Kubernetes version after the control plane is provisioned.
+	g.Expect(unstructured.SetNestedField(controlPlane.Object, "v1.33.0", "status", "version")).To(Succeed())
+	g.Expect(c.Update(ctx, controlPlane)).To(Succeed())
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(cluster), currentCluster)).To(Succeed())
+	currentCluster.Spec.Topology.Version = "v1.34.0"
+	g.Expect(c.Update(ctx, currentCluster)).To(Succeed())
+
+	out, err = topologyClient.Diff(ctx, &TopologyDiffInput{Namespace: "ns1", ClusterName: "cluster1"})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(getTopologyDiffOperations(out)).To(Equal([]string{
+		"Update TestControlPlane",
+	}))
+	g.Expect(string(out.Objects[0].Changes)).To(ContainSubstring(`"version":"v1.34.0"`))
+
+	// Diff fails for a Cluster without a managed topology.
+	g.Expect(c.Create(ctx, builder.Cluster("ns1", "cluster2").Build())).To(Succeed())
+	_, err = topologyClient.Diff(ctx, &TopologyDiffInput{Namespace: "ns1", ClusterName: "cluster2"})
+	g.Expect(err).To(HaveOccurred())
+
+	// Diff fails for a Cluster using a ClusterClass with external patches, because Runtime Extensions can't be called.
+	currentClusterClass := &clusterv1.ClusterClass{}
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(clusterClass), currentClusterClass)).To(Succeed())
+	currentClusterClass.Spec.Patches = []clusterv1.ClusterClassPatch{
+		{
+			Name: "external-patch",
+			External: &clusterv1.ExternalPatchDefinition{
+				GeneratePatchesExtension: "generate-patches.external-patches",
+			},
+		},
+	}
+	g.Expect(c.Update(ctx, currentClusterClass)).To(Succeed())
+	_, err = topologyClient.Diff(ctx, &TopologyDiffInput{Namespace: "ns1", ClusterName: "cluster1"})
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring("external patches are not supported"))
+}
+
+func getTopologyDiffOperations(out *TopologyDiffOutput) []string {
+	operations := []string{}
+	for _, objDiff := range out.Objects {
+		operations = append(operations, string(objDiff.Operation)+" "+objDiff.Object.Kind)
+	}
+	return operations
+}
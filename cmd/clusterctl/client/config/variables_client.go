@@ -21,6 +21,20 @@ const (
 	GitHubTokenVariable = "github-token"
 	// GitLabAccessTokenVariable defines a variable hosting the GitLab access token. This can be used with Personal and Project access tokens.
 	GitLabAccessTokenVariable = "gitlab-access-token"
+	// OCIRegistryUsernameVariable defines a variable hosting the username used to authenticate against an OCI registry.
+	OCIRegistryUsernameVariable = "oci-registry-username"
+	// OCIRegistryPasswordVariable defines a variable hosting the password or access token used to authenticate against an OCI registry.
+	OCIRegistryPasswordVariable = "oci-registry-password"
+	// HTTPRepositoryAuthHeaderVariable defines a variable hosting a custom header, in the form "{name}: {value}", used to authenticate against a generic HTTP repository.
+	HTTPRepositoryAuthHeaderVariable = "http-repository-auth-header"
+	// HTTPRepositoryTokenVariable defines a variable hosting a bearer token used to authenticate against a generic HTTP repository.
+	HTTPRepositoryTokenVariable = "http-repository-token"
+	// HTTPRepositoryUsernameVariable defines a variable hosting the username used to authenticate against a generic HTTP repository using basic authentication.
+	HTTPRepositoryUsernameVariable = "http-repository-username"
+	// HTTPRepositoryPasswordVariable defines a variable hosting the password used to authenticate against a generic HTTP repository using basic authentication.
+	HTTPRepositoryPasswordVariable = "http-repository-password"
+	// HTTPRepositoryHostsVariable defines a variable hosting a comma separated list of hosts, in the form "{host}[:{port}]", generic HTTP repository credentials can be sent to.
+	HTTPRepositoryHostsVariable = "http-repository-hosts"
 )
 
 // VariablesClient has methods to work with environment variables and with variables defined in the clusterctl configuration file.
@@ -0,0 +1,71 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
+)
+
+// machineResourceType is the resource type supported by DrainPlan.
+const machineResourceType = "machine"
+
+// DrainPlanOptions carries the options supported by DrainPlan.
+type DrainPlanOptions struct {
+	// Kubeconfig defines the kubeconfig to use for accessing the management cluster. If empty,
+	// default rules for kubeconfig discovery will be used.
+	Kubeconfig Kubeconfig
+
+	// Resource is the Machine to compute the drain plan for, in the machine/<name> form.
+	Resource string
+
+	// Namespace where the Machine lives. If unspecified, the namespace name will be inferred
+	// from the current configuration.
+	Namespace string
+}
+
+func (c *clusterctlClient) DrainPlan(ctx context.Context, options DrainPlanOptions) (*cluster.MachineDrainPlanOutput, error) {
+	clusterClient, err := c.clusterClientFactory(ClusterClientFactoryInput{Kubeconfig: options.Kubeconfig})
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure this command only runs against management clusters with the current Cluster API contract.
+	if err := clusterClient.ProviderInventory().CheckCAPIContract(ctx); err != nil {
+		return nil, err
+	}
+
+	if options.Resource == "" {
+		return nil, errors.New("required resource not specified")
+	}
+	objRefs, err := getObjectRefs(clusterClient, options.Namespace, []string{options.Resource})
+	if err != nil {
+		return nil, err
+	}
+	ref := objRefs[0]
+	if ref.Kind != machineResourceType {
+		return nil, errors.Errorf("invalid resource type %q, only %s/<name> is supported", ref.Kind, machineResourceType)
+	}
+
+	return clusterClient.Machine().DrainPlan(ctx, &cluster.MachineDrainPlanInput{
+		Namespace:   ref.Namespace,
+		MachineName: ref.Name,
+	})
+}
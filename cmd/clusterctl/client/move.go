@@ -39,6 +39,10 @@ type MoveOptions struct {
 	// namespace will be used.
 	Namespace string
 
+	// Clusters limits the move to the given Clusters in the namespace and to the objects they depend on, e.g. their
+	// ClusterClass or the ClusterResourceSets they are bound to. If unspecified, all the Clusters in the namespace are moved.
+	Clusters []string
+
 	// ExperimentalResourceMutatorFn accepts any number of resource mutator functions that are applied on all resources being moved.
 	// This is an experimental feature and is exposed only from the library and not (yet) through the CLI.
 	ExperimentalResourceMutators []cluster.ResourceMutatorFunc
@@ -49,8 +53,32 @@ type MoveOptions struct {
 	// ToDirectory save configuration to directory.
 	ToDirectory string
 
+	// FromArchive apply configuration from a tar.gz archive written using ToArchive.
+	FromArchive string
+
+	// ToArchive save configuration to a tar.gz archive, including a manifest with the checksum of each file.
+	ToArchive string
+
+	// ArchiveRecipients are the paths of the PEM encoded X25519 public keys Secrets are encrypted for when using ToArchive.
+	// If empty, Secrets are not encrypted.
+	ArchiveRecipients []string
+
+	// ArchiveIdentity is the path of the PEM encoded X25519 private key used for decrypting Secrets when using FromArchive.
+	ArchiveIdentity string
+
 	// DryRun means the move action is a dry run, no real action will be performed.
 	DryRun bool
+
+	// JournalFile is the path of the file where the progress of the move is recorded, so an interrupted move
+	// can be resumed or rolled back. If empty, the progress of the move is not recorded.
+	JournalFile string
+
+	// Resume resumes an interrupted move using the progress recorded in JournalFile.
+	Resume bool
+
+	// Rollback rolls back an interrupted move using the progress recorded in JournalFile, moving all the objects
+	// back to the source management cluster.
+	Rollback bool
 }
 
 func (c *clusterctlClient) Move(ctx context.Context, options MoveOptions) error {
@@ -59,17 +87,54 @@ func (c *clusterctlClient) Move(ctx context.Context, options MoveOptions) error
 		return errors.Errorf("can't set both FromDirectory and ToDirectory")
 	}
 
+	// Archives can't be used together with directories, or with each other.
+	sources := 0
+	for _, s := range []string{options.FromDirectory, options.ToDirectory, options.FromArchive, options.ToArchive} {
+		if s != "" {
+			sources++
+		}
+	}
+	if sources > 1 {
+		return errors.Errorf("only one of FromDirectory, ToDirectory, FromArchive and ToArchive can be set")
+	}
+	if len(options.ArchiveRecipients) > 0 && options.ToArchive == "" {
+		return errors.Errorf("ArchiveRecipients can be used only with ToArchive")
+	}
+	if options.ArchiveIdentity != "" && options.FromArchive == "" {
+		return errors.Errorf("ArchiveIdentity can be used only with FromArchive")
+	}
+
+	if options.Resume || options.Rollback {
+		if options.Resume && options.Rollback {
+			return errors.Errorf("can't set both Resume and Rollback")
+		}
+		if options.JournalFile == "" {
+			return errors.Errorf("JournalFile must be set when using Resume or Rollback")
+		}
+		if options.DryRun || sources > 0 {
+			return errors.Errorf("Resume and Rollback can't be used with DryRun, FromDirectory, ToDirectory, FromArchive or ToArchive")
+		}
+	}
+
+	if len(options.Clusters) > 0 && (options.FromDirectory != "" || options.FromArchive != "" || options.Resume || options.Rollback) {
+		return errors.Errorf("Clusters can't be used with FromDirectory, FromArchive, Resume or Rollback")
+	}
+
 	if !options.DryRun &&
-		options.FromDirectory == "" &&
-		options.ToDirectory == "" &&
+		sources == 0 &&
 		options.ToKubeconfig == (Kubeconfig{}) {
-		return errors.Errorf("at least one of FromDirectory, ToDirectory and ToKubeconfig must be set")
+		return errors.Errorf("at least one of FromDirectory, ToDirectory, FromArchive, ToArchive and ToKubeconfig must be set")
 	}
 
-	if options.ToDirectory != "" {
+	switch {
+	case options.ToDirectory != "":
 		return c.toDirectory(ctx, options)
-	} else if options.FromDirectory != "" {
+	case options.FromDirectory != "":
 		return c.fromDirectory(ctx, options)
+	case options.ToArchive != "":
+		return c.toArchive(ctx, options)
+	case options.FromArchive != "":
+		return c.fromArchive(ctx, options)
 	}
 
 	return c.move(ctx, options)
@@ -82,6 +147,18 @@ func (c *clusterctlClient) move(ctx context.Context, options MoveOptions) error
 		return err
 	}
 
+	// Resume or rollback an interrupted move; the namespace and the objects to move are read from the journal.
+	if options.Resume || options.Rollback {
+		toCluster, err := c.getClusterClient(ctx, options.ToKubeconfig)
+		if err != nil {
+			return err
+		}
+		if options.Rollback {
+			return fromCluster.ObjectMover().RollbackMove(ctx, toCluster, options.JournalFile)
+		}
+		return fromCluster.ObjectMover().ResumeMove(ctx, toCluster, options.JournalFile, options.ExperimentalResourceMutators...)
+	}
+
 	// If the option specifying the Namespace is empty, try to detect it.
 	if options.Namespace == "" {
 		currentNamespace, err := fromCluster.Proxy().CurrentNamespace()
@@ -99,7 +176,13 @@ func (c *clusterctlClient) move(ctx context.Context, options MoveOptions) error
 		}
 	}
 
-	return fromCluster.ObjectMover().Move(ctx, options.Namespace, toCluster, options.DryRun, options.ExperimentalResourceMutators...)
+	mover := fromCluster.ObjectMover()
+	mover.SelectClusters(options.Clusters...)
+
+	if options.JournalFile != "" && !options.DryRun {
+		return mover.MoveWithJournal(ctx, options.Namespace, toCluster, options.JournalFile, options.ExperimentalResourceMutators...)
+	}
+	return mover.Move(ctx, options.Namespace, toCluster, options.DryRun, options.ExperimentalResourceMutators...)
 }
 
 func (c *clusterctlClient) fromDirectory(ctx context.Context, options MoveOptions) error {
@@ -134,7 +217,44 @@ func (c *clusterctlClient) toDirectory(ctx context.Context, options MoveOptions)
 		return err
 	}
 
-	return fromCluster.ObjectMover().ToDirectory(ctx, options.Namespace, options.ToDirectory)
+	mover := fromCluster.ObjectMover()
+	mover.SelectClusters(options.Clusters...)
+
+	return mover.ToDirectory(ctx, options.Namespace, options.ToDirectory)
+}
+
+func (c *clusterctlClient) fromArchive(ctx context.Context, options MoveOptions) error {
+	toCluster, err := c.getClusterClient(ctx, options.ToKubeconfig)
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(options.FromArchive); err != nil {
+		return err
+	}
+
+	return toCluster.ObjectMover().FromArchive(ctx, toCluster, options.FromArchive, options.ArchiveIdentity)
+}
+
+func (c *clusterctlClient) toArchive(ctx context.Context, options MoveOptions) error {
+	fromCluster, err := c.getClusterClient(ctx, options.FromKubeconfig)
+	if err != nil {
+		return err
+	}
+
+	// If the option specifying the Namespace is empty, try to detect it.
+	if options.Namespace == "" {
+		currentNamespace, err := fromCluster.Proxy().CurrentNamespace()
+		if err != nil {
+			return err
+		}
+		options.Namespace = currentNamespace
+	}
+
+	mover := fromCluster.ObjectMover()
+	mover.SelectClusters(options.Clusters...)
+
+	return mover.ToArchive(ctx, options.Namespace, options.ToArchive, options.ArchiveRecipients)
 }
 
 func (c *clusterctlClient) getClusterClient(ctx context.Context, kubeconfig Kubeconfig) (cluster.Client, error) {
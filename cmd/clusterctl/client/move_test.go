@@ -18,6 +18,8 @@ package client
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
 	. "github.com/onsi/gomega"
@@ -122,6 +124,81 @@ func Test_clusterctlClient_Move(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "does not return an error when resuming a move",
+			fields: fields{
+				client: fakeClientForMove(),
+			},
+			args: args{
+				options: MoveOptions{
+					FromKubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+					ToKubeconfig:   Kubeconfig{Path: "kubeconfig", Context: "worker-context"},
+					JournalFile:    "/var/cache/move-journal.yaml",
+					Resume:         true,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "returns an error if both Resume and Rollback are set",
+			fields: fields{
+				client: fakeClientForMove(),
+			},
+			args: args{
+				options: MoveOptions{
+					FromKubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+					ToKubeconfig:   Kubeconfig{Path: "kubeconfig", Context: "worker-context"},
+					JournalFile:    "/var/cache/move-journal.yaml",
+					Resume:         true,
+					Rollback:       true,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "does not return an error when moving selected Clusters",
+			fields: fields{
+				client: fakeClientForMove(),
+			},
+			args: args{
+				options: MoveOptions{
+					FromKubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+					ToKubeconfig:   Kubeconfig{Path: "kubeconfig", Context: "worker-context"},
+					Clusters:       []string{"foo"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "returns an error if Clusters are set when resuming a move",
+			fields: fields{
+				client: fakeClientForMove(),
+			},
+			args: args{
+				options: MoveOptions{
+					FromKubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+					ToKubeconfig:   Kubeconfig{Path: "kubeconfig", Context: "worker-context"},
+					JournalFile:    "/var/cache/move-journal.yaml",
+					Resume:         true,
+					Clusters:       []string{"foo"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "returns an error if Rollback is set without JournalFile",
+			fields: fields{
+				client: fakeClientForMove(),
+			},
+			args: args{
+				options: MoveOptions{
+					FromKubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+					ToKubeconfig:   Kubeconfig{Path: "kubeconfig", Context: "worker-context"},
+					Rollback:       true,
+				},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -301,6 +378,28 @@ func (f *fakeObjectMover) Move(_ context.Context, _ string, _ cluster.Client, _
 	return f.moveErr
 }
 
+func (f *fakeObjectMover) MoveWithJournal(_ context.Context, _ string, _ cluster.Client, _ string, _ ...cluster.ResourceMutatorFunc) error {
+	return f.moveErr
+}
+
+func (f *fakeObjectMover) ResumeMove(_ context.Context, _ cluster.Client, _ string, _ ...cluster.ResourceMutatorFunc) error {
+	return f.moveErr
+}
+
+func (f *fakeObjectMover) RollbackMove(_ context.Context, _ cluster.Client, _ string) error {
+	return f.moveErr
+}
+
+func (f *fakeObjectMover) SelectClusters(_ ...string) {}
+
+func (f *fakeObjectMover) ToArchive(_ context.Context, _ string, _ string, _ []string) error {
+	return f.toDirectoryErr
+}
+
+func (f *fakeObjectMover) FromArchive(_ context.Context, _ cluster.Client, _ string, _ string) error {
+	return f.fromDirectoryErr
+}
+
 func (f *fakeObjectMover) ToDirectory(_ context.Context, _ string, _ string) error {
 	return f.toDirectoryErr
 }
@@ -316,3 +415,109 @@ func (f *fakeObjectMover) FromDirectory(_ context.Context, _ cluster.Client, _ s
 func (f *fakeObjectMover) Restore(_ context.Context, _ cluster.Client, _ string) error {
 	return f.fromDirectoryErr
 }
+
+func Test_clusterctlClient_Archive(t *testing.T) {
+	archive := filepath.Join(t.TempDir(), "backup.tar.gz")
+	g := NewWithT(t)
+	g.Expect(os.WriteFile(archive, []byte{}, 0o600)).To(Succeed())
+
+	type fields struct {
+		client *fakeClient
+	}
+	// These tests are checking the archive scaffolding
+	// The internal library handles the archive logic and tests can be found there
+	type args struct {
+		options MoveOptions
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "does not return error when writing an encrypted archive",
+			fields: fields{
+				client: fakeClientForMove(),
+			},
+			args: args{
+				options: MoveOptions{
+					FromKubeconfig:    Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+					ToArchive:         archive,
+					ArchiveRecipients: []string{"key.pub"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "does not return error when reading an encrypted archive",
+			fields: fields{
+				client: fakeClientForMove(),
+			},
+			args: args{
+				options: MoveOptions{
+					ToKubeconfig:    Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+					FromArchive:     archive,
+					ArchiveIdentity: "key.pem",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "returns an error if the archive does not exist",
+			fields: fields{
+				client: fakeClientForMove(),
+			},
+			args: args{
+				options: MoveOptions{
+					ToKubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+					FromArchive:  filepath.Join(t.TempDir(), "does-not-exist.tar.gz"),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "returns an error if both ToArchive and ToDirectory are set",
+			fields: fields{
+				client: fakeClientForMove(),
+			},
+			args: args{
+				options: MoveOptions{
+					FromKubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+					ToArchive:      archive,
+					ToDirectory:    t.TempDir(),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "returns an error if ArchiveRecipients are set without ToArchive",
+			fields: fields{
+				client: fakeClientForMove(),
+			},
+			args: args{
+				options: MoveOptions{
+					FromKubeconfig:    Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+					ToDirectory:       t.TempDir(),
+					ArchiveRecipients: []string{"key.pub"},
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			ctx := context.Background()
+
+			err := tt.fields.client.Move(ctx, tt.args.options)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+		})
+	}
+}
@@ -19,6 +19,7 @@ package repository
 import (
 	"context"
 	"net/url"
+	"os"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -167,6 +168,12 @@ type Repository interface {
 	GetVersions(ctx context.Context) ([]string, error)
 }
 
+// IsNotFound returns true if err reports that a file does not exist in a provider repository,
+// e.g. a cluster template for a flavor that is not published by the provider.
+func IsNotFound(err error) bool {
+	return errors.Is(err, errNotFound) || errors.Is(err, errFileNotFound) || errors.Is(err, os.ErrNotExist)
+}
+
 // repositoryFactory returns the repository implementation corresponding to the provider URL.
 func repositoryFactory(ctx context.Context, providerConfig config.Provider, configVariablesClient config.VariablesClient) (Repository, error) {
 	// parse the repository url
@@ -193,8 +200,24 @@ func repositoryFactory(ctx context.Context, providerConfig config.Provider, conf
 			}
 			return repo, err
 		}
+	}
 
-		return nil, errors.Errorf("invalid provider url. Only GitHub and GitLab are supported for %q schema", rURL.Scheme)
+	// if the url is any other http(s) server, e.g. a generic artifact server
+	if rURL.Scheme == httpsScheme || rURL.Scheme == httpScheme {
+		repo, err := NewHTTPRepository(ctx, providerConfig, configVariablesClient)
+		if err != nil {
+			return nil, errors.Wrap(err, "error creating the HTTP repository client")
+		}
+		return repo, err
+	}
+
+	// if the url is an OCI registry
+	if rURL.Scheme == ociScheme {
+		repo, err := NewOCIRepository(ctx, providerConfig, configVariablesClient)
+		if err != nil {
+			return nil, errors.Wrap(err, "error creating the OCI repository client")
+		}
+		return repo, err
 	}
 
 	// if the url is a local filesystem repository
@@ -206,5 +229,5 @@ func repositoryFactory(ctx context.Context, providerConfig config.Provider, conf
 		return repo, err
 	}
 
-	return nil, errors.Errorf("invalid provider url. there are no provider implementation for %q schema", rURL.Scheme)
+	return nil, errors.Errorf("invalid provider url. there are no provider implementation for %q schema, supported schemas are %q, %q, %q and %q", rURL.Scheme, httpsScheme, httpScheme, ociScheme, "file")
 }
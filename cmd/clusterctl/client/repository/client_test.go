@@ -18,9 +18,11 @@ package repository
 
 import (
 	"context"
+	"os"
 	"testing"
 
 	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
 
 	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
@@ -77,6 +79,20 @@ func Test_newRepositoryClient_LocalFileSystemRepository(t *testing.T) {
 			},
 			expected: &gitLabRepository{},
 		},
+		{
+			name: "successfully creates repository client with OCI backend",
+			fields: fields{
+				provider: config.NewProvider("bar", "oci://registry.example.com/capi/bootstrap-bar:v1.0.0/bootstrap-components.yaml", clusterctlv1.BootstrapProviderType),
+			},
+			expected: &ociRepository{},
+		},
+		{
+			name: "successfully creates repository client with generic HTTP backend",
+			fields: fields{
+				provider: config.NewProvider("bar", "https://artifacts.example.com/capi/bootstrap-bar/v1.0.0/bootstrap-components.yaml", clusterctlv1.BootstrapProviderType),
+			},
+			expected: &httpRepository{},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -92,6 +108,18 @@ func Test_newRepositoryClient_LocalFileSystemRepository(t *testing.T) {
 	}
 }
 
+func Test_newRepositoryClient_UnsupportedScheme(t *testing.T) {
+	g := NewWithT(t)
+
+	ctx := context.Background()
+
+	configClient, err := config.New(ctx, "", config.InjectReader(test.NewFakeReader()))
+	g.Expect(err).ToNot(HaveOccurred())
+
+	_, err = newRepositoryClient(ctx, config.NewProvider("bar", "ftp://artifacts.example.com/capi/bootstrap-bar/v1.0.0/bootstrap-components.yaml", clusterctlv1.BootstrapProviderType), configClient)
+	g.Expect(err).To(MatchError(ContainSubstring("there are no provider implementation for \"ftp\" schema")))
+}
+
 func Test_newRepositoryClient_YamlProcessor(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -149,3 +177,39 @@ func Test_newRepositoryClient_YamlProcessor(t *testing.T) {
 		})
 	}
 }
+
+func Test_IsNotFound(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "release not found",
+			err:  errors.Wrap(errNotFound, "failed to get file"),
+			want: true,
+		},
+		{
+			name: "file not found",
+			err:  errors.Wrap(errFileNotFound, "failed to get file"),
+			want: true,
+		},
+		{
+			name: "local file not found",
+			err:  errors.Wrap(os.ErrNotExist, "failed to read file"),
+			want: true,
+		},
+		{
+			name: "other errors",
+			err:  errors.New("failed to get file: unauthorized access, please check your credentials"),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			g.Expect(IsNotFound(tt.err)).To(Equal(tt.want))
+		})
+	}
+}
@@ -32,6 +32,25 @@ type ClusterClassClient interface {
 	Get(ctx context.Context, name, targetNamespace string, skipTemplateProcess bool) (Template, error)
 }
 
+// RawClusterClassClient is implemented by ClusterClassClients that can return ClusterClass templates without any processing.
+// Note: this is kept out of ClusterClassClient so existing implementations of ClusterClassClient are not affected.
+type RawClusterClassClient interface {
+	Raw(ctx context.Context, name string) ([]byte, error)
+}
+
+// GetRawClusterClass returns the template for the ClusterClass with the given name, without any processing.
+// An error is returned if the ClusterClassClient does not implement RawClusterClassClient.
+func GetRawClusterClass(ctx context.Context, c ClusterClassClient, name string) ([]byte, error) {
+	rc, ok := c.(RawClusterClassClient)
+	if !ok {
+		return nil, errors.Errorf("ClusterClass client %T does not support reading raw templates", c)
+	}
+	return rc.Raw(ctx, name)
+}
+
+// Ensure clusterClassClient implements the RawClusterClassClient interface.
+var _ RawClusterClassClient = &clusterClassClient{}
+
 type clusterClassClient struct {
 	version               string
 	provider              config.Provider
@@ -60,12 +79,28 @@ func newClusterClassClient(input ClusterClassClientInput) *clusterClassClient {
 }
 
 func (cc *clusterClassClient) Get(ctx context.Context, name, targetNamespace string, skipTemplateProcess bool) (Template, error) {
-	log := logf.Log
-
 	if targetNamespace == "" {
 		return nil, errors.New("invalid arguments: please provide a targetNamespace")
 	}
 
+	rawArtifact, err := cc.Raw(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTemplate(TemplateInput{
+		rawArtifact,
+		cc.configVariablesClient,
+		cc.processor,
+		targetNamespace,
+		skipTemplateProcess,
+	})
+}
+
+// Raw returns the template for the ClusterClass with the given name, without any processing.
+func (cc *clusterClassClient) Raw(ctx context.Context, name string) ([]byte, error) {
+	log := logf.Log
+
 	version := cc.version
 	filename := cc.processor.GetClusterClassTemplateName(version, name)
 
@@ -89,12 +124,5 @@ func (cc *clusterClassClient) Get(ctx context.Context, name, targetNamespace str
 	} else {
 		log.V(1).Info("Using", "override", filename, "provider", cc.provider.ManifestLabel(), "version", version)
 	}
-
-	return NewTemplate(TemplateInput{
-		rawArtifact,
-		cc.configVariablesClient,
-		cc.processor,
-		targetNamespace,
-		skipTemplateProcess,
-	})
+	return rawArtifact, nil
 }
@@ -50,8 +50,11 @@ const (
 )
 
 var (
-	errNotFound  = errors.New("404 Not Found")
-	errRateLimit = errors.New("rate limit for github api has been reached. Please wait one hour or get a personal API token and assign it to the GITHUB_TOKEN environment variable")
+	errNotFound = errors.New("404 Not Found")
+	// errFileNotFound is returned when a file does not exist in a release that exists.
+	// NOTE: This is different from errNotFound, which is used as a signal that a release does not exist yet.
+	errFileNotFound = errors.New("file not found")
+	errRateLimit    = errors.New("rate limit for github api has been reached. Please wait one hour or get a personal API token and assign it to the GITHUB_TOKEN environment variable")
 
 	// Caches used to limit the number of GitHub API calls.
 
@@ -454,7 +457,7 @@ func (g *gitHubRepository) downloadFilesFromRelease(ctx context.Context, release
 		}
 	}
 	if assetID == nil {
-		return nil, errors.Errorf("failed to get file %q from %q release", fileName, *release.TagName)
+		return nil, errors.Wrapf(errFileNotFound, "failed to get file %q from %q release", fileName, *release.TagName)
 	}
 
 	var reader io.ReadCloser
@@ -178,6 +178,9 @@ func (g *gitLabRepository) GetFile(ctx context.Context, version, path string) ([
 		if response.StatusCode == http.StatusUnauthorized {
 			return nil, errors.Errorf("failed to get file %q with version %q from %q: unauthorized access, please check your credentials", path, version, url)
 		}
+		if response.StatusCode == http.StatusNotFound {
+			return nil, errors.Wrapf(errFileNotFound, "failed to get file %q with version %q from %q", path, version, url)
+		}
 		return nil, errors.Errorf("failed to get file %q with version %q from %q, got %d", path, version, url, response.StatusCode)
 	}
 
@@ -0,0 +1,288 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
+)
+
+const (
+	httpScheme = "http"
+
+	// httpIndexFile is the name of the file listing the versions available in a generic HTTP repository.
+	httpIndexFile = "index.yaml"
+)
+
+// httpRepository provides support for providers hosted on a generic HTTP(S) server,
+// e.g. an artifact server like Artifactory or Nexus or an S3 bucket exposed as a website.
+//
+// A provider url should be in the form {http|https}://{host}/{basePath}/{latest|version}/{componentsPath};
+// files for each version are expected to be stored in the {basePath}/{version} folder, while the
+// list of available versions is read from the {basePath}/index.yaml file, e.g.
+//
+//	versions:
+//	- v1.0.0
+//	- v1.1.0
+type httpRepository struct {
+	providerConfig        config.Provider
+	configVariablesClient config.VariablesClient
+	httpClient            *http.Client
+	baseURL               string
+	defaultVersion        string
+	rootPath              string
+	componentsPath        string
+	header                http.Header
+}
+
+var _ Repository = &httpRepository{}
+
+type httpRepositoryOption func(*httpRepository)
+
+func injectHTTPClient(c *http.Client) httpRepositoryOption {
+	return func(r *httpRepository) {
+		r.httpClient = c
+	}
+}
+
+// httpRepositoryIndex is the content of the index file of a generic HTTP repository.
+type httpRepositoryIndex struct {
+	Versions []string `json:"versions"`
+}
+
+// NewHTTPRepository returns an httpRepository implementation.
+func NewHTTPRepository(ctx context.Context, providerConfig config.Provider, configVariablesClient config.VariablesClient, opts ...httpRepositoryOption) (Repository, error) {
+	if configVariablesClient == nil {
+		return nil, errors.New("invalid arguments: configVariablesClient can't be nil")
+	}
+
+	rURL, err := url.Parse(providerConfig.URL())
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid url")
+	}
+
+	// Check if the path is in the expected format, i.e. at least a version and a components file.
+	urlSplit := strings.Split(strings.TrimPrefix(rURL.Path, "/"), "/")
+	if (rURL.Scheme != httpsScheme && rURL.Scheme != httpScheme) || rURL.Host == "" || len(urlSplit) < 2 || urlSplit[len(urlSplit)-1] == "" || urlSplit[len(urlSplit)-2] == "" {
+		return nil, errors.New("invalid url: an HTTP repository url should be in the form {http|https}://{host}/{basePath}/{latest|version}/{componentsPath}")
+	}
+
+	// Extract all the info from url split.
+	basePath := strings.Join(urlSplit[:len(urlSplit)-2], "/")
+	defaultVersion := urlSplit[len(urlSplit)-2]
+	componentsPath := urlSplit[len(urlSplit)-1]
+
+	baseURL := url.URL{Scheme: rURL.Scheme, Host: rURL.Host, Path: "/" + basePath}
+
+	repo := &httpRepository{
+		providerConfig:        providerConfig,
+		configVariablesClient: configVariablesClient,
+		httpClient:            http.DefaultClient,
+		baseURL:               strings.TrimSuffix(baseURL.String(), "/"),
+		defaultVersion:        defaultVersion,
+		rootPath:              ".",
+		componentsPath:        componentsPath,
+		header:                http.Header{},
+	}
+
+	// Process httpRepositoryOptions.
+	for _, o := range opts {
+		o(repo)
+	}
+
+	if err := repo.setAuthHeader(rURL.Host); err != nil {
+		return nil, err
+	}
+
+	// Never send credentials in clear text.
+	if rURL.Scheme == httpScheme && len(repo.header) > 0 {
+		return nil, errors.Errorf("invalid url: credentials for the HTTP repository %q can't be sent over plain http, please use https", providerConfig.URL())
+	}
+
+	if defaultVersion == latestVersionTag {
+		repo.defaultVersion, err = latestContractRelease(ctx, repo, clusterv1.GroupVersion.Version)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get latest release")
+		}
+	}
+
+	return repo, nil
+}
+
+// setAuthHeader sets the header used to authenticate against the HTTP server according to the
+// variables defined in the clusterctl configuration, if any.
+// NOTE: Credentials are set only if host is listed in the http-repository-hosts variable, so they are
+// never sent to HTTP repositories hosted elsewhere.
+func (r *httpRepository) setAuthHeader(host string) error {
+	header := http.Header{}
+	if authHeader, err := r.configVariablesClient.Get(config.HTTPRepositoryAuthHeaderVariable); err == nil {
+		name, value, ok := strings.Cut(authHeader, ":")
+		if !ok || strings.TrimSpace(name) == "" {
+			return errors.Errorf("invalid value for the %q variable: it should be in the form \"{name}: {value}\"", config.HTTPRepositoryAuthHeaderVariable)
+		}
+		header.Set(strings.TrimSpace(name), strings.TrimSpace(value))
+	} else if token, err := r.configVariablesClient.Get(config.HTTPRepositoryTokenVariable); err == nil {
+		header.Set("Authorization", "Bearer "+token)
+	} else if username, err := r.configVariablesClient.Get(config.HTTPRepositoryUsernameVariable); err == nil {
+		password, _ := r.configVariablesClient.Get(config.HTTPRepositoryPasswordVariable)
+		request := &http.Request{Header: header}
+		request.SetBasicAuth(username, password)
+	}
+	if len(header) == 0 {
+		return nil
+	}
+
+	hosts, err := r.configVariablesClient.Get(config.HTTPRepositoryHostsVariable)
+	if err != nil {
+		return errors.Errorf("the %q variable must be set to the list of hosts credentials for HTTP repositories can be sent to", config.HTTPRepositoryHostsVariable)
+	}
+	for _, h := range strings.Split(hosts, ",") {
+		if strings.EqualFold(strings.TrimSpace(h), host) {
+			r.header = header
+			return nil
+		}
+	}
+	return nil
+}
+
+// DefaultVersion returns defaultVersion field of httpRepository struct.
+func (r *httpRepository) DefaultVersion() string {
+	return r.defaultVersion
+}
+
+// RootPath returns rootPath field of httpRepository struct.
+func (r *httpRepository) RootPath() string {
+	return r.rootPath
+}
+
+// ComponentsPath returns componentsPath field of httpRepository struct.
+func (r *httpRepository) ComponentsPath() string {
+	return r.componentsPath
+}
+
+// GetVersions returns the list of versions that are available in a provider repository
+// according to the repository index file.
+func (r *httpRepository) GetVersions(ctx context.Context) ([]string, error) {
+	if versions, ok := cacheVersions[r.baseURL]; ok {
+		return versions, nil
+	}
+
+	content, err := r.get(ctx, fmt.Sprintf("%s/%s", r.baseURL, httpIndexFile))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the repository index")
+	}
+
+	index := &httpRepositoryIndex{}
+	if err := yaml.Unmarshal(content, index); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the repository index %s/%s", r.baseURL, httpIndexFile)
+	}
+
+	cacheVersions[r.baseURL] = index.Versions
+	return index.Versions, nil
+}
+
+// GetFile returns a file for a given provider version.
+func (r *httpRepository) GetFile(ctx context.Context, version, path string) ([]byte, error) {
+	if version == "" {
+		version = r.defaultVersion
+	}
+
+	fileURL := fmt.Sprintf("%s/%s/%s", r.baseURL, version, path)
+	if content, ok := cacheFiles[fileURL]; ok {
+		return content, nil
+	}
+
+	content, err := r.get(ctx, fileURL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get file %q with version %q", path, version)
+	}
+
+	cacheFiles[fileURL] = content
+	return content, nil
+}
+
+// get executes a GET request against the HTTP server.
+func (r *httpRepository) get(ctx context.Context, fileURL string) ([]byte, error) {
+	timeoutctx, cancel := context.WithTimeoutCause(ctx, 30*time.Second, errors.New("http request timeout expired"))
+	defer cancel()
+	request, err := http.NewRequestWithContext(timeoutctx, http.MethodGet, fileURL, http.NoBody)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get %q: failed to create request", fileURL)
+	}
+	for name, values := range r.header {
+		request.Header[name] = values
+	}
+
+	httpClient := r.httpClient
+	if len(r.header) > 0 {
+		// Never send credentials to other hosts or over plain http when following redirects.
+		// NOTE: The http client drops the Authorization header on redirects to other domains, but it
+		// keeps it for subdomains and it keeps any custom header, so credentials are removed explicitly.
+		c := *r.httpClient
+		c.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+			if len(via) > 0 && !strings.EqualFold(req.URL.Host, via[0].URL.Host) {
+				for name := range r.header {
+					req.Header.Del(name)
+				}
+			} else if req.URL.Scheme != httpsScheme {
+				return errors.Errorf("redirect to %q not allowed: credentials for the HTTP repository can't be sent over plain http", req.URL.String())
+			}
+			if r.httpClient.CheckRedirect != nil {
+				return r.httpClient.CheckRedirect(req, via)
+			}
+			if len(via) >= 10 {
+				return errors.New("stopped after 10 redirects")
+			}
+			return nil
+		}
+		httpClient = &c
+	}
+
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get %q", fileURL)
+	}
+
+	defer response.Body.Close()
+
+	switch response.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, errors.Wrapf(errNotFound, "failed to get %q", fileURL)
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return nil, errors.Errorf("failed to get %q: unauthorized access, please check your credentials", fileURL)
+	default:
+		return nil, errors.Errorf("failed to get %q, got %d", fileURL, response.StatusCode)
+	}
+
+	content, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get %q", fileURL)
+	}
+	return content, nil
+}
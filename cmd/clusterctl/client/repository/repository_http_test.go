@@ -0,0 +1,390 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+	goproxytest "sigs.k8s.io/cluster-api/internal/goproxy/test"
+)
+
+func Test_httpRepository_newHTTPRepository(t *testing.T) {
+	providerURL := "https://artifacts.example.com/capi/infrastructure-foo/v1.0.0/infrastructure-components.yaml"
+
+	tests := []struct {
+		name           string
+		providerConfig config.Provider
+		variableClient config.VariablesClient
+		want           *httpRepository
+		wantedErr      string
+	}{
+		{
+			name:           "can create a new HTTP repo",
+			providerConfig: config.NewProvider("test", providerURL, clusterctlv1.InfrastructureProviderType),
+			variableClient: test.NewFakeVariableClient(),
+			want: &httpRepository{
+				providerConfig:        config.NewProvider("test", providerURL, clusterctlv1.InfrastructureProviderType),
+				configVariablesClient: test.NewFakeVariableClient(),
+				httpClient:            http.DefaultClient,
+				baseURL:               "https://artifacts.example.com/capi/infrastructure-foo",
+				defaultVersion:        "v1.0.0",
+				rootPath:              ".",
+				componentsPath:        "infrastructure-components.yaml",
+				header:                http.Header{},
+			},
+		},
+		{
+			name:           "can create a new HTTP repo without a base path",
+			providerConfig: config.NewProvider("test", "http://artifacts.example.com/v1.0.0/infrastructure-components.yaml", clusterctlv1.InfrastructureProviderType),
+			variableClient: test.NewFakeVariableClient(),
+			want: &httpRepository{
+				providerConfig:        config.NewProvider("test", "http://artifacts.example.com/v1.0.0/infrastructure-components.yaml", clusterctlv1.InfrastructureProviderType),
+				configVariablesClient: test.NewFakeVariableClient(),
+				httpClient:            http.DefaultClient,
+				baseURL:               "http://artifacts.example.com",
+				defaultVersion:        "v1.0.0",
+				rootPath:              ".",
+				componentsPath:        "infrastructure-components.yaml",
+				header:                http.Header{},
+			},
+		},
+		{
+			name:           "can create a new HTTP repo with a bearer token",
+			providerConfig: config.NewProvider("test", providerURL, clusterctlv1.InfrastructureProviderType),
+			variableClient: test.NewFakeVariableClient().WithVar(config.HTTPRepositoryTokenVariable, "token").WithVar(config.HTTPRepositoryHostsVariable, "artifacts.example.com"),
+			want: &httpRepository{
+				providerConfig:        config.NewProvider("test", providerURL, clusterctlv1.InfrastructureProviderType),
+				configVariablesClient: test.NewFakeVariableClient().WithVar(config.HTTPRepositoryTokenVariable, "token").WithVar(config.HTTPRepositoryHostsVariable, "artifacts.example.com"),
+				httpClient:            http.DefaultClient,
+				baseURL:               "https://artifacts.example.com/capi/infrastructure-foo",
+				defaultVersion:        "v1.0.0",
+				rootPath:              ".",
+				componentsPath:        "infrastructure-components.yaml",
+				header:                http.Header{"Authorization": []string{"Bearer token"}},
+			},
+		},
+		{
+			name:           "can create a new HTTP repo with basic auth",
+			providerConfig: config.NewProvider("test", providerURL, clusterctlv1.InfrastructureProviderType),
+			variableClient: test.NewFakeVariableClient().WithVar(config.HTTPRepositoryUsernameVariable, "user").WithVar(config.HTTPRepositoryPasswordVariable, "pass").WithVar(config.HTTPRepositoryHostsVariable, "artifacts.example.com"),
+			want: &httpRepository{
+				providerConfig:        config.NewProvider("test", providerURL, clusterctlv1.InfrastructureProviderType),
+				configVariablesClient: test.NewFakeVariableClient().WithVar(config.HTTPRepositoryUsernameVariable, "user").WithVar(config.HTTPRepositoryPasswordVariable, "pass").WithVar(config.HTTPRepositoryHostsVariable, "artifacts.example.com"),
+				httpClient:            http.DefaultClient,
+				baseURL:               "https://artifacts.example.com/capi/infrastructure-foo",
+				defaultVersion:        "v1.0.0",
+				rootPath:              ".",
+				componentsPath:        "infrastructure-components.yaml",
+				header:                http.Header{"Authorization": []string{"Basic dXNlcjpwYXNz"}},
+			},
+		},
+		{
+			name:           "can create a new HTTP repo with a custom auth header",
+			providerConfig: config.NewProvider("test", providerURL, clusterctlv1.InfrastructureProviderType),
+			variableClient: test.NewFakeVariableClient().WithVar(config.HTTPRepositoryAuthHeaderVariable, "X-JFrog-Art-Api: key").WithVar(config.HTTPRepositoryHostsVariable, "other.example.com, artifacts.example.com"),
+			want: &httpRepository{
+				providerConfig:        config.NewProvider("test", providerURL, clusterctlv1.InfrastructureProviderType),
+				configVariablesClient: test.NewFakeVariableClient().WithVar(config.HTTPRepositoryAuthHeaderVariable, "X-JFrog-Art-Api: key").WithVar(config.HTTPRepositoryHostsVariable, "other.example.com, artifacts.example.com"),
+				httpClient:            http.DefaultClient,
+				baseURL:               "https://artifacts.example.com/capi/infrastructure-foo",
+				defaultVersion:        "v1.0.0",
+				rootPath:              ".",
+				componentsPath:        "infrastructure-components.yaml",
+				header:                http.Header{"X-Jfrog-Art-Api": []string{"key"}},
+			},
+		},
+		{
+			name:           "does not set credentials for hosts not listed in the hosts variable",
+			providerConfig: config.NewProvider("test", providerURL, clusterctlv1.InfrastructureProviderType),
+			variableClient: test.NewFakeVariableClient().WithVar(config.HTTPRepositoryTokenVariable, "token").WithVar(config.HTTPRepositoryHostsVariable, "other.example.com"),
+			want: &httpRepository{
+				providerConfig:        config.NewProvider("test", providerURL, clusterctlv1.InfrastructureProviderType),
+				configVariablesClient: test.NewFakeVariableClient().WithVar(config.HTTPRepositoryTokenVariable, "token").WithVar(config.HTTPRepositoryHostsVariable, "other.example.com"),
+				httpClient:            http.DefaultClient,
+				baseURL:               "https://artifacts.example.com/capi/infrastructure-foo",
+				defaultVersion:        "v1.0.0",
+				rootPath:              ".",
+				componentsPath:        "infrastructure-components.yaml",
+				header:                http.Header{},
+			},
+		},
+		{
+			name:           "credentials require the hosts variable",
+			providerConfig: config.NewProvider("test", providerURL, clusterctlv1.InfrastructureProviderType),
+			variableClient: test.NewFakeVariableClient().WithVar(config.HTTPRepositoryTokenVariable, "token"),
+			wantedErr:      "the \"http-repository-hosts\" variable must be set to the list of hosts credentials for HTTP repositories can be sent to",
+		},
+		{
+			name:           "credentials can't be sent over plain http",
+			providerConfig: config.NewProvider("test", "http://artifacts.example.com/v1.0.0/infrastructure-components.yaml", clusterctlv1.InfrastructureProviderType),
+			variableClient: test.NewFakeVariableClient().WithVar(config.HTTPRepositoryTokenVariable, "token").WithVar(config.HTTPRepositoryHostsVariable, "artifacts.example.com"),
+			wantedErr:      "invalid url: credentials for the HTTP repository \"http://artifacts.example.com/v1.0.0/infrastructure-components.yaml\" can't be sent over plain http, please use https",
+		},
+		{
+			name:           "invalid custom auth header",
+			providerConfig: config.NewProvider("test", providerURL, clusterctlv1.InfrastructureProviderType),
+			variableClient: test.NewFakeVariableClient().WithVar(config.HTTPRepositoryAuthHeaderVariable, "key").WithVar(config.HTTPRepositoryHostsVariable, "artifacts.example.com"),
+			wantedErr:      "invalid value for the \"http-repository-auth-header\" variable: it should be in the form \"{name}: {value}\"",
+		},
+		{
+			name:           "missing variableClient",
+			providerConfig: config.NewProvider("test", providerURL, clusterctlv1.InfrastructureProviderType),
+			variableClient: nil,
+			wantedErr:      "invalid arguments: configVariablesClient can't be nil",
+		},
+		{
+			name:           "provider url should use http or https",
+			providerConfig: config.NewProvider("test", "ftp://artifacts.example.com/v1.0.0/infrastructure-components.yaml", clusterctlv1.InfrastructureProviderType),
+			variableClient: test.NewFakeVariableClient(),
+			wantedErr:      "invalid url: an HTTP repository url should be in the form {http|https}://{host}/{basePath}/{latest|version}/{componentsPath}",
+		},
+		{
+			name:           "provider url should include version and components path",
+			providerConfig: config.NewProvider("test", "https://artifacts.example.com/infrastructure-components.yaml", clusterctlv1.InfrastructureProviderType),
+			variableClient: test.NewFakeVariableClient(),
+			wantedErr:      "invalid url: an HTTP repository url should be in the form {http|https}://{host}/{basePath}/{latest|version}/{componentsPath}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			resetCaches()
+
+			repo, err := NewHTTPRepository(context.Background(), tt.providerConfig, tt.variableClient)
+			if tt.wantedErr != "" {
+				g.Expect(err).To(MatchError(tt.wantedErr))
+				return
+			}
+
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(repo).To(Equal(tt.want))
+		})
+	}
+}
+
+func Test_httpRepository_GetVersions(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewTLSServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/capi/infrastructure-foo/index.yaml", func(w http.ResponseWriter, r *http.Request) {
+		goproxytest.HTTPTestMethod(t, r, "GET")
+		fmt.Fprint(w, "versions:\n- v1.0.0\n- v1.1.0\n")
+	})
+
+	tests := []struct {
+		name        string
+		providerURL string
+		want        []string
+		wantErr     bool
+	}{
+		{
+			name:        "Index exists",
+			providerURL: fmt.Sprintf("%s/capi/infrastructure-foo/v1.0.0/infrastructure-components.yaml", server.URL),
+			want:        []string{"v1.0.0", "v1.1.0"},
+		},
+		{
+			name:        "Index does not exist",
+			providerURL: fmt.Sprintf("%s/capi/infrastructure-bar/v1.0.0/infrastructure-components.yaml", server.URL),
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			resetCaches()
+
+			providerConfig := config.NewProvider("test", tt.providerURL, clusterctlv1.InfrastructureProviderType)
+			repo, err := NewHTTPRepository(context.Background(), providerConfig, test.NewFakeVariableClient(), injectHTTPClient(server.Client()))
+			g.Expect(err).ToNot(HaveOccurred())
+
+			got, err := repo.GetVersions(context.Background())
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
+
+func Test_httpRepository_GetFile(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewTLSServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/capi/infrastructure-foo/v1.0.0/metadata.yaml", func(w http.ResponseWriter, r *http.Request) {
+		goproxytest.HTTPTestMethod(t, r, "GET")
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, "content")
+	})
+
+	mux.HandleFunc("/capi/infrastructure-foo/v1.0.0/cluster-template-redirect.yaml", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://"+r.Host+"/capi/infrastructure-foo/v1.0.0/cluster-template.yaml", http.StatusFound)
+	})
+
+	// otherServer serves files only if no credentials are sent.
+	otherMux := http.NewServeMux()
+	otherServer := httptest.NewTLSServer(otherMux)
+	defer otherServer.Close()
+
+	otherMux.HandleFunc("/cluster-template.yaml", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "" || r.Header.Get("X-Jfrog-Art-Api") != "" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		fmt.Fprint(w, "content")
+	})
+
+	mux.HandleFunc("/capi/infrastructure-foo/v1.0.0/cluster-template-other-host.yaml", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, otherServer.URL+"/cluster-template.yaml", http.StatusFound)
+	})
+
+	providerURL := fmt.Sprintf("%s/capi/infrastructure-foo/v1.0.0/infrastructure-components.yaml", server.URL)
+	providerConfig := config.NewProvider("test", providerURL, clusterctlv1.InfrastructureProviderType)
+	serverHost := strings.TrimPrefix(server.URL, "https://")
+
+	tests := []struct {
+		name           string
+		variableClient config.VariablesClient
+		version        string
+		fileName       string
+		want           []byte
+		wantErr        bool
+		wantNotFound   bool
+	}{
+		{
+			name:           "Release and file exist",
+			variableClient: test.NewFakeVariableClient().WithVar(config.HTTPRepositoryTokenVariable, "token").WithVar(config.HTTPRepositoryHostsVariable, serverHost),
+			version:        "v1.0.0",
+			fileName:       "metadata.yaml",
+			want:           []byte("content"),
+		},
+		{
+			name:           "Empty version defaults to the default version",
+			variableClient: test.NewFakeVariableClient().WithVar(config.HTTPRepositoryTokenVariable, "token").WithVar(config.HTTPRepositoryHostsVariable, serverHost),
+			version:        "",
+			fileName:       "metadata.yaml",
+			want:           []byte("content"),
+		},
+		{
+			name:           "File does not exist",
+			variableClient: test.NewFakeVariableClient().WithVar(config.HTTPRepositoryTokenVariable, "token").WithVar(config.HTTPRepositoryHostsVariable, serverHost),
+			version:        "v1.0.0",
+			fileName:       "cluster-template.yaml",
+			wantErr:        true,
+			wantNotFound:   true,
+		},
+		{
+			name:           "Redirect to plain http is not followed when sending credentials",
+			variableClient: test.NewFakeVariableClient().WithVar(config.HTTPRepositoryTokenVariable, "token").WithVar(config.HTTPRepositoryHostsVariable, serverHost),
+			version:        "v1.0.0",
+			fileName:       "cluster-template-redirect.yaml",
+			wantErr:        true,
+		},
+		{
+			name:           "Custom auth header is not sent on redirects to other hosts",
+			variableClient: test.NewFakeVariableClient().WithVar(config.HTTPRepositoryAuthHeaderVariable, "X-JFrog-Art-Api: key").WithVar(config.HTTPRepositoryHostsVariable, serverHost),
+			version:        "v1.0.0",
+			fileName:       "cluster-template-other-host.yaml",
+			want:           []byte("content"),
+		},
+		{
+			name:           "Bearer token is not sent on redirects to other hosts",
+			variableClient: test.NewFakeVariableClient().WithVar(config.HTTPRepositoryTokenVariable, "token").WithVar(config.HTTPRepositoryHostsVariable, serverHost),
+			version:        "v1.0.0",
+			fileName:       "cluster-template-other-host.yaml",
+			want:           []byte("content"),
+		},
+		{
+			name:           "Credentials are not sent to hosts not listed in the hosts variable",
+			variableClient: test.NewFakeVariableClient().WithVar(config.HTTPRepositoryTokenVariable, "token").WithVar(config.HTTPRepositoryHostsVariable, "artifacts.example.com"),
+			version:        "v1.0.0",
+			fileName:       "metadata.yaml",
+			wantErr:        true,
+		},
+		{
+			name:           "Missing credentials",
+			variableClient: test.NewFakeVariableClient(),
+			version:        "v1.0.0",
+			fileName:       "metadata.yaml",
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			resetCaches()
+
+			repo, err := NewHTTPRepository(context.Background(), providerConfig, tt.variableClient, injectHTTPClient(server.Client()))
+			g.Expect(err).ToNot(HaveOccurred())
+
+			got, err := repo.GetFile(context.Background(), tt.version, tt.fileName)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(errors.Is(err, errNotFound)).To(Equal(tt.wantNotFound))
+				return
+			}
+
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
+
+func Test_httpRepository_latest(t *testing.T) {
+	g := NewWithT(t)
+	resetCaches()
+
+	mux := http.NewServeMux()
+	server := httptest.NewTLSServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/index.yaml", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, "versions:\n- v1.0.0\n- v1.1.0\n- v1.2.0\n")
+	})
+	for _, v := range []string{"v1.0.0", "v1.1.0"} {
+		mux.HandleFunc(fmt.Sprintf("/%s/metadata.yaml", v), func(w http.ResponseWriter, _ *http.Request) {
+			fmt.Fprint(w, "apiVersion: clusterctl.cluster.x-k8s.io/v1alpha3\nkind: Metadata\nreleaseSeries:\n- major: 1\n  minor: 1\n  contract: v1beta2\n")
+		})
+	}
+
+	providerURL := fmt.Sprintf("%s/latest/infrastructure-components.yaml", server.URL)
+	repo, err := NewHTTPRepository(context.Background(), config.NewProvider("test", providerURL, clusterctlv1.InfrastructureProviderType), test.NewFakeVariableClient(), injectHTTPClient(server.Client()))
+	g.Expect(err).ToNot(HaveOccurred())
+	// v1.2.0 is listed in the index but not published yet, so it gets ignored.
+	g.Expect(repo.DefaultVersion()).To(Equal("v1.1.0"))
+}
@@ -94,7 +94,7 @@ func (f *MemoryRepository) GetFile(ctx context.Context, version string, path str
 			return c, nil
 		}
 	}
-	return nil, errors.Errorf("unable to get file %s for version %s", path, version)
+	return nil, errors.Wrapf(errFileNotFound, "unable to get file %s for version %s", path, version)
 }
 
 // GetVersions returns the list of versions that are available.
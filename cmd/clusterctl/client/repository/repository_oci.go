@@ -0,0 +1,465 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repository
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
+)
+
+const (
+	ociScheme = "oci"
+
+	// ociManifestMediaType is the media type of an OCI image manifest.
+	ociManifestMediaType = "application/vnd.oci.image.manifest.v1+json"
+
+	// ociTitleAnnotation is the annotation used to store the file name of a layer; this is the same
+	// annotation used by common tools like ORAS when pushing files as OCI artifacts.
+	ociTitleAnnotation = "org.opencontainers.image.title"
+)
+
+// ociRepository provides support for providers hosted on an OCI registry.
+//
+// Each provider version is expected to be published as an OCI artifact tagged with the version; each file
+// of the release (components YAML, metadata YAML, templates etc.) must be a layer of the artifact annotated
+// with the org.opencontainers.image.title annotation, like e.g. `oras push` does.
+//
+// A provider url should be in the form oci://{registry}/{repository}[:{latest|version-tag}]/{componentsPath}.
+type ociRepository struct {
+	providerConfig        config.Provider
+	configVariablesClient config.VariablesClient
+	httpClient            *http.Client
+	scheme                string
+	registry              string
+	repository            string
+	defaultVersion        string
+	componentsPath        string
+	username              string
+	password              string
+	token                 string
+}
+
+var _ Repository = &ociRepository{}
+
+// ociManifest is the subset of an OCI image manifest used by clusterctl.
+type ociManifest struct {
+	MediaType string          `json:"mediaType,omitempty"`
+	Layers    []ociDescriptor `json:"layers"`
+}
+
+// ociDescriptor is the subset of an OCI content descriptor used by clusterctl.
+type ociDescriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// ociTagList is the response of the OCI distribution tag listing API.
+type ociTagList struct {
+	Name string   `json:"name"`
+	Tags []string `json:"tags"`
+}
+
+// NewOCIRepository returns an ociRepository implementation.
+func NewOCIRepository(ctx context.Context, providerConfig config.Provider, configVariablesClient config.VariablesClient) (Repository, error) {
+	if configVariablesClient == nil {
+		return nil, errors.New("invalid arguments: configVariablesClient can't be nil")
+	}
+
+	rURL, err := url.Parse(providerConfig.URL())
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid url")
+	}
+
+	// Check if the path is in the expected format, i.e. at least a repository name and a components file.
+	urlSplit := strings.Split(strings.TrimPrefix(rURL.Path, "/"), "/")
+	if rURL.Scheme != ociScheme || rURL.Host == "" || len(urlSplit) < 2 || urlSplit[len(urlSplit)-1] == "" {
+		return nil, errors.New("invalid url: an OCI repository url should be in the form oci://{registry}/{repository}[:{latest|version-tag}]/{componentsPath}")
+	}
+
+	// Extract all the info from url split; the version is the tag of the repository, if any.
+	repository := strings.Join(urlSplit[:len(urlSplit)-1], "/")
+	componentsPath := urlSplit[len(urlSplit)-1]
+	defaultVersion := latestVersionTag
+	if i := strings.LastIndex(repository, ":"); i >= 0 {
+		defaultVersion = repository[i+1:]
+		repository = repository[:i]
+	}
+	if repository == "" || defaultVersion == "" {
+		return nil, errors.New("invalid url: an OCI repository url should be in the form oci://{registry}/{repository}[:{latest|version-tag}]/{componentsPath}")
+	}
+
+	repo := &ociRepository{
+		providerConfig:        providerConfig,
+		configVariablesClient: configVariablesClient,
+		httpClient:            http.DefaultClient,
+		scheme:                ociRegistryScheme(rURL.Hostname()),
+		registry:              rURL.Host,
+		repository:            repository,
+		defaultVersion:        defaultVersion,
+		componentsPath:        componentsPath,
+	}
+
+	if username, err := configVariablesClient.Get(config.OCIRegistryUsernameVariable); err == nil {
+		repo.username = username
+	}
+	if password, err := configVariablesClient.Get(config.OCIRegistryPasswordVariable); err == nil {
+		repo.password = password
+	}
+
+	if defaultVersion == latestVersionTag {
+		repo.defaultVersion, err = latestContractRelease(ctx, repo, clusterv1.GroupVersion.Version)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get latest release")
+		}
+	}
+
+	return repo, nil
+}
+
+// ociRegistryScheme returns the scheme to be used for talking with a registry.
+// Same as for other OCI clients, registries on the loopback interface are accessed using plain http.
+func ociRegistryScheme(host string) string {
+	if host == "localhost" {
+		return "http"
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return "http"
+	}
+	return httpsScheme
+}
+
+// DefaultVersion returns defaultVersion field of ociRepository struct.
+func (r *ociRepository) DefaultVersion() string {
+	return r.defaultVersion
+}
+
+// RootPath returns the empty string as all the files are stored as layers of the same artifact.
+func (r *ociRepository) RootPath() string {
+	return ""
+}
+
+// ComponentsPath returns componentsPath field of ociRepository struct.
+func (r *ociRepository) ComponentsPath() string {
+	return r.componentsPath
+}
+
+// GetVersions returns the list of versions that are available in a provider repository,
+// i.e. the list of tags of the OCI repository.
+func (r *ociRepository) GetVersions(ctx context.Context) ([]string, error) {
+	cacheID := fmt.Sprintf("%s/%s", r.registry, r.repository)
+	if versions, ok := cacheVersions[cacheID]; ok {
+		return versions, nil
+	}
+
+	versions := []string{}
+	next := r.registryURL("tags/list")
+	for next != "" {
+		body, header, err := r.get(ctx, next, "application/json")
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get the list of tags for %s", cacheID)
+		}
+
+		tagList := &ociTagList{}
+		if err := json.Unmarshal(body, tagList); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse the list of tags for %s", cacheID)
+		}
+		versions = append(versions, tagList.Tags...)
+
+		// The tag listing API is paginated using the Link header (RFC5988).
+		next, err = r.nextPageURL(next, header.Get("Link"))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get the list of tags for %s", cacheID)
+		}
+	}
+
+	cacheVersions[cacheID] = versions
+	return versions, nil
+}
+
+// GetFile returns a file for a given provider version.
+func (r *ociRepository) GetFile(ctx context.Context, version, path string) ([]byte, error) {
+	if version == "" {
+		version = r.defaultVersion
+	}
+
+	cacheID := fmt.Sprintf("%s/%s:%s:%s", r.registry, r.repository, version, path)
+	if content, ok := cacheFiles[cacheID]; ok {
+		return content, nil
+	}
+
+	manifest, err := r.getManifest(ctx, version)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get file %q with version %q from %s/%s", path, version, r.registry, r.repository)
+	}
+
+	var layer *ociDescriptor
+	for i := range manifest.Layers {
+		if manifest.Layers[i].Annotations[ociTitleAnnotation] == path {
+			layer = &manifest.Layers[i]
+			break
+		}
+	}
+	if layer == nil {
+		return nil, errors.Wrapf(errNotFound, "failed to get file %q with version %q from %s/%s: file is not included in the artifact", path, version, r.registry, r.repository)
+	}
+
+	content, _, err := r.get(ctx, r.registryURL("blobs/"+layer.Digest), layer.MediaType)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get file %q with version %q from %s/%s", path, version, r.registry, r.repository)
+	}
+
+	if err := verifyOCIDigest(layer.Digest, content); err != nil {
+		return nil, errors.Wrapf(err, "failed to get file %q with version %q from %s/%s", path, version, r.registry, r.repository)
+	}
+
+	cacheFiles[cacheID] = content
+	return content, nil
+}
+
+// getManifest returns the manifest of the artifact tagged with version.
+func (r *ociRepository) getManifest(ctx context.Context, version string) (*ociManifest, error) {
+	body, _, err := r.get(ctx, r.registryURL("manifests/"+version), ociManifestMediaType)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &ociManifest{}
+	if err := json.Unmarshal(body, manifest); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse manifest for version %q", version)
+	}
+	if manifest.MediaType != "" && manifest.MediaType != ociManifestMediaType {
+		return nil, errors.Errorf("unsupported manifest media type %q for version %q", manifest.MediaType, version)
+	}
+	return manifest, nil
+}
+
+// registryURL returns the url of an OCI distribution API endpoint for the repository.
+func (r *ociRepository) registryURL(endpoint string) string {
+	return fmt.Sprintf("%s://%s/v2/%s/%s", r.scheme, r.registry, r.repository, endpoint)
+}
+
+// nextPageURL returns the URL of the next page as defined in a Link header, if any.
+func (r *ociRepository) nextPageURL(current, link string) (string, error) {
+	if link == "" {
+		return "", nil
+	}
+	for _, l := range strings.Split(link, ",") {
+		parts := strings.Split(l, ";")
+		if len(parts) < 2 || !strings.Contains(parts[1], `rel="next"`) {
+			continue
+		}
+		ref, err := url.Parse(strings.Trim(strings.TrimSpace(parts[0]), "<>"))
+		if err != nil {
+			return "", errors.Wrapf(err, "invalid Link header %q", link)
+		}
+		base, err := url.Parse(current)
+		if err != nil {
+			return "", errors.Wrapf(err, "invalid url %q", current)
+		}
+		return base.ResolveReference(ref).String(), nil
+	}
+	return "", nil
+}
+
+// get executes a GET request against the registry, handling authentication if required by the registry.
+func (r *ociRepository) get(ctx context.Context, rawURL, accept string) ([]byte, http.Header, error) {
+	timeoutctx, cancel := context.WithTimeoutCause(ctx, 30*time.Second, errors.New("http request timeout expired"))
+	defer cancel()
+
+	response, err := r.do(timeoutctx, rawURL, accept, r.authorization())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// If the registry requires authentication, negotiate credentials according to the challenge and retry.
+	if response.StatusCode == http.StatusUnauthorized {
+		challenge := response.Header.Get("WWW-Authenticate")
+		response.Body.Close()
+
+		authorization, err := r.authenticate(timeoutctx, challenge)
+		if err != nil {
+			return nil, nil, err
+		}
+		response, err = r.do(timeoutctx, rawURL, accept, authorization)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	defer response.Body.Close()
+
+	switch response.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, nil, errors.Wrapf(errNotFound, "failed to get %q", rawURL)
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return nil, nil, errors.Errorf("failed to get %q: unauthorized access, please check your credentials", rawURL)
+	default:
+		return nil, nil, errors.Errorf("failed to get %q, got %d", rawURL, response.StatusCode)
+	}
+
+	content, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to get %q", rawURL)
+	}
+	return content, response.Header, nil
+}
+
+func (r *ociRepository) do(ctx context.Context, rawURL, accept, authorization string) (*http.Response, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, http.NoBody)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get %q: failed to create request", rawURL)
+	}
+	request.Header.Set("Accept", accept)
+	if authorization != "" {
+		request.Header.Set("Authorization", authorization)
+	}
+
+	response, err := r.httpClient.Do(request)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get %q", rawURL)
+	}
+	return response, nil
+}
+
+// authorization returns the value of the Authorization header to be used for requests, if any.
+func (r *ociRepository) authorization() string {
+	if r.token != "" {
+		return "Bearer " + r.token
+	}
+	if r.username != "" || r.password != "" {
+		request := &http.Request{Header: http.Header{}}
+		request.SetBasicAuth(r.username, r.password)
+		return request.Header.Get("Authorization")
+	}
+	return ""
+}
+
+// authenticate handles a WWW-Authenticate challenge returned by the registry and returns the
+// value of the Authorization header to be used for retrying the request.
+// Both basic authentication and the bearer token flow defined by the distribution spec are supported.
+func (r *ociRepository) authenticate(ctx context.Context, challenge string) (string, error) {
+	scheme, params := parseOCIChallenge(challenge)
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if r.username == "" && r.password == "" {
+			return "", errors.Errorf("registry %s requires authentication, please set %q and %q variables", r.registry, config.OCIRegistryUsernameVariable, config.OCIRegistryPasswordVariable)
+		}
+		// Drop a previously acquired token, if any, given that the registry asked for basic authentication.
+		r.token = ""
+		return r.authorization(), nil
+	case "bearer":
+	default:
+		return "", errors.Errorf("registry %s requires an unsupported authentication scheme %q", r.registry, scheme)
+	}
+
+	realm, err := url.Parse(params["realm"])
+	if err != nil || params["realm"] == "" {
+		return "", errors.Errorf("registry %s returned an invalid authentication challenge %q", r.registry, challenge)
+	}
+	query := realm.Query()
+	if service, ok := params["service"]; ok {
+		query.Set("service", service)
+	}
+	scope := params["scope"]
+	if scope == "" {
+		scope = fmt.Sprintf("repository:%s:pull", r.repository)
+	}
+	query.Set("scope", scope)
+	realm.RawQuery = query.Encode()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), http.NoBody)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get a token from %q: failed to create request", realm.Redacted())
+	}
+	if r.username != "" || r.password != "" {
+		request.SetBasicAuth(r.username, r.password)
+	}
+
+	response, err := r.httpClient.Do(request)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get a token from %q", realm.Redacted())
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return "", errors.Errorf("failed to get a token from %q, got %d", realm.Redacted(), response.StatusCode)
+	}
+
+	token := struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}{}
+	if err := json.NewDecoder(response.Body).Decode(&token); err != nil {
+		return "", errors.Wrapf(err, "failed to parse the token returned by %q", realm.Redacted())
+	}
+	r.token = token.Token
+	if r.token == "" {
+		r.token = token.AccessToken
+	}
+	if r.token == "" {
+		return "", errors.Errorf("failed to get a token from %q: the response does not contain a token", realm.Redacted())
+	}
+	return r.authorization(), nil
+}
+
+// parseOCIChallenge parses a WWW-Authenticate header, e.g. `Bearer realm="https://auth.example.com/token",service="registry.example.com"`.
+func parseOCIChallenge(challenge string) (string, map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(challenge), " ")
+	params := map[string]string{}
+	for rest != "" {
+		var key, value string
+		key, rest, _ = strings.Cut(strings.TrimLeft(rest, " ,"), "=")
+		if strings.HasPrefix(rest, `"`) {
+			value, rest, _ = strings.Cut(rest[1:], `"`)
+		} else {
+			value, rest, _ = strings.Cut(rest, ",")
+		}
+		if key != "" {
+			params[strings.ToLower(strings.TrimSpace(key))] = value
+		}
+	}
+	return scheme, params
+}
+
+// verifyOCIDigest checks that content matches the given sha256 digest.
+func verifyOCIDigest(digest string, content []byte) error {
+	algorithm, encoded, ok := strings.Cut(digest, ":")
+	if !ok || algorithm != "sha256" {
+		return errors.Errorf("unsupported digest %q", digest)
+	}
+	sum := sha256.Sum256(content)
+	if hex.EncodeToString(sum[:]) != encoded {
+		return errors.Errorf("digest mismatch, expected %q", digest)
+	}
+	return nil
+}
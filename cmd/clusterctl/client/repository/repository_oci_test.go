@@ -0,0 +1,300 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+)
+
+func Test_ociRepository_newOCIRepository(t *testing.T) {
+	providerURL := "oci://registry.example.com/capi/infrastructure-foo:v1.0.0/infrastructure-components.yaml"
+
+	tests := []struct {
+		name           string
+		providerConfig config.Provider
+		variableClient config.VariablesClient
+		want           *ociRepository
+		wantedErr      string
+	}{
+		{
+			name:           "can create a new OCI repo",
+			providerConfig: config.NewProvider("test", providerURL, clusterctlv1.InfrastructureProviderType),
+			variableClient: test.NewFakeVariableClient(),
+			want: &ociRepository{
+				providerConfig:        config.NewProvider("test", providerURL, clusterctlv1.InfrastructureProviderType),
+				configVariablesClient: test.NewFakeVariableClient(),
+				httpClient:            http.DefaultClient,
+				scheme:                "https",
+				registry:              "registry.example.com",
+				repository:            "capi/infrastructure-foo",
+				defaultVersion:        "v1.0.0",
+				componentsPath:        "infrastructure-components.yaml",
+			},
+		},
+		{
+			name:           "can create a new OCI repo with credentials",
+			providerConfig: config.NewProvider("test", providerURL, clusterctlv1.InfrastructureProviderType),
+			variableClient: test.NewFakeVariableClient().WithVar(config.OCIRegistryUsernameVariable, "user").WithVar(config.OCIRegistryPasswordVariable, "pass"),
+			want: &ociRepository{
+				providerConfig:        config.NewProvider("test", providerURL, clusterctlv1.InfrastructureProviderType),
+				configVariablesClient: test.NewFakeVariableClient().WithVar(config.OCIRegistryUsernameVariable, "user").WithVar(config.OCIRegistryPasswordVariable, "pass"),
+				httpClient:            http.DefaultClient,
+				scheme:                "https",
+				registry:              "registry.example.com",
+				repository:            "capi/infrastructure-foo",
+				defaultVersion:        "v1.0.0",
+				componentsPath:        "infrastructure-components.yaml",
+				username:              "user",
+				password:              "pass",
+			},
+		},
+		{
+			name:           "uses http for registries on localhost",
+			providerConfig: config.NewProvider("test", "oci://localhost:5000/infrastructure-foo:v1.0.0/infrastructure-components.yaml", clusterctlv1.InfrastructureProviderType),
+			variableClient: test.NewFakeVariableClient(),
+			want: &ociRepository{
+				providerConfig:        config.NewProvider("test", "oci://localhost:5000/infrastructure-foo:v1.0.0/infrastructure-components.yaml", clusterctlv1.InfrastructureProviderType),
+				configVariablesClient: test.NewFakeVariableClient(),
+				httpClient:            http.DefaultClient,
+				scheme:                "http",
+				registry:              "localhost:5000",
+				repository:            "infrastructure-foo",
+				defaultVersion:        "v1.0.0",
+				componentsPath:        "infrastructure-components.yaml",
+			},
+		},
+		{
+			name:           "missing variableClient",
+			providerConfig: config.NewProvider("test", providerURL, clusterctlv1.InfrastructureProviderType),
+			variableClient: nil,
+			wantedErr:      "invalid arguments: configVariablesClient can't be nil",
+		},
+		{
+			name:           "provider url should use the oci scheme",
+			providerConfig: config.NewProvider("test", "https://registry.example.com/capi/infrastructure-foo:v1.0.0/infrastructure-components.yaml", clusterctlv1.InfrastructureProviderType),
+			variableClient: test.NewFakeVariableClient(),
+			wantedErr:      "invalid url: an OCI repository url should be in the form oci://{registry}/{repository}[:{latest|version-tag}]/{componentsPath}",
+		},
+		{
+			name:           "provider url should include the components path",
+			providerConfig: config.NewProvider("test", "oci://registry.example.com/infrastructure-foo:v1.0.0", clusterctlv1.InfrastructureProviderType),
+			variableClient: test.NewFakeVariableClient(),
+			wantedErr:      "invalid url: an OCI repository url should be in the form oci://{registry}/{repository}[:{latest|version-tag}]/{componentsPath}",
+		},
+		{
+			name:           "provider url should not have an empty tag",
+			providerConfig: config.NewProvider("test", "oci://registry.example.com/infrastructure-foo:/infrastructure-components.yaml", clusterctlv1.InfrastructureProviderType),
+			variableClient: test.NewFakeVariableClient(),
+			wantedErr:      "invalid url: an OCI repository url should be in the form oci://{registry}/{repository}[:{latest|version-tag}]/{componentsPath}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			resetCaches()
+
+			repo, err := NewOCIRepository(context.Background(), tt.providerConfig, tt.variableClient)
+			if tt.wantedErr != "" {
+				g.Expect(err).To(MatchError(tt.wantedErr))
+				return
+			}
+
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(repo).To(Equal(tt.want))
+		})
+	}
+}
+
+func Test_ociRepository_GetVersions(t *testing.T) {
+	g := NewWithT(t)
+	resetCaches()
+
+	registry := test.NewFakeOCIRegistry()
+	defer registry.Close()
+
+	registry.AddArtifact("capi/infrastructure-foo", "v1.0.0", map[string][]byte{"metadata.yaml": []byte("v1.0.0")})
+	registry.AddArtifact("capi/infrastructure-foo", "v1.1.0", map[string][]byte{"metadata.yaml": []byte("v1.1.0")})
+
+	providerURL := fmt.Sprintf("oci://%s/capi/infrastructure-foo:v1.0.0/infrastructure-components.yaml", registry.Host())
+	repo, err := NewOCIRepository(context.Background(), config.NewProvider("test", providerURL, clusterctlv1.InfrastructureProviderType), test.NewFakeVariableClient())
+	g.Expect(err).ToNot(HaveOccurred())
+
+	got, err := repo.GetVersions(context.Background())
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(got).To(ConsistOf("v1.0.0", "v1.1.0"))
+}
+
+func Test_ociRepository_GetFile(t *testing.T) {
+	registry := test.NewFakeOCIRegistry()
+	defer registry.Close()
+
+	registry.AddArtifact("capi/infrastructure-foo", "v1.0.0", map[string][]byte{
+		"infrastructure-components.yaml": []byte("components"),
+		"metadata.yaml":                  []byte("metadata"),
+	})
+
+	providerURL := fmt.Sprintf("oci://%s/capi/infrastructure-foo:v1.0.0/infrastructure-components.yaml", registry.Host())
+	providerConfig := config.NewProvider("test", providerURL, clusterctlv1.InfrastructureProviderType)
+
+	tests := []struct {
+		name         string
+		version      string
+		fileName     string
+		want         []byte
+		wantErr      bool
+		wantNotFound bool
+	}{
+		{
+			name:     "Release and file exist",
+			version:  "v1.0.0",
+			fileName: "metadata.yaml",
+			want:     []byte("metadata"),
+		},
+		{
+			name:     "Empty version defaults to the default version",
+			version:  "",
+			fileName: "infrastructure-components.yaml",
+			want:     []byte("components"),
+		},
+		{
+			name:         "File does not exist",
+			version:      "v1.0.0",
+			fileName:     "cluster-template.yaml",
+			wantErr:      true,
+			wantNotFound: true,
+		},
+		{
+			name:         "Release does not exist",
+			version:      "v2.0.0",
+			fileName:     "metadata.yaml",
+			wantErr:      true,
+			wantNotFound: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			resetCaches()
+
+			repo, err := NewOCIRepository(context.Background(), providerConfig, test.NewFakeVariableClient())
+			g.Expect(err).ToNot(HaveOccurred())
+
+			got, err := repo.GetFile(context.Background(), tt.version, tt.fileName)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(errors.Is(err, errNotFound)).To(Equal(tt.wantNotFound))
+				return
+			}
+
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
+
+func Test_ociRepository_latest(t *testing.T) {
+	g := NewWithT(t)
+	resetCaches()
+
+	registry := test.NewFakeOCIRegistry()
+	defer registry.Close()
+
+	metadata := []byte("apiVersion: clusterctl.cluster.x-k8s.io/v1alpha3\nkind: Metadata\nreleaseSeries:\n- major: 1\n  minor: 1\n  contract: v1beta2\n")
+	registry.AddArtifact("infrastructure-foo", "v1.0.0", map[string][]byte{"metadata.yaml": metadata})
+	registry.AddArtifact("infrastructure-foo", "v1.1.0", map[string][]byte{"metadata.yaml": metadata})
+	registry.AddArtifact("infrastructure-foo", "v1.2.0-rc.0", map[string][]byte{"metadata.yaml": metadata})
+	registry.AddArtifact("infrastructure-foo", "main", map[string][]byte{"metadata.yaml": metadata})
+
+	providerURL := fmt.Sprintf("oci://%s/infrastructure-foo/infrastructure-components.yaml", registry.Host())
+	repo, err := NewOCIRepository(context.Background(), config.NewProvider("test", providerURL, clusterctlv1.InfrastructureProviderType), test.NewFakeVariableClient())
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(repo.DefaultVersion()).To(Equal("v1.1.0"))
+}
+
+func Test_ociRepository_authentication(t *testing.T) {
+	registry := test.NewFakeOCIRegistry().WithCredentials("user", "pass")
+	defer registry.Close()
+
+	registry.AddArtifact("infrastructure-foo", "v1.0.0", map[string][]byte{"metadata.yaml": []byte("metadata")})
+
+	providerURL := fmt.Sprintf("oci://%s/infrastructure-foo:v1.0.0/infrastructure-components.yaml", registry.Host())
+	providerConfig := config.NewProvider("test", providerURL, clusterctlv1.InfrastructureProviderType)
+
+	tests := []struct {
+		name           string
+		variableClient config.VariablesClient
+		wantErr        bool
+	}{
+		{
+			name:           "Valid credentials",
+			variableClient: test.NewFakeVariableClient().WithVar(config.OCIRegistryUsernameVariable, "user").WithVar(config.OCIRegistryPasswordVariable, "pass"),
+		},
+		{
+			name:           "Invalid credentials",
+			variableClient: test.NewFakeVariableClient().WithVar(config.OCIRegistryUsernameVariable, "user").WithVar(config.OCIRegistryPasswordVariable, "wrong"),
+			wantErr:        true,
+		},
+		{
+			name:           "Missing credentials",
+			variableClient: test.NewFakeVariableClient(),
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			resetCaches()
+
+			repo, err := NewOCIRepository(context.Background(), providerConfig, tt.variableClient)
+			g.Expect(err).ToNot(HaveOccurred())
+
+			got, err := repo.GetFile(context.Background(), "v1.0.0", "metadata.yaml")
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got).To(Equal([]byte("metadata")))
+		})
+	}
+}
+
+func Test_parseOCIChallenge(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme, params := parseOCIChallenge(`Bearer realm="https://auth.example.com/token",service="registry.example.com",scope="repository:foo:pull"`)
+	g.Expect(scheme).To(Equal("Bearer"))
+	g.Expect(params).To(Equal(map[string]string{
+		"realm":   "https://auth.example.com/token",
+		"service": "registry.example.com",
+		"scope":   "repository:foo:pull",
+	}))
+}
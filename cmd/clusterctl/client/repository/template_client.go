@@ -32,6 +32,22 @@ type TemplateClient interface {
 	Get(ctx context.Context, flavor, targetNamespace string, listVariablesOnly bool) (Template, error)
 }
 
+// RawTemplateClient is implemented by TemplateClients that can return templates without any processing.
+// Note: this is kept out of TemplateClient so existing implementations of TemplateClient are not affected.
+type RawTemplateClient interface {
+	Raw(ctx context.Context, flavor string) ([]byte, error)
+}
+
+// GetRawTemplate returns the template for the flavor specified, without any processing.
+// An error is returned if the TemplateClient does not implement RawTemplateClient.
+func GetRawTemplate(ctx context.Context, c TemplateClient, flavor string) ([]byte, error) {
+	rc, ok := c.(RawTemplateClient)
+	if !ok {
+		return nil, errors.Errorf("template client %T does not support reading raw templates", c)
+	}
+	return rc.Raw(ctx, flavor)
+}
+
 // templateClient implements TemplateClient.
 type templateClient struct {
 	provider              config.Provider
@@ -53,6 +69,9 @@ type TemplateClientInput struct {
 // Ensure templateClient implements the TemplateClient interface.
 var _ TemplateClient = &templateClient{}
 
+// Ensure templateClient implements the RawTemplateClient interface.
+var _ RawTemplateClient = &templateClient{}
+
 // newTemplateClient returns a templateClient. It uses the SimpleYamlProcessor
 // by default.
 func newTemplateClient(input TemplateClientInput) *templateClient {
@@ -69,12 +88,28 @@ func newTemplateClient(input TemplateClientInput) *templateClient {
 // In case the template does not exists, an error is returned.
 // Get assumes the following naming convention for templates: cluster-template[-<flavor_name>].yaml.
 func (c *templateClient) Get(ctx context.Context, flavor, targetNamespace string, skipTemplateProcess bool) (Template, error) {
-	log := logf.Log
-
 	if targetNamespace == "" {
 		return nil, errors.New("invalid arguments: please provide a targetNamespace")
 	}
 
+	rawArtifact, err := c.Raw(ctx, flavor)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTemplate(TemplateInput{
+		rawArtifact,
+		c.configVariablesClient,
+		c.processor,
+		targetNamespace,
+		skipTemplateProcess,
+	})
+}
+
+// Raw returns the template for the flavor specified, without any processing.
+func (c *templateClient) Raw(ctx context.Context, flavor string) ([]byte, error) {
+	log := logf.Log
+
 	version := c.version
 	name := c.processor.GetTemplateName(version, flavor)
 
@@ -98,12 +133,5 @@ func (c *templateClient) Get(ctx context.Context, flavor, targetNamespace string
 	} else {
 		log.V(1).Info("Using", "override", name, "provider", c.provider.ManifestLabel(), "version", version)
 	}
-
-	return NewTemplate(TemplateInput{
-		rawArtifact,
-		c.configVariablesClient,
-		c.processor,
-		targetNamespace,
-		skipTemplateProcess,
-	})
+	return rawArtifact, nil
 }
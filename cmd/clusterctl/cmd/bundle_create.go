@@ -0,0 +1,121 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/cmd/internal/templates"
+)
+
+type bundleCreateOptions struct {
+	coreProvider              string
+	bootstrapProviders        []string
+	controlPlaneProviders     []string
+	infrastructureProviders   []string
+	ipamProviders             []string
+	runtimeExtensionProviders []string
+	addonProviders            []string
+	flavors                   []string
+	clusterClasses            []string
+	output                    string
+}
+
+var bco = &bundleCreateOptions{}
+
+var bundleCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a bundle with the providers required for initializing a management cluster",
+	Long: templates.LongDesc(`
+		Create a bundle with the providers required for initializing a management cluster.
+
+		The bundle is a tar.gz archive containing the components, the metadata, the cluster templates
+		and the ClusterClasses of the selected providers, the cert-manager components and the list of
+		container images they require; it can be used with 'clusterctl init --from-bundle' for
+		initializing a management cluster without access to the provider repositories.
+
+		Please note that container images are not included in the bundle; the list of images in the
+		images.txt file of the bundle can be used for mirroring them to a registry reachable from
+		the air-gapped environment.`),
+
+	Example: templates.Examples(`
+		# Creates a bundle with the latest releases of Cluster API core components, the kubeadm
+		# bootstrap and control plane providers and the aws infrastructure provider.
+		clusterctl bundle create --infrastructure aws --output bundle.tar.gz
+
+		# Creates a bundle with specific versions of the providers.
+		clusterctl bundle create --core cluster-api:v1.1.5 --infrastructure aws:v0.5.0 --output bundle.tar.gz
+
+		# Creates a bundle including additional cluster template flavors and ClusterClasses.
+		clusterctl bundle create --infrastructure docker --flavor development --cluster-class quick-start --output bundle.tar.gz`),
+
+	Args: cobra.NoArgs,
+	RunE: func(*cobra.Command, []string) error {
+		return runBundleCreate()
+	},
+}
+
+func init() {
+	bundleCreateCmd.Flags().StringVar(&bco.coreProvider, "core", "",
+		"Core provider version (e.g. cluster-api:v1.1.5) to add to the bundle. If unspecified, Cluster API's latest release is used.")
+	bundleCreateCmd.Flags().StringSliceVarP(&bco.infrastructureProviders, "infrastructure", "i", nil,
+		"Infrastructure providers and versions (e.g. aws:v0.5.0) to add to the bundle.")
+	bundleCreateCmd.Flags().StringSliceVarP(&bco.bootstrapProviders, "bootstrap", "b", nil,
+		"Bootstrap providers and versions (e.g. kubeadm:v1.1.5) to add to the bundle. If unspecified, Kubeadm bootstrap provider's latest release is used.")
+	bundleCreateCmd.Flags().StringSliceVarP(&bco.controlPlaneProviders, "control-plane", "c", nil,
+		"Control plane providers and versions (e.g. kubeadm:v1.1.5) to add to the bundle. If unspecified, the Kubeadm control plane provider's latest release is used.")
+	bundleCreateCmd.Flags().StringSliceVar(&bco.ipamProviders, "ipam", nil,
+		"IPAM providers and versions (e.g. in-cluster:v0.1.0) to add to the bundle.")
+	bundleCreateCmd.Flags().StringSliceVar(&bco.runtimeExtensionProviders, "runtime-extension", nil,
+		"Runtime extension providers and versions to add to the bundle.")
+	bundleCreateCmd.Flags().StringSliceVar(&bco.addonProviders, "addon", nil,
+		"Add-on providers and versions (e.g. helm:v0.1.0) to add to the bundle.")
+	bundleCreateCmd.Flags().StringSliceVarP(&bco.flavors, "flavor", "f", nil,
+		"Flavors of the cluster templates to add to the bundle, read from the infrastructure providers. The default cluster template is always added, if it exists.")
+	bundleCreateCmd.Flags().StringSliceVar(&bco.clusterClasses, "cluster-class", nil,
+		"ClusterClasses to add to the bundle, read from the infrastructure providers.")
+	bundleCreateCmd.Flags().StringVarP(&bco.output, "output", "o", "",
+		"Path of the bundle file to create.")
+	_ = bundleCreateCmd.MarkFlagRequired("output")
+
+	bundleCmd.AddCommand(bundleCreateCmd)
+}
+
+func runBundleCreate() error {
+	ctx := context.Background()
+
+	c, err := client.New(ctx, cfgFile)
+	if err != nil {
+		return err
+	}
+
+	return c.CreateBundle(ctx, client.CreateBundleOptions{
+		CoreProvider:              bco.coreProvider,
+		BootstrapProviders:        bco.bootstrapProviders,
+		ControlPlaneProviders:     bco.controlPlaneProviders,
+		InfrastructureProviders:   bco.infrastructureProviders,
+		IPAMProviders:             bco.ipamProviders,
+		RuntimeExtensionProviders: bco.runtimeExtensionProviders,
+		AddonProviders:            bco.addonProviders,
+		Flavors:                   bco.flavors,
+		ClusterClasses:            bco.clusterClasses,
+		Path:                      bco.output,
+	})
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/cmd/internal/templates"
+)
+
+const (
+	// DiffOutputText is an option used to print the diff in text format.
+	DiffOutputText = "text"
+	// DiffOutputYaml is an option used to print the diff in yaml format.
+	DiffOutputYaml = "yaml"
+)
+
+var (
+	// DiffOutputs is a list of valid diff outputs.
+	DiffOutputs = []string{DiffOutputText, DiffOutputYaml}
+)
+
+type diffClusterOptions struct {
+	kubeconfig        string
+	kubeconfigContext string
+	namespace         string
+	output            string
+}
+
+var dfc = &diffClusterOptions{}
+
+var diffClusterCmd = &cobra.Command{
+	Use:   "cluster NAME",
+	Short: "Diff the desired state of a Cluster with a managed topology",
+	Long: templates.LongDesc(`
+		Compute the desired state of a Cluster with a managed topology using the same code of the
+		topology controller, and print the differences with the objects in the management cluster.
+
+		For existing objects, the command shows the changes server side apply would make, computed by running
+		server side apply in dry run mode; objects in the management cluster are never changed.`),
+
+	Example: templates.Examples(`
+		# Diff the cluster named test-1.
+		clusterctl diff cluster test-1
+
+		# Diff the cluster named test-1 in the namespace ns-1, and print the diff in yaml format.
+		clusterctl diff cluster test-1 -n ns-1 -o yaml`),
+
+	Args: func(_ *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return errors.New("please specify a cluster name")
+		}
+		return nil
+	},
+	RunE: func(_ *cobra.Command, args []string) error {
+		return runDiffCluster(args[0], os.Stdout)
+	},
+}
+
+func init() {
+	diffClusterCmd.Flags().StringVar(&dfc.kubeconfig, "kubeconfig", "",
+		"Path to a kubeconfig file to use for the management cluster. If empty, default discovery rules apply.")
+	diffClusterCmd.Flags().StringVar(&dfc.kubeconfigContext, "kubeconfig-context", "",
+		"Context to be used within the kubeconfig file. If empty, current context will be used.")
+	diffClusterCmd.Flags().StringVarP(&dfc.namespace, "namespace", "n", "",
+		"The namespace where the workload cluster is located. If unspecified, the current namespace will be used.")
+	diffClusterCmd.Flags().StringVarP(&dfc.output, "output", "o", DiffOutputText,
+		fmt.Sprintf("Output format. Valid values: %v.", DiffOutputs))
+
+	// completions
+	diffClusterCmd.ValidArgsFunction = resourceNameCompletionFunc(
+		diffClusterCmd.Flags().Lookup("kubeconfig"),
+		diffClusterCmd.Flags().Lookup("kubeconfig-context"),
+		diffClusterCmd.Flags().Lookup("namespace"),
+		clusterv1.GroupVersion.String(),
+		"cluster",
+	)
+
+	diffCmd.AddCommand(diffClusterCmd)
+}
+
+func runDiffCluster(name string, out io.Writer) error {
+	if dfc.output != DiffOutputText && dfc.output != DiffOutputYaml {
+		return errors.Errorf("invalid output format %q, valid values: %v", dfc.output, DiffOutputs)
+	}
+
+	ctx := context.Background()
+
+	c, err := client.New(ctx, cfgFile)
+	if err != nil {
+		return err
+	}
+
+	diff, err := c.DiffCluster(ctx, client.DiffClusterOptions{
+		Kubeconfig:  client.Kubeconfig{Path: dfc.kubeconfig, Context: dfc.kubeconfigContext},
+		Namespace:   dfc.namespace,
+		ClusterName: name,
+	})
+	if err != nil {
+		return err
+	}
+
+	return printDiffCluster(out, diff, dfc.output)
+}
+
+// diffClusterObject is used to print the diff of an object in yaml format.
+type diffClusterObject struct {
+	Object    corev1.ObjectReference        `json:"object"`
+	Operation cluster.TopologyDiffOperation `json:"operation"`
+	Changes   map[string]interface{}        `json:"changes,omitempty"`
+	Desired   map[string]interface{}        `json:"desired,omitempty"`
+}
+
+// printDiffCluster prints the diff of a Cluster in the given output format.
+func printDiffCluster(out io.Writer, diff *cluster.TopologyDiffOutput, output string) error {
+	if output == DiffOutputYaml {
+		objects := []diffClusterObject{}
+		for _, objDiff := range diff.Objects {
+			obj := diffClusterObject{
+				Object:    objDiff.Object,
+				Operation: objDiff.Operation,
+			}
+			switch objDiff.Operation {
+			case cluster.TopologyDiffCreate:
+				obj.Desired = objDiff.Desired.Object
+			case cluster.TopologyDiffUpdate, cluster.TopologyDiffRotate:
+				if len(objDiff.Changes) > 0 {
+					if err := json.Unmarshal(objDiff.Changes, &obj.Changes); err != nil {
+						return errors.Wrapf(err, "failed to unmarshal the diff for %s %s", objDiff.Object.Kind, klog.KRef(objDiff.Object.Namespace, objDiff.Object.Name))
+					}
+				}
+			}
+			objects = append(objects, obj)
+		}
+		y, err := yaml.Marshal(objects)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal the diff")
+		}
+		_, err = fmt.Fprint(out, string(y))
+		return err
+	}
+
+	if len(diff.Objects) == 0 {
+		_, err := fmt.Fprintf(out, "No changes for Cluster %s\n", klog.KObj(diff.Cluster))
+		return err
+	}
+
+	for _, objDiff := range diff.Objects {
+		symbol := "~"
+		switch objDiff.Operation {
+		case cluster.TopologyDiffCreate:
+			symbol = "+"
+		case cluster.TopologyDiffDelete:
+			symbol = "-"
+		}
+		if _, err := fmt.Fprintf(out, "%s %s %s (%s)\n", symbol, objDiff.Object.Kind, klog.KRef(objDiff.Object.Namespace, objDiff.Object.Name), objDiff.Operation); err != nil {
+			return err
+		}
+
+		// Print the desired object for objects to be created and the changes for objects to be updated or rotated.
+		var content []byte
+		var err error
+		switch objDiff.Operation {
+		case cluster.TopologyDiffCreate:
+			content, err = yaml.Marshal(objDiff.Desired.Object)
+		case cluster.TopologyDiffUpdate, cluster.TopologyDiffRotate:
+			if len(objDiff.Changes) > 0 {
+				content, err = yaml.JSONToYAML(objDiff.Changes)
+			}
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to marshal the diff for %s %s", objDiff.Object.Kind, klog.KRef(objDiff.Object.Namespace, objDiff.Object.Name))
+		}
+		for _, line := range strings.Split(strings.TrimSuffix(string(content), "\n"), "\n") {
+			if line == "" {
+				continue
+			}
+			if _, err := fmt.Fprintf(out, "    %s\n", line); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
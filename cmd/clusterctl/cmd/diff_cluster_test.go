@@ -0,0 +1,124 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
+)
+
+func Test_printDiffCluster(t *testing.T) {
+	diff := &cluster.TopologyDiffOutput{
+		Cluster: &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "cluster1"}},
+		Objects: []cluster.TopologyObjectDiff{
+			{
+				Object:    corev1.ObjectReference{Kind: "MachineHealthCheck", Namespace: "ns1", Name: "cluster1-md-0"},
+				Operation: cluster.TopologyDiffCreate,
+				Desired: &unstructured.Unstructured{Object: map[string]interface{}{
+					"kind": "MachineHealthCheck",
+					"metadata": map[string]interface{}{
+						"name": "cluster1-md-0",
+					},
+				}},
+			},
+			{
+				Object:    corev1.ObjectReference{Kind: "KubeadmControlPlane", Namespace: "ns1", Name: "cluster1-abcde"},
+				Operation: cluster.TopologyDiffUpdate,
+				Changes:   []byte(`{"spec":{"version":"v1.34.0"}}`),
+			},
+			{
+				Object:    corev1.ObjectReference{Kind: "MachineDeployment", Namespace: "ns1", Name: "cluster1-md-1"},
+				Operation: cluster.TopologyDiffDelete,
+			},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		diff   *cluster.TopologyDiffOutput
+		output string
+		want   string
+	}{
+		{
+			name:   "prints the diff in text format",
+			diff:   diff,
+			output: DiffOutputText,
+			want: `+ MachineHealthCheck ns1/cluster1-md-0 (Create)
+    kind: MachineHealthCheck
+    metadata:
+      name: cluster1-md-0
+~ KubeadmControlPlane ns1/cluster1-abcde (Update)
+    spec:
+      version: v1.34.0
+- MachineDeployment ns1/cluster1-md-1 (Delete)
+`,
+		},
+		{
+			name:   "prints the diff in yaml format",
+			diff:   diff,
+			output: DiffOutputYaml,
+			want: `- desired:
+    kind: MachineHealthCheck
+    metadata:
+      name: cluster1-md-0
+  object:
+    kind: MachineHealthCheck
+    name: cluster1-md-0
+    namespace: ns1
+  operation: Create
+- changes:
+    spec:
+      version: v1.34.0
+  object:
+    kind: KubeadmControlPlane
+    name: cluster1-abcde
+    namespace: ns1
+  operation: Update
+- object:
+    kind: MachineDeployment
+    name: cluster1-md-1
+    namespace: ns1
+  operation: Delete
+`,
+		},
+		{
+			name: "prints a message if there are no changes",
+			diff: &cluster.TopologyDiffOutput{
+				Cluster: diff.Cluster,
+			},
+			output: DiffOutputText,
+			want:   "No changes for Cluster ns1/cluster1\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			buf := &bytes.Buffer{}
+			g.Expect(printDiffCluster(buf, tt.diff, tt.output)).To(Succeed())
+			g.Expect(buf.String()).To(BeComparableTo(tt.want))
+		})
+	}
+}
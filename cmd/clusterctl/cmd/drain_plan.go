@@ -0,0 +1,136 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/cmd/internal/templates"
+)
+
+type drainPlanOptions struct {
+	kubeconfig        string
+	kubeconfigContext string
+	namespace         string
+}
+
+var dp = &drainPlanOptions{}
+
+var drainPlanCmd = &cobra.Command{
+	Use:   "drain-plan RESOURCE",
+	Short: "Show what draining the Node of a Machine would do",
+	Long: templates.LongDesc(`
+		Show what draining the Node of a Machine would do, using the same code of the Machine controller.
+
+		The drain plan lists the Pods on the Node grouped by drain order, in the order in which they are drained,
+		which Pods are skipped, which Pods are waited for completion and which Pods can't be evicted right now
+		because of PodDisruptionBudgets. Pods are never evicted or deleted.`),
+
+	Example: templates.Examples(`
+		# Show the drain plan of the machine named my-machine.
+		clusterctl alpha drain-plan machine/my-machine
+
+		# Show the drain plan of the machine named my-machine in the namespace ns-1.
+		clusterctl alpha drain-plan machine/my-machine -n ns-1`),
+
+	Args: func(_ *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return errors.New("please specify a machine in the machine/<name> form")
+		}
+		return nil
+	},
+	RunE: func(_ *cobra.Command, args []string) error {
+		return runDrainPlan(args[0], os.Stdout)
+	},
+}
+
+func init() {
+	drainPlanCmd.Flags().StringVar(&dp.kubeconfig, "kubeconfig", "",
+		"Path to a kubeconfig file to use for the management cluster. If empty, default discovery rules apply.")
+	drainPlanCmd.Flags().StringVar(&dp.kubeconfigContext, "kubeconfig-context", "",
+		"Context to be used within the kubeconfig file. If empty, current context will be used.")
+	drainPlanCmd.Flags().StringVarP(&dp.namespace, "namespace", "n", "",
+		"The namespace where the machine is located. If unspecified, the current namespace will be used.")
+}
+
+func runDrainPlan(resource string, out io.Writer) error {
+	ctx := context.Background()
+
+	c, err := client.New(ctx, cfgFile)
+	if err != nil {
+		return err
+	}
+
+	plan, err := c.DrainPlan(ctx, client.DrainPlanOptions{
+		Kubeconfig: client.Kubeconfig{Path: dp.kubeconfig, Context: dp.kubeconfigContext},
+		Namespace:  dp.namespace,
+		Resource:   resource,
+	})
+	if err != nil {
+		return err
+	}
+
+	return printDrainPlan(out, plan)
+}
+
+// printDrainPlan prints the drain plan of a Machine.
+func printDrainPlan(out io.Writer, plan *cluster.MachineDrainPlanOutput) error {
+	if _, err := fmt.Fprintf(out, "Drain plan for Machine %s (Node %s):\n", klog.KObj(plan.Machine), plan.NodeName); err != nil {
+		return err
+	}
+	if len(plan.Groups) == 0 && len(plan.PodsSkipped) == 0 {
+		_, err := fmt.Fprintln(out, "There are no Pods on the Node")
+		return err
+	}
+
+	w := tabwriter.NewWriter(out, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(w, "ORDER\tPOD\tBEHAVIOR\tNOTES")
+	for _, group := range plan.Groups {
+		for _, pod := range group.Pods {
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", group.Order, klog.KRef(pod.Pod.Namespace, pod.Pod.Name), pod.DrainBehavior, drainPlanPodNotes(pod))
+		}
+	}
+	for _, pod := range plan.PodsSkipped {
+		fmt.Fprintf(w, "-\t%s\t%s\t%s\n", klog.KRef(pod.Pod.Namespace, pod.Pod.Name), pod.DrainBehavior, drainPlanPodNotes(pod))
+	}
+	return w.Flush()
+}
+
+func drainPlanPodNotes(pod cluster.MachineDrainPlanPod) string {
+	var notes []string
+	if pod.Terminating {
+		notes = append(notes, "Terminating")
+	}
+	if len(pod.BlockingPodDisruptionBudgets) > 0 {
+		notes = append(notes, fmt.Sprintf("Eviction blocked by PodDisruptionBudgets: %s", strings.Join(pod.BlockingPodDisruptionBudgets, ", ")))
+	}
+	if pod.Warning != "" {
+		notes = append(notes, pod.Warning)
+	}
+	return strings.Join(notes, "; ")
+}
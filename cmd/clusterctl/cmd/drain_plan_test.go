@@ -0,0 +1,106 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
+)
+
+func Test_printDrainPlan(t *testing.T) {
+	machine := &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "machine1"}}
+
+	tests := []struct {
+		name string
+		plan *cluster.MachineDrainPlanOutput
+		want string
+	}{
+		{
+			name: "prints the drain plan",
+			plan: &cluster.MachineDrainPlanOutput{
+				Machine:  machine,
+				NodeName: "node1",
+				Groups: []cluster.MachineDrainPlanGroup{
+					{
+						Order: 0,
+						Pods: []cluster.MachineDrainPlanPod{
+							{
+								Pod:                          corev1.ObjectReference{Namespace: "app", Name: "frontend"},
+								DrainBehavior:                clusterv1.MachineDrainRuleDrainBehaviorDrain,
+								BlockingPodDisruptionBudgets: []string{"frontend"},
+							},
+							{
+								Pod:           corev1.ObjectReference{Namespace: "app", Name: "batch"},
+								DrainBehavior: clusterv1.MachineDrainRuleDrainBehaviorWaitCompleted,
+								Terminating:   true,
+							},
+						},
+					},
+					{
+						Order: 10,
+						Pods: []cluster.MachineDrainPlanPod{
+							{
+								Pod:           corev1.ObjectReference{Namespace: "app", Name: "database"},
+								DrainBehavior: clusterv1.MachineDrainRuleDrainBehaviorDrain,
+								Warning:       "Pod with local storage is evicted",
+							},
+						},
+					},
+				},
+				PodsSkipped: []cluster.MachineDrainPlanPod{
+					{
+						Pod:           corev1.ObjectReference{Namespace: "kube-system", Name: "kube-proxy"},
+						DrainBehavior: clusterv1.MachineDrainRuleDrainBehaviorSkip,
+					},
+				},
+			},
+			want: "Drain plan for Machine ns1/machine1 (Node node1):\n" +
+				"ORDER     POD                      BEHAVIOR        NOTES\n" +
+				"0         app/frontend             Drain           Eviction blocked by PodDisruptionBudgets: frontend\n" +
+				"0         app/batch                WaitCompleted   Terminating\n" +
+				"10        app/database             Drain           Pod with local storage is evicted\n" +
+				"-         kube-system/kube-proxy   Skip            \n",
+		},
+		{
+			name: "prints a drain plan without Pods",
+			plan: &cluster.MachineDrainPlanOutput{
+				Machine:  machine,
+				NodeName: "node1",
+			},
+			want: `Drain plan for Machine ns1/machine1 (Node node1):
+There are no Pods on the Node
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			out := &bytes.Buffer{}
+			g.Expect(printDrainPlan(out, tt.plan)).To(Succeed())
+			g.Expect(out.String()).To(Equal(tt.want))
+		})
+	}
+}
@@ -40,6 +40,7 @@ type initOptions struct {
 	validate                  bool
 	waitProviders             bool
 	waitProviderTimeout       int
+	fromBundle                string
 }
 
 var initOpts = &initOptions{}
@@ -83,7 +84,10 @@ var initCmd = &cobra.Command{
 		clusterctl init --infrastructure=aws,vsphere
 
 		# Initialize a management cluster with a custom target namespace for the provider resources.
-		clusterctl init --infrastructure aws --target-namespace foo`),
+		clusterctl init --infrastructure aws --target-namespace foo
+
+		# Initialize a management cluster reading providers from a bundle created with 'clusterctl bundle create'.
+		clusterctl init --infrastructure aws --from-bundle bundle.tar.gz`),
 	Args: cobra.NoArgs,
 	RunE: func(*cobra.Command, []string) error {
 		return runInit()
@@ -117,6 +121,8 @@ func init() {
 		"Wait timeout per provider installation in seconds. This value is ignored if --wait-providers is false")
 	initCmd.Flags().BoolVar(&initOpts.validate, "validate", true,
 		"If true, clusterctl will validate that the deployments will succeed on the management cluster.")
+	initCmd.Flags().StringVar(&initOpts.fromBundle, "from-bundle", "",
+		"Path to a bundle created with 'clusterctl bundle create'; if set, providers and cert-manager are read from the bundle instead of the provider repositories.")
 
 	initCmd.AddCommand(initListImagesCmd)
 	RootCmd.AddCommand(initCmd)
@@ -125,7 +131,12 @@ func init() {
 func runInit() error {
 	ctx := context.Background()
 
-	c, err := client.New(ctx, cfgFile)
+	var clientOptions []client.Option
+	if initOpts.fromBundle != "" {
+		clientOptions = append(clientOptions, client.InjectBundle(initOpts.fromBundle))
+	}
+
+	c, err := client.New(ctx, cfgFile, clientOptions...)
 	if err != nil {
 		return err
 	}
@@ -38,9 +38,17 @@ type moveOptions struct {
 	toKubeconfig          string
 	toKubeconfigContext   string
 	namespace             string
+	clusters              []string
 	fromDirectory         string
 	toDirectory           string
+	fromArchive           string
+	toArchive             string
+	encryptFor            []string
+	decryptWith           string
 	dryRun                bool
+	journal               string
+	resume                bool
+	rollback              bool
 	hideAPIWarnings       string
 }
 
@@ -59,11 +67,29 @@ var moveCmd = &cobra.Command{
 		Move Cluster API objects and all dependencies between management clusters.
 		clusterctl move --to-kubeconfig=target-kubeconfig.yaml
 
+		Move a Cluster and all its dependencies between management clusters, leaving other Clusters in the namespace untouched.
+		clusterctl move --to-kubeconfig=target-kubeconfig.yaml --cluster my-cluster
+
 		Write Cluster API objects and all dependencies from a management cluster to directory.
 		clusterctl move --to-directory /tmp/backup-directory
 
 		Read Cluster API objects and all dependencies from a directory into a management cluster.
 		clusterctl move --from-directory /tmp/backup-directory
+
+		Write Cluster API objects and all dependencies from a management cluster to an archive, encrypting Secrets.
+		clusterctl move --to-archive /tmp/backup.tar.gz --encrypt-for key.pub
+
+		Read Cluster API objects and all dependencies from an archive into a management cluster.
+		clusterctl move --from-archive /tmp/backup.tar.gz --decrypt-with key.pem
+
+		Move Cluster API objects recording the progress, so an interrupted move can be resumed or rolled back.
+		clusterctl move --to-kubeconfig=target-kubeconfig.yaml --journal /tmp/move-journal.yaml
+
+		Resume an interrupted move.
+		clusterctl move --to-kubeconfig=target-kubeconfig.yaml --journal /tmp/move-journal.yaml --resume
+
+		Roll back an interrupted move, moving all the objects back to the source management cluster.
+		clusterctl move --to-kubeconfig=target-kubeconfig.yaml --journal /tmp/move-journal.yaml --rollback
 	`),
 	Args: cobra.NoArgs,
 	RunE: func(*cobra.Command, []string) error {
@@ -82,18 +108,48 @@ func init() {
 		"Context to be used within the kubeconfig file for the destination management cluster. If empty, current context will be used.")
 	moveCmd.Flags().StringVarP(&mo.namespace, "namespace", "n", "",
 		"The namespace where the workload cluster is hosted. If unspecified, the current context's namespace is used.")
+	moveCmd.Flags().StringSliceVar(&mo.clusters, "cluster", nil,
+		"The Clusters to be moved, together with the objects they depend on. If unspecified, all the Clusters in the namespace are moved.")
 	moveCmd.Flags().BoolVar(&mo.dryRun, "dry-run", false,
 		"Enable dry run, don't really perform the move actions")
 	moveCmd.Flags().StringVar(&mo.toDirectory, "to-directory", "",
 		"Write Cluster API objects and all dependencies from a management cluster to directory.")
 	moveCmd.Flags().StringVar(&mo.fromDirectory, "from-directory", "",
 		"Read Cluster API objects and all dependencies from a directory into a management cluster.")
+	moveCmd.Flags().StringVar(&mo.toArchive, "to-archive", "",
+		"Write Cluster API objects and all dependencies from a management cluster to a tar.gz archive.")
+	moveCmd.Flags().StringVar(&mo.fromArchive, "from-archive", "",
+		"Read Cluster API objects and all dependencies from a tar.gz archive into a management cluster.")
+	moveCmd.Flags().StringSliceVar(&mo.encryptFor, "encrypt-for", nil,
+		"Path to a PEM encoded X25519 public key; when writing to an archive, Secrets are encrypted so they can be read with the corresponding private key.")
+	moveCmd.Flags().StringVar(&mo.decryptWith, "decrypt-with", "",
+		"Path to a PEM encoded X25519 private key used to decrypt Secrets when reading from an archive.")
+	moveCmd.Flags().StringVar(&mo.journal, "journal", "",
+		"Path to a file where the progress of the move is recorded, so an interrupted move can be resumed or rolled back.")
+	moveCmd.Flags().BoolVar(&mo.resume, "resume", false,
+		"Resume an interrupted move using the progress recorded in the file specified with --journal.")
+	moveCmd.Flags().BoolVar(&mo.rollback, "rollback", false,
+		"Roll back an interrupted move using the progress recorded in the file specified with --journal.")
 	moveCmd.Flags().StringVar(&mo.hideAPIWarnings, "hide-api-warnings", "default",
 		"Set of API server warnings to hide. Valid sets are \"default\" (includes metadata.finalizer warnings), \"all\" , and \"none\".")
 
 	moveCmd.MarkFlagsMutuallyExclusive("to-directory", "to-kubeconfig")
 	moveCmd.MarkFlagsMutuallyExclusive("from-directory", "to-directory")
 	moveCmd.MarkFlagsMutuallyExclusive("from-directory", "kubeconfig")
+	moveCmd.MarkFlagsMutuallyExclusive("resume", "rollback")
+	moveCmd.MarkFlagsMutuallyExclusive("resume", "dry-run")
+	moveCmd.MarkFlagsMutuallyExclusive("rollback", "dry-run")
+	moveCmd.MarkFlagsMutuallyExclusive("journal", "to-directory")
+	moveCmd.MarkFlagsMutuallyExclusive("journal", "from-directory")
+	moveCmd.MarkFlagsMutuallyExclusive("cluster", "from-directory")
+	moveCmd.MarkFlagsMutuallyExclusive("to-archive", "to-kubeconfig")
+	moveCmd.MarkFlagsMutuallyExclusive("from-archive", "kubeconfig")
+	moveCmd.MarkFlagsMutuallyExclusive("from-archive", "to-archive", "from-directory", "to-directory")
+	moveCmd.MarkFlagsMutuallyExclusive("cluster", "from-archive")
+	moveCmd.MarkFlagsMutuallyExclusive("journal", "to-archive")
+	moveCmd.MarkFlagsMutuallyExclusive("journal", "from-archive")
+	moveCmd.MarkFlagsMutuallyExclusive("cluster", "resume")
+	moveCmd.MarkFlagsMutuallyExclusive("cluster", "rollback")
 
 	RootCmd.AddCommand(moveCmd)
 }
@@ -103,9 +159,15 @@ func runMove() error {
 
 	if mo.toDirectory == "" &&
 		mo.fromDirectory == "" &&
+		mo.toArchive == "" &&
+		mo.fromArchive == "" &&
 		mo.toKubeconfig == "" &&
 		!mo.dryRun {
-		return errors.New("please specify a target cluster using the --to-kubeconfig flag when not using --dry-run, --to-directory or --from-directory")
+		return errors.New("please specify a target cluster using the --to-kubeconfig flag when not using --dry-run, --to-directory, --from-directory, --to-archive or --from-archive")
+	}
+
+	if (mo.resume || mo.rollback) && mo.journal == "" {
+		return errors.New("please specify the journal of the interrupted move using the --journal flag when using --resume or --rollback")
 	}
 
 	configClient, err := config.New(ctx, cfgFile)
@@ -162,11 +224,19 @@ func runMove() error {
 	}
 
 	return c.Move(ctx, client.MoveOptions{
-		FromKubeconfig: client.Kubeconfig{Path: mo.fromKubeconfig, Context: mo.fromKubeconfigContext},
-		ToKubeconfig:   client.Kubeconfig{Path: mo.toKubeconfig, Context: mo.toKubeconfigContext},
-		FromDirectory:  mo.fromDirectory,
-		ToDirectory:    mo.toDirectory,
-		Namespace:      mo.namespace,
-		DryRun:         mo.dryRun,
+		FromKubeconfig:    client.Kubeconfig{Path: mo.fromKubeconfig, Context: mo.fromKubeconfigContext},
+		ToKubeconfig:      client.Kubeconfig{Path: mo.toKubeconfig, Context: mo.toKubeconfigContext},
+		FromDirectory:     mo.fromDirectory,
+		ToDirectory:       mo.toDirectory,
+		FromArchive:       mo.fromArchive,
+		ToArchive:         mo.toArchive,
+		ArchiveRecipients: mo.encryptFor,
+		ArchiveIdentity:   mo.decryptWith,
+		Namespace:         mo.namespace,
+		Clusters:          mo.clusters,
+		DryRun:            mo.dryRun,
+		JournalFile:       mo.journal,
+		Resume:            mo.resume,
+		Rollback:          mo.rollback,
 	})
 }
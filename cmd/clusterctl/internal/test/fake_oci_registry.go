@@ -0,0 +1,170 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const fakeOCIRegistryToken = "fake-oci-registry-token"
+
+// FakeOCIRegistry is an in-process OCI registry implementing the subset of the OCI distribution API
+// used by clusterctl (tag listing, manifest and blob pulls).
+type FakeOCIRegistry struct {
+	server *httptest.Server
+
+	lock      sync.Mutex
+	manifests map[string]map[string][]byte
+	blobs     map[string][]byte
+	username  string
+	password  string
+}
+
+// NewFakeOCIRegistry starts an in-process OCI registry; the registry listens on the loopback interface
+// and it is served using plain http.
+func NewFakeOCIRegistry() *FakeOCIRegistry {
+	r := &FakeOCIRegistry{
+		manifests: map[string]map[string][]byte{},
+		blobs:     map[string][]byte{},
+	}
+	r.server = httptest.NewServer(http.HandlerFunc(r.serveHTTP))
+	return r
+}
+
+// WithCredentials requires clients to authenticate using the bearer token flow with the given credentials.
+func (r *FakeOCIRegistry) WithCredentials(username, password string) *FakeOCIRegistry {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.username = username
+	r.password = password
+	return r
+}
+
+// Host returns the host (and port) the registry is listening on.
+func (r *FakeOCIRegistry) Host() string {
+	u, _ := url.Parse(r.server.URL)
+	return u.Host
+}
+
+// Close shuts down the registry.
+func (r *FakeOCIRegistry) Close() {
+	r.server.Close()
+}
+
+// AddArtifact pushes an artifact containing the given files to repository and tags it with tag.
+// Each file is stored as a layer annotated with its file name.
+func (r *FakeOCIRegistry) AddArtifact(repository, tag string, files map[string][]byte) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	layers := []map[string]interface{}{}
+	for _, name := range names {
+		sum := sha256.Sum256(files[name])
+		digest := "sha256:" + hex.EncodeToString(sum[:])
+		r.blobs[digest] = files[name]
+		layers = append(layers, map[string]interface{}{
+			"mediaType":   "application/vnd.oci.image.layer.v1.tar",
+			"digest":      digest,
+			"size":        len(files[name]),
+			"annotations": map[string]string{"org.opencontainers.image.title": name},
+		})
+	}
+
+	manifest, _ := json.Marshal(map[string]interface{}{
+		"schemaVersion": 2,
+		"mediaType":     "application/vnd.oci.image.manifest.v1+json",
+		"artifactType":  "application/vnd.cluster-api.provider.v1",
+		"layers":        layers,
+	})
+	if _, ok := r.manifests[repository]; !ok {
+		r.manifests[repository] = map[string][]byte{}
+	}
+	r.manifests[repository][tag] = manifest
+}
+
+func (r *FakeOCIRegistry) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if req.URL.Path == "/token" {
+		if username, password, ok := req.BasicAuth(); !ok || username != r.username || password != r.password {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"token": fakeOCIRegistryToken})
+		return
+	}
+
+	if r.username != "" && req.Header.Get("Authorization") != "Bearer "+fakeOCIRegistryToken {
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="fake-oci-registry"`, r.server.URL))
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	path := strings.TrimPrefix(req.URL.Path, "/v2/")
+	switch {
+	case strings.HasSuffix(path, "/tags/list"):
+		repository := strings.TrimSuffix(path, "/tags/list")
+		manifests, ok := r.manifests[repository]
+		if !ok {
+			http.NotFound(w, req)
+			return
+		}
+		tags := []string{}
+		for tag := range manifests {
+			tags = append(tags, tag)
+		}
+		sort.Strings(tags)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"name": repository, "tags": tags})
+	case strings.Contains(path, "/manifests/"):
+		repository, tag, _ := strings.Cut(path, "/manifests/")
+		manifest, ok := r.manifests[repository][tag]
+		if !ok {
+			http.NotFound(w, req)
+			return
+		}
+		w.Header().Set("Content-Type", "application/vnd.oci.image.manifest.v1+json")
+		_, _ = w.Write(manifest)
+	case strings.Contains(path, "/blobs/"):
+		_, digest, _ := strings.Cut(path, "/blobs/")
+		blob, ok := r.blobs[digest]
+		if !ok {
+			http.NotFound(w, req)
+			return
+		}
+		w.Header().Set("Content-Type", "application/octet-stream")
+		_, _ = w.Write(blob)
+	default:
+		http.NotFound(w, req)
+	}
+}
@@ -84,6 +84,14 @@ type MachineReconciler struct {
 
 	AdditionalSyncMachineLabels      []*regexp.Regexp
 	AdditionalSyncMachineAnnotations []*regexp.Regexp
+
+	// MaxConcurrentDrainsPerCluster is the maximum number of Nodes that are drained concurrently in a Cluster.
+	// 0 means no limit.
+	MaxConcurrentDrainsPerCluster int
+
+	// MaxEvictionsPerSecondPerCluster is the maximum number of Pod evictions per second during Node drains in a Cluster.
+	// 0 means no limit.
+	MaxEvictionsPerSecondPerCluster float64
 }
 
 func (r *MachineReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
@@ -96,14 +104,17 @@ func (r *MachineReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manag
 		RemoteConditionsGracePeriod:      r.RemoteConditionsGracePeriod,
 		AdditionalSyncMachineLabels:      r.AdditionalSyncMachineLabels,
 		AdditionalSyncMachineAnnotations: r.AdditionalSyncMachineAnnotations,
+		MaxConcurrentDrainsPerCluster:    r.MaxConcurrentDrainsPerCluster,
+		MaxEvictionsPerSecondPerCluster:  r.MaxEvictionsPerSecondPerCluster,
 	}).SetupWithManager(ctx, mgr, options)
 }
 
 // MachineSetReconciler reconciles a MachineSet object.
 type MachineSetReconciler struct {
-	Client       client.Client
-	APIReader    client.Reader
-	ClusterCache clustercache.ClusterCache
+	Client        client.Client
+	APIReader     client.Reader
+	ClusterCache  clustercache.ClusterCache
+	RuntimeClient runtimeclient.Client
 
 	PreflightChecks sets.Set[clusterv1.MachineSetPreflightCheck]
 
@@ -116,6 +127,7 @@ func (r *MachineSetReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Ma
 		Client:           r.Client,
 		APIReader:        r.APIReader,
 		ClusterCache:     r.ClusterCache,
+		RuntimeClient:    r.RuntimeClient,
 		PreflightChecks:  r.PreflightChecks,
 		WatchFilterValue: r.WatchFilterValue,
 	}).SetupWithManager(ctx, mgr, options)
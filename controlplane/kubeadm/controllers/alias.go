@@ -46,20 +46,30 @@ type KubeadmControlPlaneReconciler struct {
 	WatchFilterValue string
 
 	RemoteConditionsGracePeriod time.Duration
+
+	// EtcdSnapshotVolumePath is the path where the PersistentVolumeClaim used by the PersistentVolumeClaim
+	// etcd snapshot storage type is mounted.
+	EtcdSnapshotVolumePath string
+
+	// EtcdRestoreMaxInlineSnapshotSize is the max size of a snapshot, base64 encoded, that can be embedded in the bootstrap
+	// data of the Machine restoring etcd, which is required for storage types not supporting download URLs; 0 disables it.
+	EtcdRestoreMaxInlineSnapshotSize int
 }
 
 // SetupWithManager sets up the reconciler with the Manager.
 func (r *KubeadmControlPlaneReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
 	return (&kubeadmcontrolplanecontrollers.KubeadmControlPlaneReconciler{
-		Client:                      r.Client,
-		APIReader:                   r.APIReader,
-		SecretCachingClient:         r.SecretCachingClient,
-		RuntimeClient:               r.RuntimeClient,
-		ClusterCache:                r.ClusterCache,
-		EtcdDialTimeout:             r.EtcdDialTimeout,
-		EtcdCallTimeout:             r.EtcdCallTimeout,
-		EtcdLogger:                  r.EtcdLogger,
-		WatchFilterValue:            r.WatchFilterValue,
-		RemoteConditionsGracePeriod: r.RemoteConditionsGracePeriod,
+		Client:                           r.Client,
+		APIReader:                        r.APIReader,
+		SecretCachingClient:              r.SecretCachingClient,
+		RuntimeClient:                    r.RuntimeClient,
+		ClusterCache:                     r.ClusterCache,
+		EtcdDialTimeout:                  r.EtcdDialTimeout,
+		EtcdCallTimeout:                  r.EtcdCallTimeout,
+		EtcdLogger:                       r.EtcdLogger,
+		WatchFilterValue:                 r.WatchFilterValue,
+		RemoteConditionsGracePeriod:      r.RemoteConditionsGracePeriod,
+		EtcdSnapshotVolumePath:           r.EtcdSnapshotVolumePath,
+		EtcdRestoreMaxInlineSnapshotSize: r.EtcdRestoreMaxInlineSnapshotSize,
 	}).SetupWithManager(ctx, mgr, options)
 }
@@ -18,6 +18,7 @@ package internal
 
 import (
 	"context"
+	"crypto/sha256"
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
@@ -65,11 +66,13 @@ type ClientCertEntry struct {
 	ClusterUID          types.UID
 	ClientCert          *tls.Certificate
 	EncryptionAlgorithm bootstrapv1.EncryptionAlgorithmType
+	// CAKeyHash is the hash of the key of the etcd CA used to sign the client cert.
+	CAKeyHash string
 }
 
 // Key returns the cache key of a ClientCertEntry.
 func (r ClientCertEntry) Key() string {
-	return fmt.Sprintf("%s/%s/%s", r.Cluster.String(), r.ClusterUID, r.EncryptionAlgorithm)
+	return fmt.Sprintf("%s/%s/%s/%s", r.Cluster.String(), r.ClusterUID, r.EncryptionAlgorithm, r.CAKeyHash)
 }
 
 // RemoteClusterConnectionError represents a failure to connect to a remote cluster.
@@ -146,8 +149,9 @@ func (m *Management) GetWorkloadCluster(ctx context.Context, cluster *clusterv1.
 	var clientCert tls.Certificate
 	if keyData != nil {
 		// Get client cert from cache if possible, otherwise generate it and add it to the cache.
-		// Note: The caching assumes that the etcd CA is not rotated during the lifetime of a Cluster.
-		if entry, ok := m.ClientCertCache.Has(ClientCertEntry{Cluster: clusterKey, ClusterUID: cluster.UID, EncryptionAlgorithm: keyEncryptionAlgorithm}.Key()); ok {
+		// Note: The cache key includes the hash of the etcd CA key, so a new client cert is generated when the etcd CA is rotated.
+		caKeyHash := fmt.Sprintf("%x", sha256.Sum256(keyData))
+		if entry, ok := m.ClientCertCache.Has(ClientCertEntry{Cluster: clusterKey, ClusterUID: cluster.UID, EncryptionAlgorithm: keyEncryptionAlgorithm, CAKeyHash: caKeyHash}.Key()); ok {
 			clientCert = *entry.ClientCert
 		} else {
 			// The client cert expires after 10 years, but that's okay as the cache has a TTL of 1 day.
@@ -155,7 +159,7 @@ func (m *Management) GetWorkloadCluster(ctx context.Context, cluster *clusterv1.
 			if err != nil {
 				return nil, err
 			}
-			m.ClientCertCache.Add(ClientCertEntry{Cluster: clusterKey, ClusterUID: cluster.UID, ClientCert: &clientCert, EncryptionAlgorithm: keyEncryptionAlgorithm})
+			m.ClientCertCache.Add(ClientCertEntry{Cluster: clusterKey, ClusterUID: cluster.UID, ClientCert: &clientCert, EncryptionAlgorithm: keyEncryptionAlgorithm, CAKeyHash: caKeyHash})
 		}
 	} else {
 		clientCert, err = m.getAPIServerEtcdClientCert(ctx, clusterKey)
@@ -81,6 +81,10 @@ type ControlPlane struct {
 	EtcdMembers                       []*etcd.Member
 	EtcdMembersAndMachinesAreMatching bool
 
+	// EtcdAlarms is the list of alarms read while computing reconcileControlPlaneConditions.
+	// NOTE: This info is used to automatically recover etcd members from NOSPACE alarms.
+	EtcdAlarms []etcd.MemberAlarm
+
 	managementCluster ManagementCluster
 	workloadCluster   WorkloadCluster
 
@@ -316,6 +320,11 @@ func (c *ControlPlane) IsEtcdManaged() bool {
 	return !c.KCP.Spec.KubeadmConfigSpec.ClusterConfiguration.Etcd.External.IsDefined()
 }
 
+// IsCARotationInProgress returns true if a certificate authorities rotation is in progress.
+func (c *ControlPlane) IsCARotationInProgress() bool {
+	return isCARotationInProgress(c.KCP)
+}
+
 // UnhealthyMachinesWithUnhealthyControlPlaneComponents returns all unhealthy control plane machines that
 // have unhealthy control plane components.
 // It differs from UnhealthyMachinesByHealthCheck which checks `MachineHealthCheck` conditions.
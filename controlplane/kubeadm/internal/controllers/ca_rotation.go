@@ -0,0 +1,334 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/collections"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/kubeconfig"
+	clog "sigs.k8s.io/cluster-api/util/log"
+	"sigs.k8s.io/cluster-api/util/secret"
+)
+
+// reconcileCARotation drives the rotation of the certificate authorities of the cluster (cluster CA, etcd CA,
+// front-proxy CA and service account keys) through the following phases:
+//   - TrustNewCertificateAuthorities: new certificate authorities are generated and trusted alongside the old ones.
+//   - SignWithNewCertificateAuthorities: new certificate authorities are used for signing, the old ones are still trusted.
+//   - RemoveOldCertificateAuthorities: old certificate authorities are not trusted anymore.
+//
+// Every phase changes the certificate authorities Secrets, which are then used to generate the bootstrap data of new
+// Machines; before moving to the next phase, all the Machines of the cluster, both control plane and workers,
+// must be replaced with Machines created after the current phase started.
+// NOTE: KCP rolls out control plane Machines (see UpToDate), while worker Machines must be rolled out by users;
+// the CARotating condition lists the MachineDeployments and the Machines to be rolled out.
+// NOTE: The rotation is blocked while the Cluster has MachinePools, and the old service account key is removed only
+// after kubelets had time to refresh the projected service account tokens signed with it.
+func (r *KubeadmControlPlaneReconciler) reconcileCARotation(ctx context.Context, controlPlane *internal.ControlPlane) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	kcp := controlPlane.KCP
+
+	startCARotation := shouldStartCARotation(kcp, time.Now())
+	if !startCARotation && !controlPlane.IsCARotationInProgress() {
+		conditions.Set(kcp, metav1.Condition{
+			Type:   controlplanev1.KubeadmControlPlaneCARotatingCondition,
+			Status: metav1.ConditionFalse,
+			Reason: controlplanev1.KubeadmControlPlaneCANotRotatingReason,
+		})
+		return ctrl.Result{}, nil
+	}
+
+	// Block the rotation while the Cluster has MachinePools, because KCP cannot track when MachinePool instances
+	// are replaced and the KubeadmConfigs of MachinePools keep the CA cert hashes computed before the rotation started.
+	machinePools, err := r.getMachinePoolsBlockingCARotation(ctx, controlPlane)
+	if err != nil {
+		setCARotatingInternalErrorCondition(kcp)
+		return ctrl.Result{}, errors.Wrap(err, "failed to rotate certificate authorities")
+	}
+	if len(machinePools) > 0 {
+		log.Info("Certificate authorities rotation is blocked while the Cluster has MachinePools", "MachinePools", clog.ObjNamesString(machinePools))
+		if startCARotation {
+			conditions.Set(kcp, metav1.Condition{
+				Type:    controlplanev1.KubeadmControlPlaneCARotatingCondition,
+				Status:  metav1.ConditionFalse,
+				Reason:  controlplanev1.KubeadmControlPlaneCANotRotatingReason,
+				Message: fmt.Sprintf("Rotation cannot start while the Cluster has MachinePools: %s", clog.ObjNamesString(machinePools)),
+			})
+		} else {
+			conditions.Set(kcp, metav1.Condition{
+				Type:   controlplanev1.KubeadmControlPlaneCARotatingCondition,
+				Status: metav1.ConditionTrue,
+				Reason: controlplanev1.KubeadmControlPlaneCARotatingReason,
+				Message: fmt.Sprintf("Phase %s, rotation cannot move to the next phase while the Cluster has MachinePools: %s",
+					kcp.Status.CARotation.Phase, clog.ObjNamesString(machinePools)),
+			})
+		}
+		return ctrl.Result{RequeueAfter: caRotationRequeueAfter}, nil
+	}
+
+	// Start a new rotation if rotateAfter expired and there was no rotation started after rotateAfter.
+	if startCARotation {
+		log.Info("Starting certificate authorities rotation")
+		if err := r.startCARotationPhase(ctx, controlPlane, controlplanev1.TrustNewCertificateAuthoritiesCARotationPhase); err != nil {
+			setCARotatingInternalErrorCondition(kcp)
+			return ctrl.Result{}, errors.Wrap(err, "failed to start certificate authorities rotation")
+		}
+		kcp.Status.CARotation.StartTime = kcp.Status.CARotation.PhaseStartTime
+		kcp.Status.CARotation.CompletionTime = metav1.Time{}
+		r.recorder.Eventf(kcp, corev1.EventTypeNormal, "CARotationStarted", "Certificate authorities rotation started")
+	}
+
+	// Gets all machines, not just control plane machines.
+	allMachines, err := r.managementCluster.GetMachinesForCluster(ctx, controlPlane.Cluster)
+	if err != nil {
+		setCARotatingInternalErrorCondition(kcp)
+		return ctrl.Result{}, errors.Wrap(err, "failed to rotate certificate authorities")
+	}
+
+	// Move to the next phase when all the Machines have been created after the current phase started.
+	machinesToRollOut := allMachines.Filter(machinesCreatedBefore(kcp.Status.CARotation.PhaseStartTime))
+	if machinesToRollOut.Len() == 0 {
+		nextPhase := nextCARotationPhase(kcp.Status.CARotation.Phase)
+
+		// Before removing the old service account key, give kubelets time to refresh the projected service account
+		// tokens of running Pods, which are still signed with the old key if they were issued before the current phase started.
+		// Note: Tokens which are not refreshed by kubelets, e.g. legacy service account token Secrets, are not valid
+		// anymore once the old key is removed, and they must be regenerated by users.
+		if nextPhase == controlplanev1.RemoveOldCertificateAuthoritiesCARotationPhase {
+			refreshedAfter := kcp.Status.CARotation.PhaseStartTime.Add(caRotationServiceAccountTokensRefreshPeriod)
+			if time.Now().Before(refreshedAfter) {
+				log.Info(fmt.Sprintf("Waiting until %s for service account tokens to be refreshed before removing the old service account key", refreshedAfter.Format(time.RFC3339)))
+				conditions.Set(kcp, metav1.Condition{
+					Type:   controlplanev1.KubeadmControlPlaneCARotatingCondition,
+					Status: metav1.ConditionTrue,
+					Reason: controlplanev1.KubeadmControlPlaneCARotatingReason,
+					Message: fmt.Sprintf("Phase %s, waiting until %s for service account tokens to be refreshed before removing the old service account key",
+						kcp.Status.CARotation.Phase, refreshedAfter.Format(time.RFC3339)),
+				})
+				return ctrl.Result{RequeueAfter: caRotationRequeueAfter}, nil
+			}
+		}
+
+		if nextPhase == controlplanev1.CompletedCARotationPhase {
+			log.Info("Certificate authorities rotation completed")
+			kcp.Status.CARotation.Phase = controlplanev1.CompletedCARotationPhase
+			kcp.Status.CARotation.CompletionTime = metav1.Time{Time: time.Now().UTC().Truncate(time.Second)}
+			r.recorder.Eventf(kcp, corev1.EventTypeNormal, "CARotationCompleted", "Certificate authorities rotation completed")
+			conditions.Set(kcp, metav1.Condition{
+				Type:   controlplanev1.KubeadmControlPlaneCARotatingCondition,
+				Status: metav1.ConditionFalse,
+				Reason: controlplanev1.KubeadmControlPlaneCANotRotatingReason,
+			})
+			return ctrl.Result{}, nil
+		}
+
+		log.Info(fmt.Sprintf("Moving certificate authorities rotation to phase %s", nextPhase))
+		if err := r.startCARotationPhase(ctx, controlPlane, nextPhase); err != nil {
+			setCARotatingInternalErrorCondition(kcp)
+			return ctrl.Result{}, errors.Wrapf(err, "failed to move certificate authorities rotation to phase %s", nextPhase)
+		}
+		machinesToRollOut = allMachines.Filter(machinesCreatedBefore(kcp.Status.CARotation.PhaseStartTime))
+	}
+
+	log.Info(fmt.Sprintf("Waiting for Machines created before certificate authorities rotation phase %s started to be rolled out", kcp.Status.CARotation.Phase),
+		"Machines", clog.ObjNamesString(machinesToRollOut.SortedByCreationTimestamp()))
+	conditions.Set(kcp, metav1.Condition{
+		Type:    controlplanev1.KubeadmControlPlaneCARotatingCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  controlplanev1.KubeadmControlPlaneCARotatingReason,
+		Message: caRotationWaitingForMachinesMessage(kcp.Status.CARotation.Phase, controlPlane.Cluster.Name, machinesToRollOut),
+	})
+	return ctrl.Result{RequeueAfter: caRotationRequeueAfter}, nil
+}
+
+// caRotationWaitingForMachinesMessage returns the message of the CARotating condition while waiting for Machines to be
+// rolled out; it tells users which worker Machines they have to roll out, because KCP only rolls out control plane Machines.
+func caRotationWaitingForMachinesMessage(phase controlplanev1.KubeadmControlPlaneCARotationPhase, clusterName string, machinesToRollOut collections.Machines) string {
+	controlPlaneMachines := machinesToRollOut.Filter(collections.ControlPlaneMachines(clusterName))
+	workerMachines := machinesToRollOut.Filter(collections.Not(collections.ControlPlaneMachines(clusterName)))
+
+	machineDeployments := sets.Set[string]{}
+	standaloneMachines := collections.Machines{}
+	for _, m := range workerMachines {
+		if name, ok := m.Labels[clusterv1.MachineDeploymentNameLabel]; ok && name != "" {
+			machineDeployments.Insert(name)
+			continue
+		}
+		standaloneMachines.Insert(m)
+	}
+
+	msg := fmt.Sprintf("Phase %s, waiting for Machines created before the phase started to be rolled out", phase)
+	if controlPlaneMachines.Len() > 0 {
+		msg += fmt.Sprintf("\n* Control plane Machines, rolled out by KubeadmControlPlane: %s", clog.ObjNamesString(controlPlaneMachines.SortedByCreationTimestamp()))
+	}
+	if machineDeployments.Len() > 0 {
+		msg += fmt.Sprintf("\n* MachineDeployments, set spec.rollout.after to roll them out: %s", clog.StringListToString(sets.List(machineDeployments)))
+	}
+	if standaloneMachines.Len() > 0 {
+		msg += fmt.Sprintf("\n* Worker Machines not owned by a MachineDeployment, delete them to roll them out: %s", clog.ObjNamesString(standaloneMachines.SortedByCreationTimestamp()))
+	}
+	return msg
+}
+
+// getMachinePoolsBlockingCARotation returns the MachinePools of the Cluster, which block the rotation of the certificate authorities.
+func (r *KubeadmControlPlaneReconciler) getMachinePoolsBlockingCARotation(ctx context.Context, controlPlane *internal.ControlPlane) ([]*clusterv1.MachinePool, error) {
+	if !feature.Gates.Enabled(feature.MachinePool) {
+		return nil, nil
+	}
+
+	machinePoolList, err := r.managementCluster.GetMachinePoolsForCluster(ctx, controlPlane.Cluster)
+	if err != nil {
+		return nil, err
+	}
+	machinePools := make([]*clusterv1.MachinePool, 0, len(machinePoolList.Items))
+	for i := range machinePoolList.Items {
+		machinePools = append(machinePools, &machinePoolList.Items[i])
+	}
+	return machinePools, nil
+}
+
+// startCARotationPhase changes the certificate authorities Secrets according to phase, regenerates the kubeconfig
+// Secret and updates the cluster-info ConfigMap in the workload cluster, and then records that phase started.
+// NOTE: All the operations are idempotent, so in case of errors the phase is started again at the next reconcile.
+func (r *KubeadmControlPlaneReconciler) startCARotationPhase(ctx context.Context, controlPlane *internal.ControlPlane, phase controlplanev1.KubeadmControlPlaneCARotationPhase) error {
+	log := ctrl.LoggerFrom(ctx)
+	kcp := controlPlane.KCP
+
+	certificates := secret.NewCertificatesForInitialControlPlane(kcp.Spec.KubeadmConfigSpec.ClusterConfiguration.DeepCopy())
+	if err := certificates.Lookup(ctx, r.Client, util.ObjectKey(controlPlane.Cluster)); err != nil {
+		return errors.Wrap(err, "failed to look up certificate authorities")
+	}
+
+	for _, certificate := range certificates {
+		// Only rotate certificate authorities generated by Cluster API; user-provided ones must be rotated by users.
+		if certificate.External || certificate.Purpose == secret.APIServerEtcdClient ||
+			certificate.Secret == nil || certificate.Secret.Type != clusterv1.ClusterSecretType ||
+			certificate.KeyPair == nil || len(certificate.KeyPair.Key) == 0 {
+			continue
+		}
+
+		var changed bool
+		var err error
+		switch phase {
+		case controlplanev1.TrustNewCertificateAuthoritiesCARotationPhase:
+			changed, err = certificate.AddNextCertificateAuthority()
+		case controlplanev1.SignWithNewCertificateAuthoritiesCARotationPhase:
+			changed, err = certificate.UseNextCertificateAuthority()
+		case controlplanev1.RemoveOldCertificateAuthoritiesCARotationPhase:
+			changed, err = certificate.RemovePreviousCertificateAuthorities()
+		default:
+			return errors.Errorf("unknown certificate authorities rotation phase %s", phase)
+		}
+		if err != nil {
+			return err
+		}
+		if !changed {
+			continue
+		}
+
+		log.Info(fmt.Sprintf("Updating %s certificate Secret for certificate authorities rotation phase %s", certificate.Purpose, phase), "Secret", klog.KObj(certificate.Secret))
+		if err := r.Client.Update(ctx, certificate.Secret); err != nil {
+			return errors.Wrapf(err, "failed to update %s certificate Secret %s", certificate.Purpose, klog.KObj(certificate.Secret))
+		}
+	}
+
+	// Regenerate the kubeconfig Secret, so it trusts the current certificate authorities and its client certificate
+	// is signed by the certificate authority currently used for signing.
+	// NOTE: Only KCP owned kubeconfig Secrets are regenerated, user-provided ones must be updated by users.
+	configSecret, err := secret.GetFromNamespacedName(ctx, r.Client, util.ObjectKey(controlPlane.Cluster), secret.Kubeconfig)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve kubeconfig Secret")
+	}
+	if util.IsControlledBy(configSecret, kcp, controlplanev1.GroupVersion.WithKind(kubeadmControlPlaneKind).GroupKind()) {
+		if err := kubeconfig.RegenerateSecret(ctx, r.Client, configSecret, kubeconfig.KeyEncryptionAlgorithm(controlPlane.GetKeyEncryptionAlgorithm())); err != nil {
+			return errors.Wrap(err, "failed to regenerate kubeconfig")
+		}
+	}
+
+	// Update the cluster-info ConfigMap, so Machines joining the cluster trust the current cluster certificate authorities.
+	if clusterCA := certificates.GetByPurpose(secret.ClusterCA); clusterCA != nil && clusterCA.Secret != nil {
+		workloadCluster, err := controlPlane.GetWorkloadCluster(ctx)
+		if err != nil {
+			return errors.Wrap(err, "cannot get remote client to workload cluster")
+		}
+		if err := workloadCluster.UpdateClusterInfoCertificateAuthorities(ctx, clusterCA.TrustedCertificates()); err != nil {
+			return err
+		}
+	}
+
+	// NOTE: The phase start time is rounded up to the next second, because creation timestamps of Machines are
+	// truncated to the second; this ensures Machines created in the same second before the phase started are rolled out.
+	kcp.Status.CARotation.Phase = phase
+	kcp.Status.CARotation.PhaseStartTime = metav1.Time{Time: time.Now().UTC().Truncate(time.Second).Add(time.Second)}
+	return nil
+}
+
+// shouldStartCARotation returns true if rotateAfter expired and no rotation was started after rotateAfter.
+func shouldStartCARotation(kcp *controlplanev1.KubeadmControlPlane, now time.Time) bool {
+	rotateAfter := kcp.Spec.CARotation.RotateAfter
+	if rotateAfter.IsZero() || now.Before(rotateAfter.Time) {
+		return false
+	}
+	status := kcp.Status.CARotation
+	if status.Phase != "" && status.Phase != controlplanev1.CompletedCARotationPhase {
+		return false
+	}
+	return status.StartTime.IsZero() || status.StartTime.Before(&rotateAfter)
+}
+
+// nextCARotationPhase returns the phase following phase.
+func nextCARotationPhase(phase controlplanev1.KubeadmControlPlaneCARotationPhase) controlplanev1.KubeadmControlPlaneCARotationPhase {
+	switch phase {
+	case controlplanev1.TrustNewCertificateAuthoritiesCARotationPhase:
+		return controlplanev1.SignWithNewCertificateAuthoritiesCARotationPhase
+	case controlplanev1.SignWithNewCertificateAuthoritiesCARotationPhase:
+		return controlplanev1.RemoveOldCertificateAuthoritiesCARotationPhase
+	default:
+		return controlplanev1.CompletedCARotationPhase
+	}
+}
+
+// machinesCreatedBefore returns a filter to find all Machines created before t.
+func machinesCreatedBefore(t metav1.Time) collections.Func {
+	return func(machine *clusterv1.Machine) bool {
+		return machine.CreationTimestamp.Before(&t)
+	}
+}
+
+func setCARotatingInternalErrorCondition(kcp *controlplanev1.KubeadmControlPlane) {
+	conditions.Set(kcp, metav1.Condition{
+		Type:    controlplanev1.KubeadmControlPlaneCARotatingCondition,
+		Status:  metav1.ConditionUnknown,
+		Reason:  controlplanev1.KubeadmControlPlaneCARotatingInternalErrorReason,
+		Message: "Please check controller logs for errors",
+	})
+}
@@ -0,0 +1,392 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"crypto/x509"
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/cert"
+	utilfeature "k8s.io/component-base/featuregate/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/collections"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/kubeconfig"
+	"sigs.k8s.io/cluster-api/util/secret"
+)
+
+func TestReconcileCARotation(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+
+	tests := []struct {
+		name                   string
+		kcp                    func(kcp *controlplanev1.KubeadmControlPlane)
+		machines               collections.Machines
+		machinePools           []string
+		wantResult             ctrl.Result
+		wantPhase              controlplanev1.KubeadmControlPlaneCARotationPhase
+		wantConditionStatus    metav1.ConditionStatus
+		wantConditionMessage   string
+		wantCertificatesBundle bool
+	}{
+		{
+			name:                "does nothing if rotation is not requested",
+			kcp:                 func(_ *controlplanev1.KubeadmControlPlane) {},
+			machines:            collections.FromMachines(machine("m1", withTimestamp(now.Add(-time.Hour)))),
+			wantConditionStatus: metav1.ConditionFalse,
+		},
+		{
+			name: "does nothing if rotateAfter is in the future",
+			kcp: func(kcp *controlplanev1.KubeadmControlPlane) {
+				kcp.Spec.CARotation.RotateAfter = metav1.NewTime(now.Add(time.Hour))
+			},
+			machines:            collections.FromMachines(machine("m1", withTimestamp(now.Add(-time.Hour)))),
+			wantConditionStatus: metav1.ConditionFalse,
+		},
+		{
+			name: "does not start a new rotation if a rotation was already started after rotateAfter",
+			kcp: func(kcp *controlplanev1.KubeadmControlPlane) {
+				kcp.Spec.CARotation.RotateAfter = metav1.NewTime(now.Add(-2 * time.Hour))
+				kcp.Status.CARotation = controlplanev1.KubeadmControlPlaneCARotationStatus{
+					Phase:          controlplanev1.CompletedCARotationPhase,
+					StartTime:      metav1.NewTime(now.Add(-time.Hour)),
+					PhaseStartTime: metav1.NewTime(now.Add(-30 * time.Minute)),
+					CompletionTime: metav1.NewTime(now.Add(-10 * time.Minute)),
+				}
+			},
+			machines:            collections.FromMachines(machine("m1", withTimestamp(now.Add(-time.Minute)))),
+			wantPhase:           controlplanev1.CompletedCARotationPhase,
+			wantConditionStatus: metav1.ConditionFalse,
+		},
+		{
+			name: "starts the rotation if rotateAfter expired",
+			kcp: func(kcp *controlplanev1.KubeadmControlPlane) {
+				kcp.Spec.CARotation.RotateAfter = metav1.NewTime(now.Add(-time.Minute))
+			},
+			machines:            collections.FromMachines(machine("m1", withTimestamp(now.Add(-time.Hour)))),
+			wantResult:          ctrl.Result{RequeueAfter: caRotationRequeueAfter},
+			wantPhase:           controlplanev1.TrustNewCertificateAuthoritiesCARotationPhase,
+			wantConditionStatus: metav1.ConditionTrue,
+			wantConditionMessage: "Phase TrustNewCertificateAuthorities, waiting for Machines created before the phase started to be rolled out\n" +
+				"* Worker Machines not owned by a MachineDeployment, delete them to roll them out: m1",
+			wantCertificatesBundle: true,
+		},
+		{
+			name: "starts a new rotation if rotateAfter is after the start of the previous rotation",
+			kcp: func(kcp *controlplanev1.KubeadmControlPlane) {
+				kcp.Spec.CARotation.RotateAfter = metav1.NewTime(now.Add(-time.Minute))
+				kcp.Status.CARotation = controlplanev1.KubeadmControlPlaneCARotationStatus{
+					Phase:          controlplanev1.CompletedCARotationPhase,
+					StartTime:      metav1.NewTime(now.Add(-time.Hour)),
+					PhaseStartTime: metav1.NewTime(now.Add(-30 * time.Minute)),
+					CompletionTime: metav1.NewTime(now.Add(-10 * time.Minute)),
+				}
+			},
+			machines:            collections.FromMachines(machine("m1", withTimestamp(now.Add(-time.Minute)))),
+			wantResult:          ctrl.Result{RequeueAfter: caRotationRequeueAfter},
+			wantPhase:           controlplanev1.TrustNewCertificateAuthoritiesCARotationPhase,
+			wantConditionStatus: metav1.ConditionTrue,
+			wantConditionMessage: "Phase TrustNewCertificateAuthorities, waiting for Machines created before the phase started to be rolled out\n" +
+				"* Worker Machines not owned by a MachineDeployment, delete them to roll them out: m1",
+			wantCertificatesBundle: true,
+		},
+		{
+			name: "waits for Machines created before the current phase started",
+			kcp: func(kcp *controlplanev1.KubeadmControlPlane) {
+				kcp.Spec.CARotation.RotateAfter = metav1.NewTime(now.Add(-time.Hour))
+				kcp.Status.CARotation = controlplanev1.KubeadmControlPlaneCARotationStatus{
+					Phase:          controlplanev1.SignWithNewCertificateAuthoritiesCARotationPhase,
+					StartTime:      metav1.NewTime(now.Add(-time.Hour)),
+					PhaseStartTime: metav1.NewTime(now.Add(-30 * time.Minute)),
+				}
+			},
+			machines: collections.FromMachines(
+				machine("m1", withTimestamp(now.Add(-time.Hour)), withLabels(map[string]string{clusterv1.MachineControlPlaneLabel: "", clusterv1.ClusterNameLabel: "foo"})),
+				machine("m2", withTimestamp(now.Add(-time.Minute)), withLabels(map[string]string{clusterv1.MachineControlPlaneLabel: "", clusterv1.ClusterNameLabel: "foo"})),
+				machine("m3", withTimestamp(now.Add(-time.Hour)), withLabels(map[string]string{clusterv1.MachineDeploymentNameLabel: "md1"})),
+				machine("m4", withTimestamp(now.Add(-time.Hour)), withLabels(map[string]string{clusterv1.MachineDeploymentNameLabel: "md1"})),
+				machine("m5", withTimestamp(now.Add(-time.Hour)), withLabels(map[string]string{clusterv1.MachineDeploymentNameLabel: "md2"})),
+				machine("m6", withTimestamp(now.Add(-time.Minute)), withLabels(map[string]string{clusterv1.MachineDeploymentNameLabel: "md3"})),
+				machine("m7", withTimestamp(now.Add(-time.Hour))),
+			),
+			wantResult:          ctrl.Result{RequeueAfter: caRotationRequeueAfter},
+			wantPhase:           controlplanev1.SignWithNewCertificateAuthoritiesCARotationPhase,
+			wantConditionStatus: metav1.ConditionTrue,
+			wantConditionMessage: "Phase SignWithNewCertificateAuthorities, waiting for Machines created before the phase started to be rolled out\n" +
+				"* Control plane Machines, rolled out by KubeadmControlPlane: m1\n" +
+				"* MachineDeployments, set spec.rollout.after to roll them out: md1, md2\n" +
+				"* Worker Machines not owned by a MachineDeployment, delete them to roll them out: m7",
+		},
+		{
+			name: "does not start the rotation if the Cluster has MachinePools",
+			kcp: func(kcp *controlplanev1.KubeadmControlPlane) {
+				kcp.Spec.CARotation.RotateAfter = metav1.NewTime(now.Add(-time.Minute))
+			},
+			machines:             collections.FromMachines(machine("m1", withTimestamp(now.Add(-time.Hour)))),
+			machinePools:         []string{"mp1", "mp2"},
+			wantResult:           ctrl.Result{RequeueAfter: caRotationRequeueAfter},
+			wantConditionStatus:  metav1.ConditionFalse,
+			wantConditionMessage: "Rotation cannot start while the Cluster has MachinePools: mp1, mp2",
+		},
+		{
+			name: "does not move to the next phase if the Cluster has MachinePools",
+			kcp: func(kcp *controlplanev1.KubeadmControlPlane) {
+				kcp.Spec.CARotation.RotateAfter = metav1.NewTime(now.Add(-time.Hour))
+				kcp.Status.CARotation = controlplanev1.KubeadmControlPlaneCARotationStatus{
+					Phase:          controlplanev1.TrustNewCertificateAuthoritiesCARotationPhase,
+					StartTime:      metav1.NewTime(now.Add(-time.Hour)),
+					PhaseStartTime: metav1.NewTime(now.Add(-time.Hour)),
+				}
+			},
+			machines:             collections.FromMachines(machine("m1", withTimestamp(now.Add(-time.Minute)))),
+			machinePools:         []string{"mp1"},
+			wantResult:           ctrl.Result{RequeueAfter: caRotationRequeueAfter},
+			wantPhase:            controlplanev1.TrustNewCertificateAuthoritiesCARotationPhase,
+			wantConditionStatus:  metav1.ConditionTrue,
+			wantConditionMessage: "Phase TrustNewCertificateAuthorities, rotation cannot move to the next phase while the Cluster has MachinePools: mp1",
+		},
+		{
+			name: "waits for service account tokens to be refreshed before removing the old certificate authorities",
+			kcp: func(kcp *controlplanev1.KubeadmControlPlane) {
+				kcp.Spec.CARotation.RotateAfter = metav1.NewTime(now.Add(-time.Hour))
+				kcp.Status.CARotation = controlplanev1.KubeadmControlPlaneCARotationStatus{
+					Phase:          controlplanev1.SignWithNewCertificateAuthoritiesCARotationPhase,
+					StartTime:      metav1.NewTime(now.Add(-time.Hour)),
+					PhaseStartTime: metav1.NewTime(now.Add(-30 * time.Minute)),
+				}
+			},
+			machines:            collections.FromMachines(machine("m1", withTimestamp(now.Add(-time.Minute)))),
+			wantResult:          ctrl.Result{RequeueAfter: caRotationRequeueAfter},
+			wantPhase:           controlplanev1.SignWithNewCertificateAuthoritiesCARotationPhase,
+			wantConditionStatus: metav1.ConditionTrue,
+			wantConditionMessage: fmt.Sprintf("Phase SignWithNewCertificateAuthorities, waiting until %s for service account tokens to be refreshed before removing the old service account key",
+				now.Add(-30*time.Minute+caRotationServiceAccountTokensRefreshPeriod).Format(time.RFC3339)),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.MachinePool, true)
+
+			kcp := newCARotationKCP()
+			tt.kcp(kcp)
+			r, controlPlane, workloadCluster := setupCARotation(g, kcp, tt.machines)
+			for _, name := range tt.machinePools {
+				r.managementCluster.(*fakeManagementCluster).MachinePools.Items = append(r.managementCluster.(*fakeManagementCluster).MachinePools.Items,
+					clusterv1.MachinePool{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: metav1.NamespaceDefault}})
+			}
+
+			result, err := r.reconcileCARotation(ctx, controlPlane)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(result).To(Equal(tt.wantResult))
+			g.Expect(kcp.Status.CARotation.Phase).To(Equal(tt.wantPhase))
+
+			c := conditions.Get(kcp, controlplanev1.KubeadmControlPlaneCARotatingCondition)
+			g.Expect(c).ToNot(BeNil())
+			g.Expect(c.Status).To(Equal(tt.wantConditionStatus))
+			g.Expect(c.Message).To(Equal(tt.wantConditionMessage))
+
+			clusterCA := getCertificateSecret(g, r.Client, controlPlane, secret.ClusterCA)
+			if tt.wantCertificatesBundle {
+				g.Expect(kcp.Status.CARotation.StartTime).To(Equal(kcp.Status.CARotation.PhaseStartTime))
+				g.Expect(kcp.Status.CARotation.PhaseStartTime.After(now)).To(BeTrue())
+				g.Expect(kcp.Status.CARotation.CompletionTime.IsZero()).To(BeTrue())
+				g.Expect(parseCerts(g, clusterCA.Data[secret.TLSCrtDataName])).To(HaveLen(1))
+				g.Expect(parseCerts(g, clusterCA.Data[secret.TrustedTLSCrtDataName])).To(HaveLen(2))
+				g.Expect(workloadCluster.clusterInfoCertificateAuthorities).To(Equal(clusterCA.Data[secret.TrustedTLSCrtDataName]))
+				return
+			}
+			g.Expect(parseCerts(g, clusterCA.Data[secret.TLSCrtDataName])).To(HaveLen(1))
+			g.Expect(clusterCA.Data).ToNot(HaveKey(secret.TrustedTLSCrtDataName))
+			g.Expect(workloadCluster.clusterInfoCertificateAuthorities).To(BeNil())
+		})
+	}
+}
+
+func TestReconcileCARotationPhases(t *testing.T) {
+	g := NewWithT(t)
+
+	kcp := newCARotationKCP()
+	kcp.Spec.CARotation.RotateAfter = metav1.NewTime(time.Now().Add(-time.Minute))
+	machines := collections.FromMachines(
+		machine("m1", withTimestamp(time.Now().Add(-time.Hour))),
+		machine("m2", withTimestamp(time.Now().Add(-time.Hour))),
+	)
+	r, controlPlane, workloadCluster := setupCARotation(g, kcp, machines)
+
+	oldClusterCA := getCertificateSecret(g, r.Client, controlPlane, secret.ClusterCA)
+	oldClusterCACerts := parseCerts(g, oldClusterCA.Data[secret.TLSCrtDataName])
+	g.Expect(oldClusterCACerts).To(HaveLen(1))
+
+	// rollOutMachines simulates the replacement of all the Machines created before the current phase started.
+	rollOutMachines := func() {
+		for _, m := range machines {
+			m.CreationTimestamp = metav1.NewTime(kcp.Status.CARotation.PhaseStartTime.Add(time.Minute))
+		}
+	}
+
+	// Phase TrustNewCertificateAuthorities: the new cluster CA is trusted, the old one is still used for signing.
+	// Note: The cluster CA used for signing is always a single certificate, as required by the CSR signer of kube-controller-manager.
+	_, err := r.reconcileCARotation(ctx, controlPlane)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(kcp.Status.CARotation.Phase).To(Equal(controlplanev1.TrustNewCertificateAuthoritiesCARotationPhase))
+	clusterCA := getCertificateSecret(g, r.Client, controlPlane, secret.ClusterCA)
+	g.Expect(clusterCA.Data[secret.TLSCrtDataName]).To(Equal(oldClusterCA.Data[secret.TLSCrtDataName]))
+	clusterCACerts := parseCerts(g, clusterCA.Data[secret.TrustedTLSCrtDataName])
+	g.Expect(clusterCACerts).To(HaveLen(2))
+	g.Expect(clusterCACerts[0]).To(Equal(oldClusterCACerts[0]))
+	newClusterCACert := clusterCACerts[1]
+	g.Expect(clusterCA.Data[secret.TLSKeyDataName]).To(Equal(oldClusterCA.Data[secret.TLSKeyDataName]))
+	g.Expect(workloadCluster.clusterInfoCertificateAuthorities).To(Equal(clusterCA.Data[secret.TrustedTLSCrtDataName]))
+	expectKubeconfig(g, r.Client, controlPlane, clusterCA.Data[secret.TrustedTLSCrtDataName], oldClusterCACerts[0])
+	for _, purpose := range []secret.Purpose{secret.EtcdCA, secret.FrontProxyCA, secret.ServiceAccount} {
+		s := getCertificateSecret(g, r.Client, controlPlane, purpose)
+		g.Expect(s.Data).To(HaveKey(secret.NextTLSCrtDataName))
+		g.Expect(s.Data).To(HaveKey(secret.NextTLSKeyDataName))
+	}
+
+	// The rotation does not move to the next phase until all the Machines are rolled out.
+	phaseStartTime := kcp.Status.CARotation.PhaseStartTime
+	_, err = r.reconcileCARotation(ctx, controlPlane)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(kcp.Status.CARotation.Phase).To(Equal(controlplanev1.TrustNewCertificateAuthoritiesCARotationPhase))
+	g.Expect(kcp.Status.CARotation.PhaseStartTime).To(Equal(phaseStartTime))
+
+	// Phase SignWithNewCertificateAuthorities: the new cluster CA is used for signing, the old one is still trusted.
+	rollOutMachines()
+	_, err = r.reconcileCARotation(ctx, controlPlane)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(kcp.Status.CARotation.Phase).To(Equal(controlplanev1.SignWithNewCertificateAuthoritiesCARotationPhase))
+	clusterCA = getCertificateSecret(g, r.Client, controlPlane, secret.ClusterCA)
+	g.Expect(parseCerts(g, clusterCA.Data[secret.TLSCrtDataName])).To(Equal([]*x509.Certificate{newClusterCACert}))
+	g.Expect(parseCerts(g, clusterCA.Data[secret.TrustedTLSCrtDataName])).To(Equal([]*x509.Certificate{newClusterCACert, oldClusterCACerts[0]}))
+	g.Expect(clusterCA.Data).ToNot(HaveKey(secret.NextTLSKeyDataName))
+	g.Expect(workloadCluster.clusterInfoCertificateAuthorities).To(Equal(clusterCA.Data[secret.TrustedTLSCrtDataName]))
+	expectKubeconfig(g, r.Client, controlPlane, clusterCA.Data[secret.TrustedTLSCrtDataName], newClusterCACert)
+
+	// The rotation does not move to the next phase until service account tokens have been refreshed.
+	rollOutMachines()
+	_, err = r.reconcileCARotation(ctx, controlPlane)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(kcp.Status.CARotation.Phase).To(Equal(controlplanev1.SignWithNewCertificateAuthoritiesCARotationPhase))
+
+	// Phase RemoveOldCertificateAuthorities: the old cluster CA is not trusted anymore.
+	kcp.Status.CARotation.PhaseStartTime = metav1.NewTime(kcp.Status.CARotation.PhaseStartTime.Add(-caRotationServiceAccountTokensRefreshPeriod - time.Minute))
+	rollOutMachines()
+	_, err = r.reconcileCARotation(ctx, controlPlane)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(kcp.Status.CARotation.Phase).To(Equal(controlplanev1.RemoveOldCertificateAuthoritiesCARotationPhase))
+	clusterCA = getCertificateSecret(g, r.Client, controlPlane, secret.ClusterCA)
+	g.Expect(parseCerts(g, clusterCA.Data[secret.TLSCrtDataName])).To(Equal([]*x509.Certificate{newClusterCACert}))
+	g.Expect(clusterCA.Data).ToNot(HaveKey(secret.TrustedTLSCrtDataName))
+	g.Expect(workloadCluster.clusterInfoCertificateAuthorities).To(Equal(clusterCA.Data[secret.TLSCrtDataName]))
+	expectKubeconfig(g, r.Client, controlPlane, clusterCA.Data[secret.TLSCrtDataName], newClusterCACert)
+
+	// Rotation completed.
+	rollOutMachines()
+	result, err := r.reconcileCARotation(ctx, controlPlane)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result).To(Equal(ctrl.Result{}))
+	g.Expect(kcp.Status.CARotation.Phase).To(Equal(controlplanev1.CompletedCARotationPhase))
+	g.Expect(kcp.Status.CARotation.CompletionTime.IsZero()).To(BeFalse())
+	g.Expect(conditions.IsFalse(kcp, controlplanev1.KubeadmControlPlaneCARotatingCondition)).To(BeTrue())
+}
+
+func newCARotationKCP() *controlplanev1.KubeadmControlPlane {
+	return &controlplanev1.KubeadmControlPlane{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: controlplanev1.GroupVersion.String(),
+			Kind:       kubeadmControlPlaneKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "kcp",
+			UID:       "kcp-uid",
+		},
+		Spec: controlplanev1.KubeadmControlPlaneSpec{
+			Version: "v1.30.0",
+		},
+	}
+}
+
+// setupCARotation returns a reconciler with a fake client containing the certificate authorities and the
+// kubeconfig Secrets of the Cluster.
+func setupCARotation(g *WithT, kcp *controlplanev1.KubeadmControlPlane, machines collections.Machines) (*KubeadmControlPlaneReconciler, *internal.ControlPlane, *fakeWorkloadCluster) {
+	cluster := newCluster(&types.NamespacedName{Name: "foo", Namespace: metav1.NamespaceDefault})
+	controllerRef := metav1.NewControllerRef(kcp, controlplanev1.GroupVersion.WithKind(kubeadmControlPlaneKind))
+
+	fakeClient := newFakeClient()
+	certificates := secret.NewCertificatesForInitialControlPlane(&kcp.Spec.KubeadmConfigSpec.ClusterConfiguration)
+	g.Expect(certificates.LookupOrGenerate(ctx, fakeClient, util.ObjectKey(cluster), *controllerRef)).To(Succeed())
+	g.Expect(kubeconfig.CreateSecretWithOwner(ctx, fakeClient, util.ObjectKey(cluster), "https://1.2.3.4:6443", *controllerRef)).To(Succeed())
+
+	workloadCluster := &fakeWorkloadCluster{}
+	managementCluster := &fakeManagementCluster{
+		Machines:     machines,
+		MachinePools: &clusterv1.MachinePoolList{},
+		Workload:     workloadCluster,
+	}
+	r := &KubeadmControlPlaneReconciler{
+		Client:              fakeClient,
+		SecretCachingClient: fakeClient,
+		managementCluster:   managementCluster,
+		recorder:            record.NewFakeRecorder(32),
+	}
+
+	controlPlane, err := internal.NewControlPlane(ctx, managementCluster, fakeClient, cluster, kcp, collections.Machines{})
+	g.Expect(err).ToNot(HaveOccurred())
+	return r, controlPlane, workloadCluster
+}
+
+func getCertificateSecret(g *WithT, c client.Client, controlPlane *internal.ControlPlane, purpose secret.Purpose) *corev1.Secret {
+	s, err := secret.GetFromNamespacedName(ctx, c, util.ObjectKey(controlPlane.Cluster), purpose)
+	g.Expect(err).ToNot(HaveOccurred())
+	return s
+}
+
+// expectKubeconfig checks that the kubeconfig trusts caData and that its client certificate is signed by signingCA.
+func expectKubeconfig(g *WithT, c client.Client, controlPlane *internal.ControlPlane, caData []byte, signingCA *x509.Certificate) {
+	s := getCertificateSecret(g, c, controlPlane, secret.Kubeconfig)
+	config, err := clientcmd.Load(s.Data[secret.KubeconfigDataName])
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(config.Clusters[controlPlane.Cluster.Name].CertificateAuthorityData).To(Equal(caData))
+	clientCerts := parseCerts(g, config.AuthInfos[controlPlane.Cluster.Name+"-admin"].ClientCertificateData)
+	g.Expect(clientCerts).To(HaveLen(1))
+	g.Expect(clientCerts[0].CheckSignatureFrom(signingCA)).To(Succeed())
+}
+
+func parseCerts(g *WithT, data []byte) []*x509.Certificate {
+	certificates, err := cert.ParseCertsPEM(data)
+	g.Expect(err).ToNot(HaveOccurred())
+	return certificates
+}
@@ -30,4 +30,22 @@ const (
 	// dependentCertRequeueAfter is how long to wait before checking again to see if
 	// dependent certificates have been created.
 	dependentCertRequeueAfter = 30 * time.Second
+
+	// etcdRestoreRequeueAfter is how long to wait before checking again to see if
+	// the control plane machine restoring etcd from a snapshot is healthy.
+	etcdRestoreRequeueAfter = 30 * time.Second
+
+	// etcdDefragmentationRequeueAfter is how long to wait after defragmenting an etcd member
+	// before defragmenting the next one.
+	etcdDefragmentationRequeueAfter = 30 * time.Second
+
+	// caRotationRequeueAfter is how long to wait before checking again to see if
+	// all the Machines have been rolled out during a certificate authorities rotation.
+	caRotationRequeueAfter = 1 * time.Minute
+
+	// caRotationServiceAccountTokensRefreshPeriod is the minimum duration of the SignWithNewCertificateAuthorities
+	// phase of a certificate authorities rotation, so kubelets have time to refresh the projected service account tokens
+	// signed with the old service account key before it is removed.
+	// Note: kubelets refresh projected tokens when 80% of their TTL has passed; the default TTL is one hour.
+	caRotationServiceAccountTokensRefreshPeriod = 1 * time.Hour
 )
@@ -98,9 +98,19 @@ type KubeadmControlPlaneReconciler struct {
 
 	RemoteConditionsGracePeriod time.Duration
 
+	// EtcdSnapshotVolumePath is the path where the PersistentVolumeClaim used by the PersistentVolumeClaim
+	// etcd snapshot storage type is mounted.
+	EtcdSnapshotVolumePath string
+
+	// EtcdRestoreMaxInlineSnapshotSize is the max size of a snapshot, base64 encoded, that can be embedded in the bootstrap
+	// data of the Machine restoring etcd, which is required for storage types not supporting download URLs; 0 disables it.
+	EtcdRestoreMaxInlineSnapshotSize int
+
 	managementCluster         internal.ManagementCluster
 	managementClusterUncached internal.ManagementCluster
 	ssaCache                  ssa.Cache
+	etcdSnapshotFailureCache  cache.Cache[etcdSnapshotFailureEntry]
+	etcdSnapshots             etcdSnapshotTracker
 
 	// Only used for testing.
 	overrideTryInPlaceUpdateFunc       func(ctx context.Context, controlPlane *internal.ControlPlane, machineToInPlaceUpdate *clusterv1.Machine, machineUpToDateResult internal.UpToDateResult) (bool, ctrl.Result, error)
@@ -156,6 +166,7 @@ func (r *KubeadmControlPlaneReconciler) SetupWithManager(ctx context.Context, mg
 	r.controller = c
 	r.recorder = mgr.GetEventRecorderFor("kubeadmcontrolplane-controller")
 	r.ssaCache = ssa.NewCache("kubeadmcontrolplane")
+	r.etcdSnapshotFailureCache = cache.New[etcdSnapshotFailureEntry](etcdSnapshotRetryInterval)
 
 	if r.managementCluster == nil {
 		r.managementCluster = &internal.Management{
@@ -183,6 +194,8 @@ func (r *KubeadmControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.
 	kcp := &controlplanev1.KubeadmControlPlane{}
 	if err := r.Client.Get(ctx, req.NamespacedName, kcp); err != nil {
 		if apierrors.IsNotFound(err) {
+			// Drop the result of the etcd snapshot taken in background for the deleted KubeadmControlPlane, if any.
+			r.etcdSnapshots.delete(req.NamespacedName)
 			return ctrl.Result{}, nil
 		}
 		return ctrl.Result{}, err
@@ -373,6 +386,9 @@ func patchKubeadmControlPlane(ctx context.Context, patchHelper *patch.Helper, kc
 			controlplanev1.KubeadmControlPlaneScalingUpCondition,
 			controlplanev1.KubeadmControlPlaneScalingDownCondition,
 			controlplanev1.KubeadmControlPlaneRemediatingCondition,
+			controlplanev1.KubeadmControlPlaneCARotatingCondition,
+			controlplanev1.KubeadmControlPlaneEtcdSnapshotSucceededCondition,
+			controlplanev1.KubeadmControlPlaneEtcdDefragmentationSucceededCondition,
 			controlplanev1.KubeadmControlPlaneDeletingCondition,
 		}},
 	)
@@ -464,6 +480,12 @@ func (r *KubeadmControlPlaneReconciler) reconcile(ctx context.Context, controlPl
 		return ctrl.Result{}, err
 	}
 
+	// Restore etcd from a snapshot if requested; while the restore is in progress, all the other operations are blocked.
+	// NOTE: The restore replaces all the control plane Machines, so it must be done before operations on etcd members.
+	if result, err := r.reconcileEtcdRestore(ctx, controlPlane); err != nil || !result.IsZero() {
+		return result, err
+	}
+
 	// Ensures the number of etcd members is in sync with the number of machines/nodes.
 	// NOTE: This is usually required after a machine deletion.
 	if err := r.reconcileEtcdMembers(ctx, controlPlane); err != nil {
@@ -494,6 +516,14 @@ func (r *KubeadmControlPlaneReconciler) reconcile(ctx context.Context, controlPl
 		return result, err
 	}
 
+	// Recover etcd members from NOSPACE alarms if defragmentation is enabled; while etcd members are recovering,
+	// all the other operations are blocked, because the etcd cluster only accepts reads and deletes.
+	// Note: This requires that all the etcd members are reachable, so it is done after MHC remediation to ensure
+	// it doesn't block remediation of the control plane Machines hosting unreachable etcd members.
+	if result, err := r.reconcileEtcdAlarms(ctx, controlPlane); err != nil || !result.IsZero() {
+		return result, err
+	}
+
 	// Wait for in-place update to complete.
 	// Note: If a Machine becomes unhealthy during in-place update reconcileUnhealthyMachines above remediates it.
 	// Note: We have to wait here even if there are no more Machines that need rollout (in-place update in
@@ -580,7 +610,24 @@ func (r *KubeadmControlPlaneReconciler) reconcile(ctx context.Context, controlPl
 	if err := r.reconcileCertificateExpiries(ctx, controlPlane); err != nil {
 		return ctrl.Result{}, err
 	}
-	return ctrl.Result{}, nil
+
+	// Take etcd snapshots and defragment etcd members if enabled.
+	// Note: For the same reasons as above this is done at the end of the reconcile; this also ensures
+	// that snapshots are taken and members are defragmented only when the control plane is stable, e.g. not during rollouts.
+	// Note: Failures taking etcd snapshots and defragmenting etcd members are surfaced in the EtcdSnapshotSucceeded
+	// and EtcdDefragmentationSucceeded conditions and retried later, so they don't block each other and the rotation
+	// of certificate authorities.
+	snapshotResult := r.reconcileEtcdSnapshot(ctx, controlPlane)
+	defragmentationResult := r.reconcileEtcdDefragmentation(ctx, controlPlane)
+
+	// Rotate certificate authorities if requested.
+	// Note: For the same reasons as above this is done at the end of the reconcile; this also ensures that
+	// the rotation moves to the next phase only when the control plane is stable, e.g. not during rollouts.
+	caRotationResult, err := r.reconcileCARotation(ctx, controlPlane)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	return util.LowestNonZeroResult(util.LowestNonZeroResult(snapshotResult, defragmentationResult), caRotationResult), nil
 }
 
 // reconcileClusterCertificates ensures that all the cluster certificates exists and
@@ -0,0 +1,279 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/etcd"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// reconcileEtcdAlarms recovers etcd members from NOSPACE alarms, one member at a time, by compacting the etcd key space,
+// defragmenting the member and then disarming the alarm.
+// NOTE: NOSPACE alarms are recovered only if defragmentation is enabled.
+func (r *KubeadmControlPlaneReconciler) reconcileEtcdAlarms(ctx context.Context, controlPlane *internal.ControlPlane) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	kcp := controlPlane.KCP
+
+	// Return if defragmentation is not enabled, or if etcd is not managed by KCP.
+	if kcp.Spec.Etcd.Defragmentation.IntervalSeconds == 0 || !controlPlane.IsEtcdManaged() {
+		return ctrl.Result{}, nil
+	}
+
+	// Return if there are no NOSPACE alarms.
+	// NOTE: Alarms are read from etcd while computing conditions.
+	alarmedMemberIDs := []uint64{}
+	for _, alarm := range controlPlane.EtcdAlarms {
+		if alarm.Type == etcd.AlarmNoSpace {
+			alarmedMemberIDs = append(alarmedMemberIDs, alarm.MemberID)
+		}
+	}
+	if len(alarmedMemberIDs) == 0 {
+		return ctrl.Result{}, nil
+	}
+
+	workloadCluster, err := controlPlane.GetWorkloadCluster(ctx)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to recover etcd from NOSPACE alarms: cannot get remote client to workload cluster")
+	}
+
+	// NOTE: EtcdMembersDBStatus fails if any etcd member is not reachable; this ensures that members are defragmented
+	// only when all the other members are available, so defragmentation never puts etcd quorum at risk.
+	// In this case recovery is retried later, e.g. after unreachable members have been remediated.
+	members, err := workloadCluster.EtcdMembersDBStatus(ctx)
+	if err != nil {
+		log.Info("Cannot recover etcd from NOSPACE alarms: waiting for all the etcd members to be reachable", "err", err)
+		return ctrl.Result{RequeueAfter: etcdDefragmentationRequeueAfter}, nil
+	}
+	setEtcdMembersStatus(kcp, members)
+
+	sortEtcdMembersForDefragmentation(members)
+	i := slices.IndexFunc(members, func(m internal.EtcdMemberDBStatus) bool { return slices.Contains(alarmedMemberIDs, m.ID) })
+	if i < 0 {
+		// NOTE: This should never happen, because all the etcd members are hosted on control plane nodes.
+		log.Info("Cannot recover etcd from NOSPACE alarms: alarms are raised by etcd members not hosted on control plane Nodes")
+		return ctrl.Result{}, nil
+	}
+	member := members[i]
+
+	log.Info("Recovering etcd member from NOSPACE alarm", "Node", member.Name, "dbSize", member.DBSize, "dbSizeInUse", member.DBSizeInUse)
+	if err := workloadCluster.CompactEtcd(ctx); err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to recover etcd member on Node %s from NOSPACE alarm", member.Name)
+	}
+	if err := defragmentEtcdMember(ctx, workloadCluster, kcp, member.Name); err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to recover etcd member on Node %s from NOSPACE alarm", member.Name)
+	}
+	if err := workloadCluster.DisarmEtcdMemberNoSpaceAlarm(ctx, member.Name); err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to recover etcd member on Node %s from NOSPACE alarm", member.Name)
+	}
+	r.recorder.Eventf(kcp, corev1.EventTypeNormal, "EtcdAlarmDisarmed", "NOSPACE alarm of etcd member on Node %s disarmed", member.Name)
+
+	// Requeue to recover other members, if any, and to give etcd time to settle after the member has been defragmented.
+	return ctrl.Result{RequeueAfter: etcdDefragmentationRequeueAfter}, nil
+}
+
+// reconcileEtcdDefragmentation checks the database size of etcd members when the defragmentation interval expired since the last check,
+// and defragments, one at a time, the members with fragmentation exceeding the configured threshold.
+// NOTE: Failures are surfaced in the EtcdDefragmentationSucceeded condition and retried after etcdDefragmentationRequeueAfter,
+// but they are not returned as errors, so they do not block other operations, e.g. the rotation of certificate authorities.
+func (r *KubeadmControlPlaneReconciler) reconcileEtcdDefragmentation(ctx context.Context, controlPlane *internal.ControlPlane) ctrl.Result {
+	log := ctrl.LoggerFrom(ctx)
+	kcp := controlPlane.KCP
+	defragmentationSpec := kcp.Spec.Etcd.Defragmentation
+
+	// Return if defragmentation is not enabled, or if etcd is not managed by KCP.
+	// NOTE: Info about etcd members are reported only when defragmentation is enabled.
+	if defragmentationSpec.IntervalSeconds == 0 || !controlPlane.IsEtcdManaged() {
+		kcp.Status.Etcd.Members = nil
+		kcp.Status.Etcd.LastDefragmentationCheckTime = metav1.Time{}
+		conditions.Delete(kcp, controlplanev1.KubeadmControlPlaneEtcdDefragmentationSucceededCondition)
+		return ctrl.Result{}
+	}
+
+	// Return if KCP is not yet initialized (no etcd to defragment).
+	if !ptr.Deref(kcp.Status.Initialization.ControlPlaneInitialized, false) {
+		return ctrl.Result{}
+	}
+
+	interval := time.Duration(defragmentationSpec.IntervalSeconds) * time.Second
+	now := time.Now().UTC().Truncate(time.Second)
+	lastCheckTime := kcp.Status.Etcd.LastDefragmentationCheckTime.Time
+	if !lastCheckTime.IsZero() {
+		if nextCheckTime := lastCheckTime.Add(interval); now.Before(nextCheckTime) {
+			return ctrl.Result{RequeueAfter: nextCheckTime.Sub(now)}
+		}
+	}
+
+	// Defer defragmentation if the etcd cluster is not healthy.
+	if !conditions.IsTrue(kcp, controlplanev1.KubeadmControlPlaneEtcdClusterHealthyCondition) {
+		log.Info("Waiting for the etcd cluster to be healthy before checking if etcd members must be defragmented")
+		return ctrl.Result{RequeueAfter: etcdDefragmentationRequeueAfter}
+	}
+
+	workloadCluster, err := controlPlane.GetWorkloadCluster(ctx)
+	if err != nil {
+		setEtcdDefragmentationFailedCondition(ctx, kcp, errors.Wrap(err, "cannot get remote client to workload cluster"))
+		return ctrl.Result{RequeueAfter: etcdDefragmentationRequeueAfter}
+	}
+
+	// NOTE: EtcdMembersDBStatus fails if any etcd member is not reachable; this ensures that members are defragmented
+	// only when all the other members are available, so defragmentation never puts etcd quorum at risk.
+	members, err := workloadCluster.EtcdMembersDBStatus(ctx)
+	if err != nil {
+		setEtcdDefragmentationFailedCondition(ctx, kcp, err)
+		return ctrl.Result{RequeueAfter: etcdDefragmentationRequeueAfter}
+	}
+	setEtcdMembersStatus(kcp, members)
+
+	// Skip defragmentation if etcd has a single member, because while the only member is defragmented etcd
+	// does not serve requests, and thus all the writes to the workload cluster are blocked.
+	// NOTE: The only member is still defragmented to recover from NOSPACE alarms, when writes are blocked anyway.
+	if len(members) < 2 {
+		log.V(4).Info("Skipping defragmentation of etcd: defragmenting the only etcd member blocks all writes")
+		kcp.Status.Etcd.LastDefragmentationCheckTime = metav1.NewTime(now)
+		conditions.Delete(kcp, controlplanev1.KubeadmControlPlaneEtcdDefragmentationSucceededCondition)
+		return ctrl.Result{RequeueAfter: interval}
+	}
+
+	minFragmentationPercent := ptr.Deref(defragmentationSpec.MinFragmentationPercent, controlplanev1.DefaultEtcdMinFragmentationPercent)
+	candidates := etcdMembersToDefragment(kcp, members, minFragmentationPercent)
+	if len(candidates) == 0 {
+		kcp.Status.Etcd.LastDefragmentationCheckTime = metav1.NewTime(now)
+		conditions.Set(kcp, metav1.Condition{
+			Type:   controlplanev1.KubeadmControlPlaneEtcdDefragmentationSucceededCondition,
+			Status: metav1.ConditionTrue,
+			Reason: controlplanev1.KubeadmControlPlaneEtcdDefragmentationSucceededReason,
+		})
+		return ctrl.Result{RequeueAfter: interval}
+	}
+
+	// Defragment one member at a time; the next member, if any, is defragmented at the next reconcile.
+	// NOTE: The last check time is updated only after all the members exceeding the threshold have been defragmented.
+	member := candidates[0]
+	log.Info("Defragmenting etcd member", "Node", member.Name, "dbSize", member.DBSize, "dbSizeInUse", member.DBSizeInUse, "minFragmentationPercent", minFragmentationPercent)
+	if err := defragmentEtcdMember(ctx, workloadCluster, kcp, member.Name); err != nil {
+		r.recorder.Eventf(kcp, corev1.EventTypeWarning, "EtcdMemberDefragmentationFailed", "Failed to defragment etcd member on Node %s: %v", member.Name, err)
+		setEtcdDefragmentationFailedCondition(ctx, kcp, errors.Wrapf(err, "failed to defragment etcd member on Node %s", member.Name))
+		return ctrl.Result{RequeueAfter: etcdDefragmentationRequeueAfter}
+	}
+	r.recorder.Eventf(kcp, corev1.EventTypeNormal, "EtcdMemberDefragmented", "Etcd member on Node %s defragmented", member.Name)
+	conditions.Set(kcp, metav1.Condition{
+		Type:   controlplanev1.KubeadmControlPlaneEtcdDefragmentationSucceededCondition,
+		Status: metav1.ConditionTrue,
+		Reason: controlplanev1.KubeadmControlPlaneEtcdDefragmentationSucceededReason,
+	})
+
+	return ctrl.Result{RequeueAfter: etcdDefragmentationRequeueAfter}
+}
+
+// setEtcdDefragmentationFailedCondition logs a failure defragmenting etcd and surfaces it in the EtcdDefragmentationSucceeded condition.
+func setEtcdDefragmentationFailedCondition(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane, err error) {
+	log := ctrl.LoggerFrom(ctx)
+	log.Error(err, "Failed to defragment etcd")
+	conditions.Set(kcp, metav1.Condition{
+		Type:    controlplanev1.KubeadmControlPlaneEtcdDefragmentationSucceededCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  controlplanev1.KubeadmControlPlaneEtcdDefragmentationFailedReason,
+		Message: fmt.Sprintf("Failed to defragment etcd: %v", err),
+	})
+}
+
+// etcdMembersToDefragment returns the etcd members with fragmentation exceeding minFragmentationPercent, in the order they
+// should be defragmented. Members already defragmented since the last check are excluded.
+func etcdMembersToDefragment(kcp *controlplanev1.KubeadmControlPlane, members []internal.EtcdMemberDBStatus, minFragmentationPercent int32) []internal.EtcdMemberDBStatus {
+	candidates := []internal.EtcdMemberDBStatus{}
+	for _, member := range members {
+		if member.DBSize <= 0 {
+			continue
+		}
+		if (member.DBSize-member.DBSizeInUse)*100/member.DBSize < int64(minFragmentationPercent) {
+			continue
+		}
+		if i := slices.IndexFunc(kcp.Status.Etcd.Members, func(m controlplanev1.EtcdMemberStatus) bool { return m.Name == member.Name }); i >= 0 {
+			if kcp.Status.Etcd.Members[i].LastDefragmentationTime.After(kcp.Status.Etcd.LastDefragmentationCheckTime.Time) {
+				continue
+			}
+		}
+		candidates = append(candidates, member)
+	}
+	sortEtcdMembersForDefragmentation(candidates)
+	return candidates
+}
+
+// sortEtcdMembersForDefragmentation sorts etcd members by name, with the leader last.
+// NOTE: The leader is defragmented last to minimize the number of leader elections.
+func sortEtcdMembersForDefragmentation(members []internal.EtcdMemberDBStatus) {
+	slices.SortFunc(members, func(a, b internal.EtcdMemberDBStatus) int {
+		if a.IsLeader != b.IsLeader {
+			if a.IsLeader {
+				return 1
+			}
+			return -1
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+}
+
+// defragmentEtcdMember defragments an etcd member and reports its database size after defragmentation in the KCP status.
+func defragmentEtcdMember(ctx context.Context, workloadCluster internal.WorkloadCluster, kcp *controlplanev1.KubeadmControlPlane, name string) error {
+	member, err := workloadCluster.DefragmentEtcdMember(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	now := metav1.NewTime(time.Now().UTC().Truncate(time.Second))
+	for i := range kcp.Status.Etcd.Members {
+		if kcp.Status.Etcd.Members[i].Name != member.Name {
+			continue
+		}
+		kcp.Status.Etcd.Members[i].DBSizeBytes = ptr.To(member.DBSize)
+		kcp.Status.Etcd.Members[i].DBSizeInUseBytes = ptr.To(member.DBSizeInUse)
+		kcp.Status.Etcd.Members[i].LastDefragmentationTime = now
+	}
+	return nil
+}
+
+// setEtcdMembersStatus reports the database size of etcd members in the KCP status, preserving the last defragmentation time.
+func setEtcdMembersStatus(kcp *controlplanev1.KubeadmControlPlane, members []internal.EtcdMemberDBStatus) {
+	memberStatuses := make([]controlplanev1.EtcdMemberStatus, 0, len(members))
+	for _, member := range members {
+		memberStatus := controlplanev1.EtcdMemberStatus{
+			Name:             member.Name,
+			DBSizeBytes:      ptr.To(member.DBSize),
+			DBSizeInUseBytes: ptr.To(member.DBSizeInUse),
+		}
+		if i := slices.IndexFunc(kcp.Status.Etcd.Members, func(m controlplanev1.EtcdMemberStatus) bool { return m.Name == member.Name }); i >= 0 {
+			memberStatus.LastDefragmentationTime = kcp.Status.Etcd.Members[i].LastDefragmentationTime
+		}
+		memberStatuses = append(memberStatuses, memberStatus)
+	}
+	slices.SortFunc(memberStatuses, func(a, b controlplanev1.EtcdMemberStatus) int { return strings.Compare(a.Name, b.Name) })
+	kcp.Status.Etcd.Members = memberStatuses
+}
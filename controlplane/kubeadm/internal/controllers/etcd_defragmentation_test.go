@@ -0,0 +1,423 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/etcd"
+	"sigs.k8s.io/cluster-api/util/collections"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestReconcileEtcdAlarms(t *testing.T) {
+	cluster := newCluster(&types.NamespacedName{Name: "foo", Namespace: metav1.NamespaceDefault})
+	kcp := func(transforms ...func(kcp *controlplanev1.KubeadmControlPlane)) *controlplanev1.KubeadmControlPlane {
+		kcp := &controlplanev1.KubeadmControlPlane{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: metav1.NamespaceDefault,
+				Name:      "kcp",
+			},
+			Spec: controlplanev1.KubeadmControlPlaneSpec{
+				Version: "v1.30.0",
+				Etcd: controlplanev1.KubeadmControlPlaneEtcdSpec{
+					Defragmentation: controlplanev1.KubeadmControlPlaneEtcdDefragmentationSpec{
+						IntervalSeconds: 3600,
+					},
+				},
+			},
+		}
+		for _, transform := range transforms {
+			transform(kcp)
+		}
+		return kcp
+	}
+	members := []internal.EtcdMemberDBStatus{
+		{Name: "cp1", ID: 1, IsLeader: true, DBSize: 2000, DBSizeInUse: 500},
+		{Name: "cp2", ID: 2, DBSize: 2000, DBSizeInUse: 500},
+		{Name: "cp3", ID: 3, DBSize: 2000, DBSizeInUse: 500},
+	}
+
+	tests := []struct {
+		name                       string
+		kcp                        *controlplanev1.KubeadmControlPlane
+		alarms                     []etcd.MemberAlarm
+		etcdMembersDBStatusErr     error
+		wantErr                    string
+		wantResult                 ctrl.Result
+		wantDefragmentedEtcdMember string
+	}{
+		{
+			name: "does nothing if defragmentation is not enabled",
+			kcp: kcp(func(kcp *controlplanev1.KubeadmControlPlane) {
+				kcp.Spec.Etcd = controlplanev1.KubeadmControlPlaneEtcdSpec{}
+			}),
+			alarms: []etcd.MemberAlarm{{MemberID: 2, Type: etcd.AlarmNoSpace}},
+		},
+		{
+			name: "does nothing if etcd is external",
+			kcp: kcp(func(kcp *controlplanev1.KubeadmControlPlane) {
+				kcp.Spec.KubeadmConfigSpec.ClusterConfiguration.Etcd.External = bootstrapv1.ExternalEtcd{
+					Endpoints: []string{"https://etcd:2379"},
+				}
+			}),
+			alarms: []etcd.MemberAlarm{{MemberID: 2, Type: etcd.AlarmNoSpace}},
+		},
+		{
+			name:   "does nothing if there are no NOSPACE alarms",
+			kcp:    kcp(),
+			alarms: []etcd.MemberAlarm{{MemberID: 2, Type: etcd.AlarmCorrupt}},
+		},
+		{
+			name:                   "requeues if some etcd members are not reachable",
+			kcp:                    kcp(),
+			alarms:                 []etcd.MemberAlarm{{MemberID: 2, Type: etcd.AlarmNoSpace}},
+			etcdMembersDBStatusErr: errors.New("failed to create etcd client for Node cp3"),
+			wantResult:             ctrl.Result{RequeueAfter: etcdDefragmentationRequeueAfter},
+		},
+		{
+			name:                       "recovers the etcd member with the NOSPACE alarm",
+			kcp:                        kcp(),
+			alarms:                     []etcd.MemberAlarm{{MemberID: 2, Type: etcd.AlarmNoSpace}},
+			wantResult:                 ctrl.Result{RequeueAfter: etcdDefragmentationRequeueAfter},
+			wantDefragmentedEtcdMember: "cp2",
+		},
+		{
+			name:                       "recovers followers before the leader",
+			kcp:                        kcp(),
+			alarms:                     []etcd.MemberAlarm{{MemberID: 1, Type: etcd.AlarmNoSpace}, {MemberID: 3, Type: etcd.AlarmNoSpace}},
+			wantResult:                 ctrl.Result{RequeueAfter: etcdDefragmentationRequeueAfter},
+			wantDefragmentedEtcdMember: "cp3",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			fakeClient := newFakeClient()
+			workloadCluster := &fakeWorkloadCluster{
+				EtcdMembersDBStatusResult: members,
+				EtcdMembersDBStatusErr:    tt.etcdMembersDBStatusErr,
+			}
+			managementCluster := &fakeManagementCluster{
+				Workload: workloadCluster,
+			}
+			r := &KubeadmControlPlaneReconciler{
+				Client:              fakeClient,
+				SecretCachingClient: fakeClient,
+				managementCluster:   managementCluster,
+				recorder:            record.NewFakeRecorder(32),
+			}
+
+			controlPlane, err := internal.NewControlPlane(ctx, managementCluster, fakeClient, cluster, tt.kcp, collections.Machines{})
+			g.Expect(err).ToNot(HaveOccurred())
+			controlPlane.EtcdAlarms = tt.alarms
+
+			result, err := r.reconcileEtcdAlarms(ctx, controlPlane)
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(tt.wantErr))
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(result).To(Equal(tt.wantResult))
+
+			if tt.wantDefragmentedEtcdMember == "" {
+				g.Expect(workloadCluster.compactEtcdCalled).To(Equal(0))
+				g.Expect(workloadCluster.defragmentedEtcdMembers).To(BeEmpty())
+				g.Expect(workloadCluster.disarmedEtcdMembers).To(BeEmpty())
+				g.Expect(tt.kcp.Status.Etcd.Members).To(BeEmpty())
+				return
+			}
+			g.Expect(workloadCluster.compactEtcdCalled).To(Equal(1))
+			g.Expect(workloadCluster.defragmentedEtcdMembers).To(Equal([]string{tt.wantDefragmentedEtcdMember}))
+			g.Expect(workloadCluster.disarmedEtcdMembers).To(Equal([]string{tt.wantDefragmentedEtcdMember}))
+			g.Expect(tt.kcp.Status.Etcd.Members).To(HaveLen(3))
+			for _, m := range tt.kcp.Status.Etcd.Members {
+				if m.Name == tt.wantDefragmentedEtcdMember {
+					g.Expect(m.DBSizeBytes).To(Equal(ptr.To[int64](500)))
+					g.Expect(m.LastDefragmentationTime.IsZero()).To(BeFalse())
+					continue
+				}
+				g.Expect(m.DBSizeBytes).To(Equal(ptr.To[int64](2000)))
+				g.Expect(m.LastDefragmentationTime.IsZero()).To(BeTrue())
+			}
+		})
+	}
+}
+
+func TestReconcileEtcdDefragmentation(t *testing.T) {
+	cluster := newCluster(&types.NamespacedName{Name: "foo", Namespace: metav1.NamespaceDefault})
+	kcp := func(transforms ...func(kcp *controlplanev1.KubeadmControlPlane)) *controlplanev1.KubeadmControlPlane {
+		kcp := &controlplanev1.KubeadmControlPlane{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: metav1.NamespaceDefault,
+				Name:      "kcp",
+			},
+			Spec: controlplanev1.KubeadmControlPlaneSpec{
+				Version: "v1.30.0",
+				Etcd: controlplanev1.KubeadmControlPlaneEtcdSpec{
+					Defragmentation: controlplanev1.KubeadmControlPlaneEtcdDefragmentationSpec{
+						IntervalSeconds: 3600,
+					},
+				},
+			},
+			Status: controlplanev1.KubeadmControlPlaneStatus{
+				Initialization: controlplanev1.KubeadmControlPlaneInitializationStatus{
+					ControlPlaneInitialized: ptr.To(true),
+				},
+			},
+		}
+		conditions.Set(kcp, metav1.Condition{
+			Type:   controlplanev1.KubeadmControlPlaneEtcdClusterHealthyCondition,
+			Status: metav1.ConditionTrue,
+			Reason: controlplanev1.KubeadmControlPlaneEtcdClusterHealthyReason,
+		})
+		for _, transform := range transforms {
+			transform(kcp)
+		}
+		return kcp
+	}
+	fragmentedMembers := []internal.EtcdMemberDBStatus{
+		{Name: "cp1", ID: 1, IsLeader: true, DBSize: 2000, DBSizeInUse: 500},
+		{Name: "cp2", ID: 2, DBSize: 2000, DBSizeInUse: 1500},
+		{Name: "cp3", ID: 3, DBSize: 2000, DBSizeInUse: 500},
+	}
+	lastCheckTime := metav1.NewTime(time.Now().Add(-2 * time.Hour).UTC().Truncate(time.Second))
+
+	tests := []struct {
+		name                        string
+		kcp                         *controlplanev1.KubeadmControlPlane
+		members                     []internal.EtcdMemberDBStatus
+		membersErr                  error
+		defragmentErr               error
+		wantResult                  func(g *WithT, result ctrl.Result)
+		wantDefragmentedEtcdMembers []string
+		wantMembers                 []string
+		wantLastCheckTimeUpdated    bool
+		wantConditionReason         string
+	}{
+		{
+			name: "cleans up status if defragmentation is not enabled",
+			kcp: kcp(func(kcp *controlplanev1.KubeadmControlPlane) {
+				kcp.Spec.Etcd = controlplanev1.KubeadmControlPlaneEtcdSpec{}
+				kcp.Status.Etcd.LastDefragmentationCheckTime = lastCheckTime
+				kcp.Status.Etcd.Members = []controlplanev1.EtcdMemberStatus{{Name: "cp1"}}
+			}),
+			wantResult: func(g *WithT, result ctrl.Result) {
+				g.Expect(result.IsZero()).To(BeTrue())
+			},
+			wantLastCheckTimeUpdated: true,
+		},
+		{
+			name: "does nothing if the control plane is not initialized",
+			kcp: kcp(func(kcp *controlplanev1.KubeadmControlPlane) {
+				kcp.Status.Initialization.ControlPlaneInitialized = nil
+			}),
+			wantResult: func(g *WithT, result ctrl.Result) {
+				g.Expect(result.IsZero()).To(BeTrue())
+			},
+		},
+		{
+			name: "requeues if the interval since the last check did not expire",
+			kcp: kcp(func(kcp *controlplanev1.KubeadmControlPlane) {
+				kcp.Status.Etcd.LastDefragmentationCheckTime = metav1.NewTime(time.Now().Add(-10 * time.Minute))
+			}),
+			members: fragmentedMembers,
+			wantResult: func(g *WithT, result ctrl.Result) {
+				g.Expect(result.RequeueAfter).To(BeNumerically("~", 50*time.Minute, time.Minute))
+			},
+		},
+		{
+			name: "requeues if the etcd cluster is not healthy",
+			kcp: kcp(func(kcp *controlplanev1.KubeadmControlPlane) {
+				conditions.Set(kcp, metav1.Condition{
+					Type:   controlplanev1.KubeadmControlPlaneEtcdClusterHealthyCondition,
+					Status: metav1.ConditionFalse,
+					Reason: controlplanev1.KubeadmControlPlaneEtcdClusterNotHealthyReason,
+				})
+			}),
+			members: fragmentedMembers,
+			wantResult: func(g *WithT, result ctrl.Result) {
+				g.Expect(result.RequeueAfter).To(Equal(etcdDefragmentationRequeueAfter))
+			},
+		},
+		{
+			name: "updates the last check time if no members exceed the threshold",
+			kcp: kcp(func(kcp *controlplanev1.KubeadmControlPlane) {
+				kcp.Status.Etcd.LastDefragmentationCheckTime = lastCheckTime
+			}),
+			members: []internal.EtcdMemberDBStatus{
+				{Name: "cp1", ID: 1, IsLeader: true, DBSize: 2000, DBSizeInUse: 1500},
+				{Name: "cp2", ID: 2, DBSize: 2000, DBSizeInUse: 1500},
+			},
+			wantResult: func(g *WithT, result ctrl.Result) {
+				g.Expect(result.RequeueAfter).To(Equal(time.Hour))
+			},
+			wantMembers:              []string{"cp1", "cp2"},
+			wantLastCheckTimeUpdated: true,
+			wantConditionReason:      controlplanev1.KubeadmControlPlaneEtcdDefragmentationSucceededReason,
+		},
+		{
+			name: "does not defragment etcd with a single member",
+			kcp: kcp(func(kcp *controlplanev1.KubeadmControlPlane) {
+				kcp.Status.Etcd.LastDefragmentationCheckTime = lastCheckTime
+			}),
+			members: []internal.EtcdMemberDBStatus{
+				{Name: "cp1", ID: 1, IsLeader: true, DBSize: 2000, DBSizeInUse: 500},
+			},
+			wantResult: func(g *WithT, result ctrl.Result) {
+				g.Expect(result.RequeueAfter).To(Equal(time.Hour))
+			},
+			wantMembers:              []string{"cp1"},
+			wantLastCheckTimeUpdated: true,
+		},
+		{
+			name: "reports a failure if some etcd members are not reachable",
+			kcp: kcp(func(kcp *controlplanev1.KubeadmControlPlane) {
+				kcp.Status.Etcd.LastDefragmentationCheckTime = lastCheckTime
+			}),
+			membersErr: errors.New("etcd member cp2 not reachable"),
+			wantResult: func(g *WithT, result ctrl.Result) {
+				g.Expect(result.RequeueAfter).To(Equal(etcdDefragmentationRequeueAfter))
+			},
+			wantConditionReason: controlplanev1.KubeadmControlPlaneEtcdDefragmentationFailedReason,
+		},
+		{
+			name: "reports a failure if defragmenting an etcd member fails",
+			kcp: kcp(func(kcp *controlplanev1.KubeadmControlPlane) {
+				kcp.Status.Etcd.LastDefragmentationCheckTime = lastCheckTime
+			}),
+			members:       fragmentedMembers,
+			defragmentErr: errors.New("context deadline exceeded"),
+			wantResult: func(g *WithT, result ctrl.Result) {
+				g.Expect(result.RequeueAfter).To(Equal(etcdDefragmentationRequeueAfter))
+			},
+			wantDefragmentedEtcdMembers: []string{"cp3"},
+			wantMembers:                 []string{"cp1", "cp2", "cp3"},
+			wantConditionReason:         controlplanev1.KubeadmControlPlaneEtcdDefragmentationFailedReason,
+		},
+		{
+			name: "defragments the first follower exceeding the threshold",
+			kcp: kcp(func(kcp *controlplanev1.KubeadmControlPlane) {
+				kcp.Status.Etcd.LastDefragmentationCheckTime = lastCheckTime
+			}),
+			members: fragmentedMembers,
+			wantResult: func(g *WithT, result ctrl.Result) {
+				g.Expect(result.RequeueAfter).To(Equal(etcdDefragmentationRequeueAfter))
+			},
+			wantDefragmentedEtcdMembers: []string{"cp3"},
+			wantMembers:                 []string{"cp1", "cp2", "cp3"},
+
+			wantConditionReason: controlplanev1.KubeadmControlPlaneEtcdDefragmentationSucceededReason,
+		},
+		{
+			name: "defragments the leader last",
+			kcp: kcp(func(kcp *controlplanev1.KubeadmControlPlane) {
+				kcp.Status.Etcd.LastDefragmentationCheckTime = lastCheckTime
+				kcp.Status.Etcd.Members = []controlplanev1.EtcdMemberStatus{
+					{Name: "cp3", LastDefragmentationTime: metav1.NewTime(time.Now().Add(-time.Minute))},
+				}
+			}),
+			members: fragmentedMembers,
+			wantResult: func(g *WithT, result ctrl.Result) {
+				g.Expect(result.RequeueAfter).To(Equal(etcdDefragmentationRequeueAfter))
+			},
+			wantDefragmentedEtcdMembers: []string{"cp1"},
+			wantMembers:                 []string{"cp1", "cp2", "cp3"},
+
+			wantConditionReason: controlplanev1.KubeadmControlPlaneEtcdDefragmentationSucceededReason,
+		},
+		{
+			name: "honors minFragmentationPercent",
+			kcp: kcp(func(kcp *controlplanev1.KubeadmControlPlane) {
+				kcp.Spec.Etcd.Defragmentation.MinFragmentationPercent = ptr.To[int32](20)
+				kcp.Status.Etcd.LastDefragmentationCheckTime = lastCheckTime
+				kcp.Status.Etcd.Members = []controlplanev1.EtcdMemberStatus{
+					{Name: "cp1", LastDefragmentationTime: metav1.NewTime(time.Now().Add(-time.Minute))},
+					{Name: "cp3", LastDefragmentationTime: metav1.NewTime(time.Now().Add(-time.Minute))},
+				}
+			}),
+			members: fragmentedMembers,
+			wantResult: func(g *WithT, result ctrl.Result) {
+				g.Expect(result.RequeueAfter).To(Equal(etcdDefragmentationRequeueAfter))
+			},
+			wantDefragmentedEtcdMembers: []string{"cp2"},
+			wantMembers:                 []string{"cp1", "cp2", "cp3"},
+
+			wantConditionReason: controlplanev1.KubeadmControlPlaneEtcdDefragmentationSucceededReason,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			fakeClient := newFakeClient()
+			workloadCluster := &fakeWorkloadCluster{
+				EtcdMembersDBStatusResult: tt.members,
+				EtcdMembersDBStatusErr:    tt.membersErr,
+				DefragmentEtcdMemberErr:   tt.defragmentErr,
+			}
+			managementCluster := &fakeManagementCluster{
+				Workload: workloadCluster,
+			}
+			r := &KubeadmControlPlaneReconciler{
+				Client:              fakeClient,
+				SecretCachingClient: fakeClient,
+				managementCluster:   managementCluster,
+				recorder:            record.NewFakeRecorder(32),
+			}
+
+			lastDefragmentationCheckTime := tt.kcp.Status.Etcd.LastDefragmentationCheckTime
+			controlPlane, err := internal.NewControlPlane(ctx, managementCluster, fakeClient, cluster, tt.kcp, collections.Machines{})
+			g.Expect(err).ToNot(HaveOccurred())
+
+			result := r.reconcileEtcdDefragmentation(ctx, controlPlane)
+			tt.wantResult(g, result)
+
+			g.Expect(workloadCluster.defragmentedEtcdMembers).To(Equal(tt.wantDefragmentedEtcdMembers))
+			var memberNames []string
+			for _, m := range tt.kcp.Status.Etcd.Members {
+				memberNames = append(memberNames, m.Name)
+			}
+			g.Expect(memberNames).To(Equal(tt.wantMembers))
+			if tt.wantLastCheckTimeUpdated {
+				g.Expect(tt.kcp.Status.Etcd.LastDefragmentationCheckTime).ToNot(Equal(lastDefragmentationCheckTime))
+			} else {
+				g.Expect(tt.kcp.Status.Etcd.LastDefragmentationCheckTime).To(Equal(lastDefragmentationCheckTime))
+			}
+			condition := conditions.Get(tt.kcp, controlplanev1.KubeadmControlPlaneEtcdDefragmentationSucceededCondition)
+			if tt.wantConditionReason == "" {
+				g.Expect(condition).To(BeNil())
+			} else {
+				g.Expect(condition).ToNot(BeNil())
+				g.Expect(condition.Reason).To(Equal(tt.wantConditionReason))
+			}
+		})
+	}
+}
@@ -0,0 +1,470 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/etcd/snapshot"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+const (
+	// etcdRestoreDir is the directory on the Machine restoring etcd where the restore script and the snapshot are stored.
+	etcdRestoreDir = "/etc/kubernetes/etcd-restore"
+
+	// etcdRestoreSnapshotKey is the key of the snapshot in the Secret created to pass the snapshot to the Machine restoring etcd.
+	etcdRestoreSnapshotKey = "snapshot"
+
+	// etcdRestoreSnapshotURLKey is the key of the URL to download the snapshot in the Secret created to pass the snapshot
+	// to the Machine restoring etcd.
+	// NOTE: The URL allows to download the snapshot without credentials, so it is passed via a Secret instead of being
+	// set in the KubeadmConfig of the Machine.
+	etcdRestoreSnapshotURLKey = "snapshotURL"
+
+	// etcdRestoreSnapshotURLExpiry is how long the URL used by the Machine restoring etcd to download the snapshot is valid.
+	etcdRestoreSnapshotURLExpiry = 24 * time.Hour
+
+	// defaultEtcdDataDir is the default etcd data directory used by kubeadm.
+	defaultEtcdDataDir = "/var/lib/etcd"
+)
+
+// etcdRestoreScriptTemplate is the script restoring etcd from a snapshot on the Machine restoring etcd, before kubeadm init.
+// The etcd static Pod manifest is generated upfront to get the etcd configuration for the Machine, e.g. the member name,
+// the peer URL, the data directory and the image; then the snapshot is restored using etcdutl from the etcd image.
+// Finally, kubeadm init starts etcd with the restored data, which is possible by ignoring the DirAvailable preflight
+// check for the etcd data directory.
+// NOTE: The script requires containerd as container runtime, crictl and ctr, as well as curl if the snapshot is downloaded.
+var etcdRestoreScriptTemplate = template.Must(template.New("etcd-restore").Parse(`#!/bin/bash
+# Restores etcd from the snapshot {{ .SnapshotName }} before kubeadm init.
+# This script is generated by the KubeadmControlPlane controller.
+set -o errexit
+set -o nounset
+set -o pipefail
+
+DIR={{ .Dir }}
+MANIFEST=/etc/kubernetes/manifests/etcd.yaml
+{{- if .DownloadSnapshot }}
+
+curl --fail --silent --show-error --location --retry 5 --output "${DIR}/snapshot.db.gz" "$(cat "${DIR}/snapshot-url")"
+rm -f "${DIR}/snapshot-url"
+{{- end }}
+gunzip --force "${DIR}/snapshot.db.gz"
+
+kubeadm init phase etcd local --config /run/kubeadm/kubeadm.yaml
+etcd_flag() {
+  sed -n "s/^ *- --$1=//p" "${MANIFEST}"
+}
+NAME="$(etcd_flag name)"
+DATA_DIR="$(etcd_flag data-dir)"
+PEER_URL="$(etcd_flag initial-advertise-peer-urls)"
+IMAGE="$(sed -n 's/^ *image: *//p' "${MANIFEST}")"
+rm -f "${MANIFEST}"
+
+crictl pull "${IMAGE}"
+rm -rf "${DATA_DIR}"
+ctr --namespace k8s.io run --rm \
+  --mount "type=bind,src=${DIR},dst=${DIR},options=rbind:ro" \
+  --mount "type=bind,src=$(dirname "${DATA_DIR}"),dst=$(dirname "${DATA_DIR}"),options=rbind:rw" \
+  "${IMAGE}" etcd-restore \
+  etcdutl snapshot restore "${DIR}/snapshot.db" \
+  --name "${NAME}" \
+  --initial-cluster "${NAME}=${PEER_URL}" \
+  --initial-advertise-peer-urls "${PEER_URL}" \
+  --data-dir "${DATA_DIR}"
+rm -f "${DIR}/snapshot.db"
+`))
+
+// reconcileEtcdRestore restores etcd from the snapshot defined in spec.etcd.restore.
+// The restore starts only after users confirm it via the EtcdRestoreConfirmedAnnotation; then it deletes all the control plane Machines, then creates a single Machine which restores etcd from
+// the snapshot before running kubeadm init. When etcd on this Machine is healthy, the restore is completed
+// and the regular reconcile scales up the control plane to the desired number of replicas.
+// NOTE: While a restore is in progress, this func returns a non-zero result and all the other KCP operations are blocked.
+func (r *KubeadmControlPlaneReconciler) reconcileEtcdRestore(ctx context.Context, controlPlane *internal.ControlPlane) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	kcp := controlPlane.KCP
+	restoreSpec := kcp.Spec.Etcd.Restore
+	restoreStatus := &kcp.Status.Etcd.Restore
+
+	// If restore is not set, cleanup info about previous restores, so the same snapshot can be restored again.
+	if restoreSpec.SnapshotName == "" {
+		if restoreStatus.SnapshotName != "" {
+			if err := r.deleteEtcdRestoreSecret(ctx, kcp); err != nil {
+				return ctrl.Result{}, err
+			}
+			*restoreStatus = controlplanev1.EtcdRestoreStatus{}
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// Return if etcd is not managed by KCP (this is also prevented by the KCP webhook).
+	if !controlPlane.IsEtcdManaged() {
+		return ctrl.Result{}, nil
+	}
+
+	// Return if the restore is already completed.
+	if restoreStatus.SnapshotName == restoreSpec.SnapshotName && !restoreStatus.CompletionTime.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	store, err := snapshot.NewStore(ctx, r.Client, kcp, r.EtcdSnapshotVolumePath)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to restore etcd from snapshot %s", restoreSpec.SnapshotName)
+	}
+
+	// Start the restore, if not already started.
+	if restoreStatus.SnapshotName != restoreSpec.SnapshotName {
+		// Ensure the snapshot exists before deleting the control plane Machines.
+		infos, err := store.List(ctx)
+		if err != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to restore etcd from snapshot %s", restoreSpec.SnapshotName)
+		}
+		found := false
+		for _, info := range infos {
+			if info.Name == restoreSpec.SnapshotName {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return ctrl.Result{}, errors.Errorf("failed to restore etcd from snapshot %s: snapshot does not exist", restoreSpec.SnapshotName)
+		}
+
+		// Wait for users to confirm the restore, given that it deletes all the control plane Machines skipping drain,
+		// wait for volume detach and the KCP pre-terminate hook.
+		if kcp.Annotations[controlplanev1.EtcdRestoreConfirmedAnnotation] != restoreSpec.SnapshotName {
+			log.Info(fmt.Sprintf("Waiting for the %s annotation to be set to %s before restoring etcd; the restore deletes all the control plane Machines",
+				controlplanev1.EtcdRestoreConfirmedAnnotation, restoreSpec.SnapshotName))
+			r.recorder.Eventf(kcp, corev1.EventTypeWarning, "EtcdRestoreNotConfirmed", "Restoring etcd from snapshot %s requires the %s annotation to be set to %s",
+				restoreSpec.SnapshotName, controlplanev1.EtcdRestoreConfirmedAnnotation, restoreSpec.SnapshotName)
+			return ctrl.Result{}, nil
+		}
+
+		// Ensure the snapshot can be passed to the Machine restoring etcd before deleting the control plane Machines.
+		if err := r.createEtcdRestoreSecret(ctx, kcp, store, restoreSpec.SnapshotName); err != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to restore etcd from snapshot %s", restoreSpec.SnapshotName)
+		}
+
+		*restoreStatus = controlplanev1.EtcdRestoreStatus{
+			SnapshotName: restoreSpec.SnapshotName,
+			StartTime:    metav1.Now(),
+		}
+		log.Info(fmt.Sprintf("Restoring etcd from snapshot %s", restoreSpec.SnapshotName))
+		r.recorder.Eventf(kcp, corev1.EventTypeNormal, "EtcdRestoreStarted", "Restoring etcd from snapshot %s", restoreSpec.SnapshotName)
+	}
+
+	var restoreMachine *clusterv1.Machine
+	if m, ok := controlPlane.Machines[restoreStatus.Machine]; ok && m.DeletionTimestamp.IsZero() {
+		restoreMachine = m
+	}
+
+	// Delete all the control plane Machines, except the Machine restoring etcd.
+	// Note: This also deletes the Machine restoring etcd if it is already being deleted, e.g. by a user; in this case
+	// a new Machine restoring etcd will be created.
+	machinesToDelete := controlPlane.Machines.Filter(func(machine *clusterv1.Machine) bool {
+		return restoreMachine == nil || machine.Name != restoreMachine.Name
+	})
+	if machinesToDelete.Len() > 0 {
+		for _, machine := range machinesToDelete {
+			if err := r.deleteMachineForEtcdRestore(ctx, machine); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+		log.Info("Waiting for control plane Machines to be deleted before restoring etcd", "machines", strings.Join(machinesToDelete.Names(), ", "))
+		return ctrl.Result{RequeueAfter: deleteRequeueAfter}, nil
+	}
+
+	// Create the Machine restoring etcd, if it does not exist yet.
+	if restoreMachine == nil {
+		return r.createEtcdRestoreMachine(ctx, controlPlane, store)
+	}
+
+	// Wait for etcd on the Machine restoring etcd to be healthy.
+	if !restoreMachine.Status.NodeRef.IsDefined() ||
+		!conditions.IsTrue(restoreMachine, controlplanev1.KubeadmControlPlaneMachineEtcdMemberHealthyCondition) ||
+		!conditions.IsTrue(restoreMachine, controlplanev1.KubeadmControlPlaneMachineAPIServerPodHealthyCondition) {
+		log.Info(fmt.Sprintf("Waiting for Machine %s to restore etcd from snapshot %s", restoreMachine.Name, restoreStatus.SnapshotName), "Machine", klog.KObj(restoreMachine))
+		return ctrl.Result{RequeueAfter: etcdRestoreRequeueAfter}, nil
+	}
+
+	// Delete the control plane Nodes of the Machines deleted before the restore; they are part of the snapshot.
+	workloadCluster, err := controlPlane.GetWorkloadCluster(ctx)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to complete etcd restore: cannot get remote client to workload cluster")
+	}
+	removedNodes, err := workloadCluster.RemoveStaleControlPlaneNodes(ctx, []string{restoreMachine.Status.NodeRef.Name})
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to complete etcd restore")
+	}
+	if len(removedNodes) > 0 {
+		log.Info("Deleted control plane Nodes of Machines deleted before the etcd restore", "nodes", strings.Join(removedNodes, ", "))
+	}
+
+	if err := r.deleteEtcdRestoreSecret(ctx, kcp); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	restoreStatus.CompletionTime = metav1.Now()
+	log.Info(fmt.Sprintf("Etcd restored from snapshot %s", restoreStatus.SnapshotName))
+	r.recorder.Eventf(kcp, corev1.EventTypeNormal, "EtcdRestoreCompleted", "Etcd restored from snapshot %s", restoreStatus.SnapshotName)
+
+	// Requeue the control plane, so it can be scaled up to the desired number of replicas.
+	return ctrl.Result{Requeue: true}, nil
+}
+
+// deleteMachineForEtcdRestore deletes a control plane Machine before restoring etcd.
+// Given that the etcd cluster is going to be replaced and that the workload cluster is most probably not reachable,
+// drain and wait for volume detach are skipped, and the KCP pre-terminate hook is removed.
+func (r *KubeadmControlPlaneReconciler) deleteMachineForEtcdRestore(ctx context.Context, machine *clusterv1.Machine) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	_, hasPreTerminateHook := machine.Annotations[controlplanev1.PreTerminateHookCleanupAnnotation]
+	_, hasExcludeNodeDraining := machine.Annotations[clusterv1.ExcludeNodeDrainingAnnotation]
+	_, hasExcludeWaitForNodeVolumeDetach := machine.Annotations[clusterv1.ExcludeWaitForNodeVolumeDetachAnnotation]
+	if hasPreTerminateHook || !hasExcludeNodeDraining || !hasExcludeWaitForNodeVolumeDetach {
+		machineOriginal := machine.DeepCopy()
+		if machine.Annotations == nil {
+			machine.Annotations = map[string]string{}
+		}
+		delete(machine.Annotations, controlplanev1.PreTerminateHookCleanupAnnotation)
+		machine.Annotations[clusterv1.ExcludeNodeDrainingAnnotation] = ""
+		machine.Annotations[clusterv1.ExcludeWaitForNodeVolumeDetachAnnotation] = ""
+		if err := r.Client.Patch(ctx, machine, client.MergeFrom(machineOriginal)); err != nil {
+			return errors.Wrapf(err, "failed to delete control plane Machine %s before restoring etcd", klog.KObj(machine))
+		}
+	}
+
+	if !machine.DeletionTimestamp.IsZero() {
+		return nil
+	}
+	if err := r.Client.Delete(ctx, machine); err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "failed to delete control plane Machine %s before restoring etcd", klog.KObj(machine))
+	}
+	log.Info(fmt.Sprintf("Machine %s deleting (etcd restore)", machine.Name), "Machine", klog.KObj(machine))
+	return nil
+}
+
+// createEtcdRestoreMachine creates the Machine restoring etcd from the snapshot.
+func (r *KubeadmControlPlaneReconciler) createEtcdRestoreMachine(ctx context.Context, controlPlane *internal.ControlPlane, store snapshot.Store) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	kcp := controlPlane.KCP
+	snapshotName := kcp.Status.Etcd.Restore.SnapshotName
+
+	// Make the snapshot available to the Machine; the Secret is updated in case the Machine restoring etcd is re-created,
+	// so the URL to download the snapshot, if any, is not expired.
+	if err := r.createEtcdRestoreSecret(ctx, kcp, store, snapshotName); err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to restore etcd from snapshot %s", snapshotName)
+	}
+
+	_, downloadSnapshot := store.(snapshot.URLStore)
+	mutateKubeadmConfig, err := etcdRestoreKubeadmConfigMutator(kcp, snapshotName, downloadSnapshot)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to restore etcd from snapshot %s", snapshotName)
+	}
+
+	fd, err := controlPlane.NextFailureDomainForScaleUp(ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	newMachine, err := r.cloneConfigsAndGenerateMachine(ctx, controlPlane.Cluster, kcp, false, fd, mutateKubeadmConfig)
+	if err != nil {
+		log.Error(err, "Failed to create control plane Machine restoring etcd")
+		r.recorder.Eventf(kcp, corev1.EventTypeWarning, "FailedEtcdRestore", "Failed to create control plane Machine restoring etcd from snapshot %s for cluster %s control plane: %v", snapshotName, klog.KObj(controlPlane.Cluster), err)
+		return ctrl.Result{}, err
+	}
+	kcp.Status.Etcd.Restore.Machine = newMachine.Name
+
+	log.WithValues(controlPlane.StatusToLogKeyAndValues(newMachine, nil)...).
+		Info(fmt.Sprintf("Machine %s created (etcd restore)", newMachine.Name),
+			"Machine", klog.KObj(newMachine),
+			newMachine.Spec.InfrastructureRef.Kind, klog.KRef(newMachine.Namespace, newMachine.Spec.InfrastructureRef.Name),
+			newMachine.Spec.Bootstrap.ConfigRef.Kind, klog.KRef(newMachine.Namespace, newMachine.Spec.Bootstrap.ConfigRef.Name))
+
+	// Requeue the control plane, in case there are additional operations to perform
+	return ctrl.Result{Requeue: true}, nil
+}
+
+// etcdRestoreKubeadmConfigMutator returns a func adding to the KubeadmConfig of the Machine restoring etcd the script
+// restoring the snapshot before kubeadm init, as well as either the URL to download the snapshot, if downloadSnapshot
+// is true, or the snapshot itself; both of them are read from the Secret created by createEtcdRestoreSecret.
+func etcdRestoreKubeadmConfigMutator(kcp *controlplanev1.KubeadmControlPlane, snapshotName string, downloadSnapshot bool) (func(*bootstrapv1.KubeadmConfig), error) {
+	script := &bytes.Buffer{}
+	if err := etcdRestoreScriptTemplate.Execute(script, map[string]any{
+		"Dir":              etcdRestoreDir,
+		"SnapshotName":     snapshotName,
+		"DownloadSnapshot": downloadSnapshot,
+	}); err != nil {
+		return nil, errors.Wrap(err, "failed to generate etcd restore script")
+	}
+
+	dataDir := kcp.Spec.KubeadmConfigSpec.ClusterConfiguration.Etcd.Local.DataDir
+	if dataDir == "" {
+		dataDir = defaultEtcdDataDir
+	}
+
+	return func(kubeadmConfig *bootstrapv1.KubeadmConfig) {
+		if kubeadmConfig.Annotations == nil {
+			kubeadmConfig.Annotations = map[string]string{}
+		}
+		kubeadmConfig.Annotations[bootstrapv1.EtcdRestoreAnnotation] = snapshotName
+
+		kubeadmConfig.Spec.Files = append(kubeadmConfig.Spec.Files, bootstrapv1.File{
+			Path:        etcdRestoreDir + "/restore.sh",
+			Owner:       "root:root",
+			Permissions: "0700",
+			Content:     script.String(),
+		})
+		if downloadSnapshot {
+			kubeadmConfig.Spec.Files = append(kubeadmConfig.Spec.Files, bootstrapv1.File{
+				Path:        etcdRestoreDir + "/snapshot-url",
+				Owner:       "root:root",
+				Permissions: "0600",
+				ContentFrom: bootstrapv1.FileSource{
+					Secret: bootstrapv1.SecretFileSource{
+						Name: etcdRestoreSecretName(kcp),
+						Key:  etcdRestoreSnapshotURLKey,
+					},
+				},
+			})
+		} else {
+			kubeadmConfig.Spec.Files = append(kubeadmConfig.Spec.Files, bootstrapv1.File{
+				Path:        etcdRestoreDir + "/snapshot.db.gz",
+				Owner:       "root:root",
+				Permissions: "0600",
+				Encoding:    bootstrapv1.Base64,
+				ContentFrom: bootstrapv1.FileSource{
+					Secret: bootstrapv1.SecretFileSource{
+						Name: etcdRestoreSecretName(kcp),
+						Key:  etcdRestoreSnapshotKey,
+					},
+				},
+			})
+		}
+
+		// Restore etcd after all the other pre kubeadm commands, e.g. after commands configuring the container runtime.
+		kubeadmConfig.Spec.PreKubeadmCommands = append(kubeadmConfig.Spec.PreKubeadmCommands, etcdRestoreDir+"/restore.sh")
+
+		// Allow kubeadm init to use the etcd data directory with the restored data.
+		kubeadmConfig.Spec.InitConfiguration.NodeRegistration.IgnorePreflightErrors = append(kubeadmConfig.Spec.InitConfiguration.NodeRegistration.IgnorePreflightErrors,
+			"DirAvailable-"+strings.ReplaceAll(dataDir, "/", "-"))
+	}, nil
+}
+
+func etcdRestoreSecretName(kcp *controlplanev1.KubeadmControlPlane) string {
+	return fmt.Sprintf("%s-etcd-restore", kcp.Name)
+}
+
+// createEtcdRestoreSecret creates the Secret passing the snapshot to the Machine restoring etcd, either as a URL to
+// download the snapshot, if supported by the store, or as the snapshot itself.
+// NOTE: The snapshot itself ends up in the bootstrap data of the Machine, so it can be passed only if it is not bigger
+// than EtcdRestoreMaxInlineSnapshotSize, which must be set according to the bootstrap data size limits of the
+// infrastructure provider. The snapshot is stored base64 encoded, because the content of files in bootstrap data
+// can't be binary.
+func (r *KubeadmControlPlaneReconciler) createEtcdRestoreSecret(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane, store snapshot.Store, snapshotName string) error {
+	var key string
+	data := &bytes.Buffer{}
+	if urlStore, ok := store.(snapshot.URLStore); ok {
+		u, err := urlStore.GetURL(snapshotName, etcdRestoreSnapshotURLExpiry)
+		if err != nil {
+			return err
+		}
+		key = etcdRestoreSnapshotURLKey
+		data.WriteString(u)
+	} else {
+		maxSize := min(r.EtcdRestoreMaxInlineSnapshotSize, snapshot.MaxSecretSnapshotSize)
+		if maxSize <= 0 {
+			return errors.Errorf("snapshots from the %s storage are embedded in the bootstrap data of the Machine restoring etcd, "+
+				"which requires the --etcd-restore-max-inline-snapshot-size flag to be set according to the bootstrap data size limits "+
+				"of the infrastructure provider; please set the flag or use the S3 storage", kcp.Spec.Etcd.Snapshot.Storage.Type)
+		}
+
+		key = etcdRestoreSnapshotKey
+		encoder := base64.NewEncoder(base64.StdEncoding, data)
+		if err := store.Get(ctx, snapshotName, encoder); err != nil {
+			return err
+		}
+		if err := encoder.Close(); err != nil {
+			return errors.Wrap(err, "failed to encode snapshot")
+		}
+		if data.Len() > maxSize {
+			return errors.Errorf("snapshot is %d bytes when encoded, but it can be embedded in bootstrap data only up to %d bytes; please use the S3 storage",
+				data.Len(), maxSize)
+		}
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: kcp.Namespace,
+			Name:      etcdRestoreSecretName(kcp),
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(kcp, controlplanev1.GroupVersion.WithKind(kubeadmControlPlaneKind)),
+			},
+		},
+		Data: map[string][]byte{
+			key: data.Bytes(),
+		},
+	}
+	if err := r.Client.Create(ctx, secret); err != nil {
+		if !apierrors.IsAlreadyExists(err) {
+			return errors.Wrapf(err, "failed to create Secret %s", klog.KObj(secret))
+		}
+
+		// The Secret is left over from a previous attempt to create the Machine restoring etcd; update it.
+		existing := &corev1.Secret{}
+		if err := r.Client.Get(ctx, client.ObjectKeyFromObject(secret), existing); err != nil {
+			return errors.Wrapf(err, "failed to get Secret %s", klog.KObj(secret))
+		}
+		existing.Data = secret.Data
+		if err := r.Client.Update(ctx, existing); err != nil {
+			return errors.Wrapf(err, "failed to update Secret %s", klog.KObj(secret))
+		}
+	}
+	return nil
+}
+
+// deleteEtcdRestoreSecret deletes the Secret passing the snapshot to the Machine restoring etcd, if any.
+func (r *KubeadmControlPlaneReconciler) deleteEtcdRestoreSecret(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane) error {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: kcp.Namespace,
+			Name:      etcdRestoreSecretName(kcp),
+		},
+	}
+	if err := r.Client.Delete(ctx, secret); err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "failed to delete Secret %s", klog.KObj(secret))
+	}
+	return nil
+}
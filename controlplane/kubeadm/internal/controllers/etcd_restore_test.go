@@ -0,0 +1,463 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/etcd/snapshot"
+	"sigs.k8s.io/cluster-api/util/collections"
+)
+
+func TestReconcileEtcdRestore(t *testing.T) {
+	cluster := newCluster(&types.NamespacedName{Name: "foo", Namespace: metav1.NamespaceDefault})
+	snapshotName := snapshot.Name("kcp", time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC))
+	kcp := func(transforms ...func(kcp *controlplanev1.KubeadmControlPlane)) *controlplanev1.KubeadmControlPlane {
+		kcp := &controlplanev1.KubeadmControlPlane{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: metav1.NamespaceDefault,
+				Name:      "kcp",
+				Annotations: map[string]string{
+					controlplanev1.EtcdRestoreConfirmedAnnotation: snapshotName,
+				},
+			},
+			Spec: controlplanev1.KubeadmControlPlaneSpec{
+				Version: "v1.30.0",
+				Etcd: controlplanev1.KubeadmControlPlaneEtcdSpec{
+					Snapshot: controlplanev1.KubeadmControlPlaneEtcdSnapshotSpec{
+						IntervalSeconds: 3600,
+						Storage: controlplanev1.KubeadmControlPlaneEtcdSnapshotStorage{
+							Type: controlplanev1.SecretEtcdSnapshotStorageType,
+						},
+					},
+					Restore: controlplanev1.KubeadmControlPlaneEtcdRestoreSpec{
+						SnapshotName: snapshotName,
+					},
+				},
+			},
+		}
+		for _, transform := range transforms {
+			transform(kcp)
+		}
+		return kcp
+	}
+	restoreStarted := func(machineName string) func(kcp *controlplanev1.KubeadmControlPlane) {
+		return func(kcp *controlplanev1.KubeadmControlPlane) {
+			kcp.Status.Etcd.Restore = controlplanev1.EtcdRestoreStatus{
+				SnapshotName: snapshotName,
+				Machine:      machineName,
+				StartTime:    metav1.Now(),
+			}
+		}
+	}
+	snapshotSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      snapshotName,
+			Labels:    map[string]string{controlplanev1.EtcdSnapshotLabel: "kcp"},
+		},
+	}
+	restoreSecret := func() *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: metav1.NamespaceDefault,
+				Name:      "kcp-etcd-restore",
+			},
+		}
+	}
+	healthyMachine := func(name string) *clusterv1.Machine {
+		m := machine(name)
+		setMachineHealthy(m)
+		return m
+	}
+	deletingMachine := func(name string) *clusterv1.Machine {
+		return machine(name, func(m *clusterv1.Machine) {
+			m.DeletionTimestamp = ptr.To(metav1.Now())
+			m.Finalizers = []string{clusterv1.MachineFinalizer}
+		})
+	}
+
+	tests := []struct {
+		name                   string
+		kcp                    *controlplanev1.KubeadmControlPlane
+		machines               []*clusterv1.Machine
+		objs                   []client.Object
+		inlineSnapshotDisabled bool
+		wantResult             ctrl.Result
+		wantErr                string
+		wantRestoreStatus      func(g *WithT, restoreStatus controlplanev1.EtcdRestoreStatus)
+		wantMachines           []string
+		wantRestoreSecret      bool
+		wantRemovedStaleNodes  bool
+		wantEventsWithPrefixes []string
+	}{
+		{
+			name: "does nothing if restore is not set",
+			kcp: kcp(func(kcp *controlplanev1.KubeadmControlPlane) {
+				kcp.Spec.Etcd.Restore = controlplanev1.KubeadmControlPlaneEtcdRestoreSpec{}
+			}),
+			machines:     []*clusterv1.Machine{machine("m1")},
+			wantMachines: []string{"m1"},
+			wantRestoreStatus: func(g *WithT, restoreStatus controlplanev1.EtcdRestoreStatus) {
+				g.Expect(restoreStatus).To(Equal(controlplanev1.EtcdRestoreStatus{}))
+			},
+		},
+		{
+			name: "cleans up a previous restore if restore is not set anymore",
+			kcp: kcp(restoreStarted("m1"), func(kcp *controlplanev1.KubeadmControlPlane) {
+				kcp.Spec.Etcd.Restore = controlplanev1.KubeadmControlPlaneEtcdRestoreSpec{}
+			}),
+			machines:     []*clusterv1.Machine{machine("m1")},
+			objs:         []client.Object{restoreSecret()},
+			wantMachines: []string{"m1"},
+			wantRestoreStatus: func(g *WithT, restoreStatus controlplanev1.EtcdRestoreStatus) {
+				g.Expect(restoreStatus).To(Equal(controlplanev1.EtcdRestoreStatus{}))
+			},
+		},
+		{
+			name: "does nothing if the restore is completed",
+			kcp: kcp(restoreStarted("m1"), func(kcp *controlplanev1.KubeadmControlPlane) {
+				kcp.Status.Etcd.Restore.CompletionTime = metav1.Now()
+			}),
+			machines:     []*clusterv1.Machine{healthyMachine("m1"), machine("m2")},
+			wantMachines: []string{"m1", "m2"},
+			wantRestoreStatus: func(g *WithT, restoreStatus controlplanev1.EtcdRestoreStatus) {
+				g.Expect(restoreStatus.CompletionTime.IsZero()).To(BeFalse())
+			},
+		},
+		{
+			name:         "fails if the snapshot does not exist",
+			kcp:          kcp(),
+			machines:     []*clusterv1.Machine{machine("m1")},
+			wantErr:      "snapshot does not exist",
+			wantMachines: []string{"m1"},
+			wantRestoreStatus: func(g *WithT, restoreStatus controlplanev1.EtcdRestoreStatus) {
+				g.Expect(restoreStatus).To(Equal(controlplanev1.EtcdRestoreStatus{}))
+			},
+		},
+		{
+			name: "waits for the restore to be confirmed before deleting the control plane Machines",
+			kcp: kcp(func(kcp *controlplanev1.KubeadmControlPlane) {
+				kcp.Annotations[controlplanev1.EtcdRestoreConfirmedAnnotation] = "another-snapshot"
+			}),
+			machines:     []*clusterv1.Machine{machine("m1")},
+			objs:         []client.Object{snapshotSecret},
+			wantMachines: []string{"m1"},
+			wantRestoreStatus: func(g *WithT, restoreStatus controlplanev1.EtcdRestoreStatus) {
+				g.Expect(restoreStatus).To(Equal(controlplanev1.EtcdRestoreStatus{}))
+			},
+			wantEventsWithPrefixes: []string{"Warning EtcdRestoreNotConfirmed"},
+		},
+		{
+			name:                   "fails before deleting the control plane Machines if the snapshot can't be embedded in bootstrap data",
+			kcp:                    kcp(),
+			machines:               []*clusterv1.Machine{machine("m1")},
+			objs:                   []client.Object{snapshotSecret},
+			inlineSnapshotDisabled: true,
+			wantErr:                "--etcd-restore-max-inline-snapshot-size",
+			wantMachines:           []string{"m1"},
+			wantRestoreStatus: func(g *WithT, restoreStatus controlplanev1.EtcdRestoreStatus) {
+				g.Expect(restoreStatus).To(Equal(controlplanev1.EtcdRestoreStatus{}))
+			},
+		},
+		{
+			name:              "starts the restore and deletes all the control plane Machines",
+			kcp:               kcp(),
+			machines:          []*clusterv1.Machine{machine("m1"), machine("m2"), machine("m3")},
+			objs:              []client.Object{snapshotSecret},
+			wantResult:        ctrl.Result{RequeueAfter: deleteRequeueAfter},
+			wantMachines:      []string{},
+			wantRestoreSecret: true,
+			wantRestoreStatus: func(g *WithT, restoreStatus controlplanev1.EtcdRestoreStatus) {
+				g.Expect(restoreStatus.SnapshotName).To(Equal(snapshotName))
+				g.Expect(restoreStatus.StartTime.IsZero()).To(BeFalse())
+				g.Expect(restoreStatus.Machine).To(BeEmpty())
+			},
+			wantEventsWithPrefixes: []string{"Normal EtcdRestoreStarted"},
+		},
+		{
+			name:         "waits for control plane Machines to be deleted, skipping drain and pre-terminate hook",
+			kcp:          kcp(restoreStarted("")),
+			machines:     []*clusterv1.Machine{deletingMachine("m1")},
+			wantResult:   ctrl.Result{RequeueAfter: deleteRequeueAfter},
+			wantMachines: []string{"m1"},
+			wantRestoreStatus: func(g *WithT, restoreStatus controlplanev1.EtcdRestoreStatus) {
+				g.Expect(restoreStatus.Machine).To(BeEmpty())
+			},
+		},
+		{
+			name:         "deletes the Machine restoring etcd if it is being deleted",
+			kcp:          kcp(restoreStarted("m1")),
+			machines:     []*clusterv1.Machine{deletingMachine("m1")},
+			wantResult:   ctrl.Result{RequeueAfter: deleteRequeueAfter},
+			wantMachines: []string{"m1"},
+			wantRestoreStatus: func(g *WithT, restoreStatus controlplanev1.EtcdRestoreStatus) {
+				g.Expect(restoreStatus.Machine).To(Equal("m1"))
+			},
+		},
+		{
+			name:              "waits for the Machine restoring etcd to be healthy",
+			kcp:               kcp(restoreStarted("m1")),
+			machines:          []*clusterv1.Machine{machine("m1")},
+			objs:              []client.Object{restoreSecret()},
+			wantResult:        ctrl.Result{RequeueAfter: etcdRestoreRequeueAfter},
+			wantMachines:      []string{"m1"},
+			wantRestoreSecret: true,
+			wantRestoreStatus: func(g *WithT, restoreStatus controlplanev1.EtcdRestoreStatus) {
+				g.Expect(restoreStatus.CompletionTime.IsZero()).To(BeTrue())
+			},
+		},
+		{
+			name:                  "completes the restore when the Machine restoring etcd is healthy",
+			kcp:                   kcp(restoreStarted("m1")),
+			machines:              []*clusterv1.Machine{healthyMachine("m1")},
+			objs:                  []client.Object{restoreSecret()},
+			wantResult:            ctrl.Result{Requeue: true},
+			wantMachines:          []string{"m1"},
+			wantRemovedStaleNodes: true,
+			wantRestoreStatus: func(g *WithT, restoreStatus controlplanev1.EtcdRestoreStatus) {
+				g.Expect(restoreStatus.Machine).To(Equal("m1"))
+				g.Expect(restoreStatus.CompletionTime.IsZero()).To(BeFalse())
+			},
+			wantEventsWithPrefixes: []string{"Normal EtcdRestoreCompleted"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			objs := append([]client.Object{}, tt.objs...)
+			machines := collections.Machines{}
+			for _, m := range tt.machines {
+				objs = append(objs, m.DeepCopy())
+				machines.Insert(m)
+			}
+			fakeClient := newFakeClient(objs...)
+			workloadCluster := &fakeWorkloadCluster{}
+			managementCluster := &fakeManagementCluster{
+				Workload: workloadCluster,
+			}
+			recorder := record.NewFakeRecorder(32)
+			r := &KubeadmControlPlaneReconciler{
+				Client:                           fakeClient,
+				SecretCachingClient:              fakeClient,
+				EtcdRestoreMaxInlineSnapshotSize: 1024,
+				managementCluster:                managementCluster,
+				recorder:                         recorder,
+			}
+			if tt.inlineSnapshotDisabled {
+				r.EtcdRestoreMaxInlineSnapshotSize = 0
+			}
+
+			controlPlane := &internal.ControlPlane{
+				KCP:      tt.kcp,
+				Cluster:  cluster,
+				Machines: machines,
+			}
+			controlPlane.InjectTestManagementCluster(managementCluster)
+
+			result, err := r.reconcileEtcdRestore(ctx, controlPlane)
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+			g.Expect(result).To(Equal(tt.wantResult))
+			tt.wantRestoreStatus(g, tt.kcp.Status.Etcd.Restore)
+
+			machineList := &clusterv1.MachineList{}
+			g.Expect(fakeClient.List(ctx, machineList)).To(Succeed())
+			machineNames := []string{}
+			for _, m := range machineList.Items {
+				machineNames = append(machineNames, m.Name)
+				if !m.DeletionTimestamp.IsZero() {
+					g.Expect(m.Annotations).To(HaveKey(clusterv1.ExcludeNodeDrainingAnnotation))
+					g.Expect(m.Annotations).To(HaveKey(clusterv1.ExcludeWaitForNodeVolumeDetachAnnotation))
+					g.Expect(m.Annotations).ToNot(HaveKey(controlplanev1.PreTerminateHookCleanupAnnotation))
+				}
+			}
+			g.Expect(machineNames).To(ConsistOf(tt.wantMachines))
+
+			err = fakeClient.Get(ctx, client.ObjectKeyFromObject(restoreSecret()), &corev1.Secret{})
+			if tt.wantRestoreSecret {
+				g.Expect(err).ToNot(HaveOccurred())
+			} else {
+				g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			}
+
+			g.Expect(workloadCluster.removeStaleControlPlaneNodesCalled > 0).To(Equal(tt.wantRemovedStaleNodes))
+
+			close(recorder.Events)
+			events := []string{}
+			for event := range recorder.Events {
+				events = append(events, event)
+			}
+			g.Expect(events).To(HaveLen(len(tt.wantEventsWithPrefixes)))
+			for i, prefix := range tt.wantEventsWithPrefixes {
+				g.Expect(events[i]).To(HavePrefix(prefix))
+			}
+		})
+	}
+}
+
+func TestCreateEtcdRestoreSecret(t *testing.T) {
+	g := NewWithT(t)
+
+	kcp := &controlplanev1.KubeadmControlPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "kcp",
+			UID:       "kcp-uid",
+		},
+	}
+	fakeClient := newFakeClient()
+	r := &KubeadmControlPlaneReconciler{Client: fakeClient, EtcdRestoreMaxInlineSnapshotSize: 1024}
+	store := &snapshot.SecretStore{Client: fakeClient, Namespace: metav1.NamespaceDefault, KCPName: "kcp"}
+	g.Expect(store.Put(ctx, "snapshot-1", strings.NewReader("data-1"), 6)).To(Succeed())
+	g.Expect(store.Put(ctx, "snapshot-2", strings.NewReader("data-2"), 6)).To(Succeed())
+
+	// Create the Secret, then update it with another snapshot.
+	for _, tc := range []struct{ snapshotName, want string }{
+		{snapshotName: "snapshot-1", want: "ZGF0YS0x"},
+		{snapshotName: "snapshot-2", want: "ZGF0YS0y"},
+	} {
+		g.Expect(r.createEtcdRestoreSecret(ctx, kcp, store, tc.snapshotName)).To(Succeed())
+
+		secret := &corev1.Secret{}
+		g.Expect(fakeClient.Get(ctx, client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: "kcp-etcd-restore"}, secret)).To(Succeed())
+		g.Expect(string(secret.Data[etcdRestoreSnapshotKey])).To(Equal(tc.want))
+		g.Expect(secret.OwnerReferences).To(HaveLen(1))
+		g.Expect(secret.OwnerReferences[0].Kind).To(Equal(kubeadmControlPlaneKind))
+		g.Expect(secret.Labels).ToNot(HaveKey(controlplanev1.EtcdSnapshotLabel))
+	}
+
+	g.Expect(r.createEtcdRestoreSecret(ctx, kcp, store, "snapshot-3")).ToNot(Succeed())
+
+	// Fail if the snapshot is bigger than the max size that can be embedded in bootstrap data.
+	r.EtcdRestoreMaxInlineSnapshotSize = 4
+	g.Expect(r.createEtcdRestoreSecret(ctx, kcp, store, "snapshot-1")).To(MatchError(ContainSubstring("can be embedded in bootstrap data only up to 4 bytes")))
+
+	// Fail if embedding snapshots in bootstrap data is disabled.
+	r.EtcdRestoreMaxInlineSnapshotSize = 0
+	g.Expect(r.createEtcdRestoreSecret(ctx, kcp, store, "snapshot-1")).To(MatchError(ContainSubstring("--etcd-restore-max-inline-snapshot-size")))
+
+	// Pass the URL to download the snapshot if supported by the store.
+	g.Expect(fakeClient.Delete(ctx, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "kcp-etcd-restore"}})).To(Succeed())
+	s3Store := &snapshot.S3Store{
+		Endpoint:        "https://s3.example.com",
+		Bucket:          "bucket",
+		Region:          "us-east-1",
+		AccessKeyID:     "access-key-id",
+		SecretAccessKey: "secret-access-key",
+		KCPName:         "kcp",
+	}
+	g.Expect(r.createEtcdRestoreSecret(ctx, kcp, s3Store, "kcp-etcd-snapshot-20260101100000")).To(Succeed())
+
+	secret := &corev1.Secret{}
+	g.Expect(fakeClient.Get(ctx, client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: "kcp-etcd-restore"}, secret)).To(Succeed())
+	g.Expect(secret.Data).ToNot(HaveKey(etcdRestoreSnapshotKey))
+	g.Expect(string(secret.Data[etcdRestoreSnapshotURLKey])).To(HavePrefix("https://s3.example.com/bucket/kcp-etcd-snapshot-20260101100000?"))
+}
+
+func TestEtcdRestoreKubeadmConfigMutator(t *testing.T) {
+	tests := []struct {
+		name                      string
+		dataDir                   string
+		downloadSnapshot          bool
+		wantFiles                 []string
+		wantIgnorePreflightErrors []string
+	}{
+		{
+			name:                      "snapshot from Secret",
+			wantFiles:                 []string{"/etc/kubernetes/etcd-restore/restore.sh", "/etc/kubernetes/etcd-restore/snapshot.db.gz"},
+			wantIgnorePreflightErrors: []string{"Swap", "DirAvailable--var-lib-etcd"},
+		},
+		{
+			name:                      "snapshot downloaded from URL and custom data dir",
+			dataDir:                   "/mnt/etcd/data",
+			downloadSnapshot:          true,
+			wantFiles:                 []string{"/etc/kubernetes/etcd-restore/restore.sh", "/etc/kubernetes/etcd-restore/snapshot-url"},
+			wantIgnorePreflightErrors: []string{"Swap", "DirAvailable--mnt-etcd-data"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			kcp := &controlplanev1.KubeadmControlPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: metav1.NamespaceDefault,
+					Name:      "kcp",
+				},
+			}
+			kcp.Spec.KubeadmConfigSpec.ClusterConfiguration.Etcd.Local.DataDir = tt.dataDir
+
+			mutate, err := etcdRestoreKubeadmConfigMutator(kcp, "kcp-etcd-snapshot-20260101100000", tt.downloadSnapshot)
+			g.Expect(err).ToNot(HaveOccurred())
+
+			kubeadmConfig := &bootstrapv1.KubeadmConfig{
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					PreKubeadmCommands: []string{"setup-containerd"},
+					InitConfiguration: bootstrapv1.InitConfiguration{
+						NodeRegistration: bootstrapv1.NodeRegistrationOptions{
+							IgnorePreflightErrors: []string{"Swap"},
+						},
+					},
+				},
+			}
+			mutate(kubeadmConfig)
+
+			g.Expect(kubeadmConfig.Annotations).To(HaveKeyWithValue(bootstrapv1.EtcdRestoreAnnotation, "kcp-etcd-snapshot-20260101100000"))
+			g.Expect(kubeadmConfig.Spec.PreKubeadmCommands).To(Equal([]string{"setup-containerd", "/etc/kubernetes/etcd-restore/restore.sh"}))
+			g.Expect(kubeadmConfig.Spec.InitConfiguration.NodeRegistration.IgnorePreflightErrors).To(Equal(tt.wantIgnorePreflightErrors))
+
+			files := []string{}
+			for _, f := range kubeadmConfig.Spec.Files {
+				files = append(files, f.Path)
+			}
+			g.Expect(files).To(Equal(tt.wantFiles))
+
+			script := kubeadmConfig.Spec.Files[0].Content
+			g.Expect(script).To(ContainSubstring("etcdutl snapshot restore"))
+			if tt.downloadSnapshot {
+				g.Expect(script).To(ContainSubstring("curl"))
+				g.Expect(kubeadmConfig.Spec.Files[1].Content).To(BeEmpty())
+				g.Expect(kubeadmConfig.Spec.Files[1].ContentFrom.Secret).To(Equal(bootstrapv1.SecretFileSource{Name: "kcp-etcd-restore", Key: "snapshotURL"}))
+			} else {
+				g.Expect(script).ToNot(ContainSubstring("curl"))
+				g.Expect(kubeadmConfig.Spec.Files[1].ContentFrom.Secret).To(Equal(bootstrapv1.SecretFileSource{Name: "kcp-etcd-restore", Key: "snapshot"}))
+				g.Expect(kubeadmConfig.Spec.Files[1].Encoding).To(Equal(bootstrapv1.Base64))
+			}
+		})
+	}
+}
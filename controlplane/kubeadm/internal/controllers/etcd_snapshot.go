@@ -0,0 +1,290 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/etcd/snapshot"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// etcdSnapshotRetryInterval is the interval after which a failed etcd snapshot is retried.
+const etcdSnapshotRetryInterval = 5 * time.Minute
+
+// etcdSnapshotPollInterval is the interval at which KCP checks if an etcd snapshot taken in background is completed.
+const etcdSnapshotPollInterval = 10 * time.Second
+
+// etcdSnapshotTimeout is the maximum duration of an etcd snapshot taken in background, including storing the snapshot
+// and deleting the snapshots exceeding retention.
+const etcdSnapshotTimeout = 30 * time.Minute
+
+// etcdSnapshotFailureEntry is an entry in the cache of failed etcd snapshots, which ensures failed etcd snapshots
+// are retried after etcdSnapshotRetryInterval instead of at every reconcile of the KubeadmControlPlane.
+type etcdSnapshotFailureEntry struct {
+	KCP  client.ObjectKey
+	Time time.Time
+}
+
+// Key returns the cache key of an etcdSnapshotFailureEntry.
+func (e etcdSnapshotFailureEntry) Key() string {
+	return e.KCP.String()
+}
+
+// etcdSnapshotOperation is an etcd snapshot taken in background.
+type etcdSnapshotOperation struct {
+	name string
+	time time.Time
+
+	// done is closed when the snapshot is completed; the fields below must be read only after done is closed.
+	done     chan struct{}
+	size     int64
+	err      error
+	deleted  []string
+	pruneErr error
+}
+
+// completed returns true if the etcd snapshot is completed.
+func (o *etcdSnapshotOperation) completed() bool {
+	select {
+	case <-o.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// etcdSnapshotTracker tracks the etcd snapshots taken in background, at most one for each KubeadmControlPlane.
+// NOTE: Streaming the snapshot from etcd and storing it can take several minutes for big etcd databases, so snapshots
+// are taken in background to not block the reconcile of the KubeadmControlPlane.
+type etcdSnapshotTracker struct {
+	lock       sync.Mutex
+	operations map[client.ObjectKey]*etcdSnapshotOperation
+}
+
+// get returns the etcd snapshot taken in background for a KubeadmControlPlane, if any.
+func (t *etcdSnapshotTracker) get(kcp client.ObjectKey) *etcdSnapshotOperation {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.operations[kcp]
+}
+
+// delete stops tracking the etcd snapshot taken in background for a KubeadmControlPlane, if any.
+// NOTE: If the snapshot is not yet completed, it is not stopped, but its result is dropped.
+func (t *etcdSnapshotTracker) delete(kcp client.ObjectKey) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	delete(t.operations, kcp)
+}
+
+// start takes an etcd snapshot in background for a KubeadmControlPlane by calling f, which sets the result on the operation.
+func (t *etcdSnapshotTracker) start(ctx context.Context, kcp client.ObjectKey, operation *etcdSnapshotOperation, f func(ctx context.Context, operation *etcdSnapshotOperation)) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.operations == nil {
+		t.operations = map[client.ObjectKey]*etcdSnapshotOperation{}
+	}
+	operation.done = make(chan struct{})
+	t.operations[kcp] = operation
+
+	// NOTE: The snapshot must not be canceled when the reconcile is completed, so the reconcile context is not used
+	// to cancel the snapshot; the snapshot is canceled after etcdSnapshotTimeout instead.
+	go func() {
+		defer close(operation.done)
+		ctx, cancel := context.WithTimeoutCause(context.WithoutCancel(ctx), etcdSnapshotTimeout, errors.New("etcd snapshot timeout expired"))
+		defer cancel()
+		f(ctx, operation)
+	}()
+}
+
+// reconcileEtcdSnapshot takes a snapshot of the etcd cluster when the snapshot interval expired since the last snapshot,
+// stores it in the snapshot storage and deletes the oldest snapshots exceeding retention.
+// The snapshot is taken in background, and its result is reported in the KCP status at the first reconcile after the
+// snapshot is completed.
+// NOTE: Failures are surfaced in the EtcdSnapshotSucceeded condition and retried after etcdSnapshotRetryInterval,
+// but they are not returned as errors, so they do not block other operations, e.g. etcd defragmentation.
+func (r *KubeadmControlPlaneReconciler) reconcileEtcdSnapshot(ctx context.Context, controlPlane *internal.ControlPlane) ctrl.Result {
+	log := ctrl.LoggerFrom(ctx)
+	kcp := controlPlane.KCP
+	snapshotSpec := kcp.Spec.Etcd.Snapshot
+	kcpKey := client.ObjectKeyFromObject(kcp)
+
+	// Return if snapshots are not enabled, or if etcd is not managed by KCP.
+	if snapshotSpec.IntervalSeconds == 0 || !controlPlane.IsEtcdManaged() {
+		r.etcdSnapshots.delete(kcpKey)
+		conditions.Delete(kcp, controlplanev1.KubeadmControlPlaneEtcdSnapshotSucceededCondition)
+		return ctrl.Result{}
+	}
+
+	// Return if KCP is not yet initialized (no etcd to take a snapshot from).
+	if !ptr.Deref(kcp.Status.Initialization.ControlPlaneInitialized, false) {
+		return ctrl.Result{}
+	}
+
+	// Report the result of the snapshot taken in background, if completed.
+	if operation := r.etcdSnapshots.get(kcpKey); operation != nil {
+		if !operation.completed() {
+			log.V(4).Info("Waiting for etcd snapshot to be completed", "snapshot", operation.name)
+			return ctrl.Result{RequeueAfter: etcdSnapshotPollInterval}
+		}
+		r.etcdSnapshots.delete(kcpKey)
+		return r.setEtcdSnapshotResult(ctx, kcp, operation)
+	}
+
+	interval := time.Duration(snapshotSpec.IntervalSeconds) * time.Second
+	now := time.Now().UTC().Truncate(time.Second)
+	if lastSnapshotTime := kcp.Status.Etcd.LastSnapshot.Time; !lastSnapshotTime.IsZero() {
+		if nextSnapshotTime := lastSnapshotTime.Add(interval); now.Before(nextSnapshotTime) {
+			return ctrl.Result{RequeueAfter: nextSnapshotTime.Sub(now)}
+		}
+	}
+
+	// Wait before retrying a failed snapshot.
+	if r.etcdSnapshotFailureCache != nil {
+		if entry, ok := r.etcdSnapshotFailureCache.Has(kcpKey.String()); ok {
+			if retryTime := entry.Time.Add(etcdSnapshotRetryInterval); now.Before(retryTime) {
+				return ctrl.Result{RequeueAfter: retryTime.Sub(now)}
+			}
+		}
+	}
+
+	operation := &etcdSnapshotOperation{name: snapshot.Name(kcp.Name, now), time: now}
+	store, err := snapshot.NewStore(ctx, r.Client, kcp, r.EtcdSnapshotVolumePath)
+	if err != nil {
+		operation.err = err
+		return r.setEtcdSnapshotResult(ctx, kcp, operation)
+	}
+	workloadCluster, err := controlPlane.GetWorkloadCluster(ctx)
+	if err != nil {
+		operation.err = errors.Wrap(err, "cannot get remote client to workload cluster")
+		return r.setEtcdSnapshotResult(ctx, kcp, operation)
+	}
+
+	log.Info("Taking etcd snapshot", "snapshot", operation.name)
+	retention := ptr.Deref(snapshotSpec.Retention, controlplanev1.DefaultEtcdSnapshotRetention)
+	r.etcdSnapshots.start(ctx, kcpKey, operation, func(ctx context.Context, operation *etcdSnapshotOperation) {
+		operation.size, operation.err = takeEtcdSnapshot(ctx, workloadCluster, store, operation.name)
+		if operation.err != nil {
+			return
+		}
+		operation.deleted, operation.pruneErr = snapshot.Prune(ctx, store, int(retention))
+	})
+	return ctrl.Result{RequeueAfter: etcdSnapshotPollInterval}
+}
+
+// setEtcdSnapshotResult reports the result of an etcd snapshot in the KCP status.
+func (r *KubeadmControlPlaneReconciler) setEtcdSnapshotResult(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane, operation *etcdSnapshotOperation) ctrl.Result {
+	log := ctrl.LoggerFrom(ctx)
+	name := operation.name
+
+	if err := operation.err; err != nil {
+		log.Error(err, "Failed to take etcd snapshot", "snapshot", name)
+		r.recorder.Eventf(kcp, corev1.EventTypeWarning, "EtcdSnapshotFailed", "Failed to take etcd snapshot %s: %v", name, err)
+		conditions.Set(kcp, metav1.Condition{
+			Type:    controlplanev1.KubeadmControlPlaneEtcdSnapshotSucceededCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  controlplanev1.KubeadmControlPlaneEtcdSnapshotFailedReason,
+			Message: fmt.Sprintf("Failed to take etcd snapshot %s: %v", name, err),
+		})
+		if r.etcdSnapshotFailureCache != nil {
+			r.etcdSnapshotFailureCache.Add(etcdSnapshotFailureEntry{KCP: client.ObjectKeyFromObject(kcp), Time: time.Now().UTC()})
+		}
+		return ctrl.Result{RequeueAfter: etcdSnapshotRetryInterval}
+	}
+
+	kcp.Status.Etcd.LastSnapshot = controlplanev1.EtcdSnapshotStatus{
+		Name:      name,
+		Time:      metav1.NewTime(operation.time),
+		SizeBytes: ptr.To(operation.size),
+	}
+	r.recorder.Eventf(kcp, corev1.EventTypeNormal, "EtcdSnapshotTaken", "Etcd snapshot %s taken", name)
+
+	// NOTE: Failures deleting snapshots exceeding retention are retried after the next snapshot.
+	interval := time.Duration(kcp.Spec.Etcd.Snapshot.IntervalSeconds) * time.Second
+	if len(operation.deleted) > 0 {
+		retention := ptr.Deref(kcp.Spec.Etcd.Snapshot.Retention, controlplanev1.DefaultEtcdSnapshotRetention)
+		log.Info("Deleted etcd snapshots exceeding retention", "snapshots", strings.Join(operation.deleted, ","), "retention", retention)
+	}
+	if err := operation.pruneErr; err != nil {
+		log.Error(err, "Failed to delete etcd snapshots exceeding retention")
+		conditions.Set(kcp, metav1.Condition{
+			Type:    controlplanev1.KubeadmControlPlaneEtcdSnapshotSucceededCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  controlplanev1.KubeadmControlPlaneEtcdSnapshotFailedReason,
+			Message: fmt.Sprintf("Etcd snapshot %s taken, but failed to delete etcd snapshots exceeding retention: %v", name, err),
+		})
+		return ctrl.Result{RequeueAfter: interval}
+	}
+
+	conditions.Set(kcp, metav1.Condition{
+		Type:   controlplanev1.KubeadmControlPlaneEtcdSnapshotSucceededCondition,
+		Status: metav1.ConditionTrue,
+		Reason: controlplanev1.KubeadmControlPlaneEtcdSnapshotSucceededReason,
+	})
+	return ctrl.Result{RequeueAfter: interval}
+}
+
+// takeEtcdSnapshot takes a snapshot of the etcd cluster and stores it compressed with gzip in the snapshot storage.
+// It returns the size of the snapshot before compression.
+// NOTE: The snapshot is buffered in a temporary file, because it can be too big to be kept in memory.
+func takeEtcdSnapshot(ctx context.Context, workloadCluster internal.WorkloadCluster, store snapshot.Store, name string) (_ int64, reterr error) {
+	f, err := os.CreateTemp("", "etcd-snapshot-")
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to create temporary file")
+	}
+	defer func() {
+		reterr = kerrors.NewAggregate([]error{reterr, f.Close(), os.Remove(f.Name())})
+	}()
+
+	gzipWriter := gzip.NewWriter(f)
+	size, err := workloadCluster.EtcdSnapshot(ctx, gzipWriter)
+	if err != nil {
+		return 0, err
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return 0, errors.Wrap(err, "failed to compress snapshot")
+	}
+
+	compressedSize, err := f.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to read temporary file")
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return 0, errors.Wrap(err, "failed to read temporary file")
+	}
+	if err := store.Put(ctx, name, f, compressedSize); err != nil {
+		return 0, err
+	}
+	return size, nil
+}
@@ -0,0 +1,320 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/etcd/snapshot"
+	"sigs.k8s.io/cluster-api/util/cache"
+	"sigs.k8s.io/cluster-api/util/collections"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestReconcileEtcdSnapshot(t *testing.T) {
+	cluster := newCluster(&types.NamespacedName{Name: "foo", Namespace: metav1.NamespaceDefault})
+	kcp := func(transforms ...func(kcp *controlplanev1.KubeadmControlPlane)) *controlplanev1.KubeadmControlPlane {
+		kcp := &controlplanev1.KubeadmControlPlane{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: metav1.NamespaceDefault,
+				Name:      "kcp",
+			},
+			Spec: controlplanev1.KubeadmControlPlaneSpec{
+				Version: "v1.30.0",
+				Etcd: controlplanev1.KubeadmControlPlaneEtcdSpec{
+					Snapshot: controlplanev1.KubeadmControlPlaneEtcdSnapshotSpec{
+						IntervalSeconds: 3600,
+						Retention:       ptr.To[int32](2),
+						Storage: controlplanev1.KubeadmControlPlaneEtcdSnapshotStorage{
+							Type: controlplanev1.SecretEtcdSnapshotStorageType,
+						},
+					},
+				},
+			},
+			Status: controlplanev1.KubeadmControlPlaneStatus{
+				Initialization: controlplanev1.KubeadmControlPlaneInitializationStatus{
+					ControlPlaneInitialized: ptr.To(true),
+				},
+			},
+		}
+		for _, transform := range transforms {
+			transform(kcp)
+		}
+		return kcp
+	}
+	snapshotSecret := func(t time.Time) client.Object {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: metav1.NamespaceDefault,
+				Name:      snapshot.Name("kcp", t),
+				Labels:    map[string]string{controlplanev1.EtcdSnapshotLabel: "kcp"},
+			},
+		}
+	}
+	oldSnapshotTime := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name              string
+		kcp               *controlplanev1.KubeadmControlPlane
+		objs              []client.Object
+		lastFailure       time.Time
+		inProgress        bool
+		snapshotErr       error
+		wantResult        func(g *WithT, result ctrl.Result)
+		wantSnapshotTaken bool
+		wantSnapshots     int
+		wantCondition     *metav1.Condition // Note: Only Status and Reason are compared.
+	}{
+		{
+			name: "does nothing if snapshots are not enabled",
+			kcp: kcp(func(kcp *controlplanev1.KubeadmControlPlane) {
+				kcp.Spec.Etcd = controlplanev1.KubeadmControlPlaneEtcdSpec{}
+			}),
+			wantResult: func(g *WithT, result ctrl.Result) {
+				g.Expect(result.IsZero()).To(BeTrue())
+			},
+		},
+		{
+			name: "does nothing if etcd is external",
+			kcp: kcp(func(kcp *controlplanev1.KubeadmControlPlane) {
+				kcp.Spec.KubeadmConfigSpec.ClusterConfiguration.Etcd.External = bootstrapv1.ExternalEtcd{
+					Endpoints: []string{"https://etcd:2379"},
+				}
+			}),
+			wantResult: func(g *WithT, result ctrl.Result) {
+				g.Expect(result.IsZero()).To(BeTrue())
+			},
+		},
+		{
+			name: "does nothing if the control plane is not initialized",
+			kcp: kcp(func(kcp *controlplanev1.KubeadmControlPlane) {
+				kcp.Status.Initialization.ControlPlaneInitialized = nil
+			}),
+			wantResult: func(g *WithT, result ctrl.Result) {
+				g.Expect(result.IsZero()).To(BeTrue())
+			},
+		},
+		{
+			name: "requeues if the interval since the last snapshot did not expire",
+			kcp: kcp(func(kcp *controlplanev1.KubeadmControlPlane) {
+				kcp.Status.Etcd.LastSnapshot = controlplanev1.EtcdSnapshotStatus{
+					Name: "kcp-etcd-snapshot-recent",
+					Time: metav1.NewTime(time.Now().Add(-10 * time.Minute)),
+				}
+			}),
+			wantResult: func(g *WithT, result ctrl.Result) {
+				g.Expect(result.RequeueAfter).To(BeNumerically("~", 50*time.Minute, time.Minute))
+			},
+		},
+		{
+			name: "takes a snapshot and deletes snapshots exceeding retention",
+			kcp: kcp(func(kcp *controlplanev1.KubeadmControlPlane) {
+				kcp.Status.Etcd.LastSnapshot = controlplanev1.EtcdSnapshotStatus{
+					Name: snapshot.Name("kcp", oldSnapshotTime.Add(time.Hour)),
+					Time: metav1.NewTime(oldSnapshotTime.Add(time.Hour)),
+				}
+			}),
+			objs: []client.Object{
+				snapshotSecret(oldSnapshotTime),
+				snapshotSecret(oldSnapshotTime.Add(time.Hour)),
+			},
+			wantResult: func(g *WithT, result ctrl.Result) {
+				g.Expect(result.RequeueAfter).To(Equal(time.Hour))
+			},
+			wantSnapshotTaken: true,
+			wantSnapshots:     2,
+			wantCondition: &metav1.Condition{
+				Status: metav1.ConditionTrue,
+				Reason: controlplanev1.KubeadmControlPlaneEtcdSnapshotSucceededReason,
+			},
+		},
+		{
+			name: "surfaces failures in a condition and requeues without returning an error",
+			kcp: kcp(func(kcp *controlplanev1.KubeadmControlPlane) {
+				kcp.Spec.Etcd.Snapshot.Storage = controlplanev1.KubeadmControlPlaneEtcdSnapshotStorage{
+					Type: controlplanev1.S3EtcdSnapshotStorageType,
+					S3: controlplanev1.KubeadmControlPlaneEtcdSnapshotS3Storage{
+						Endpoint:              "https://s3.example.com",
+						Bucket:                "etcd-snapshots",
+						CredentialsSecretName: "does-not-exist",
+					},
+				}
+			}),
+			wantResult: func(g *WithT, result ctrl.Result) {
+				g.Expect(result.RequeueAfter).To(Equal(etcdSnapshotRetryInterval))
+			},
+			wantCondition: &metav1.Condition{
+				Status: metav1.ConditionFalse,
+				Reason: controlplanev1.KubeadmControlPlaneEtcdSnapshotFailedReason,
+			},
+		},
+		{
+			name:        "surfaces failures of snapshots taken in background in a condition",
+			kcp:         kcp(),
+			snapshotErr: errors.New("etcd snapshot timeout expired"),
+			wantResult: func(g *WithT, result ctrl.Result) {
+				g.Expect(result.RequeueAfter).To(Equal(etcdSnapshotRetryInterval))
+			},
+			wantCondition: &metav1.Condition{
+				Status: metav1.ConditionFalse,
+				Reason: controlplanev1.KubeadmControlPlaneEtcdSnapshotFailedReason,
+			},
+		},
+		{
+			name:       "waits for the snapshot in progress to be completed",
+			kcp:        kcp(),
+			inProgress: true,
+			wantResult: func(g *WithT, result ctrl.Result) {
+				g.Expect(result.RequeueAfter).To(Equal(etcdSnapshotPollInterval))
+			},
+		},
+		{
+			name: "fails if the PersistentVolumeClaim storage is not mounted",
+			kcp: kcp(func(kcp *controlplanev1.KubeadmControlPlane) {
+				kcp.Spec.Etcd.Snapshot.Storage = controlplanev1.KubeadmControlPlaneEtcdSnapshotStorage{
+					Type: controlplanev1.PersistentVolumeClaimEtcdSnapshotStorageType,
+				}
+			}),
+			wantResult: func(g *WithT, result ctrl.Result) {
+				g.Expect(result.RequeueAfter).To(Equal(etcdSnapshotRetryInterval))
+			},
+			wantCondition: &metav1.Condition{
+				Status: metav1.ConditionFalse,
+				Reason: controlplanev1.KubeadmControlPlaneEtcdSnapshotFailedReason,
+			},
+		},
+		{
+			name:        "requeues if the retry interval since the last failure did not expire",
+			kcp:         kcp(),
+			lastFailure: time.Now().Add(-2 * time.Minute),
+			wantResult: func(g *WithT, result ctrl.Result) {
+				g.Expect(result.RequeueAfter).To(BeNumerically("~", 3*time.Minute, 5*time.Second))
+			},
+		},
+		{
+			name:        "retries a failed snapshot after the retry interval",
+			kcp:         kcp(),
+			lastFailure: time.Now().Add(-6 * time.Minute),
+			wantResult: func(g *WithT, result ctrl.Result) {
+				g.Expect(result.RequeueAfter).To(Equal(time.Hour))
+			},
+			wantSnapshotTaken: true,
+			wantSnapshots:     1,
+			wantCondition: &metav1.Condition{
+				Status: metav1.ConditionTrue,
+				Reason: controlplanev1.KubeadmControlPlaneEtcdSnapshotSucceededReason,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			fakeClient := newFakeClient(tt.objs...)
+			managementCluster := &fakeManagementCluster{
+				Workload: &fakeWorkloadCluster{
+					EtcdSnapshotData: []byte("etcd-snapshot-data"),
+					EtcdSnapshotErr:  tt.snapshotErr,
+				},
+			}
+			r := &KubeadmControlPlaneReconciler{
+				Client:                   fakeClient,
+				SecretCachingClient:      fakeClient,
+				managementCluster:        managementCluster,
+				recorder:                 record.NewFakeRecorder(32),
+				etcdSnapshotFailureCache: cache.New[etcdSnapshotFailureEntry](etcdSnapshotRetryInterval),
+			}
+			if !tt.lastFailure.IsZero() {
+				r.etcdSnapshotFailureCache.Add(etcdSnapshotFailureEntry{KCP: client.ObjectKeyFromObject(tt.kcp), Time: tt.lastFailure})
+			}
+			if tt.inProgress {
+				completeSnapshot := make(chan struct{})
+				defer close(completeSnapshot)
+				r.etcdSnapshots.start(ctx, client.ObjectKeyFromObject(tt.kcp), &etcdSnapshotOperation{name: "kcp-etcd-snapshot-in-progress"}, func(_ context.Context, _ *etcdSnapshotOperation) {
+					<-completeSnapshot
+				})
+			}
+
+			lastSnapshot := tt.kcp.Status.Etcd.LastSnapshot
+			controlPlane, err := internal.NewControlPlane(ctx, managementCluster, fakeClient, cluster, tt.kcp, collections.Machines{})
+			g.Expect(err).ToNot(HaveOccurred())
+
+			result := r.reconcileEtcdSnapshot(ctx, controlPlane)
+
+			// Wait for the snapshot taken in background, if any, and reconcile again to report its result.
+			if operation := r.etcdSnapshots.get(client.ObjectKeyFromObject(tt.kcp)); operation != nil && !tt.inProgress {
+				g.Expect(result.RequeueAfter).To(Equal(etcdSnapshotPollInterval))
+				g.Expect(tt.kcp.Status.Etcd.LastSnapshot).To(Equal(lastSnapshot))
+
+				<-operation.done
+				result = r.reconcileEtcdSnapshot(ctx, controlPlane)
+				g.Expect(r.etcdSnapshots.get(client.ObjectKeyFromObject(tt.kcp))).To(BeNil())
+			}
+			tt.wantResult(g, result)
+
+			condition := conditions.Get(tt.kcp, controlplanev1.KubeadmControlPlaneEtcdSnapshotSucceededCondition)
+			if tt.wantCondition == nil {
+				g.Expect(condition).To(BeNil())
+			} else {
+				g.Expect(condition).ToNot(BeNil())
+				g.Expect(condition.Status).To(Equal(tt.wantCondition.Status))
+				g.Expect(condition.Reason).To(Equal(tt.wantCondition.Reason))
+			}
+
+			if !tt.wantSnapshotTaken {
+				g.Expect(tt.kcp.Status.Etcd.LastSnapshot).To(Equal(lastSnapshot))
+				return
+			}
+
+			g.Expect(tt.kcp.Status.Etcd.LastSnapshot.Name).ToNot(Equal(lastSnapshot.Name))
+			g.Expect(tt.kcp.Status.Etcd.LastSnapshot.SizeBytes).To(Equal(ptr.To(int64(len("etcd-snapshot-data")))))
+
+			store := &snapshot.SecretStore{Client: fakeClient, Namespace: metav1.NamespaceDefault, KCPName: "kcp"}
+			infos, err := store.List(ctx)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(infos).To(HaveLen(tt.wantSnapshots))
+			g.Expect(infos[len(infos)-1].Name).To(Equal(tt.kcp.Status.Etcd.LastSnapshot.Name))
+
+			// Verify the snapshot is stored compressed.
+			data := &bytes.Buffer{}
+			g.Expect(store.Get(ctx, tt.kcp.Status.Etcd.LastSnapshot.Name, data)).To(Succeed())
+			gzipReader, err := gzip.NewReader(data)
+			g.Expect(err).ToNot(HaveOccurred())
+			uncompressed, err := io.ReadAll(gzipReader)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(string(uncompressed)).To(Equal("etcd-snapshot-data"))
+		})
+	}
+}
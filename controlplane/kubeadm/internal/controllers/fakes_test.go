@@ -18,6 +18,8 @@ package controllers
 
 import (
 	"context"
+	"io"
+	"slices"
 	"time"
 
 	"github.com/blang/semver/v4"
@@ -72,9 +74,19 @@ type fakeWorkloadCluster struct {
 	Status                     internal.ClusterStatus
 	EtcdMembersResult          []string
 	APIServerCertificateExpiry *time.Time
+	EtcdSnapshotData           []byte
+	EtcdSnapshotErr            error
+	EtcdMembersDBStatusResult  []internal.EtcdMemberDBStatus
+	EtcdMembersDBStatusErr     error
+	DefragmentEtcdMemberErr    error
 
-	forwardEtcdLeadershipCalled      int
-	removeEtcdMemberForMachineCalled int
+	forwardEtcdLeadershipCalled        int
+	removeEtcdMemberForMachineCalled   int
+	removeStaleControlPlaneNodesCalled int
+	compactEtcdCalled                  int
+	defragmentedEtcdMembers            []string
+	disarmedEtcdMembers                []string
+	clusterInfoCertificateAuthorities  []byte
 }
 
 func (f *fakeWorkloadCluster) ForwardEtcdLeadership(_ context.Context, _ *clusterv1.Machine, leaderCandidate *clusterv1.Machine) error {
@@ -114,6 +126,52 @@ func (f *fakeWorkloadCluster) EtcdMembers(_ context.Context) ([]string, error) {
 	return f.EtcdMembersResult, nil
 }
 
+func (f *fakeWorkloadCluster) EtcdSnapshot(_ context.Context, w io.Writer) (int64, error) {
+	if f.EtcdSnapshotErr != nil {
+		return 0, f.EtcdSnapshotErr
+	}
+	n, err := w.Write(f.EtcdSnapshotData)
+	return int64(n), err
+}
+
+func (f *fakeWorkloadCluster) RemoveStaleControlPlaneNodes(_ context.Context, _ []string) ([]string, error) {
+	f.removeStaleControlPlaneNodesCalled++
+	return nil, nil
+}
+
+func (f *fakeWorkloadCluster) EtcdMembersDBStatus(_ context.Context) ([]internal.EtcdMemberDBStatus, error) {
+	return slices.Clone(f.EtcdMembersDBStatusResult), f.EtcdMembersDBStatusErr
+}
+
+func (f *fakeWorkloadCluster) CompactEtcd(_ context.Context) error {
+	f.compactEtcdCalled++
+	return nil
+}
+
+func (f *fakeWorkloadCluster) DefragmentEtcdMember(_ context.Context, name string) (*internal.EtcdMemberDBStatus, error) {
+	f.defragmentedEtcdMembers = append(f.defragmentedEtcdMembers, name)
+	if f.DefragmentEtcdMemberErr != nil {
+		return nil, f.DefragmentEtcdMemberErr
+	}
+	for _, m := range f.EtcdMembersDBStatusResult {
+		if m.Name == name {
+			m.DBSize = m.DBSizeInUse
+			return &m, nil
+		}
+	}
+	return nil, errors.Errorf("etcd member %s not found", name)
+}
+
+func (f *fakeWorkloadCluster) DisarmEtcdMemberNoSpaceAlarm(_ context.Context, name string) error {
+	f.disarmedEtcdMembers = append(f.disarmedEtcdMembers, name)
+	return nil
+}
+
+func (f *fakeWorkloadCluster) UpdateClusterInfoCertificateAuthorities(_ context.Context, caData []byte) error {
+	f.clusterInfoCertificateAuthorities = caData
+	return nil
+}
+
 func (f *fakeWorkloadCluster) UpdateClusterConfiguration(context.Context, semver.Version, ...func(*bootstrapv1.ClusterConfiguration)) error {
 	return nil
 }
@@ -166,7 +166,10 @@ func (r *KubeadmControlPlaneReconciler) reconcileExternalReference(ctx context.C
 	return patchHelper.Patch(ctx, obj)
 }
 
-func (r *KubeadmControlPlaneReconciler) cloneConfigsAndGenerateMachine(ctx context.Context, cluster *clusterv1.Cluster, kcp *controlplanev1.KubeadmControlPlane, isJoin bool, failureDomain string) (*clusterv1.Machine, error) {
+// cloneConfigsAndGenerateMachine creates a new control plane Machine, together with its InfraMachine and KubeadmConfig.
+// If mutateKubeadmConfig is set, it is applied to the desired KubeadmConfig before creating it, e.g. to add the files and
+// commands required to restore etcd from a snapshot.
+func (r *KubeadmControlPlaneReconciler) cloneConfigsAndGenerateMachine(ctx context.Context, cluster *clusterv1.Cluster, kcp *controlplanev1.KubeadmControlPlane, isJoin bool, failureDomain string, mutateKubeadmConfig func(*bootstrapv1.KubeadmConfig)) (*clusterv1.Machine, error) {
 	var errs []error
 
 	machine, err := desiredstate.ComputeDesiredMachine(kcp, cluster, failureDomain, nil)
@@ -184,7 +187,7 @@ func (r *KubeadmControlPlaneReconciler) cloneConfigsAndGenerateMachine(ctx conte
 	machine.Spec.InfrastructureRef = infraRef
 
 	// Clone the bootstrap configuration
-	bootstrapConfig, bootstrapRef, err := r.createKubeadmConfig(ctx, kcp, cluster, isJoin, machine.Name)
+	bootstrapConfig, bootstrapRef, err := r.createKubeadmConfig(ctx, kcp, cluster, isJoin, machine.Name, mutateKubeadmConfig)
 	if err != nil {
 		v1beta1conditions.MarkFalse(kcp, controlplanev1.MachinesCreatedV1Beta1Condition, controlplanev1.BootstrapTemplateCloningFailedV1Beta1Reason,
 			clusterv1.ConditionSeverityError, "%s", err.Error())
@@ -258,11 +261,14 @@ func (r *KubeadmControlPlaneReconciler) createInfraMachine(ctx context.Context,
 	}, nil
 }
 
-func (r *KubeadmControlPlaneReconciler) createKubeadmConfig(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane, cluster *clusterv1.Cluster, isJoin bool, name string) (*bootstrapv1.KubeadmConfig, clusterv1.ContractVersionedObjectReference, error) {
+func (r *KubeadmControlPlaneReconciler) createKubeadmConfig(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane, cluster *clusterv1.Cluster, isJoin bool, name string, mutateKubeadmConfig func(*bootstrapv1.KubeadmConfig)) (*bootstrapv1.KubeadmConfig, clusterv1.ContractVersionedObjectReference, error) {
 	kubeadmConfig, err := desiredstate.ComputeDesiredKubeadmConfig(kcp, cluster, isJoin, name, nil)
 	if err != nil {
 		return nil, clusterv1.ContractVersionedObjectReference{}, errors.Wrapf(err, "failed to create KubeadmConfig")
 	}
+	if mutateKubeadmConfig != nil {
+		mutateKubeadmConfig(kubeadmConfig)
+	}
 
 	// Create the full object with capi-kubeadmcontrolplane.
 	// Below ssa.RemoveManagedFieldsForLabelsAndAnnotations will drop ownership for labels and annotations
@@ -359,7 +359,7 @@ func TestCloneConfigsAndGenerateMachineAndSyncMachines(t *testing.T) {
 		recorder:            record.NewFakeRecorder(32),
 	}
 
-	_, err := r.cloneConfigsAndGenerateMachine(ctx, cluster, kcp, true, "")
+	_, err := r.cloneConfigsAndGenerateMachine(ctx, cluster, kcp, true, "", nil)
 	g.Expect(err).To(Succeed())
 
 	machineList := &clusterv1.MachineList{}
@@ -588,7 +588,7 @@ func TestCloneConfigsAndGenerateMachineFailInfraMachineCreation(t *testing.T) {
 
 	// Break InfraMachine cloning
 	kcp.Spec.MachineTemplate.Spec.InfrastructureRef.Name = "something_invalid"
-	_, err := r.cloneConfigsAndGenerateMachine(ctx, cluster, kcp, true, "")
+	_, err := r.cloneConfigsAndGenerateMachine(ctx, cluster, kcp, true, "", nil)
 	g.Expect(err).To(HaveOccurred())
 	g.Expect(&kcp.GetV1Beta1Conditions()[0]).Should(v1beta1conditions.HaveSameStateOf(&clusterv1.Condition{
 		Type:     controlplanev1.MachinesCreatedV1Beta1Condition,
@@ -674,7 +674,7 @@ func TestCloneConfigsAndGenerateMachineFailKubeadmConfigCreation(t *testing.T) {
 
 	// Break KubeadmConfig computation
 	kcp.Spec.Version = "something_invalid"
-	_, err := r.cloneConfigsAndGenerateMachine(ctx, cluster, kcp, true, "")
+	_, err := r.cloneConfigsAndGenerateMachine(ctx, cluster, kcp, true, "", nil)
 	g.Expect(err).To(HaveOccurred())
 	g.Expect(&kcp.GetV1Beta1Conditions()[0]).Should(v1beta1conditions.HaveSameStateOf(&clusterv1.Condition{
 		Type:     controlplanev1.MachinesCreatedV1Beta1Condition,
@@ -771,7 +771,7 @@ func TestCloneConfigsAndGenerateMachineFailMachineCreation(t *testing.T) {
 		disableRemoveManagedFieldsForLabelsAndAnnotations: true,
 	}
 
-	_, err := r.cloneConfigsAndGenerateMachine(ctx, cluster, kcp, true, "")
+	_, err := r.cloneConfigsAndGenerateMachine(ctx, cluster, kcp, true, "", nil)
 	g.Expect(err).To(HaveOccurred())
 	g.Expect(&kcp.GetV1Beta1Conditions()[0]).Should(v1beta1conditions.HaveSameStateOf(&clusterv1.Condition{
 		Type:     controlplanev1.MachinesCreatedV1Beta1Condition,
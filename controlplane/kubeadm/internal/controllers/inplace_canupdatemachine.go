@@ -19,6 +19,7 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"maps"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -30,6 +31,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
 	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
@@ -147,6 +149,19 @@ func createRequest(ctx context.Context, c client.Client, currentMachine *cluster
 	currentInfraMachineForDiff.SetLabels(desiredInfraMachineForDiff.GetLabels())
 	currentInfraMachineForDiff.SetAnnotations(desiredInfraMachineForDiff.GetAnnotations())
 
+	// If certificates must be renewed, add RenewCertificatesAnnotation only to the desired KubeadmConfig, so extensions
+	// have to patch the annotation into the current KubeadmConfig to report that they can renew certificates.
+	// Note: Otherwise, if certificates must be renewed without any other change, current and desired objects are equal
+	// and extensions would accept the in-place update without being aware that certificates must be renewed.
+	if machineUpToDateResult.RenewCertificates {
+		desiredAnnotations := maps.Clone(desiredKubeadmConfigForDiff.GetAnnotations())
+		if desiredAnnotations == nil {
+			desiredAnnotations = map[string]string{}
+		}
+		desiredAnnotations[controlplanev1.RenewCertificatesAnnotation] = ""
+		desiredKubeadmConfigForDiff.SetAnnotations(desiredAnnotations)
+	}
+
 	// Apply defaulting to current / desired Machine / KubeadmConfig / InfraMachine.
 	// Machine
 	// Note: currentMachineForDiff doesn't need a dry-run as it was just written in syncMachines and then
@@ -259,7 +274,8 @@ func applyPatchesToRequest(ctx context.Context, req *runtimehooksv1.CanUpdateMac
 	}
 
 	if resp.BootstrapConfigPatch.IsDefined() {
-		if _, err := patch.ApplyPatchToObject(ctx, &req.Current.BootstrapConfig, resp.BootstrapConfigPatch, "spec"); err != nil {
+		// Note: Annotations are picked up as well, so extensions can patch RenewCertificatesAnnotation (see createRequest).
+		if _, err := patch.ApplyPatchToObject(ctx, &req.Current.BootstrapConfig, resp.BootstrapConfigPatch, "spec", "metadata.annotations"); err != nil {
 			return err
 		}
 	}
@@ -289,6 +305,13 @@ func matchesMachine(req *runtimehooksv1.CanUpdateMachineRequest) (bool, []string
 	if !match {
 		reasons = append(reasons, fmt.Sprintf("KubeadmConfig cannot be updated in-place: %s", diff))
 	}
+	match, err = matchesRenewCertificatesAnnotation(req.Current.BootstrapConfig, req.Desired.BootstrapConfig)
+	if err != nil {
+		return false, nil, errors.Wrapf(err, "failed to match KubeadmConfig")
+	}
+	if !match {
+		reasons = append(reasons, fmt.Sprintf("KubeadmConfig cannot be updated in-place: certificates must be renewed, but the %s annotation was not patched", controlplanev1.RenewCertificatesAnnotation))
+	}
 	match, diff, err = matchesUnstructuredSpec(req.Current.InfrastructureMachine, req.Desired.InfrastructureMachine)
 	if err != nil {
 		return false, nil, errors.Wrapf(err, "failed to match %s", req.Current.InfrastructureMachine.Object.GetObjectKind().GroupVersionKind().Kind)
@@ -341,3 +364,19 @@ func matchesUnstructuredSpec(patched, desired runtime.RawExtension) (equal bool,
 		},
 	)
 }
+
+func matchesRenewCertificatesAnnotation(patched, desired runtime.RawExtension) (bool, error) {
+	// Note: Both patched and desired objects are always Unstructured as createRequest and
+	//       applyPatchToObject are always setting objects as Unstructured.
+	patchedUnstructured, ok := patched.Object.(*unstructured.Unstructured)
+	if !ok {
+		return false, errors.Errorf("patched object is not an Unstructured")
+	}
+	desiredUnstructured, ok := desired.Object.(*unstructured.Unstructured)
+	if !ok {
+		return false, errors.Errorf("desired object is not an Unstructured")
+	}
+	_, desiredRenewCertificates := desiredUnstructured.GetAnnotations()[controlplanev1.RenewCertificatesAnnotation]
+	_, patchedRenewCertificates := patchedUnstructured.GetAnnotations()[controlplanev1.RenewCertificatesAnnotation]
+	return !desiredRenewCertificates || patchedRenewCertificates, nil
+}
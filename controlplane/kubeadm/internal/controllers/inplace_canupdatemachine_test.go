@@ -34,6 +34,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
 	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/defaulting"
@@ -261,6 +262,10 @@ func Test_canExtensionsUpdateMachine(t *testing.T) {
 		PatchType: runtimehooksv1.JSONMergePatchType,
 		Patch:     []byte{},
 	}
+	patchToRenewCertificates := runtimehooksv1.Patch{
+		PatchType: runtimehooksv1.JSONMergePatchType,
+		Patch:     []byte(`{"metadata":{"annotations":{"controlplane.cluster.x-k8s.io/renew-certificates":""}}}`),
+	}
 
 	tests := []struct {
 		name                         string
@@ -289,6 +294,46 @@ func Test_canExtensionsUpdateMachine(t *testing.T) {
 			},
 			wantCanUpdateMachine: true,
 		},
+		{
+			name: "Return false if certificates must be renewed, current and desired objects are equal and no patches are returned",
+			machineUpToDateResult: internal.UpToDateResult{
+				DesiredMachine:       currentMachine,
+				CurrentInfraMachine:  currentInfraMachine,
+				DesiredInfraMachine:  currentInfraMachine,
+				CurrentKubeadmConfig: currentKubeadmConfig,
+				DesiredKubeadmConfig: currentKubeadmConfig,
+				RenewCertificates:    true,
+			},
+			extensionHandlers: []string{"test-update-extension"},
+			callExtensionResponses: map[string]runtimehooksv1.ResponseObject{
+				"test-update-extension": responseWithEmptyPatches,
+			},
+			wantCanUpdateMachine: false,
+			wantReasons: []string{
+				"KubeadmConfig cannot be updated in-place: certificates must be renewed, but the controlplane.cluster.x-k8s.io/renew-certificates annotation was not patched",
+			},
+		},
+		{
+			name: "Return true if certificates must be renewed, current and desired objects are equal and the renew certificates annotation is patched",
+			machineUpToDateResult: internal.UpToDateResult{
+				DesiredMachine:       currentMachine,
+				CurrentInfraMachine:  currentInfraMachine,
+				DesiredInfraMachine:  currentInfraMachine,
+				CurrentKubeadmConfig: currentKubeadmConfig,
+				DesiredKubeadmConfig: currentKubeadmConfig,
+				RenewCertificates:    true,
+			},
+			extensionHandlers: []string{"test-update-extension"},
+			callExtensionResponses: map[string]runtimehooksv1.ResponseObject{
+				"test-update-extension": &runtimehooksv1.CanUpdateMachineResponse{
+					CommonResponse:             runtimehooksv1.CommonResponse{Status: runtimehooksv1.ResponseStatusSuccess},
+					MachinePatch:               emptyPatch,
+					InfrastructureMachinePatch: emptyPatch,
+					BootstrapConfigPatch:       patchToRenewCertificates,
+				},
+			},
+			wantCanUpdateMachine: true,
+		},
 		{
 			name: "Return false if current and desired objects are not equal and no patches are returned",
 			machineUpToDateResult: internal.UpToDateResult{
@@ -510,6 +555,10 @@ func validateCanUpdateMachineRequests(currentMachine *clusterv1.Machine, machine
 			desiredKubeadmConfig.SetGroupVersionKind(bootstrapv1.GroupVersion.WithKind("KubeadmConfig"))
 			desiredKubeadmConfig.ResourceVersion = ""                                 // cleanupKubeadmConfig drops ResourceVersion.
 			defaulting.ApplyPreviousKubeadmConfigDefaults(&desiredKubeadmConfig.Spec) // PrepareKubeadmConfigsForDiff applies defaults.
+			if machineUpToDateResult.RenewCertificates {
+				// createRequest adds RenewCertificatesAnnotation to the desired KubeadmConfig.
+				desiredKubeadmConfig.Annotations = map[string]string{controlplanev1.RenewCertificatesAnnotation: ""}
+			}
 			desiredKubeadmConfigBytes, _ := json.Marshal(desiredKubeadmConfig)
 			if d := diff(req.Desired.BootstrapConfig.Raw, desiredKubeadmConfigBytes); d != "" {
 				return fmt.Errorf("expected desiredKubeadmConfig to be equal, got diff: %s", d)
@@ -27,6 +27,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
 	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
@@ -111,6 +112,12 @@ func (r *KubeadmControlPlaneReconciler) triggerInPlaceUpdate(ctx context.Context
 		// Machine controller waits for this annotation to exist on Machine and related objects before starting the in-place update.
 		clusterv1.UpdateInProgressAnnotation: "",
 	}
+	if machineUpToDateResult.RenewCertificates {
+		// Signal to the UpdateMachine extension that certificates must be renewed as part of the in-place update.
+		// Note: The annotation is intentionally written with kcpManagerName, so it is going to be removed
+		// the next time an in-place update is triggered without renewing certificates.
+		desiredKubeadmConfig.Annotations[controlplanev1.RenewCertificatesAnnotation] = ""
+	}
 	if err := ssa.Patch(ctx, r.Client, kcpManagerName, desiredKubeadmConfig); err != nil {
 		return errors.Wrapf(err, "failed to complete triggering in-place update for Machine %s", klog.KObj(machine))
 	}
@@ -134,6 +141,12 @@ func (r *KubeadmControlPlaneReconciler) triggerInPlaceUpdate(ctx context.Context
 		return errors.Wrapf(err, "failed to complete triggering in-place update for Machine %s", klog.KObj(machine))
 	}
 
+	if machineUpToDateResult.RenewCertificates {
+		if err := r.removeCertificatesExpiryAnnotation(ctx, machineUpToDateResult.CurrentKubeadmConfig); err != nil {
+			return errors.Wrapf(err, "failed to complete triggering in-place update for Machine %s", klog.KObj(machine))
+		}
+	}
+
 	log.Info(fmt.Sprintf("Completed triggering in-place update for Machine %s", machine.Name))
 	r.recorder.Event(machine, corev1.EventTypeNormal, "SuccessfulStartInPlaceUpdate", "Machine starting in-place update")
 
@@ -168,3 +181,23 @@ func (r *KubeadmControlPlaneReconciler) removeInitConfiguration(ctx context.Cont
 	}
 	return nil
 }
+
+func (r *KubeadmControlPlaneReconciler) removeCertificatesExpiryAnnotation(ctx context.Context, currentKubeadmConfig *bootstrapv1.KubeadmConfig) error {
+	// Remove the certificates expiry annotation, so the expiry date of the renewed certificates is going to be
+	// detected again by reconcileCertificateExpiries once the in-place update is completed.
+	// Note: This is done only after marking the UpdateMachine hook as pending; if triggerInPlaceUpdate fails
+	// before, the Machine is still reported as having certificates about to expire and accordingly the request
+	// to renew certificates is preserved when completing the trigger.
+	if currentKubeadmConfig == nil {
+		return nil
+	}
+	if _, ok := currentKubeadmConfig.Annotations[clusterv1.MachineCertificatesExpiryDateAnnotation]; !ok {
+		return nil
+	}
+	origKubeadmConfig := currentKubeadmConfig.DeepCopy()
+	delete(currentKubeadmConfig.Annotations, clusterv1.MachineCertificatesExpiryDateAnnotation)
+	if err := r.Client.Patch(ctx, currentKubeadmConfig, client.MergeFrom(origKubeadmConfig)); err != nil {
+		return errors.Wrapf(err, "failed to patch KubeadmConfig: failed to remove %s annotation", clusterv1.MachineCertificatesExpiryDateAnnotation)
+	}
+	return nil
+}
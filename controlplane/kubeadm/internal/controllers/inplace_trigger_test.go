@@ -29,6 +29,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	runtimev1 "sigs.k8s.io/cluster-api/api/runtime/v1beta2"
 	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
@@ -118,6 +119,8 @@ func Test_triggerInPlaceUpdate(t *testing.T) {
 	currentKubeadmConfigWithInitConfiguration := currentKubeadmConfig.DeepCopy()
 	currentKubeadmConfigWithInitConfiguration.Spec.InitConfiguration.NodeRegistration = currentKubeadmConfigWithInitConfiguration.Spec.JoinConfiguration.NodeRegistration
 	currentKubeadmConfigWithInitConfiguration.Spec.JoinConfiguration = bootstrapv1.JoinConfiguration{}
+	currentKubeadmConfigWithCertificatesExpiry := currentKubeadmConfig.DeepCopy()
+	currentKubeadmConfigWithCertificatesExpiry.Annotations[clusterv1.MachineCertificatesExpiryDateAnnotation] = "2026-01-01T00:00:00Z"
 	desiredKubeadmConfig := currentKubeadmConfig.DeepCopy()
 	desiredKubeadmConfig.Spec.ClusterConfiguration.Etcd.Local.ImageTag = "3.6.4-0"
 
@@ -163,6 +166,8 @@ func Test_triggerInPlaceUpdate(t *testing.T) {
 		desiredMachine                              *clusterv1.Machine
 		desiredInfraMachine                         *unstructured.Unstructured
 		desiredKubeadmConfig                        *bootstrapv1.KubeadmConfig
+		renewCertificates                           bool
+		wantKubeadmConfigAnnotations                map[string]string
 		wantError                                   bool
 		wantErrorMessage                            string
 	}{
@@ -217,6 +222,21 @@ func Test_triggerInPlaceUpdate(t *testing.T) {
 			desiredInfraMachine:                 desiredInfraMachine,
 			desiredKubeadmConfig:                desiredKubeadmConfig,
 		},
+		{
+			name:                 "Trigger in-place update: renew certificates",
+			currentMachine:       currentMachine,
+			currentInfraMachine:  currentInfraMachine,
+			currentKubeadmConfig: currentKubeadmConfigWithCertificatesExpiry,
+			desiredMachine:       desiredMachine,
+			desiredInfraMachine:  desiredInfraMachine,
+			desiredKubeadmConfig: desiredKubeadmConfig,
+			renewCertificates:    true,
+			wantKubeadmConfigAnnotations: map[string]string{
+				"annotation-1":                             "annotation-value-1",
+				clusterv1.UpdateInProgressAnnotation:       "",
+				controlplanev1.RenewCertificatesAnnotation: "",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -276,6 +296,7 @@ func Test_triggerInPlaceUpdate(t *testing.T) {
 				DesiredMachine:       tt.desiredMachine.DeepCopy(),
 				DesiredInfraMachine:  tt.desiredInfraMachine.DeepCopy(),
 				DesiredKubeadmConfig: tt.desiredKubeadmConfig.DeepCopy(),
+				RenewCertificates:    tt.renewCertificates,
 			}
 
 			r := KubeadmControlPlaneReconciler{
@@ -313,10 +334,14 @@ func Test_triggerInPlaceUpdate(t *testing.T) {
 
 			gotKubeadmConfig := &bootstrapv1.KubeadmConfig{}
 			g.Expect(env.GetAPIReader().Get(ctx, client.ObjectKeyFromObject(tt.desiredKubeadmConfig), gotKubeadmConfig)).To(Succeed())
-			g.Expect(gotKubeadmConfig.Annotations).To(Equal(map[string]string{
-				"annotation-1":                       "annotation-value-1",
-				clusterv1.UpdateInProgressAnnotation: "",
-			}))
+			wantKubeadmConfigAnnotations := tt.wantKubeadmConfigAnnotations
+			if wantKubeadmConfigAnnotations == nil {
+				wantKubeadmConfigAnnotations = map[string]string{
+					"annotation-1":                       "annotation-value-1",
+					clusterv1.UpdateInProgressAnnotation: "",
+				}
+			}
+			g.Expect(gotKubeadmConfig.Annotations).To(Equal(wantKubeadmConfigAnnotations))
 			g.Expect(gotKubeadmConfig.Spec).To(BeComparableTo(tt.desiredKubeadmConfig.Spec))
 		})
 	}
@@ -19,11 +19,13 @@ package etcd
 import (
 	"context"
 	"crypto/tls"
+	"io"
 	"net"
 	"time"
 
 	"github.com/pkg/errors"
 	"go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 	clientv3 "go.etcd.io/etcd/client/v3"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
@@ -38,12 +40,16 @@ type GRPCDial func(ctx context.Context, addr string) (net.Conn, error)
 // etcd wraps the etcd client from etcd's clientv3 package.
 // This interface is implemented by both the clientv3 package and the backoff adapter that adds retries to the client.
 type etcd interface {
+	AlarmDisarm(ctx context.Context, m *clientv3.AlarmMember) (*clientv3.AlarmResponse, error)
 	AlarmList(ctx context.Context) (*clientv3.AlarmResponse, error)
 	Close() error
+	Compact(ctx context.Context, rev int64, opts ...clientv3.CompactOption) (*clientv3.CompactResponse, error)
+	Defragment(ctx context.Context, endpoint string) (*clientv3.DefragmentResponse, error)
 	Endpoints() []string
 	MemberList(ctx context.Context, opts ...clientv3.OpOption) (*clientv3.MemberListResponse, error)
 	MemberRemove(ctx context.Context, id uint64) (*clientv3.MemberRemoveResponse, error)
 	MoveLeader(ctx context.Context, id uint64) (*clientv3.MoveLeaderResponse, error)
+	SnapshotWithVersion(ctx context.Context) (*clientv3.SnapshotResponse, error)
 	Status(ctx context.Context, endpoint string) (*clientv3.StatusResponse, error)
 }
 
@@ -83,6 +89,16 @@ const (
 // for read and write operations to etcd.
 const DefaultCallTimeout = 15 * time.Second
 
+// SnapshotTimeout represents the duration that the etcd client waits at most
+// for streaming a snapshot from etcd.
+// NOTE: snapshots are streamed through the API server proxy, so this is longer than DefaultCallTimeout.
+const SnapshotTimeout = 5 * time.Minute
+
+// DefragmentTimeout represents the duration that the etcd client waits at most
+// for the defragmentation of an etcd member.
+// NOTE: defragmentation blocks the member until it completes, and it can take long for big databases.
+const DefragmentTimeout = 5 * time.Minute
+
 // AlarmTypeName provides a text translation for AlarmType codes.
 var AlarmTypeName = map[AlarmType]string{
 	AlarmOK:      "NONE",
@@ -90,6 +106,25 @@ var AlarmTypeName = map[AlarmType]string{
 	AlarmCorrupt: "CORRUPT",
 }
 
+// MemberStatus describes the status of the etcd member the client is connected to.
+type MemberStatus struct {
+	// MemberID is the ID of the member.
+	MemberID uint64
+
+	// LeaderID is the ID of the current leader of the cluster, as seen by the member.
+	LeaderID uint64
+
+	// Revision is the current revision of the key-value store.
+	Revision int64
+
+	// DBSize is the size of the backend database of the member, in bytes.
+	DBSize int64
+
+	// DBSizeInUse is the size of the backend database of the member logically in use, in bytes.
+	// The difference between DBSize and DBSizeInUse can be reclaimed by defragmenting the member.
+	DBSizeInUse int64
+}
+
 // Adapted from kubeadm.
 
 // Member struct defines an etcd member; it is used to avoid spreading
@@ -255,3 +290,75 @@ func (c *Client) Alarms(ctx context.Context) ([]MemberAlarm, error) {
 
 	return memberAlarms, nil
 }
+
+// Snapshot streams a point-in-time snapshot of the etcd member the client is connected to into w,
+// and returns the number of bytes written.
+func (c *Client) Snapshot(ctx context.Context, w io.Writer) (int64, error) {
+	ctx, cancel := context.WithTimeoutCause(ctx, SnapshotTimeout, errors.New("snapshot timeout expired"))
+	defer cancel()
+
+	resp, err := c.EtcdClient.SnapshotWithVersion(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get etcd snapshot")
+	}
+	defer resp.Snapshot.Close()
+
+	size, err := io.Copy(w, resp.Snapshot)
+	if err != nil {
+		return size, errors.Wrap(err, "failed to read etcd snapshot")
+	}
+	return size, nil
+}
+
+// Status returns the status of the etcd member the client is connected to.
+func (c *Client) Status(ctx context.Context) (*MemberStatus, error) {
+	ctx, cancel := context.WithTimeoutCause(ctx, c.CallTimeout, errors.New("call timeout expired"))
+	defer cancel()
+
+	status, err := c.EtcdClient.Status(ctx, c.Endpoint)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get etcd status")
+	}
+
+	return &MemberStatus{
+		MemberID:    status.Header.GetMemberId(),
+		LeaderID:    status.Leader,
+		Revision:    status.Header.GetRevision(),
+		DBSize:      status.DbSize,
+		DBSizeInUse: status.DbSizeInUse,
+	}, nil
+}
+
+// Compact compacts the key-value store history up to the given revision, and waits for the compaction
+// to be physically applied, so the space of the compacted revisions can be reclaimed by defragmentation.
+// NOTE: Compacting at a revision already compacted is a no-op.
+func (c *Client) Compact(ctx context.Context, revision int64) error {
+	ctx, cancel := context.WithTimeoutCause(ctx, c.CallTimeout, errors.New("call timeout expired"))
+	defer cancel()
+
+	if _, err := c.EtcdClient.Compact(ctx, revision, clientv3.WithCompactPhysical()); err != nil && !errors.Is(err, rpctypes.ErrCompacted) {
+		return errors.Wrapf(err, "failed to compact etcd at revision %d", revision)
+	}
+	return nil
+}
+
+// Defragment defragments the etcd member the client is connected to.
+func (c *Client) Defragment(ctx context.Context) error {
+	ctx, cancel := context.WithTimeoutCause(ctx, DefragmentTimeout, errors.New("defragment timeout expired"))
+	defer cancel()
+
+	_, err := c.EtcdClient.Defragment(ctx, c.Endpoint)
+	return errors.Wrap(err, "failed to defragment etcd member")
+}
+
+// DisarmAlarm disarms an alarm raised by a member.
+func (c *Client) DisarmAlarm(ctx context.Context, alarm MemberAlarm) error {
+	ctx, cancel := context.WithTimeoutCause(ctx, c.CallTimeout, errors.New("call timeout expired"))
+	defer cancel()
+
+	_, err := c.EtcdClient.AlarmDisarm(ctx, &clientv3.AlarmMember{
+		MemberID: alarm.MemberID,
+		Alarm:    etcdserverpb.AlarmType(alarm.Type),
+	})
+	return errors.Wrapf(err, "failed to disarm etcd alarm %s for member %x", AlarmTypeName[alarm.Type], alarm.MemberID)
+}
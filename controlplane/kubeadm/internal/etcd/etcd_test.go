@@ -17,11 +17,13 @@ limitations under the License.
 package etcd
 
 import (
+	"bytes"
 	"testing"
 
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
 	"go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 	clientv3 "go.etcd.io/etcd/client/v3"
 	ctrl "sigs.k8s.io/controller-runtime"
 
@@ -61,6 +63,18 @@ func TestEtcdMembers_WithErrors(t *testing.T) {
 
 	err = client.RemoveMember(ctx, 1234)
 	g.Expect(err).To(HaveOccurred())
+
+	_, err = client.Snapshot(ctx, &bytes.Buffer{})
+	g.Expect(err).To(HaveOccurred())
+
+	err = client.Compact(ctx, 100)
+	g.Expect(err).To(HaveOccurred())
+
+	err = client.Defragment(ctx)
+	g.Expect(err).To(HaveOccurred())
+
+	err = client.DisarmAlarm(ctx, MemberAlarm{MemberID: 1234, Type: AlarmNoSpace})
+	g.Expect(err).To(HaveOccurred())
 }
 
 func TestEtcdMembers_WithSuccess(t *testing.T) {
@@ -77,7 +91,13 @@ func TestEtcdMembers_WithSuccess(t *testing.T) {
 		MoveLeaderResponse:   &clientv3.MoveLeaderResponse{},
 		MemberRemoveResponse: &clientv3.MemberRemoveResponse{},
 		AlarmResponse:        &clientv3.AlarmResponse{},
-		StatusResponse:       &clientv3.StatusResponse{},
+		SnapshotData:         []byte("snapshot"),
+		StatusResponse: &clientv3.StatusResponse{
+			Header:      &etcdserverpb.ResponseHeader{MemberId: 1234, Revision: 100},
+			Leader:      1234,
+			DbSize:      2000,
+			DbSizeInUse: 500,
+		},
 	}
 
 	client, err := newEtcdClient(ctx, fakeEtcdClient, DefaultCallTimeout)
@@ -92,4 +112,31 @@ func TestEtcdMembers_WithSuccess(t *testing.T) {
 
 	err = client.RemoveMember(ctx, 1234)
 	g.Expect(err).ToNot(HaveOccurred())
+
+	snapshot := &bytes.Buffer{}
+	size, err := client.Snapshot(ctx, snapshot)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(size).To(Equal(int64(8)))
+	g.Expect(snapshot.String()).To(Equal("snapshot"))
+
+	status, err := client.Status(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(status).To(Equal(&MemberStatus{MemberID: 1234, LeaderID: 1234, Revision: 100, DBSize: 2000, DBSizeInUse: 500}))
+
+	err = client.Compact(ctx, status.Revision)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(fakeEtcdClient.CompactedRevision).To(Equal(int64(100)))
+
+	fakeEtcdClient.ErrorResponse = rpctypes.ErrCompacted
+	err = client.Compact(ctx, status.Revision)
+	g.Expect(err).ToNot(HaveOccurred())
+	fakeEtcdClient.ErrorResponse = nil
+
+	err = client.Defragment(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(fakeEtcdClient.Defragmented).To(BeTrue())
+
+	err = client.DisarmAlarm(ctx, MemberAlarm{MemberID: 1234, Type: AlarmNoSpace})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(fakeEtcdClient.DisarmedAlarms).To(Equal([]*clientv3.AlarmMember{{MemberID: 1234, Alarm: etcdserverpb.AlarmType_NOSPACE}}))
 }
@@ -18,7 +18,9 @@ limitations under the License.
 package fake
 
 import (
+	"bytes"
 	"context"
+	"io"
 
 	clientv3 "go.etcd.io/etcd/client/v3"
 )
@@ -29,10 +31,14 @@ type FakeEtcdClient struct { //nolint:revive
 	MemberListResponse   *clientv3.MemberListResponse
 	MemberRemoveResponse *clientv3.MemberRemoveResponse
 	MoveLeaderResponse   *clientv3.MoveLeaderResponse
+	SnapshotData         []byte
 	StatusResponse       *clientv3.StatusResponse
 	ErrorResponse        error
 	MovedLeader          uint64
 	RemovedMember        uint64
+	CompactedRevision    int64
+	Defragmented         bool
+	DisarmedAlarms       []*clientv3.AlarmMember
 }
 
 func (c *FakeEtcdClient) Endpoints() []string {
@@ -48,10 +54,25 @@ func (c *FakeEtcdClient) Close() error {
 	return nil
 }
 
+func (c *FakeEtcdClient) AlarmDisarm(_ context.Context, m *clientv3.AlarmMember) (*clientv3.AlarmResponse, error) {
+	c.DisarmedAlarms = append(c.DisarmedAlarms, m)
+	return &clientv3.AlarmResponse{}, c.ErrorResponse
+}
+
 func (c *FakeEtcdClient) AlarmList(_ context.Context) (*clientv3.AlarmResponse, error) {
 	return c.AlarmResponse, c.ErrorResponse
 }
 
+func (c *FakeEtcdClient) Compact(_ context.Context, rev int64, _ ...clientv3.CompactOption) (*clientv3.CompactResponse, error) {
+	c.CompactedRevision = rev
+	return &clientv3.CompactResponse{}, c.ErrorResponse
+}
+
+func (c *FakeEtcdClient) Defragment(_ context.Context, _ string) (*clientv3.DefragmentResponse, error) {
+	c.Defragmented = true
+	return &clientv3.DefragmentResponse{}, c.ErrorResponse
+}
+
 func (c *FakeEtcdClient) MemberList(_ context.Context, _ ...clientv3.OpOption) (*clientv3.MemberListResponse, error) {
 	return c.MemberListResponse, c.ErrorResponse
 }
@@ -62,3 +83,10 @@ func (c *FakeEtcdClient) MemberRemove(_ context.Context, i uint64) (*clientv3.Me
 func (c *FakeEtcdClient) Status(_ context.Context, _ string) (*clientv3.StatusResponse, error) {
 	return c.StatusResponse, nil
 }
+
+func (c *FakeEtcdClient) SnapshotWithVersion(_ context.Context) (*clientv3.SnapshotResponse, error) {
+	if c.ErrorResponse != nil {
+		return nil, c.ErrorResponse
+	}
+	return &clientv3.SnapshotResponse{Snapshot: io.NopCloser(bytes.NewReader(c.SnapshotData))}, nil
+}
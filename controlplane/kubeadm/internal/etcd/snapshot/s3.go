@@ -0,0 +1,387 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snapshot
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// emptyPayloadHash is the SHA256 of an empty payload.
+	emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+	// unsignedPayload is used as payload hash when uploading snapshots, so they can be streamed.
+	unsignedPayload = "UNSIGNED-PAYLOAD"
+
+	// maxErrorBodySize is the max number of bytes read from the body of an error response.
+	maxErrorBodySize = 1024
+
+	// amzDateFormat and scopeDateFormat are the formats of the request time and of the date in the credential scope.
+	amzDateFormat   = "20060102T150405Z"
+	scopeDateFormat = "20060102"
+
+	// s3RequestTimeout is the timeout of requests to the S3-compatible object storage, including the time
+	// to upload or download a snapshot.
+	s3RequestTimeout = 10 * time.Minute
+)
+
+// S3Store stores etcd snapshots in a bucket of an S3-compatible object storage.
+// Snapshots are stored with key <KeyPrefix>/<snapshot name>, and buckets are addressed using path-style URLs.
+// NOTE: S3Store implements the minimal subset of the S3 API required to store etcd snapshots, i.e.
+// PutObject, GetObject, DeleteObject and ListObjectsV2, with requests authenticated using AWS Signature Version 4.
+type S3Store struct {
+	Endpoint        string
+	Bucket          string
+	KeyPrefix       string
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+	KCPName         string
+	HTTPClient      *http.Client
+
+	// now returns the current time, it can be overridden in tests.
+	now func() time.Time
+}
+
+var _ URLStore = &S3Store{}
+
+// Put uploads a snapshot to the bucket.
+func (s *S3Store) Put(ctx context.Context, name string, r io.Reader, size int64) error {
+	resp, err := s.do(ctx, http.MethodPut, s.key(name), nil, r, size)
+	if err != nil {
+		return errors.Wrapf(err, "failed to store etcd snapshot %s", name)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errors.Wrapf(responseError(resp), "failed to store etcd snapshot %s", name)
+	}
+	return nil
+}
+
+// Get downloads a snapshot from the bucket and writes it into w.
+func (s *S3Store) Get(ctx context.Context, name string, w io.Writer) error {
+	resp, err := s.do(ctx, http.MethodGet, s.key(name), nil, nil, 0)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get etcd snapshot %s", name)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errors.Wrapf(responseError(resp), "failed to get etcd snapshot %s", name)
+	}
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return errors.Wrapf(err, "failed to get etcd snapshot %s", name)
+	}
+	return nil
+}
+
+// listBucketResult is the response of ListObjectsV2.
+type listBucketResult struct {
+	Contents []struct {
+		Key string `xml:"Key"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// List returns the snapshots in the bucket, from the oldest to the newest.
+func (s *S3Store) List(ctx context.Context) ([]Info, error) {
+	infos := []Info{}
+	continuationToken := ""
+	for {
+		query := url.Values{}
+		query.Set("list-type", "2")
+		query.Set("prefix", s.key(namePrefix(s.KCPName)))
+		if continuationToken != "" {
+			query.Set("continuation-token", continuationToken)
+		}
+
+		result, err := s.list(ctx, query)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list etcd snapshots")
+		}
+		for _, c := range result.Contents {
+			name := path.Base(c.Key)
+			t, ok := parseName(s.KCPName, name)
+			if !ok {
+				continue
+			}
+			infos = append(infos, Info{Name: name, Time: t})
+		}
+
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			break
+		}
+		continuationToken = result.NextContinuationToken
+	}
+	sortInfos(infos)
+	return infos, nil
+}
+
+func (s *S3Store) list(ctx context.Context, query url.Values) (*listBucketResult, error) {
+	resp, err := s.do(ctx, http.MethodGet, "", query, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, responseError(resp)
+	}
+
+	result := &listBucketResult{}
+	if err := xml.NewDecoder(resp.Body).Decode(result); err != nil {
+		return nil, errors.Wrap(err, "failed to decode response")
+	}
+	return result, nil
+}
+
+// Delete deletes a snapshot from the bucket.
+func (s *S3Store) Delete(ctx context.Context, name string) error {
+	resp, err := s.do(ctx, http.MethodDelete, s.key(name), nil, nil, 0)
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete etcd snapshot %s", name)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return errors.Wrapf(responseError(resp), "failed to delete etcd snapshot %s", name)
+	}
+	return nil
+}
+
+func (s *S3Store) key(name string) string {
+	if s.KeyPrefix == "" {
+		return name
+	}
+	return s.KeyPrefix + "/" + name
+}
+
+// do sends a signed request for an object in the bucket, or for the bucket itself if key is empty.
+func (s *S3Store) do(ctx context.Context, method, key string, query url.Values, body io.Reader, size int64) (*http.Response, error) {
+	u, err := s.url(key, query)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	payloadHash := emptyPayloadHash
+	if body != nil {
+		req.ContentLength = size
+		payloadHash = unsignedPayload
+	}
+	s.sign(req, payloadHash)
+
+	httpClient := s.HTTPClient
+	if httpClient == nil {
+		if httpClient, err = NewS3HTTPClient(nil); err != nil {
+			return nil, err
+		}
+	}
+	return httpClient.Do(req)
+}
+
+// NewS3HTTPClient returns the HTTP client used to access an S3-compatible object storage.
+// The client uses the proxy configured via the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables
+// and, if caData is set, trusts the certificate authorities in caData in addition to the system ones.
+// NOTE: Keep-alives are disabled because a new client is created for every KubeadmControlPlane
+// and snapshots are taken infrequently; this ensures idle connections are not leaked.
+func NewS3HTTPClient(caData []byte) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DisableKeepAlives = true
+	if len(caData) > 0 {
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil {
+			rootCAs = x509.NewCertPool()
+		}
+		if !rootCAs.AppendCertsFromPEM(caData) {
+			return nil, errors.New("failed to parse certificate authorities for the S3 storage")
+		}
+		transport.TLSClientConfig = &tls.Config{
+			RootCAs:    rootCAs,
+			MinVersion: tls.VersionTLS12,
+		}
+	}
+	return &http.Client{
+		Transport: transport,
+		Timeout:   s3RequestTimeout,
+	}, nil
+}
+
+// url returns the URL of an object in the bucket, or of the bucket itself if key is empty.
+func (s *S3Store) url(key string, query url.Values) (*url.URL, error) {
+	u, err := url.Parse(s.Endpoint)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid endpoint %q", s.Endpoint)
+	}
+	segments := []string{s.Bucket}
+	if key != "" {
+		segments = append(segments, strings.Split(key, "/")...)
+	}
+	for i := range segments {
+		segments[i] = url.PathEscape(segments[i])
+	}
+	u.RawPath = strings.TrimSuffix(u.Path, "/") + "/" + strings.Join(segments, "/")
+	u.Path, err = url.PathUnescape(u.RawPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid key %q", key)
+	}
+	u.RawQuery = canonicalQuery(query)
+	return u, nil
+}
+
+// GetURL returns a presigned URL to download a snapshot from the bucket, which is valid for the given duration.
+func (s *S3Store) GetURL(name string, expires time.Duration) (string, error) {
+	u, err := s.url(s.key(name), nil)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get URL for etcd snapshot %s", name)
+	}
+	s.presign(u, expires)
+	return u.String(), nil
+}
+
+// sign adds the AWS Signature Version 4 authorization headers to the request.
+// See https://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-header-based-auth.html.
+func (s *S3Store) sign(req *http.Request, payloadHash string) {
+	t := s.currentTime()
+	amzDate := t.Format(amzDateFormat)
+
+	req.Header.Set("x-amz-date", amzDate)
+	req.Header.Set("x-amz-content-sha256", payloadHash)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalHeaders := fmt.Sprintf("host:%s\nx-amz-content-sha256:%s\nx-amz-date:%s\n", req.URL.Host, payloadHash, amzDate)
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope, signature := s.signature(t, canonicalRequest)
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.AccessKeyID, scope, signedHeaders, signature))
+}
+
+// presign adds the AWS Signature Version 4 query parameters to the URL of a GET request, so the URL
+// can be used without credentials until it expires.
+// See https://docs.aws.amazon.com/AmazonS3/latest/API/sigv4-query-string-auth.html.
+func (s *S3Store) presign(u *url.URL, expires time.Duration) {
+	t := s.currentTime()
+
+	query := u.Query()
+	query.Set("X-Amz-Algorithm", "AWS4-HMAC-SHA256")
+	query.Set("X-Amz-Credential", fmt.Sprintf("%s/%s", s.AccessKeyID, s.scope(t)))
+	query.Set("X-Amz-Date", t.Format(amzDateFormat))
+	query.Set("X-Amz-Expires", strconv.Itoa(int(expires.Seconds())))
+	query.Set("X-Amz-SignedHeaders", "host")
+	u.RawQuery = canonicalQuery(query)
+
+	canonicalRequest := strings.Join([]string{
+		http.MethodGet,
+		u.EscapedPath(),
+		u.RawQuery,
+		fmt.Sprintf("host:%s\n", u.Host),
+		"host",
+		unsignedPayload,
+	}, "\n")
+
+	_, signature := s.signature(t, canonicalRequest)
+	u.RawQuery += "&X-Amz-Signature=" + signature
+}
+
+// signature returns the credential scope and the signature of a canonical request.
+func (s *S3Store) signature(t time.Time, canonicalRequest string) (string, string) {
+	scope := s.scope(t)
+	canonicalRequestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		t.Format(amzDateFormat),
+		scope,
+		hex.EncodeToString(canonicalRequestHash[:]),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.SecretAccessKey), t.Format(scopeDateFormat))
+	key = hmacSHA256(key, s.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	return scope, hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+func (s *S3Store) scope(t time.Time) string {
+	return fmt.Sprintf("%s/%s/s3/aws4_request", t.Format(scopeDateFormat), s.Region)
+}
+
+func (s *S3Store) currentTime() time.Time {
+	if s.now != nil {
+		return s.now().UTC()
+	}
+	return time.Now().UTC()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	_, _ = h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// canonicalQuery returns the query string sorted by key and encoded as required by AWS Signature Version 4.
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := []string{}
+	for _, k := range keys {
+		values := append([]string{}, query[k]...)
+		sort.Strings(values)
+		for _, v := range values {
+			parts = append(parts, uriEncode(k)+"="+uriEncode(v))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+func uriEncode(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
+
+func responseError(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	return errors.Errorf("unexpected response status %q: %s", resp.Status, strings.TrimSpace(string(body)))
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snapshot
+
+import (
+	"context"
+	"io"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+)
+
+const (
+	// SecretDataKey is the key of the snapshot in Secrets storing etcd snapshots.
+	SecretDataKey = "snapshot"
+
+	// SecretType is the type of Secrets storing etcd snapshots.
+	SecretType corev1.SecretType = "controlplane.cluster.x-k8s.io/etcd-snapshot"
+
+	// MaxSecretSnapshotSize is the max size of a snapshot that can be stored in a Secret.
+	// NOTE: A Secret can't be bigger than 1MiB; some room is left for the Secret metadata.
+	MaxSecretSnapshotSize = 1024*1024 - 32*1024
+)
+
+// SecretStore stores etcd snapshots in Secrets in the namespace of the KubeadmControlPlane.
+// NOTE: Secrets storing etcd snapshots intentionally have no owner reference and no cluster name label,
+// so they are not deleted when the KubeadmControlPlane is deleted and they are not cached by the controller.
+type SecretStore struct {
+	Client    client.Client
+	Namespace string
+	KCPName   string
+}
+
+var _ Store = &SecretStore{}
+
+// Put stores a snapshot in a Secret.
+func (s *SecretStore) Put(ctx context.Context, name string, r io.Reader, size int64) error {
+	if size > MaxSecretSnapshotSize {
+		return errors.Errorf("failed to store etcd snapshot %s: snapshot is %d bytes, but the Secret storage supports up to %d bytes; please use the S3 storage",
+			name, size, MaxSecretSnapshotSize)
+	}
+	data, err := io.ReadAll(io.LimitReader(r, size))
+	if err != nil {
+		return errors.Wrapf(err, "failed to store etcd snapshot %s", name)
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: s.Namespace,
+			Name:      name,
+			Labels: map[string]string{
+				controlplanev1.EtcdSnapshotLabel: s.KCPName,
+			},
+		},
+		Type: SecretType,
+		Data: map[string][]byte{
+			SecretDataKey: data,
+		},
+	}
+	if err := s.Client.Create(ctx, secret); err != nil {
+		return errors.Wrapf(err, "failed to store etcd snapshot %s: failed to create Secret %s", name, klog.KObj(secret))
+	}
+	return nil
+}
+
+// Get writes the snapshot stored in a Secret into w.
+func (s *SecretStore) Get(ctx context.Context, name string, w io.Writer) error {
+	secret := &corev1.Secret{}
+	if err := s.Client.Get(ctx, client.ObjectKey{Namespace: s.Namespace, Name: name}, secret); err != nil {
+		return errors.Wrapf(err, "failed to get etcd snapshot %s: failed to get Secret %s", name, klog.KRef(s.Namespace, name))
+	}
+	if secret.Labels[controlplanev1.EtcdSnapshotLabel] != s.KCPName {
+		return errors.Errorf("failed to get etcd snapshot %s: Secret %s is not an etcd snapshot of KubeadmControlPlane %s",
+			name, klog.KObj(secret), klog.KRef(s.Namespace, s.KCPName))
+	}
+	if _, err := w.Write(secret.Data[SecretDataKey]); err != nil {
+		return errors.Wrapf(err, "failed to get etcd snapshot %s", name)
+	}
+	return nil
+}
+
+// List returns the snapshots stored in Secrets, from the oldest to the newest.
+func (s *SecretStore) List(ctx context.Context) ([]Info, error) {
+	// NOTE: Only metadata is listed to avoid reading all snapshots.
+	secrets := &metav1.PartialObjectMetadataList{}
+	secrets.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("SecretList"))
+	if err := s.Client.List(ctx, secrets, client.InNamespace(s.Namespace), client.MatchingLabels{controlplanev1.EtcdSnapshotLabel: s.KCPName}); err != nil {
+		return nil, errors.Wrap(err, "failed to list etcd snapshots: failed to list Secrets")
+	}
+
+	infos := []Info{}
+	for _, secret := range secrets.Items {
+		t, ok := parseName(s.KCPName, secret.Name)
+		if !ok {
+			continue
+		}
+		infos = append(infos, Info{Name: secret.Name, Time: t})
+	}
+	sortInfos(infos)
+	return infos, nil
+}
+
+// Delete deletes the Secret storing a snapshot.
+func (s *SecretStore) Delete(ctx context.Context, name string) error {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: s.Namespace,
+			Name:      name,
+		},
+	}
+	if err := s.Client.Delete(ctx, secret); err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "failed to delete etcd snapshot %s: failed to delete Secret %s", name, klog.KObj(secret))
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+)
+
+func TestSecretStore(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	notASnapshot := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "kcp-etcd-snapshot-foo",
+			Labels:    map[string]string{controlplanev1.EtcdSnapshotLabel: "kcp"},
+		},
+	}
+	c := fake.NewClientBuilder().WithObjects(notASnapshot).Build()
+	s := &SecretStore{
+		Client:    c,
+		Namespace: metav1.NamespaceDefault,
+		KCPName:   "kcp",
+	}
+	other := &SecretStore{
+		Client:    c,
+		Namespace: metav1.NamespaceDefault,
+		KCPName:   "other-kcp",
+	}
+
+	t1 := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Hour)
+	g.Expect(s.Put(ctx, Name("kcp", t2), strings.NewReader("data-2"), 6)).To(Succeed())
+	g.Expect(s.Put(ctx, Name("kcp", t1), strings.NewReader("data-1"), 6)).To(Succeed())
+	g.Expect(other.Put(ctx, Name("other-kcp", t1), strings.NewReader("data-other"), 10)).To(Succeed())
+
+	secret := &corev1.Secret{}
+	g.Expect(c.Get(ctx, client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: "kcp-etcd-snapshot-20260101100000"}, secret)).To(Succeed())
+	g.Expect(secret.Type).To(Equal(SecretType))
+	g.Expect(secret.Labels).To(Equal(map[string]string{controlplanev1.EtcdSnapshotLabel: "kcp"}))
+	g.Expect(secret.OwnerReferences).To(BeEmpty())
+
+	infos, err := s.List(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(infos).To(Equal([]Info{
+		{Name: "kcp-etcd-snapshot-20260101100000", Time: t1},
+		{Name: "kcp-etcd-snapshot-20260101110000", Time: t2},
+	}))
+
+	data := &bytes.Buffer{}
+	g.Expect(s.Get(ctx, "kcp-etcd-snapshot-20260101110000", data)).To(Succeed())
+	g.Expect(data.String()).To(Equal("data-2"))
+
+	// Snapshots of other KubeadmControlPlanes can't be read.
+	g.Expect(s.Get(ctx, "other-kcp-etcd-snapshot-20260101100000", data)).ToNot(Succeed())
+
+	g.Expect(s.Delete(ctx, "kcp-etcd-snapshot-20260101100000")).To(Succeed())
+	g.Expect(s.Delete(ctx, "does-not-exist")).To(Succeed())
+	infos, err = s.List(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(infos).To(Equal([]Info{
+		{Name: "kcp-etcd-snapshot-20260101110000", Time: t2},
+	}))
+
+	// Snapshots bigger than the max size of a Secret are rejected.
+	err = s.Put(ctx, Name("kcp", t2.Add(time.Hour)), strings.NewReader(""), MaxSecretSnapshotSize+1)
+	g.Expect(err).To(MatchError(ContainSubstring("please use the S3 storage")))
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package snapshot implements storages for etcd snapshots taken by the KubeadmControlPlane controller.
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+)
+
+const (
+	// AccessKeyIDKey is the key of the access key ID in the Secret with the credentials for the S3 storage.
+	AccessKeyIDKey = "accessKeyID"
+
+	// SecretAccessKeyKey is the key of the secret access key in the Secret with the credentials for the S3 storage.
+	SecretAccessKeyKey = "secretAccessKey"
+
+	// CACertKey is the key of the certificate authorities in the Secret referenced by caSecretName for the S3 storage.
+	CACertKey = "ca.crt"
+
+	// defaultS3Region is the region used when the region of the S3 storage is not set.
+	defaultS3Region = "us-east-1"
+
+	// timeFormat is the format of the time in snapshot names.
+	timeFormat = "20060102150405"
+)
+
+// Info describes an etcd snapshot in a Store.
+type Info struct {
+	// Name is the name of the snapshot.
+	Name string
+
+	// Time is when the snapshot has been taken.
+	Time time.Time
+}
+
+// Store stores etcd snapshots of a KubeadmControlPlane.
+// NOTE: snapshots are opaque to the Store; the KubeadmControlPlane controller compresses them before calling Put.
+type Store interface {
+	// Put stores a snapshot with the given name, reading size bytes from r.
+	Put(ctx context.Context, name string, r io.Reader, size int64) error
+
+	// Get writes the snapshot with the given name into w.
+	Get(ctx context.Context, name string, w io.Writer) error
+
+	// List returns the snapshots of the KubeadmControlPlane, from the oldest to the newest.
+	List(ctx context.Context) ([]Info, error)
+
+	// Delete deletes the snapshot with the given name; deleting a snapshot that doesn't exist is not an error.
+	Delete(ctx context.Context, name string) error
+}
+
+// URLStore is a Store which can provide time-limited URLs to download snapshots without credentials,
+// e.g. from the Machine restoring etcd from a snapshot.
+type URLStore interface {
+	Store
+
+	// GetURL returns a URL to download the snapshot with the given name, which is valid for the given duration.
+	GetURL(name string, expires time.Duration) (string, error)
+}
+
+// Name returns the name of the snapshot of a KubeadmControlPlane taken at the given time.
+func Name(kcpName string, t time.Time) string {
+	return fmt.Sprintf("%s%s", namePrefix(kcpName), t.UTC().Format(timeFormat))
+}
+
+func namePrefix(kcpName string) string {
+	return fmt.Sprintf("%s-etcd-snapshot-", kcpName)
+}
+
+// parseName returns the time a snapshot of a KubeadmControlPlane has been taken.
+// It returns false if name is not the name of a snapshot of the KubeadmControlPlane.
+func parseName(kcpName, name string) (time.Time, bool) {
+	suffix, ok := strings.CutPrefix(name, namePrefix(kcpName))
+	if !ok {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(timeFormat, suffix)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+func sortInfos(infos []Info) {
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Time.Before(infos[j].Time)
+	})
+}
+
+// NewStore returns the Store for the snapshot storage defined in the KubeadmControlPlane.
+// volumePath is the path where the PersistentVolumeClaim used by the PersistentVolumeClaim storage type
+// is mounted into the KubeadmControlPlane controller.
+func NewStore(ctx context.Context, c client.Client, kcp *controlplanev1.KubeadmControlPlane, volumePath string) (Store, error) {
+	storage := kcp.Spec.Etcd.Snapshot.Storage
+	switch storage.Type {
+	case controlplanev1.SecretEtcdSnapshotStorageType:
+		return &SecretStore{
+			Client:    c,
+			Namespace: kcp.Namespace,
+			KCPName:   kcp.Name,
+		}, nil
+	case controlplanev1.PersistentVolumeClaimEtcdSnapshotStorageType:
+		if volumePath == "" {
+			return nil, errors.Errorf("the %s storage requires a PersistentVolumeClaim mounted into the KubeadmControlPlane controller, "+
+				"with the mount path set via the --etcd-snapshot-volume-path flag", storage.Type)
+		}
+		return &VolumeStore{
+			Dir:     filepath.Join(volumePath, kcp.Namespace),
+			KCPName: kcp.Name,
+		}, nil
+	case controlplanev1.S3EtcdSnapshotStorageType:
+		credentials := &corev1.Secret{}
+		credentialsKey := client.ObjectKey{Namespace: kcp.Namespace, Name: storage.S3.CredentialsSecretName}
+		if err := c.Get(ctx, credentialsKey, credentials); err != nil {
+			return nil, errors.Wrapf(err, "failed to get Secret %s with the credentials for the S3 storage", klog.KRef(credentialsKey.Namespace, credentialsKey.Name))
+		}
+		accessKeyID, secretAccessKey := string(credentials.Data[AccessKeyIDKey]), string(credentials.Data[SecretAccessKeyKey])
+		if accessKeyID == "" || secretAccessKey == "" {
+			return nil, errors.Errorf("Secret %s with the credentials for the S3 storage must have the %s and %s keys",
+				klog.KRef(credentialsKey.Namespace, credentialsKey.Name), AccessKeyIDKey, SecretAccessKeyKey)
+		}
+
+		region := storage.S3.Region
+		if region == "" {
+			region = defaultS3Region
+		}
+		keyPrefix := kcp.Namespace
+		if storage.S3.Prefix != "" {
+			keyPrefix = strings.TrimSuffix(storage.S3.Prefix, "/") + "/" + kcp.Namespace
+		}
+
+		var caData []byte
+		if storage.S3.CASecretName != "" {
+			ca := &corev1.Secret{}
+			caKey := client.ObjectKey{Namespace: kcp.Namespace, Name: storage.S3.CASecretName}
+			if err := c.Get(ctx, caKey, ca); err != nil {
+				return nil, errors.Wrapf(err, "failed to get Secret %s with the certificate authorities for the S3 storage", klog.KRef(caKey.Namespace, caKey.Name))
+			}
+			if caData = ca.Data[CACertKey]; len(caData) == 0 {
+				return nil, errors.Errorf("Secret %s with the certificate authorities for the S3 storage must have the %s key",
+					klog.KRef(caKey.Namespace, caKey.Name), CACertKey)
+			}
+		}
+		httpClient, err := NewS3HTTPClient(caData)
+		if err != nil {
+			return nil, err
+		}
+		return &S3Store{
+			Endpoint:        strings.TrimSuffix(storage.S3.Endpoint, "/"),
+			Bucket:          storage.S3.Bucket,
+			KeyPrefix:       keyPrefix,
+			Region:          region,
+			AccessKeyID:     accessKeyID,
+			SecretAccessKey: secretAccessKey,
+			KCPName:         kcp.Name,
+			HTTPClient:      httpClient,
+		}, nil
+	default:
+		return nil, errors.Errorf("unknown etcd snapshot storage type %q", storage.Type)
+	}
+}
+
+// Prune deletes the oldest snapshots in the Store exceeding retention, and returns the names of the deleted snapshots.
+func Prune(ctx context.Context, s Store, retention int) ([]string, error) {
+	infos, err := s.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	deleted := []string{}
+	for i := 0; i < len(infos)-retention; i++ {
+		if err := s.Delete(ctx, infos[i].Name); err != nil {
+			return deleted, err
+		}
+		deleted = append(deleted, infos[i].Name)
+	}
+	return deleted, nil
+}
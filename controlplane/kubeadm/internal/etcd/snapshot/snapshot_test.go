@@ -0,0 +1,228 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snapshot
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+)
+
+func TestParseName(t *testing.T) {
+	tests := []struct {
+		name     string
+		kcpName  string
+		wantTime time.Time
+		wantOK   bool
+	}{
+		{
+			name:     "kcp-etcd-snapshot-20260101100000",
+			kcpName:  "kcp",
+			wantTime: time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC),
+			wantOK:   true,
+		},
+		{
+			name:    "kcp-etcd-snapshot-20260101100000",
+			kcpName: "other-kcp",
+			wantOK:  false,
+		},
+		{
+			name:    "kcp-etcd-snapshot-foo",
+			kcpName: "kcp",
+			wantOK:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			gotTime, gotOK := parseName(tt.kcpName, tt.name)
+			g.Expect(gotOK).To(Equal(tt.wantOK))
+			g.Expect(gotTime).To(Equal(tt.wantTime))
+		})
+	}
+}
+
+func TestPrune(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	s := &SecretStore{
+		Client:    fake.NewClientBuilder().Build(),
+		Namespace: metav1.NamespaceDefault,
+		KCPName:   "kcp",
+	}
+	t1 := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	for i := range 4 {
+		g.Expect(s.Put(ctx, Name("kcp", t1.Add(time.Duration(i)*time.Hour)), strings.NewReader("data"), 4)).To(Succeed())
+	}
+
+	deleted, err := Prune(ctx, s, 2)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(deleted).To(Equal([]string{"kcp-etcd-snapshot-20260101100000", "kcp-etcd-snapshot-20260101110000"}))
+
+	deleted, err = Prune(ctx, s, 2)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(deleted).To(BeEmpty())
+
+	infos, err := s.List(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(infos).To(HaveLen(2))
+}
+
+func TestNewStore(t *testing.T) {
+	kcp := func(storage controlplanev1.KubeadmControlPlaneEtcdSnapshotStorage) *controlplanev1.KubeadmControlPlane {
+		return &controlplanev1.KubeadmControlPlane{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns",
+				Name:      "kcp",
+			},
+			Spec: controlplanev1.KubeadmControlPlaneSpec{
+				Etcd: controlplanev1.KubeadmControlPlaneEtcdSpec{
+					Snapshot: controlplanev1.KubeadmControlPlaneEtcdSnapshotSpec{
+						IntervalSeconds: 3600,
+						Storage:         storage,
+					},
+				},
+			},
+		}
+	}
+	s3Storage := controlplanev1.KubeadmControlPlaneEtcdSnapshotStorage{
+		Type: controlplanev1.S3EtcdSnapshotStorageType,
+		S3: controlplanev1.KubeadmControlPlaneEtcdSnapshotS3Storage{
+			Endpoint:              "https://s3.example.com/",
+			Bucket:                "bucket",
+			Prefix:                "backups/",
+			CredentialsSecretName: "credentials",
+		},
+	}
+	credentials := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "credentials",
+		},
+		Data: map[string][]byte{
+			AccessKeyIDKey:     []byte("access-key-id"),
+			SecretAccessKeyKey: []byte("secret-access-key"),
+		},
+	}
+
+	tests := []struct {
+		name       string
+		kcp        *controlplanev1.KubeadmControlPlane
+		objs       []*corev1.Secret
+		volumePath string
+		wantStore  Store
+		wantErr    string
+	}{
+		{
+			name: "Secret storage",
+			kcp:  kcp(controlplanev1.KubeadmControlPlaneEtcdSnapshotStorage{Type: controlplanev1.SecretEtcdSnapshotStorageType}),
+			wantStore: &SecretStore{
+				Namespace: "ns",
+				KCPName:   "kcp",
+			},
+		},
+		{
+			name: "S3 storage",
+			kcp:  kcp(s3Storage),
+			objs: []*corev1.Secret{credentials},
+			wantStore: &S3Store{
+				Endpoint:        "https://s3.example.com",
+				Bucket:          "bucket",
+				KeyPrefix:       "backups/ns",
+				Region:          "us-east-1",
+				AccessKeyID:     "access-key-id",
+				SecretAccessKey: "secret-access-key",
+				KCPName:         "kcp",
+			},
+		},
+		{
+			name: "S3 storage fails if the CA Secret has no valid certificates",
+			kcp: kcp(func() controlplanev1.KubeadmControlPlaneEtcdSnapshotStorage {
+				storage := s3Storage
+				storage.S3.CASecretName = "ca"
+				return storage
+			}()),
+			objs: []*corev1.Secret{credentials, {
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "ca"},
+				Data:       map[string][]byte{CACertKey: []byte("invalid")},
+			}},
+			wantErr: "failed to parse certificate authorities",
+		},
+		{
+			name:       "PersistentVolumeClaim storage",
+			kcp:        kcp(controlplanev1.KubeadmControlPlaneEtcdSnapshotStorage{Type: controlplanev1.PersistentVolumeClaimEtcdSnapshotStorageType}),
+			volumePath: "/snapshots",
+			wantStore: &VolumeStore{
+				Dir:     "/snapshots/ns",
+				KCPName: "kcp",
+			},
+		},
+		{
+			name:    "PersistentVolumeClaim storage fails if no volume is mounted",
+			kcp:     kcp(controlplanev1.KubeadmControlPlaneEtcdSnapshotStorage{Type: controlplanev1.PersistentVolumeClaimEtcdSnapshotStorageType}),
+			wantErr: "--etcd-snapshot-volume-path",
+		},
+		{
+			name:    "S3 storage fails if the credentials Secret doesn't exist",
+			kcp:     kcp(s3Storage),
+			wantErr: "failed to get Secret ns/credentials",
+		},
+		{
+			name: "S3 storage fails if the credentials Secret has no credentials",
+			kcp:  kcp(s3Storage),
+			objs: []*corev1.Secret{{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "credentials"},
+			}},
+			wantErr: "must have the accessKeyID and secretAccessKey keys",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			c := fake.NewClientBuilder()
+			for _, o := range tt.objs {
+				c = c.WithObjects(o)
+			}
+
+			store, err := NewStore(context.Background(), c.Build(), tt.kcp, tt.volumePath)
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+
+			switch s := store.(type) {
+			case *SecretStore:
+				s.Client = nil
+			case *S3Store:
+				s.HTTPClient = nil
+			}
+			g.Expect(store).To(Equal(tt.wantStore))
+		})
+	}
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snapshot
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+// VolumeStore stores etcd snapshots as files in a directory, e.g. of a PersistentVolumeClaim mounted
+// into the KubeadmControlPlane controller.
+// NOTE: Snapshots are written to a temporary file first and then renamed, so incomplete snapshots
+// are never listed.
+type VolumeStore struct {
+	Dir     string
+	KCPName string
+}
+
+var _ Store = &VolumeStore{}
+
+// Put stores a snapshot in a file.
+func (s *VolumeStore) Put(_ context.Context, name string, r io.Reader, size int64) (reterr error) {
+	if err := os.MkdirAll(s.Dir, 0o700); err != nil {
+		return errors.Wrapf(err, "failed to store etcd snapshot %s: failed to create directory %s", name, s.Dir)
+	}
+
+	f, err := os.CreateTemp(s.Dir, ".tmp-"+name+"-")
+	if err != nil {
+		return errors.Wrapf(err, "failed to store etcd snapshot %s: failed to create temporary file", name)
+	}
+	defer func() {
+		if reterr != nil {
+			reterr = kerrors.NewAggregate([]error{reterr, f.Close(), os.Remove(f.Name())})
+		}
+	}()
+
+	if _, err := io.Copy(f, io.LimitReader(r, size)); err != nil {
+		return errors.Wrapf(err, "failed to store etcd snapshot %s", name)
+	}
+	if err := f.Sync(); err != nil {
+		return errors.Wrapf(err, "failed to store etcd snapshot %s", name)
+	}
+	if err := f.Close(); err != nil {
+		return errors.Wrapf(err, "failed to store etcd snapshot %s", name)
+	}
+	if err := os.Rename(f.Name(), filepath.Join(s.Dir, name)); err != nil {
+		return errors.Wrapf(err, "failed to store etcd snapshot %s", name)
+	}
+	return nil
+}
+
+// Get writes the snapshot stored in a file into w.
+func (s *VolumeStore) Get(_ context.Context, name string, w io.Writer) error {
+	if _, ok := parseName(s.KCPName, name); !ok {
+		return errors.Errorf("failed to get etcd snapshot %s: not an etcd snapshot of KubeadmControlPlane %s", name, s.KCPName)
+	}
+
+	f, err := os.Open(filepath.Join(s.Dir, name))
+	if err != nil {
+		return errors.Wrapf(err, "failed to get etcd snapshot %s", name)
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(w, f); err != nil {
+		return errors.Wrapf(err, "failed to get etcd snapshot %s", name)
+	}
+	return nil
+}
+
+// List returns the snapshots stored in files, from the oldest to the newest.
+func (s *VolumeStore) List(_ context.Context) ([]Info, error) {
+	entries, err := os.ReadDir(s.Dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []Info{}, nil
+		}
+		return nil, errors.Wrapf(err, "failed to list etcd snapshots: failed to read directory %s", s.Dir)
+	}
+
+	infos := []Info{}
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		t, ok := parseName(s.KCPName, entry.Name())
+		if !ok {
+			continue
+		}
+		infos = append(infos, Info{Name: entry.Name(), Time: t})
+	}
+	sortInfos(infos)
+	return infos, nil
+}
+
+// Delete deletes the file storing a snapshot.
+func (s *VolumeStore) Delete(_ context.Context, name string) error {
+	if _, ok := parseName(s.KCPName, name); !ok {
+		return errors.Errorf("failed to delete etcd snapshot %s: not an etcd snapshot of KubeadmControlPlane %s", name, s.KCPName)
+	}
+
+	if err := os.Remove(filepath.Join(s.Dir, name)); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "failed to delete etcd snapshot %s", name)
+	}
+	return nil
+}
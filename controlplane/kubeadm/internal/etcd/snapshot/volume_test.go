@@ -0,0 +1,81 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestVolumeStore(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	dir := filepath.Join(t.TempDir(), "default")
+	s := &VolumeStore{
+		Dir:     dir,
+		KCPName: "kcp",
+	}
+	other := &VolumeStore{
+		Dir:     dir,
+		KCPName: "other-kcp",
+	}
+
+	// Listing snapshots before the directory is created returns no snapshots.
+	infos, err := s.List(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(infos).To(BeEmpty())
+
+	t1 := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Hour)
+	g.Expect(s.Put(ctx, Name("kcp", t2), strings.NewReader("data-2"), 6)).To(Succeed())
+	g.Expect(s.Put(ctx, Name("kcp", t1), strings.NewReader("data-1"), 6)).To(Succeed())
+	g.Expect(other.Put(ctx, Name("other-kcp", t1), strings.NewReader("data-other"), 10)).To(Succeed())
+
+	// Temporary files of snapshots that are being stored are not listed.
+	g.Expect(os.WriteFile(filepath.Join(dir, ".tmp-kcp-etcd-snapshot-20260101120000-123"), []byte("partial"), 0o600)).To(Succeed())
+
+	infos, err = s.List(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(infos).To(Equal([]Info{
+		{Name: "kcp-etcd-snapshot-20260101100000", Time: t1},
+		{Name: "kcp-etcd-snapshot-20260101110000", Time: t2},
+	}))
+
+	data := &bytes.Buffer{}
+	g.Expect(s.Get(ctx, "kcp-etcd-snapshot-20260101110000", data)).To(Succeed())
+	g.Expect(data.String()).To(Equal("data-2"))
+
+	// Snapshots of other KubeadmControlPlanes can't be read or deleted.
+	g.Expect(s.Get(ctx, "other-kcp-etcd-snapshot-20260101100000", data)).ToNot(Succeed())
+	g.Expect(s.Delete(ctx, "other-kcp-etcd-snapshot-20260101100000")).ToNot(Succeed())
+
+	g.Expect(s.Delete(ctx, "kcp-etcd-snapshot-20260101100000")).To(Succeed())
+	g.Expect(s.Delete(ctx, "kcp-etcd-snapshot-20260101100000")).To(Succeed())
+	infos, err = s.List(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(infos).To(Equal([]Info{
+		{Name: "kcp-etcd-snapshot-20260101110000", Time: t2},
+	}))
+}
@@ -39,6 +39,7 @@ type UpToDateResult struct {
 	LogMessages              []string
 	ConditionMessages        []string
 	EligibleForInPlaceUpdate bool
+	RenewCertificates        bool
 	DesiredMachine           *clusterv1.Machine
 	CurrentInfraMachine      *unstructured.Unstructured
 	DesiredInfraMachine      *unstructured.Unstructured
@@ -46,6 +47,11 @@ type UpToDateResult struct {
 	DesiredKubeadmConfig     *bootstrapv1.KubeadmConfig
 }
 
+// isCARotationInProgress returns true if a certificate authorities rotation is in progress.
+func isCARotationInProgress(kcp *controlplanev1.KubeadmControlPlane) bool {
+	return kcp.Status.CARotation.Phase != "" && kcp.Status.CARotation.Phase != controlplanev1.CompletedCARotationPhase
+}
+
 // UpToDate checks if a Machine is up to date with the control plane's configuration.
 // If not, messages explaining why are provided with different level of detail for logs and conditions.
 func UpToDate(
@@ -75,10 +81,16 @@ func UpToDate(
 	}
 
 	// Machines whose certificates are about to expire.
+	// Note: If certificates must be renewed in-place, the Machine is still eligible for in-place update, and
+	// the in-place update is going to ask the UpdateMachine extension to renew certificates.
 	if collections.ShouldRolloutBefore(reconciliationTime, kcp.Spec.Rollout.Before)(machine) {
 		res.LogMessages = append(res.LogMessages, "certificates will expire soon, rolloutBefore expired")
 		res.ConditionMessages = append(res.ConditionMessages, "Certificates will expire soon")
-		res.EligibleForInPlaceUpdate = false
+		if kcp.Spec.Rollout.Before.CertificatesRenewalStrategy == controlplanev1.InPlaceCertificatesRenewalStrategy {
+			res.RenewCertificates = true
+		} else {
+			res.EligibleForInPlaceUpdate = false
+		}
 	}
 
 	// Machines that are scheduled for rollout (KCP.Spec.RolloutAfter set,
@@ -89,6 +101,14 @@ func UpToDate(
 		res.EligibleForInPlaceUpdate = false
 	}
 
+	// Machines created before the current phase of the certificate authorities rotation started,
+	// which must be replaced to pick up the certificate authorities of the current phase.
+	if isCARotationInProgress(kcp) && machine.CreationTimestamp.Before(&kcp.Status.CARotation.PhaseStartTime) {
+		res.LogMessages = append(res.LogMessages, fmt.Sprintf("certificate authorities rotation in phase %s", kcp.Status.CARotation.Phase))
+		res.ConditionMessages = append(res.ConditionMessages, "Certificate authorities are being rotated")
+		res.EligibleForInPlaceUpdate = false
+	}
+
 	// Machines that do not match with KCP config.
 	// Note: matchesMachineSpec will update res with desired and current objects if necessary.
 	matches, specLogMessages, specConditionMessages, err := matchesMachineSpec(ctx, c, infraMachines, kubeadmConfigs, kcp, cluster, machine, res)
@@ -1574,6 +1574,7 @@ func TestUpToDate(t *testing.T) {
 		machineConfigs                 map[string]*bootstrapv1.KubeadmConfig
 		expectUptoDate                 bool
 		expectEligibleForInPlaceUpdate bool
+		expectRenewCertificates        bool
 		expectLogMessages              []string
 		expectConditionMessages        []string
 	}{
@@ -1603,6 +1604,23 @@ func TestUpToDate(t *testing.T) {
 			expectLogMessages:              []string{"certificates will expire soon, rolloutBefore expired"},
 			expectConditionMessages:        []string{"Certificates will expire soon"},
 		},
+		{
+			name: "certificate are expiring soon and must be renewed in-place",
+			kcp: func() *controlplanev1.KubeadmControlPlane {
+				kcp := defaultKcp.DeepCopy()
+				kcp.Spec.Rollout.Before.CertificatesExpiryDays = 150 // renew if certificates will expire in less then 150 days.
+				kcp.Spec.Rollout.Before.CertificatesRenewalStrategy = controlplanev1.InPlaceCertificatesRenewalStrategy
+				return kcp
+			}(),
+			machine:                        defaultMachine, // certificates will expire in 100 days from now.
+			infraConfigs:                   defaultInfraConfigs,
+			machineConfigs:                 defaultMachineConfigs,
+			expectUptoDate:                 false,
+			expectEligibleForInPlaceUpdate: true,
+			expectRenewCertificates:        true,
+			expectLogMessages:              []string{"certificates will expire soon, rolloutBefore expired"},
+			expectConditionMessages:        []string{"Certificates will expire soon"},
+		},
 		{
 			name: "rollout after expired",
 			kcp: func() *controlplanev1.KubeadmControlPlane {
@@ -1618,6 +1636,44 @@ func TestUpToDate(t *testing.T) {
 			expectLogMessages:              []string{"rolloutAfter expired"},
 			expectConditionMessages:        []string{"KubeadmControlPlane spec.rolloutAfter expired"},
 		},
+		{
+			name: "certificate authorities are being rotated",
+			kcp: func() *controlplanev1.KubeadmControlPlane {
+				kcp := defaultKcp.DeepCopy()
+				kcp.Status.CARotation = controlplanev1.KubeadmControlPlaneCARotationStatus{
+					Phase:          controlplanev1.TrustNewCertificateAuthoritiesCARotationPhase,
+					StartTime:      metav1.Time{Time: reconciliationTime.Add(-1 * 24 * time.Hour)}, // one day ago
+					PhaseStartTime: metav1.Time{Time: reconciliationTime.Add(-1 * 24 * time.Hour)}, // one day ago
+				}
+				return kcp
+			}(),
+			machine:                        defaultMachine, // created two days ago
+			infraConfigs:                   defaultInfraConfigs,
+			machineConfigs:                 defaultMachineConfigs,
+			expectUptoDate:                 false,
+			expectEligibleForInPlaceUpdate: false,
+			expectLogMessages:              []string{"certificate authorities rotation in phase TrustNewCertificateAuthorities"},
+			expectConditionMessages:        []string{"Certificate authorities are being rotated"},
+		},
+		{
+			name: "certificate authorities are being rotated, machine created after the phase started",
+			kcp: func() *controlplanev1.KubeadmControlPlane {
+				kcp := defaultKcp.DeepCopy()
+				kcp.Status.CARotation = controlplanev1.KubeadmControlPlaneCARotationStatus{
+					Phase:          controlplanev1.SignWithNewCertificateAuthoritiesCARotationPhase,
+					StartTime:      metav1.Time{Time: reconciliationTime.Add(-3 * 24 * time.Hour)}, // three days ago
+					PhaseStartTime: metav1.Time{Time: reconciliationTime.Add(-3 * 24 * time.Hour)}, // three days ago
+				}
+				return kcp
+			}(),
+			machine:                        defaultMachine, // created two days ago
+			infraConfigs:                   defaultInfraConfigs,
+			machineConfigs:                 defaultMachineConfigs,
+			expectUptoDate:                 true,
+			expectEligibleForInPlaceUpdate: false,
+			expectLogMessages:              nil,
+			expectConditionMessages:        nil,
+		},
 		{
 			name: "kubernetes version does not match",
 			kcp: func() *controlplanev1.KubeadmControlPlane {
@@ -1746,6 +1802,7 @@ func TestUpToDate(t *testing.T) {
 			g.Expect(upToDate).To(Equal(tt.expectUptoDate))
 			g.Expect(res).ToNot(BeNil())
 			g.Expect(res.EligibleForInPlaceUpdate).To(Equal(tt.expectEligibleForInPlaceUpdate))
+			g.Expect(res.RenewCertificates).To(Equal(tt.expectRenewCertificates))
 			g.Expect(res.DesiredMachine).ToNot(BeNil())
 			g.Expect(res.DesiredMachine.Spec.Version).To(Equal(tt.kcp.Spec.Version))
 			g.Expect(res.CurrentInfraMachine).ToNot(BeNil())
@@ -20,6 +20,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"reflect"
 	"strings"
 
@@ -39,6 +40,7 @@ import (
 	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
 	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/feature"
 	topologynames "sigs.k8s.io/cluster-api/internal/topology/names"
 	"sigs.k8s.io/cluster-api/util/container"
 	"sigs.k8s.io/cluster-api/util/secret"
@@ -209,8 +211,12 @@ func (webhook *KubeadmControlPlane) ValidateUpdate(_ context.Context, oldObj, ne
 		{spec, "remediation", "*"},
 		{spec, "machineNaming"},
 		{spec, "machineNaming", "*"},
+		{spec, "etcd"},
+		{spec, "etcd", "*"},
 		{spec, "rollout"},
 		{spec, "rollout", "*"},
+		{spec, "caRotation"},
+		{spec, "caRotation", "*"},
 	}
 
 	oldK, ok := oldObj.(*controlplanev1.KubeadmControlPlane)
@@ -262,6 +268,7 @@ func (webhook *KubeadmControlPlane) ValidateUpdate(_ context.Context, oldObj, ne
 	allErrs = append(allErrs, webhook.validateVersion(oldK, newK)...)
 	allErrs = append(allErrs, validateClusterConfiguration(&oldK.Spec.KubeadmConfigSpec.ClusterConfiguration, &newK.Spec.KubeadmConfigSpec.ClusterConfiguration, field.NewPath("spec", "kubeadmConfigSpec", "clusterConfiguration"))...)
 	allErrs = append(allErrs, webhook.validateCoreDNSVersion(oldK, newK)...)
+	allErrs = append(allErrs, validateEtcdRestore(oldK.Spec.Etcd.Restore, newK.Spec.Etcd.Restore, field.NewPath("spec", "etcd", "restore"))...)
 	allErrs = append(allErrs, newK.Spec.KubeadmConfigSpec.Validate(true, field.NewPath("spec", "kubeadmConfigSpec"))...)
 
 	if len(allErrs) > 0 {
@@ -347,7 +354,9 @@ func validateKubeadmControlPlaneSpec(s controlplanev1.KubeadmControlPlaneSpec, p
 	}
 
 	allErrs = append(allErrs, validateRolloutAndCertValidityFields(s.Rollout, s.KubeadmConfigSpec.ClusterConfiguration, s.Replicas, pathPrefix)...)
+	allErrs = append(allErrs, validateCertificatesRenewal(s.Rollout.Before, pathPrefix.Child("rollout", "before"))...)
 	allErrs = append(allErrs, validateNaming(s.MachineNaming, pathPrefix.Child("machineNaming"))...)
+	allErrs = append(allErrs, validateEtcd(s.Etcd, s.KubeadmConfigSpec.ClusterConfiguration, pathPrefix.Child("etcd"))...)
 	return allErrs
 }
 
@@ -428,6 +437,34 @@ func validateRolloutAndCertValidityFields(rolloutSpec controlplanev1.KubeadmCont
 	return allErrs
 }
 
+func validateCertificatesRenewal(rolloutBefore controlplanev1.KubeadmControlPlaneRolloutBeforeSpec, pathPrefix *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if rolloutBefore.CertificatesRenewalStrategy != controlplanev1.InPlaceCertificatesRenewalStrategy {
+		return nil
+	}
+
+	if !feature.Gates.Enabled(feature.InPlaceUpdates) {
+		allErrs = append(allErrs,
+			field.Forbidden(
+				pathPrefix.Child("certificatesRenewalStrategy"),
+				fmt.Sprintf("%s can be used only if the InPlaceUpdates feature flag is enabled", controlplanev1.InPlaceCertificatesRenewalStrategy),
+			),
+		)
+	}
+
+	if rolloutBefore.CertificatesExpiryDays == 0 {
+		allErrs = append(allErrs,
+			field.Forbidden(
+				pathPrefix.Child("certificatesRenewalStrategy"),
+				"cannot be set when certificatesExpiryDays is not set",
+			),
+		)
+	}
+
+	return allErrs
+}
+
 func validateNaming(machineNaming controlplanev1.MachineNamingSpec, pathPrefix *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
 
@@ -463,6 +500,83 @@ func validateNaming(machineNaming controlplanev1.MachineNamingSpec, pathPrefix *
 	return allErrs
 }
 
+func validateEtcd(etcd controlplanev1.KubeadmControlPlaneEtcdSpec, clusterConfiguration bootstrapv1.ClusterConfiguration, pathPrefix *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if reflect.DeepEqual(etcd, controlplanev1.KubeadmControlPlaneEtcdSpec{}) {
+		return nil
+	}
+
+	if clusterConfiguration.Etcd.External.IsDefined() {
+		allErrs = append(allErrs,
+			field.Forbidden(
+				pathPrefix,
+				"cannot be set when using external etcd",
+			),
+		)
+	}
+
+	storage := etcd.Snapshot.Storage
+	storagePath := pathPrefix.Child("snapshot", "storage")
+	switch storage.Type {
+	case controlplanev1.SecretEtcdSnapshotStorageType, controlplanev1.PersistentVolumeClaimEtcdSnapshotStorageType:
+		if !reflect.DeepEqual(storage.S3, controlplanev1.KubeadmControlPlaneEtcdSnapshotS3Storage{}) {
+			allErrs = append(allErrs,
+				field.Forbidden(
+					storagePath.Child("s3"),
+					fmt.Sprintf("cannot be set when type is %s", storage.Type),
+				),
+			)
+		}
+	case controlplanev1.S3EtcdSnapshotStorageType:
+		if reflect.DeepEqual(storage.S3, controlplanev1.KubeadmControlPlaneEtcdSnapshotS3Storage{}) {
+			allErrs = append(allErrs,
+				field.Required(
+					storagePath.Child("s3"),
+					fmt.Sprintf("must be set when type is %s", controlplanev1.S3EtcdSnapshotStorageType),
+				),
+			)
+			break
+		}
+		if u, err := url.Parse(storage.S3.Endpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			allErrs = append(allErrs,
+				field.Invalid(
+					storagePath.Child("s3", "endpoint"),
+					storage.S3.Endpoint,
+					"must be a valid http or https URL",
+				),
+			)
+		}
+	}
+
+	// Restore uses the snapshot storage, so snapshots must be configured.
+	if etcd.Restore.SnapshotName != "" && etcd.Snapshot.IntervalSeconds == 0 {
+		allErrs = append(allErrs,
+			field.Forbidden(
+				pathPrefix.Child("restore"),
+				"cannot be set when snapshot is not set",
+			),
+		)
+	}
+
+	return allErrs
+}
+
+// validateEtcdRestore validates that the snapshot to restore from does not change while restore is set.
+// NOTE: Changing the snapshot while a restore is in progress could leave the control plane with a mix of Machines
+// restoring different snapshots; restore must be unset and set again to restore from another snapshot.
+func validateEtcdRestore(oldRestore, newRestore controlplanev1.KubeadmControlPlaneEtcdRestoreSpec, pathPrefix *field.Path) field.ErrorList {
+	if oldRestore.SnapshotName == "" || newRestore.SnapshotName == "" || oldRestore.SnapshotName == newRestore.SnapshotName {
+		return nil
+	}
+	return field.ErrorList{
+		field.Forbidden(
+			pathPrefix.Child("snapshotName"),
+			"cannot be modified; unset restore before restoring from another snapshot",
+		),
+	}
+}
+
 func validateClusterConfiguration(oldClusterConfiguration, newClusterConfiguration *bootstrapv1.ClusterConfiguration, pathPrefix *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -188,11 +188,79 @@ func TestKubeadmControlPlaneValidateCreate(t *testing.T) {
 	invalidRolloutBeforeCertificatesExpiryDays.Spec.Rollout.Before.CertificatesExpiryDays = 8
 	invalidRolloutBeforeCertificatesExpiryDays.Spec.KubeadmConfigSpec.ClusterConfiguration.CertificateValidityPeriodDays = 7
 
+	validEtcdSnapshotSecretStorage := valid.DeepCopy()
+	validEtcdSnapshotSecretStorage.Spec.Etcd.Snapshot = controlplanev1.KubeadmControlPlaneEtcdSnapshotSpec{
+		IntervalSeconds: 3600,
+		Storage: controlplanev1.KubeadmControlPlaneEtcdSnapshotStorage{
+			Type: controlplanev1.SecretEtcdSnapshotStorageType,
+		},
+	}
+
+	validEtcdSnapshotS3Storage := valid.DeepCopy()
+	validEtcdSnapshotS3Storage.Spec.Etcd.Snapshot = controlplanev1.KubeadmControlPlaneEtcdSnapshotSpec{
+		IntervalSeconds: 3600,
+		Storage: controlplanev1.KubeadmControlPlaneEtcdSnapshotStorage{
+			Type: controlplanev1.S3EtcdSnapshotStorageType,
+			S3: controlplanev1.KubeadmControlPlaneEtcdSnapshotS3Storage{
+				Endpoint:              "https://s3.eu-west-1.amazonaws.com",
+				Bucket:                "bucket",
+				CredentialsSecretName: "credentials",
+			},
+		},
+	}
+
+	invalidEtcdSnapshotExternalEtcd := validEtcdSnapshotSecretStorage.DeepCopy()
+	invalidEtcdSnapshotExternalEtcd.Spec.KubeadmConfigSpec.ClusterConfiguration.Etcd.External = bootstrapv1.ExternalEtcd{
+		Endpoints: []string{"1.2.3.4"},
+	}
+
+	invalidEtcdSnapshotS3StorageMissing := validEtcdSnapshotS3Storage.DeepCopy()
+	invalidEtcdSnapshotS3StorageMissing.Spec.Etcd.Snapshot.Storage.S3 = controlplanev1.KubeadmControlPlaneEtcdSnapshotS3Storage{}
+
+	invalidEtcdSnapshotS3StorageEndpoint := validEtcdSnapshotS3Storage.DeepCopy()
+	invalidEtcdSnapshotS3StorageEndpoint.Spec.Etcd.Snapshot.Storage.S3.Endpoint = "s3.eu-west-1.amazonaws.com"
+
+	invalidEtcdSnapshotSecretStorageWithS3 := validEtcdSnapshotS3Storage.DeepCopy()
+	invalidEtcdSnapshotSecretStorageWithS3.Spec.Etcd.Snapshot.Storage.Type = controlplanev1.SecretEtcdSnapshotStorageType
+
+	validEtcdSnapshotPersistentVolumeClaimStorage := validEtcdSnapshotSecretStorage.DeepCopy()
+	validEtcdSnapshotPersistentVolumeClaimStorage.Spec.Etcd.Snapshot.Storage.Type = controlplanev1.PersistentVolumeClaimEtcdSnapshotStorageType
+
+	invalidEtcdSnapshotPersistentVolumeClaimStorageWithS3 := validEtcdSnapshotS3Storage.DeepCopy()
+	invalidEtcdSnapshotPersistentVolumeClaimStorageWithS3.Spec.Etcd.Snapshot.Storage.Type = controlplanev1.PersistentVolumeClaimEtcdSnapshotStorageType
+
+	validEtcdRestore := validEtcdSnapshotSecretStorage.DeepCopy()
+	validEtcdRestore.Spec.Etcd.Restore.SnapshotName = "kcp-etcd-snapshot-20260101100000"
+
+	invalidEtcdRestoreWithoutSnapshot := validEtcdRestore.DeepCopy()
+	invalidEtcdRestoreWithoutSnapshot.Spec.Etcd.Snapshot = controlplanev1.KubeadmControlPlaneEtcdSnapshotSpec{}
+
+	validEtcdDefragmentation := valid.DeepCopy()
+	validEtcdDefragmentation.Spec.Etcd.Defragmentation = controlplanev1.KubeadmControlPlaneEtcdDefragmentationSpec{
+		IntervalSeconds:         86400,
+		MinFragmentationPercent: ptr.To[int32](50),
+	}
+
+	invalidEtcdDefragmentationExternalEtcd := validEtcdDefragmentation.DeepCopy()
+	invalidEtcdDefragmentationExternalEtcd.Spec.KubeadmConfigSpec.ClusterConfiguration.Etcd.External = bootstrapv1.ExternalEtcd{
+		Endpoints: []string{"1.2.3.4"},
+	}
+
+	validInPlaceCertificatesRenewal := valid.DeepCopy()
+	validInPlaceCertificatesRenewal.Spec.Rollout.Before = controlplanev1.KubeadmControlPlaneRolloutBeforeSpec{
+		CertificatesExpiryDays:      21,
+		CertificatesRenewalStrategy: controlplanev1.InPlaceCertificatesRenewalStrategy,
+	}
+
+	invalidInPlaceCertificatesRenewalWithoutExpiryDays := validInPlaceCertificatesRenewal.DeepCopy()
+	invalidInPlaceCertificatesRenewalWithoutExpiryDays.Spec.Rollout.Before.CertificatesExpiryDays = 0
+
 	tests := []struct {
-		name                  string
-		enableIgnitionFeature bool
-		expectErr             bool
-		kcp                   *controlplanev1.KubeadmControlPlane
+		name                        string
+		enableIgnitionFeature       bool
+		enableInPlaceUpdatesFeature bool
+		expectErr                   bool
+		kcp                         *controlplanev1.KubeadmControlPlane
 	}{
 		{
 			name:      "should succeed when given a valid config",
@@ -291,6 +359,77 @@ func TestKubeadmControlPlaneValidateCreate(t *testing.T) {
 			expectErr: true,
 			kcp:       invalidRolloutBeforeCertificatesExpiryDays,
 		},
+		{
+			name: "should succeed when etcd snapshots use the Secret storage",
+			kcp:  validEtcdSnapshotSecretStorage,
+		},
+		{
+			name: "should succeed when etcd snapshots use the S3 storage",
+			kcp:  validEtcdSnapshotS3Storage,
+		},
+		{
+			name:      "should return error when etcd is set with external etcd",
+			expectErr: true,
+			kcp:       invalidEtcdSnapshotExternalEtcd,
+		},
+		{
+			name:      "should return error when etcd snapshots use the S3 storage without s3",
+			expectErr: true,
+			kcp:       invalidEtcdSnapshotS3StorageMissing,
+		},
+		{
+			name:      "should return error when etcd snapshots use the S3 storage with an invalid endpoint",
+			expectErr: true,
+			kcp:       invalidEtcdSnapshotS3StorageEndpoint,
+		},
+		{
+			name:      "should return error when etcd snapshots use the Secret storage with s3",
+			expectErr: true,
+			kcp:       invalidEtcdSnapshotSecretStorageWithS3,
+		},
+		{
+			name: "should succeed when etcd snapshots use the PersistentVolumeClaim storage",
+			kcp:  validEtcdSnapshotPersistentVolumeClaimStorage,
+		},
+		{
+			name:      "should return error when etcd snapshots use the PersistentVolumeClaim storage with s3",
+			expectErr: true,
+			kcp:       invalidEtcdSnapshotPersistentVolumeClaimStorageWithS3,
+		},
+		{
+			name: "should succeed when etcd restore is set with etcd snapshots",
+			kcp:  validEtcdRestore,
+		},
+		{
+			name:      "should return error when etcd restore is set without etcd snapshots",
+			expectErr: true,
+			kcp:       invalidEtcdRestoreWithoutSnapshot,
+		},
+		{
+			name: "should succeed when etcd defragmentation is set",
+			kcp:  validEtcdDefragmentation,
+		},
+		{
+			name:      "should return error when etcd defragmentation is set with external etcd",
+			expectErr: true,
+			kcp:       invalidEtcdDefragmentationExternalEtcd,
+		},
+		{
+			name:                        "should succeed when certificates are renewed in-place with the InPlaceUpdates feature flag enabled",
+			enableInPlaceUpdatesFeature: true,
+			kcp:                         validInPlaceCertificatesRenewal,
+		},
+		{
+			name:      "should return error when certificates are renewed in-place with the InPlaceUpdates feature flag disabled",
+			expectErr: true,
+			kcp:       validInPlaceCertificatesRenewal,
+		},
+		{
+			name:                        "should return error when certificates are renewed in-place without certificatesExpiryDays",
+			enableInPlaceUpdatesFeature: true,
+			expectErr:                   true,
+			kcp:                         invalidInPlaceCertificatesRenewalWithoutExpiryDays,
+		},
 	}
 
 	for _, tt := range tests {
@@ -300,6 +439,9 @@ func TestKubeadmControlPlaneValidateCreate(t *testing.T) {
 				// Enabling the feature flag temporarily for this test.
 				utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.KubeadmBootstrapFormatIgnition, true)
 			}
+			if tt.enableInPlaceUpdatesFeature {
+				utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.InPlaceUpdates, true)
+			}
 
 			g := NewWithT(t)
 
@@ -478,6 +620,12 @@ func TestKubeadmControlPlaneValidateUpdate(t *testing.T) {
 		RetryPeriodSeconds:      ptr.To[int32](10 * 60),
 	}
 	validUpdate.Spec.KubeadmConfigSpec.Format = bootstrapv1.CloudConfig
+	validUpdate.Spec.Etcd.Snapshot = controlplanev1.KubeadmControlPlaneEtcdSnapshotSpec{
+		IntervalSeconds: 3600,
+		Storage: controlplanev1.KubeadmControlPlaneEtcdSnapshotStorage{
+			Type: controlplanev1.SecretEtcdSnapshotStorageType,
+		},
+	}
 
 	scaleToZero := before.DeepCopy()
 	scaleToZero.Spec.Replicas = ptr.To[int32](0)
@@ -759,6 +907,23 @@ func TestKubeadmControlPlaneValidateUpdate(t *testing.T) {
 	validEncryptionAlgorithm := before.DeepCopy()
 	validEncryptionAlgorithm.Spec.KubeadmConfigSpec.ClusterConfiguration.EncryptionAlgorithm = bootstrapv1.EncryptionAlgorithmRSA3072
 
+	etcdSnapshot := before.DeepCopy()
+	etcdSnapshot.Spec.Etcd.Snapshot = controlplanev1.KubeadmControlPlaneEtcdSnapshotSpec{
+		IntervalSeconds: 3600,
+		Storage: controlplanev1.KubeadmControlPlaneEtcdSnapshotStorage{
+			Type: controlplanev1.SecretEtcdSnapshotStorageType,
+		},
+	}
+
+	etcdRestore := etcdSnapshot.DeepCopy()
+	etcdRestore.Spec.Etcd.Restore.SnapshotName = "kcp-etcd-snapshot-20260101100000"
+
+	etcdRestoreChangedSnapshot := etcdRestore.DeepCopy()
+	etcdRestoreChangedSnapshot.Spec.Etcd.Restore.SnapshotName = "kcp-etcd-snapshot-20260101110000"
+
+	caRotation := before.DeepCopy()
+	caRotation.Spec.CARotation.RotateAfter = metav1.NewTime(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+
 	tests := []struct {
 		name                  string
 		enableIgnitionFeature bool
@@ -1127,6 +1292,27 @@ func TestKubeadmControlPlaneValidateUpdate(t *testing.T) {
 			before: before,
 			kcp:    validEncryptionAlgorithm,
 		},
+		{
+			name:   "should allow to set etcd restore",
+			before: etcdSnapshot,
+			kcp:    etcdRestore,
+		},
+		{
+			name:   "should allow to unset etcd restore",
+			before: etcdRestore,
+			kcp:    etcdSnapshot,
+		},
+		{
+			name:      "should return error when trying to change the snapshot of etcd restore",
+			expectErr: true,
+			before:    etcdRestore,
+			kcp:       etcdRestoreChangedSnapshot,
+		},
+		{
+			name:   "should allow to set caRotation",
+			before: before,
+			kcp:    caRotation,
+		},
 	}
 
 	for _, tt := range tests {
@@ -135,6 +135,7 @@ func validateKubeadmControlPlaneTemplateResourceSpec(s controlplanev1.KubeadmCon
 	allErrs := field.ErrorList{}
 
 	allErrs = append(allErrs, validateRolloutAndCertValidityFields(s.Rollout, s.KubeadmConfigSpec.ClusterConfiguration, nil, pathPrefix)...)
+	allErrs = append(allErrs, validateCertificatesRenewal(s.Rollout.Before, pathPrefix.Child("rollout", "before"))...)
 	allErrs = append(allErrs, validateNaming(s.MachineNaming, pathPrefix.Child("machineNaming"))...)
 
 	// Validate the metadata of the MachineTemplate
@@ -17,6 +17,7 @@ limitations under the License.
 package internal
 
 import (
+	"bytes"
 	"context"
 	"crypto"
 	"crypto/rand"
@@ -24,6 +25,7 @@ import (
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"fmt"
+	"io"
 	"math/big"
 	"reflect"
 	"time"
@@ -36,6 +38,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/util/retry"
 	"k8s.io/utils/ptr"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -55,6 +58,8 @@ import (
 
 const (
 	kubeProxyKey                   = "kube-proxy"
+	clusterInfoKey                 = "cluster-info"
+	clusterInfoKubeconfigKey       = "kubeconfig"
 	kubeadmConfigKey               = "kubeadm-config"
 	kubeadmAPIServerCertCommonName = "kube-apiserver"
 	labelNodeRoleControlPlane      = "node-role.kubernetes.io/control-plane"
@@ -97,6 +102,19 @@ type WorkloadCluster interface {
 
 	// State recovery tasks.
 	ReconcileEtcdMembersAndControlPlaneNodes(ctx context.Context, members []*etcd.Member, nodeNames []string) ([]string, error)
+
+	// Backup related tasks.
+	EtcdSnapshot(ctx context.Context, w io.Writer) (int64, error)
+	RemoveStaleControlPlaneNodes(ctx context.Context, nodeNames []string) ([]string, error)
+
+	// Maintenance related tasks.
+	EtcdMembersDBStatus(ctx context.Context) ([]EtcdMemberDBStatus, error)
+	CompactEtcd(ctx context.Context) error
+	DefragmentEtcdMember(ctx context.Context, name string) (*EtcdMemberDBStatus, error)
+	DisarmEtcdMemberNoSpaceAlarm(ctx context.Context, name string) error
+
+	// Certificate authorities rotation related tasks.
+	UpdateClusterInfoCertificateAuthorities(ctx context.Context, caData []byte) error
 }
 
 // Workload defines operations on workload clusters.
@@ -408,6 +426,42 @@ func newClientCert(caCert *x509.Certificate, key crypto.Signer, caKey crypto.Sig
 	return c, errors.WithStack(err)
 }
 
+// UpdateClusterInfoCertificateAuthorities updates the certificate authorities in the kubeconfig of the cluster-info
+// ConfigMap, which is used by kubeadm join to discover the cluster when using bootstrap tokens.
+// Note: caData can contain more than one certificate authority, e.g. while the cluster CA is being rotated.
+func (w *Workload) UpdateClusterInfoCertificateAuthorities(ctx context.Context, caData []byte) error {
+	configMap, err := w.getConfigMap(ctx, ctrlclient.ObjectKey{Name: clusterInfoKey, Namespace: metav1.NamespacePublic})
+	if err != nil {
+		return err
+	}
+
+	kubeconfig, err := clientcmd.Load([]byte(configMap.Data[clusterInfoKubeconfigKey]))
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse kubeconfig in %s/%s configmap", metav1.NamespacePublic, clusterInfoKey)
+	}
+
+	changed := false
+	for _, cluster := range kubeconfig.Clusters {
+		if !bytes.Equal(cluster.CertificateAuthorityData, caData) {
+			cluster.CertificateAuthorityData = caData
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	out, err := clientcmd.Write(*kubeconfig)
+	if err != nil {
+		return errors.Wrapf(err, "failed to serialize kubeconfig in %s/%s configmap", metav1.NamespacePublic, clusterInfoKey)
+	}
+	configMap.Data[clusterInfoKubeconfigKey] = string(out)
+	if err := w.Client.Update(ctx, configMap); err != nil {
+		return errors.Wrapf(err, "failed to update %s/%s configmap", metav1.NamespacePublic, clusterInfoKey)
+	}
+	return nil
+}
+
 func staticPodName(component, nodeName string) string {
 	return fmt.Sprintf("%s-%s", component, nodeName)
 }
@@ -18,8 +18,11 @@ package internal
 
 import (
 	"context"
+	"io"
+	"slices"
 
 	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 
 	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
@@ -134,6 +137,54 @@ func (w *Workload) removeMemberForNode(ctx context.Context, name string) error {
 	return nil
 }
 
+// EtcdSnapshot streams a point-in-time snapshot of the etcd cluster into w, and returns the number of bytes written.
+// The snapshot is taken from the first etcd member reachable through the control plane nodes.
+func (w *Workload) EtcdSnapshot(ctx context.Context, wr io.Writer) (int64, error) {
+	controlPlaneNodes, err := w.getControlPlaneNodes(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if len(controlPlaneNodes.Items) == 0 {
+		return 0, errors.New("failed to take etcd snapshot: there are no control plane nodes")
+	}
+
+	nodeNames := make([]string, 0, len(controlPlaneNodes.Items))
+	for _, n := range controlPlaneNodes.Items {
+		nodeNames = append(nodeNames, n.Name)
+	}
+	etcdClient, err := w.etcdClientGenerator.forFirstAvailableNode(ctx, nodeNames)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to create etcd client")
+	}
+	defer etcdClient.Close()
+
+	return etcdClient.Snapshot(ctx, wr)
+}
+
+// RemoveStaleControlPlaneNodes deletes the control plane nodes not included in nodeNames, and returns the names of the deleted nodes.
+// This is required after restoring etcd from a snapshot, because the snapshot contains the nodes of the Machines deleted before the restore.
+func (w *Workload) RemoveStaleControlPlaneNodes(ctx context.Context, nodeNames []string) ([]string, error) {
+	controlPlaneNodes, err := w.getControlPlaneNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	removedNodes := []string{}
+	errs := []error{}
+	for i := range controlPlaneNodes.Items {
+		node := &controlPlaneNodes.Items[i]
+		if slices.Contains(nodeNames, node.Name) {
+			continue
+		}
+
+		removedNodes = append(removedNodes, node.Name)
+		if err := w.Client.Delete(ctx, node); err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, errors.Wrapf(err, "failed to delete Node %s", node.Name))
+		}
+	}
+	return removedNodes, kerrors.NewAggregate(errs)
+}
+
 // ForwardEtcdLeadership forwards etcd leadership to the first follower.
 func (w *Workload) ForwardEtcdLeadership(ctx context.Context, machine *clusterv1.Machine, leaderCandidate *clusterv1.Machine) error {
 	if machine == nil || !machine.Status.NodeRef.IsDefined() {
@@ -219,3 +270,117 @@ func (w *Workload) EtcdMembers(ctx context.Context) ([]string, error) {
 	}
 	return names, nil
 }
+
+// EtcdMemberDBStatus contains information about the backend database of a single etcd member.
+type EtcdMemberDBStatus struct {
+	// Name is the name of the member, which is the same as the name of the corresponding control plane node.
+	Name string
+
+	// ID is the ID of the member.
+	ID uint64
+
+	// IsLeader is true if the member is the etcd leader.
+	IsLeader bool
+
+	// DBSize is the size of the backend database of the member, in bytes.
+	DBSize int64
+
+	// DBSizeInUse is the size of the backend database of the member logically in use, in bytes.
+	DBSizeInUse int64
+}
+
+// EtcdMembersDBStatus returns the status of the backend database of the etcd members hosted on the control plane nodes.
+//
+// NOTE: An error is returned if any of the etcd members is not reachable; this is intended to allow informed
+// decisions on maintenance tasks temporarily impacting etcd members, like e.g. defragmentation.
+func (w *Workload) EtcdMembersDBStatus(ctx context.Context) ([]EtcdMemberDBStatus, error) {
+	nodes, err := w.getControlPlaneNodes(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list control plane nodes")
+	}
+
+	statuses := make([]EtcdMemberDBStatus, 0, len(nodes.Items))
+	for _, node := range nodes.Items {
+		status, err := w.etcdMemberDBStatus(ctx, node.Name)
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, *status)
+	}
+	return statuses, nil
+}
+
+// CompactEtcd compacts the etcd key-value store history up to the current revision.
+func (w *Workload) CompactEtcd(ctx context.Context) error {
+	nodes, err := w.getControlPlaneNodes(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to list control plane nodes")
+	}
+	nodeNames := make([]string, 0, len(nodes.Items))
+	for _, node := range nodes.Items {
+		nodeNames = append(nodeNames, node.Name)
+	}
+	etcdClient, err := w.etcdClientGenerator.forFirstAvailableNode(ctx, nodeNames)
+	if err != nil {
+		return errors.Wrap(err, "failed to create etcd client")
+	}
+	defer etcdClient.Close()
+
+	status, err := etcdClient.Status(ctx)
+	if err != nil {
+		return err
+	}
+	return etcdClient.Compact(ctx, status.Revision)
+}
+
+// DefragmentEtcdMember defragments the etcd member hosted on the given control plane node, and returns
+// the status of its backend database after defragmentation.
+//
+// NOTE: The member does not serve requests while it is being defragmented; callers should defragment one member at a time.
+func (w *Workload) DefragmentEtcdMember(ctx context.Context, name string) (*EtcdMemberDBStatus, error) {
+	etcdClient, err := w.etcdClientGenerator.forFirstAvailableNode(ctx, []string{name})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create etcd client for Node %s", name)
+	}
+	defer etcdClient.Close()
+
+	if err := etcdClient.Defragment(ctx); err != nil {
+		return nil, errors.Wrapf(err, "failed to defragment etcd member on Node %s", name)
+	}
+	return w.etcdMemberDBStatus(ctx, name)
+}
+
+// DisarmEtcdMemberNoSpaceAlarm disarms the NOSPACE alarm raised by the etcd member hosted on the given control plane node.
+func (w *Workload) DisarmEtcdMemberNoSpaceAlarm(ctx context.Context, name string) error {
+	etcdClient, err := w.etcdClientGenerator.forFirstAvailableNode(ctx, []string{name})
+	if err != nil {
+		return errors.Wrapf(err, "failed to create etcd client for Node %s", name)
+	}
+	defer etcdClient.Close()
+
+	status, err := etcdClient.Status(ctx)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get status of etcd member on Node %s", name)
+	}
+	return etcdClient.DisarmAlarm(ctx, etcd.MemberAlarm{MemberID: status.MemberID, Type: etcd.AlarmNoSpace})
+}
+
+func (w *Workload) etcdMemberDBStatus(ctx context.Context, name string) (*EtcdMemberDBStatus, error) {
+	etcdClient, err := w.etcdClientGenerator.forFirstAvailableNode(ctx, []string{name})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create etcd client for Node %s", name)
+	}
+	defer etcdClient.Close()
+
+	status, err := etcdClient.Status(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get status of etcd member on Node %s", name)
+	}
+	return &EtcdMemberDBStatus{
+		Name:        name,
+		ID:          status.MemberID,
+		IsLeader:    status.MemberID == status.LeaderID,
+		DBSize:      status.DBSize,
+		DBSizeInUse: status.DBSizeInUse,
+	}, nil
+}
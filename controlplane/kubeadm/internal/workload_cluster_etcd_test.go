@@ -17,6 +17,7 @@ limitations under the License.
 package internal
 
 import (
+	"bytes"
 	"context"
 	"testing"
 
@@ -362,6 +363,284 @@ func TestRemoveEtcdMemberForMachine(t *testing.T) {
 	}
 }
 
+func TestEtcdSnapshot(t *testing.T) {
+	cp1 := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cp1",
+			Labels: map[string]string{
+				labelNodeRoleControlPlane: "",
+			},
+		},
+	}
+
+	tests := []struct {
+		name                string
+		objs                []client.Object
+		etcdClientGenerator etcdClientFor
+		wantSnapshot        string
+		wantErr             string
+	}{
+		{
+			name:    "returns an error if there are no control plane nodes",
+			wantErr: "there are no control plane nodes",
+		},
+		{
+			name:                "returns an error if it fails to create the etcd client",
+			objs:                []client.Object{cp1},
+			etcdClientGenerator: &fakeEtcdClientGenerator{forNodesErr: errors.New("no client")},
+			wantErr:             "failed to create etcd client",
+		},
+		{
+			name: "returns an error if the client errors taking the snapshot",
+			objs: []client.Object{cp1},
+			etcdClientGenerator: &fakeEtcdClientGenerator{
+				forNodesClient: &etcd.Client{
+					EtcdClient: &fake2.FakeEtcdClient{
+						ErrorResponse: errors.New("cannot take snapshot"),
+					},
+				},
+			},
+			wantErr: "cannot take snapshot",
+		},
+		{
+			name: "takes the snapshot",
+			objs: []client.Object{cp1},
+			etcdClientGenerator: &fakeEtcdClientGenerator{
+				forNodesClient: &etcd.Client{
+					EtcdClient: &fake2.FakeEtcdClient{
+						SnapshotData: []byte("snapshot"),
+					},
+				},
+			},
+			wantSnapshot: "snapshot",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			w := &Workload{
+				Client:              fake.NewClientBuilder().WithObjects(tt.objs...).Build(),
+				etcdClientGenerator: tt.etcdClientGenerator,
+			}
+
+			snapshot := &bytes.Buffer{}
+			size, err := w.EtcdSnapshot(ctx, snapshot)
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(size).To(Equal(int64(len(tt.wantSnapshot))))
+			g.Expect(snapshot.String()).To(Equal(tt.wantSnapshot))
+		})
+	}
+}
+
+func TestRemoveStaleControlPlaneNodes(t *testing.T) {
+	g := NewWithT(t)
+
+	controlPlaneNode := func(name string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+				Labels: map[string]string{
+					labelNodeRoleControlPlane: "",
+				},
+			},
+		}
+	}
+	workerNode := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "worker",
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithObjects(controlPlaneNode("cp1"), controlPlaneNode("cp2"), controlPlaneNode("cp3"), workerNode).Build()
+	w := &Workload{
+		Client: fakeClient,
+	}
+
+	removedNodes, err := w.RemoveStaleControlPlaneNodes(ctx, []string{"cp3"})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(removedNodes).To(ConsistOf("cp1", "cp2"))
+
+	nodes := &corev1.NodeList{}
+	g.Expect(fakeClient.List(ctx, nodes)).To(Succeed())
+	nodeNames := []string{}
+	for _, n := range nodes.Items {
+		nodeNames = append(nodeNames, n.Name)
+	}
+	g.Expect(nodeNames).To(ConsistOf("cp3", "worker"))
+}
+
+func TestEtcdMembersDBStatus(t *testing.T) {
+	controlPlaneNode := func(name string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+				Labels: map[string]string{
+					labelNodeRoleControlPlane: "",
+				},
+			},
+		}
+	}
+	statusClient := func(memberID, leaderID uint64, dbSize, dbSizeInUse int64) *etcd.Client {
+		return &etcd.Client{
+			EtcdClient: &fake2.FakeEtcdClient{
+				StatusResponse: &clientv3.StatusResponse{
+					Header:      &pb.ResponseHeader{MemberId: memberID},
+					Leader:      leaderID,
+					DbSize:      dbSize,
+					DbSizeInUse: dbSizeInUse,
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name                string
+		objs                []client.Object
+		etcdClientGenerator etcdClientFor
+		want                []EtcdMemberDBStatus
+		wantErr             string
+	}{
+		{
+			name: "returns an error if one of the members is not reachable",
+			objs: []client.Object{controlPlaneNode("cp1"), controlPlaneNode("cp2")},
+			etcdClientGenerator: &fakeEtcdClientGenerator{
+				forNodesClientFunc: func(n []string) (*etcd.Client, error) {
+					if n[0] == "cp2" {
+						return nil, errors.New("no client")
+					}
+					return statusClient(1, 1, 100, 50), nil
+				},
+			},
+			wantErr: "failed to create etcd client for Node cp2",
+		},
+		{
+			name: "returns the status of all the members",
+			objs: []client.Object{controlPlaneNode("cp1"), controlPlaneNode("cp2")},
+			etcdClientGenerator: &fakeEtcdClientGenerator{
+				forNodesClientFunc: func(n []string) (*etcd.Client, error) {
+					if n[0] == "cp2" {
+						return statusClient(2, 1, 200, 150), nil
+					}
+					return statusClient(1, 1, 100, 50), nil
+				},
+			},
+			want: []EtcdMemberDBStatus{
+				{Name: "cp1", ID: 1, IsLeader: true, DBSize: 100, DBSizeInUse: 50},
+				{Name: "cp2", ID: 2, IsLeader: false, DBSize: 200, DBSizeInUse: 150},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			w := &Workload{
+				Client:              fake.NewClientBuilder().WithObjects(tt.objs...).Build(),
+				etcdClientGenerator: tt.etcdClientGenerator,
+			}
+
+			got, err := w.EtcdMembersDBStatus(ctx)
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestCompactEtcd(t *testing.T) {
+	g := NewWithT(t)
+
+	cp1 := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cp1",
+			Labels: map[string]string{
+				labelNodeRoleControlPlane: "",
+			},
+		},
+	}
+	fakeEtcdClient := &fake2.FakeEtcdClient{
+		StatusResponse: &clientv3.StatusResponse{
+			Header: &pb.ResponseHeader{MemberId: 1, Revision: 42},
+		},
+	}
+	w := &Workload{
+		Client: fake.NewClientBuilder().WithObjects(cp1).Build(),
+		etcdClientGenerator: &fakeEtcdClientGenerator{
+			forNodesClient: &etcd.Client{EtcdClient: fakeEtcdClient},
+		},
+	}
+
+	g.Expect(w.CompactEtcd(ctx)).To(Succeed())
+	g.Expect(fakeEtcdClient.CompactedRevision).To(Equal(int64(42)))
+}
+
+func TestDefragmentEtcdMember(t *testing.T) {
+	t.Run("returns an error if defragmentation fails", func(t *testing.T) {
+		g := NewWithT(t)
+
+		w := &Workload{
+			etcdClientGenerator: &fakeEtcdClientGenerator{
+				forNodesClient: &etcd.Client{
+					EtcdClient: &fake2.FakeEtcdClient{
+						ErrorResponse: errors.New("defragment failed"),
+					},
+				},
+			},
+		}
+
+		_, err := w.DefragmentEtcdMember(ctx, "cp1")
+		g.Expect(err).To(MatchError(ContainSubstring("failed to defragment etcd member on Node cp1")))
+	})
+	t.Run("defragments the member and returns its status", func(t *testing.T) {
+		g := NewWithT(t)
+
+		fakeEtcdClient := &fake2.FakeEtcdClient{
+			StatusResponse: &clientv3.StatusResponse{
+				Header:      &pb.ResponseHeader{MemberId: 2},
+				Leader:      1,
+				DbSize:      100,
+				DbSizeInUse: 90,
+			},
+		}
+		w := &Workload{
+			etcdClientGenerator: &fakeEtcdClientGenerator{
+				forNodesClient: &etcd.Client{EtcdClient: fakeEtcdClient},
+			},
+		}
+
+		got, err := w.DefragmentEtcdMember(ctx, "cp1")
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(fakeEtcdClient.Defragmented).To(BeTrue())
+		g.Expect(got).To(Equal(&EtcdMemberDBStatus{Name: "cp1", ID: 2, IsLeader: false, DBSize: 100, DBSizeInUse: 90}))
+	})
+}
+
+func TestDisarmEtcdMemberNoSpaceAlarm(t *testing.T) {
+	g := NewWithT(t)
+
+	fakeEtcdClient := &fake2.FakeEtcdClient{
+		StatusResponse: &clientv3.StatusResponse{
+			Header: &pb.ResponseHeader{MemberId: 2},
+		},
+	}
+	w := &Workload{
+		etcdClientGenerator: &fakeEtcdClientGenerator{
+			forNodesClient: &etcd.Client{EtcdClient: fakeEtcdClient},
+		},
+	}
+
+	g.Expect(w.DisarmEtcdMemberNoSpaceAlarm(ctx, "cp1")).To(Succeed())
+	g.Expect(fakeEtcdClient.DisarmedAlarms).To(ConsistOf(&clientv3.AlarmMember{MemberID: 2, Alarm: pb.AlarmType_NOSPACE}))
+}
+
 func TestForwardEtcdLeadership(t *testing.T) {
 	t.Run("handles errors correctly", func(t *testing.T) {
 		tests := []struct {
@@ -28,6 +28,8 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -1086,6 +1088,84 @@ func TestUpdateCertificateValidityPeriodDaysInKubeadmConfigMap(t *testing.T) {
 	}
 }
 
+func TestUpdateClusterInfoCertificateAuthorities(t *testing.T) {
+	clusterInfoKubeconfig := func(caData []byte) string {
+		kubeconfig := clientcmdapi.NewConfig()
+		kubeconfig.Clusters[""] = &clientcmdapi.Cluster{
+			Server:                   "https://1.2.3.4:6443",
+			CertificateAuthorityData: caData,
+		}
+		out, err := clientcmd.Write(*kubeconfig)
+		if err != nil {
+			panic(err)
+		}
+		return string(out)
+	}
+
+	tests := []struct {
+		name      string
+		objs      []client.Object
+		caData    []byte
+		expectErr bool
+	}{
+		{
+			name: "it should update the certificate authorities in the cluster-info kubeconfig",
+			objs: []client.Object{&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      clusterInfoKey,
+					Namespace: metav1.NamespacePublic,
+				},
+				Data: map[string]string{
+					clusterInfoKubeconfigKey: clusterInfoKubeconfig([]byte("old-ca")),
+					"jws-kubeconfig-abcdef":  "signature",
+				},
+			}},
+			caData: []byte("new-ca\nold-ca"),
+		},
+		{
+			name: "it should not fail if the certificate authorities are already up to date",
+			objs: []client.Object{&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      clusterInfoKey,
+					Namespace: metav1.NamespacePublic,
+				},
+				Data: map[string]string{
+					clusterInfoKubeconfigKey: clusterInfoKubeconfig([]byte("new-ca")),
+				},
+			}},
+			caData: []byte("new-ca"),
+		},
+		{
+			name:      "it should fail if the cluster-info configmap does not exist",
+			caData:    []byte("new-ca"),
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			w := &Workload{
+				Client: fake.NewClientBuilder().WithObjects(tt.objs...).Build(),
+			}
+
+			err := w.UpdateClusterInfoCertificateAuthorities(ctx, tt.caData)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+
+			var actualConfigMap corev1.ConfigMap
+			g.Expect(w.Client.Get(ctx, client.ObjectKey{Name: clusterInfoKey, Namespace: metav1.NamespacePublic}, &actualConfigMap)).To(Succeed())
+			kubeconfig, err := clientcmd.Load([]byte(actualConfigMap.Data[clusterInfoKubeconfigKey]))
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(kubeconfig.Clusters).To(HaveKey(""))
+			g.Expect(kubeconfig.Clusters[""].Server).To(Equal("https://1.2.3.4:6443"))
+			g.Expect(kubeconfig.Clusters[""].CertificateAuthorityData).To(Equal(tt.caData))
+		})
+	}
+}
+
 func getProxyImageInfo(ctx context.Context, c client.Client) (string, error) {
 	ds := &appsv1.DaemonSet{}
 
@@ -108,13 +108,15 @@ var (
 	managerOptions              = flags.ManagerOptions{}
 	logOptions                  = logs.NewOptions()
 	// KCP specific flags.
-	remoteConditionsGracePeriod    time.Duration
-	kubeadmControlPlaneConcurrency int
-	clusterCacheConcurrency        int
-	skipCRDMigrationPhases         []string
-	etcdDialTimeout                time.Duration
-	etcdCallTimeout                time.Duration
-	etcdLogLevel                   string
+	remoteConditionsGracePeriod      time.Duration
+	kubeadmControlPlaneConcurrency   int
+	clusterCacheConcurrency          int
+	skipCRDMigrationPhases           []string
+	etcdDialTimeout                  time.Duration
+	etcdCallTimeout                  time.Duration
+	etcdLogLevel                     string
+	etcdSnapshotVolumePath           string
+	etcdRestoreMaxInlineSnapshotSize int
 )
 
 func init() {
@@ -218,6 +220,14 @@ func InitFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&etcdLogLevel, "etcd-client-log-level", zapcore.InfoLevel.String(),
 		"Logging level for etcd client. Possible values are: debug, info, warn, error, dpanic, panic, fatal.")
 
+	fs.StringVar(&etcdSnapshotVolumePath, "etcd-snapshot-volume-path", "",
+		"Path where the PersistentVolumeClaim used by KubeadmControlPlanes with the PersistentVolumeClaim etcd snapshot storage type is mounted.")
+
+	fs.IntVar(&etcdRestoreMaxInlineSnapshotSize, "etcd-restore-max-inline-snapshot-size", 0,
+		"Max size in bytes of a base64 encoded etcd snapshot that can be embedded in the bootstrap data of the Machine restoring etcd "+
+			"when using etcd snapshot storage types without download URLs, e.g. Secret or PersistentVolumeClaim. "+
+			"It must be set according to the bootstrap data size limits of the infrastructure provider; 0 disables restoring from these storage types.")
+
 	flags.AddManagerOptions(fs, &managerOptions)
 
 	feature.MutableGates.AddFlag(fs)
@@ -485,16 +495,18 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 	}
 
 	if err := (&kubeadmcontrolplanecontrollers.KubeadmControlPlaneReconciler{
-		Client:                      mgr.GetClient(),
-		APIReader:                   mgr.GetAPIReader(),
-		SecretCachingClient:         secretCachingClient,
-		ClusterCache:                clusterCache,
-		WatchFilterValue:            watchFilterValue,
-		EtcdDialTimeout:             etcdDialTimeout,
-		EtcdCallTimeout:             etcdCallTimeout,
-		EtcdLogger:                  etcdLogger,
-		RemoteConditionsGracePeriod: remoteConditionsGracePeriod,
-		RuntimeClient:               runtimeClient,
+		Client:                           mgr.GetClient(),
+		APIReader:                        mgr.GetAPIReader(),
+		SecretCachingClient:              secretCachingClient,
+		ClusterCache:                     clusterCache,
+		WatchFilterValue:                 watchFilterValue,
+		EtcdDialTimeout:                  etcdDialTimeout,
+		EtcdCallTimeout:                  etcdCallTimeout,
+		EtcdLogger:                       etcdLogger,
+		EtcdSnapshotVolumePath:           etcdSnapshotVolumePath,
+		EtcdRestoreMaxInlineSnapshotSize: etcdRestoreMaxInlineSnapshotSize,
+		RemoteConditionsGracePeriod:      remoteConditionsGracePeriod,
+		RuntimeClient:                    runtimeClient,
 	}).SetupWithManager(ctx, mgr, concurrency(kubeadmControlPlaneConcurrency)); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "KubeadmControlPlane")
 		os.Exit(1)
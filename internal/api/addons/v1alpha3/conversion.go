@@ -24,6 +24,7 @@ import (
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/conversion"
 
+	addonsv1beta1 "sigs.k8s.io/cluster-api/api/addons/v1beta1"
 	addonsv1 "sigs.k8s.io/cluster-api/api/addons/v1beta2"
 	clusterv1alpha3 "sigs.k8s.io/cluster-api/internal/api/core/v1alpha3"
 	utilconversion "sigs.k8s.io/cluster-api/util/conversion"
@@ -53,6 +54,11 @@ func (src *ClusterResourceSet) ConvertTo(dstRaw conversion.Hub) error {
 		return err
 	}
 	dst.Status.Conditions = restored.Status.Conditions
+	dst.Spec.Prune = restored.Spec.Prune
+	dst.Spec.DriftCorrectionIntervalSeconds = restored.Spec.DriftCorrectionIntervalSeconds
+	dst.Spec.TemplateEngine = restored.Spec.TemplateEngine
+	dst.Spec.WaitForHealthy = restored.Spec.WaitForHealthy
+	dst.Spec.DependsOn = restored.Spec.DependsOn
 
 	return nil
 }
@@ -93,6 +99,8 @@ func (src *ClusterResourceSetBinding) ConvertTo(dstRaw conversion.Hub) error {
 		return err
 	}
 	dst.Spec.ClusterName = restored.Spec.ClusterName
+	addonsv1beta1.RestoreResourceBindingObjects(restored.Spec.Bindings, dst.Spec.Bindings)
+	dst.Status = restored.Status
 	return nil
 }
 
@@ -111,6 +119,18 @@ func (dst *ClusterResourceSetBinding) ConvertFrom(srcRaw conversion.Hub) error {
 	return nil
 }
 
+// Convert_v1beta2_ClusterResourceSetBinding_To_v1alpha3_ClusterResourceSetBinding is a conversion function.
+func Convert_v1beta2_ClusterResourceSetBinding_To_v1alpha3_ClusterResourceSetBinding(in *addonsv1.ClusterResourceSetBinding, out *ClusterResourceSetBinding, s apimachineryconversion.Scope) error {
+	// Status does not exist in ClusterResourceSetBinding v1alpha3 API.
+	return autoConvert_v1beta2_ClusterResourceSetBinding_To_v1alpha3_ClusterResourceSetBinding(in, out, s)
+}
+
+// Convert_v1beta2_ClusterResourceSetSpec_To_v1alpha3_ClusterResourceSetSpec is a conversion function.
+func Convert_v1beta2_ClusterResourceSetSpec_To_v1alpha3_ClusterResourceSetSpec(in *addonsv1.ClusterResourceSetSpec, out *ClusterResourceSetSpec, s apimachineryconversion.Scope) error {
+	// Spec.Prune, Spec.DriftCorrectionIntervalSeconds, Spec.TemplateEngine, Spec.WaitForHealthy and Spec.DependsOn do not exist in ClusterResourceSet v1alpha3 API.
+	return autoConvert_v1beta2_ClusterResourceSetSpec_To_v1alpha3_ClusterResourceSetSpec(in, out, s)
+}
+
 // Convert_v1beta2_ClusterResourceSetBindingSpec_To_v1alpha3_ClusterResourceSetBindingSpec is a conversion function.
 func Convert_v1beta2_ClusterResourceSetBindingSpec_To_v1alpha3_ClusterResourceSetBindingSpec(in *addonsv1.ClusterResourceSetBindingSpec, out *ClusterResourceSetBindingSpec, s apimachineryconversion.Scope) error {
 	// Spec.ClusterName does not exist in ClusterResourceSetBinding v1alpha3 API.
@@ -104,6 +104,12 @@ func (src *KubeadmControlPlane) ConvertTo(dstRaw conversion.Hub) error {
 
 		dst.Spec.MachineNaming = restored.Spec.MachineNaming
 
+		dst.Spec.Etcd = restored.Spec.Etcd
+		dst.Status.Etcd = restored.Status.Etcd
+
+		dst.Spec.CARotation = restored.Spec.CARotation
+		dst.Status.CARotation = restored.Status.CARotation
+
 		bootstrapv1alpha4.RestoreKubeadmConfigSpec(&dst.Spec.KubeadmConfigSpec, &restored.Spec.KubeadmConfigSpec)
 		dst.Status.Conditions = restored.Status.Conditions
 		dst.Status.AvailableReplicas = restored.Status.AvailableReplicas
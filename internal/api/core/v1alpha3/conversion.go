@@ -431,6 +431,7 @@ func (src *MachineDeployment) ConvertTo(dstRaw conversion.Hub) error {
 		dst.Spec.Template.Spec.Deletion.NodeDeletionTimeoutSeconds = restored.Spec.Template.Spec.Deletion.NodeDeletionTimeoutSeconds
 		dst.Spec.Template.Spec.Deletion.NodeVolumeDetachTimeoutSeconds = restored.Spec.Template.Spec.Deletion.NodeVolumeDetachTimeoutSeconds
 		dst.Spec.Rollout.After = restored.Spec.Rollout.After
+		dst.Spec.Rollout.Strategy.Canary = restored.Spec.Rollout.Strategy.Canary
 		if restored.Status.Deprecated != nil && restored.Status.Deprecated.V1Beta1 != nil {
 			dst.Status.Deprecated.V1Beta1.Conditions = restored.Status.Deprecated.V1Beta1.Conditions
 		}
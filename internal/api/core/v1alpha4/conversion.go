@@ -518,6 +518,7 @@ func (src *MachineDeployment) ConvertTo(dstRaw conversion.Hub) error {
 		dst.Spec.Template.Spec.Deletion.NodeDeletionTimeoutSeconds = restored.Spec.Template.Spec.Deletion.NodeDeletionTimeoutSeconds
 		dst.Spec.Template.Spec.Deletion.NodeVolumeDetachTimeoutSeconds = restored.Spec.Template.Spec.Deletion.NodeVolumeDetachTimeoutSeconds
 		dst.Spec.Rollout.After = restored.Spec.Rollout.After
+		dst.Spec.Rollout.Strategy.Canary = restored.Spec.Rollout.Strategy.Canary
 		dst.Spec.Remediation = restored.Spec.Remediation
 		dst.Spec.MachineNaming = restored.Spec.MachineNaming
 		dst.Spec.Template.Spec.Taints = restored.Spec.Template.Spec.Taints
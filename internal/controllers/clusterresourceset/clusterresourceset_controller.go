@@ -19,6 +19,7 @@ package clusterresourceset
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -49,6 +50,7 @@ import (
 	"sigs.k8s.io/cluster-api/util/conditions"
 	v1beta1conditions "sigs.k8s.io/cluster-api/util/conditions/deprecated/v1beta1"
 	"sigs.k8s.io/cluster-api/util/finalizers"
+	clog "sigs.k8s.io/cluster-api/util/log"
 	"sigs.k8s.io/cluster-api/util/patch"
 	"sigs.k8s.io/cluster-api/util/paused"
 	"sigs.k8s.io/cluster-api/util/predicates"
@@ -57,10 +59,24 @@ import (
 // ErrSecretTypeNotSupported signals that a Secret is not supported.
 var ErrSecretTypeNotSupported = errors.New("unsupported secret type")
 
+// errDependenciesNotApplied signals that the ClusterResourceSets in dependsOn are not applied to a Cluster yet.
+var errDependenciesNotApplied = errors.New("dependencies not applied")
+
+// errResourcesNotHealthy signals that the objects applied to a Cluster are not healthy yet.
+var errResourcesNotHealthy = errors.New("resources not healthy")
+
+// healthCheckRequeueAfter is the interval after which the health of objects applied to a Cluster that are
+// not healthy yet is checked again.
+const healthCheckRequeueAfter = 20 * time.Second
+
+// dependenciesRequeueAfter is the interval after which a ClusterResourceSet waiting for the ClusterResourceSets
+// in dependsOn to be applied to a Cluster is reconciled again.
+const dependenciesRequeueAfter = 10 * time.Second
+
 // +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;patch
 // +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;patch;update
 // +kubebuilder:rbac:groups=addons.cluster.x-k8s.io,resources=*,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups=addons.cluster.x-k8s.io,resources=clusterresourcesets/status;clusterresourcesets/finalizers,verbs=get;update;patch
+// +kubebuilder:rbac:groups=addons.cluster.x-k8s.io,resources=clusterresourcesets/status;clusterresourcesets/finalizers;clusterresourcesetbindings/status,verbs=get;update;patch
 
 // Reconciler reconciles a ClusterResourceSet object.
 type Reconciler struct {
@@ -182,9 +198,42 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 		return ctrl.Result{}, r.reconcileDelete(ctx, clusters, clusterResourceSet)
 	}
 
+	// Do not apply the ClusterResourceSet if the ClusterResourceSets in dependsOn can never be applied.
+	// Note: Requeue to check again, given that dependencies might be created or changed later.
+	if len(clusterResourceSet.Spec.DependsOn) > 0 {
+		invalid, err := r.invalidDependencies(ctx, clusterResourceSet)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if len(invalid) > 0 {
+			message := "Invalid dependsOn: " + strings.Join(invalid, "; ")
+			log.Info(message)
+			v1beta1conditions.MarkFalse(clusterResourceSet, addonsv1.ResourcesAppliedV1Beta1Condition, addonsv1.InvalidDependenciesV1Beta1Reason, clusterv1.ConditionSeverityWarning, "%s", message)
+			conditions.Set(clusterResourceSet, metav1.Condition{
+				Type:    addonsv1.ClusterResourceSetResourcesAppliedCondition,
+				Status:  metav1.ConditionFalse,
+				Reason:  addonsv1.ClusterResourceSetResourcesAppliedInvalidDependenciesReason,
+				Message: message,
+			})
+			return ctrl.Result{RequeueAfter: dependenciesRequeueAfter}, nil
+		}
+	}
+
 	errs := []error{}
+	waitingForDependencies := false
+	waitingForHealthy := []string{}
 	for _, cluster := range clusters {
 		if err := r.ApplyClusterResourceSet(ctx, cluster, clusterResourceSet); err != nil {
+			// Requeue instead of going on exponential backoff when waiting for the ClusterResourceSets in dependsOn.
+			if errors.Is(err, errDependenciesNotApplied) {
+				waitingForDependencies = true
+				continue
+			}
+			// Requeue instead of going on exponential backoff when waiting for the applied objects to become healthy.
+			if errors.Is(err, errResourcesNotHealthy) {
+				waitingForHealthy = append(waitingForHealthy, klog.KObj(cluster).String())
+				continue
+			}
 			errs = append(errs, err)
 		}
 	}
@@ -210,7 +259,36 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 		return ctrl.Result{}, kerrors.NewAggregate(errs)
 	}
 
-	return ctrl.Result{}, nil
+	result := ctrl.Result{}
+
+	// Requeue to periodically check and correct drift of the objects applied to the Clusters.
+	if clusterResourceSet.Spec.DriftCorrectionIntervalSeconds != nil && len(clusters) > 0 {
+		result.RequeueAfter = time.Duration(*clusterResourceSet.Spec.DriftCorrectionIntervalSeconds) * time.Second
+	}
+
+	// Requeue to check again the health of the objects applied to the Clusters if some of them are not healthy yet.
+	// Note: The ResourcesApplied condition is set after all the Clusters have been reconciled, so it is not
+	// overridden by Clusters where the applied objects are already healthy.
+	if len(waitingForHealthy) > 0 {
+		message := fmt.Sprintf("Waiting for objects applied to Clusters %s to be healthy", clog.ListToString(waitingForHealthy, func(s string) string { return s }, 5))
+		v1beta1conditions.MarkFalse(clusterResourceSet, addonsv1.ResourcesAppliedV1Beta1Condition, addonsv1.WaitingForHealthyV1Beta1Reason, clusterv1.ConditionSeverityInfo, "%s", message)
+		conditions.Set(clusterResourceSet, metav1.Condition{
+			Type:    addonsv1.ClusterResourceSetResourcesAppliedCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  addonsv1.ClusterResourceSetResourcesAppliedWaitingForHealthyReason,
+			Message: message,
+		})
+		if result.RequeueAfter == 0 || result.RequeueAfter > healthCheckRequeueAfter {
+			result.RequeueAfter = healthCheckRequeueAfter
+		}
+	}
+
+	// Requeue to check again if the ClusterResourceSets in dependsOn have been applied to the Clusters.
+	if waitingForDependencies && (result.RequeueAfter == 0 || result.RequeueAfter > dependenciesRequeueAfter) {
+		result.RequeueAfter = dependenciesRequeueAfter
+	}
+
+	return result, nil
 }
 
 // reconcileDelete removes the deleted ClusterResourceSet from all the ClusterResourceSetBindings it is added to.
@@ -295,6 +373,9 @@ func (r *Reconciler) getClustersByClusterResourceSetSelector(ctx context.Context
 // It applies resources best effort and continue on scenarios like: unsupported resource types, failure during creation, missing resources.
 // In Reconcile strategy, resources are re-applied to a particular cluster when their definition changes. The hash in ClusterResourceSetBinding is used to check
 // if a resource has changed or not.
+// In Reconcile strategy, objects are applied using server-side apply with a field manager dedicated to the ClusterResourceSet. If the drift correction
+// interval is set, objects that have been changed or deleted in the cluster after being applied are tracked in ClusterResourceSetBinding, and they are
+// re-applied forcing ownership of their fields when the drift correction interval expires.
 // TODO: If a resource already exists in the cluster but not applied by ClusterResourceSet, the resource will be updated ?
 func (r *Reconciler) ApplyClusterResourceSet(ctx context.Context, cluster *clusterv1.Cluster, clusterResourceSet *addonsv1.ClusterResourceSet) (rerr error) {
 	log := ctrl.LoggerFrom(ctx, "Cluster", klog.KObj(cluster))
@@ -352,14 +433,42 @@ func (r *Reconciler) ApplyClusterResourceSet(ctx context.Context, cluster *clust
 		return err
 	}
 
-	patch := client.MergeFromWithOptions(clusterResourceSetBinding.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	bindingPatch := client.MergeFromWithOptions(clusterResourceSetBinding.DeepCopy(), client.MergeFromWithOptimisticLock{})
 
 	defer func() {
 		// Always attempt to Patch the ClusterResourceSetBinding object after each reconciliation.
-		// Note only the ClusterResourceSetBinding spec will be patched as it does not have a status field, and so
-		// using the patch helper is unnecessary.
-		if err := r.Client.Patch(ctx, clusterResourceSetBinding, patch); err != nil {
+		// Note: The spec is patched using optimistic locking, given that the ClusterResourceSetBinding is shared by all
+		// the ClusterResourceSets applied to the Cluster.
+		if err := r.Client.Patch(ctx, clusterResourceSetBinding, bindingPatch); err != nil {
 			rerr = kerrors.NewAggregate([]error{rerr, errors.Wrapf(err, "failed to patch ClusterResourceSetBinding %s", klog.KObj(clusterResourceSetBinding))})
+			return
+		}
+
+		// Set the Drifted condition from the spec that has just been patched, so it surfaces the drift of objects
+		// applied by all the ClusterResourceSets applied to the Cluster.
+		statusPatch := client.MergeFrom(clusterResourceSetBinding.DeepCopy())
+		setDriftedCondition(clusterResourceSetBinding)
+		if err := r.Client.Status().Patch(ctx, clusterResourceSetBinding, statusPatch); err != nil {
+			rerr = kerrors.NewAggregate([]error{rerr, errors.Wrapf(err, "failed to patch status of ClusterResourceSetBinding %s", klog.KObj(clusterResourceSetBinding))})
+		}
+
+		// Set the ResourcesHealthy condition on the Cluster, so it surfaces the health of the objects
+		// applied by all the ClusterResourceSets applied to the Cluster.
+		// Note: The Cluster is only patched if health is tracked by this ClusterResourceSet or if the condition has
+		// to be removed; the patch helper skips the patch if the condition did not change.
+		if !ptr.Deref(clusterResourceSet.Spec.WaitForHealthy, false) && !conditions.Has(cluster, clusterv1.ClusterResourcesHealthyCondition) {
+			return
+		}
+		clusterPatchHelper, err := patch.NewHelper(cluster, r.Client)
+		if err != nil {
+			rerr = kerrors.NewAggregate([]error{rerr, err})
+			return
+		}
+		setResourcesHealthyCondition(cluster, clusterResourceSetBinding)
+		if err := clusterPatchHelper.Patch(ctx, cluster, patch.WithOwnedConditions{Conditions: []string{
+			clusterv1.ClusterResourcesHealthyCondition,
+		}}); err != nil {
+			rerr = kerrors.NewAggregate([]error{rerr, errors.Wrapf(err, "failed to patch Cluster %s", klog.KObj(cluster))})
 		}
 	}()
 
@@ -371,6 +480,26 @@ func (r *Reconciler) ApplyClusterResourceSet(ctx context.Context, cluster *clust
 		UID:        clusterResourceSet.UID,
 	}))
 
+	// Wait for the ClusterResourceSets in dependsOn to be applied to the Cluster before applying this ClusterResourceSet.
+	if len(clusterResourceSet.Spec.DependsOn) > 0 {
+		notApplied, err := r.dependenciesNotApplied(ctx, cluster, clusterResourceSet, clusterResourceSetBinding)
+		if err != nil {
+			return err
+		}
+		if len(notApplied) > 0 {
+			message := fmt.Sprintf("Waiting for ClusterResourceSets %s to be applied to Cluster %s", strings.Join(notApplied, ", "), klog.KObj(cluster))
+			log.Info(message)
+			v1beta1conditions.MarkFalse(clusterResourceSet, addonsv1.ResourcesAppliedV1Beta1Condition, addonsv1.WaitingForDependenciesV1Beta1Reason, clusterv1.ConditionSeverityInfo, "%s", message)
+			conditions.Set(clusterResourceSet, metav1.Condition{
+				Type:    addonsv1.ClusterResourceSetResourcesAppliedCondition,
+				Status:  metav1.ConditionFalse,
+				Reason:  addonsv1.ClusterResourceSetResourcesAppliedWaitingForDependenciesReason,
+				Message: message,
+			})
+			return errors.Wrapf(errDependenciesNotApplied, "failed to apply ClusterResourceSet %s to Cluster %s", klog.KObj(clusterResourceSet), klog.KObj(cluster))
+		}
+	}
+
 	resourceSetBinding := clusterResourceSetBinding.GetOrCreateBinding(clusterResourceSet)
 
 	remoteClient, err := r.ClusterCache.GetClient(ctx, util.ObjectKey(cluster))
@@ -400,21 +529,85 @@ func (r *Reconciler) ApplyClusterResourceSet(ctx context.Context, cluster *clust
 			continue
 		}
 
-		resourceScope, err := reconcileScopeForResource(clusterResourceSet, resource, resourceSetBinding, unstructuredObj)
+		resourceScope, err := reconcileScopeForResource(clusterResourceSet, resource, resourceSetBinding, unstructuredObj, cluster)
 		if err != nil {
+			var objects []addonsv1.ResourceBindingObject
+			if resourceBinding := resourceSetBinding.GetResource(resource); resourceBinding != nil {
+				objects = resourceBinding.Objects
+			}
 			resourceSetBinding.SetBinding(addonsv1.ResourceBinding{
 				ResourceRef:     resource,
 				Hash:            "",
 				Applied:         ptr.To(false),
 				LastAppliedTime: metav1.Time{Time: time.Now().UTC()},
+				Objects:         objects,
 			})
 
+			// Note: This surfaces e.g. templates referring to variables not defined for the Cluster.
+			log.Error(err, "Failed to prepare ClusterResourceSet resource", resource.Kind, klog.KRef(clusterResourceSet.Namespace, resource.Name))
+			v1beta1conditions.MarkFalse(clusterResourceSet, addonsv1.ResourcesAppliedV1Beta1Condition, addonsv1.ApplyFailedV1Beta1Reason, clusterv1.ConditionSeverityWarning, "%s", err.Error())
+			conditions.Set(clusterResourceSet, metav1.Condition{
+				Type:    addonsv1.ClusterResourceSetResourcesAppliedCondition,
+				Status:  metav1.ConditionFalse,
+				Reason:  addonsv1.ClusterResourceSetResourcesNotAppliedReason,
+				Message: "Failed to apply ClusterResourceSet resources to Cluster",
+			})
 			errList = append(errList, err)
 			continue
 		}
 
+		// Check if objects applied from the resource have been changed or deleted in the Cluster.
+		// Note: Drift is only checked if the drift correction interval is set, at most once per interval.
+		// Drifted objects are re-applied only if the resource has to be applied anyway or if the drift correction
+		// interval is expired.
+		var driftedObjects []addonsv1.ResourceBindingObject
+		var lastDriftCheckTime metav1.Time
 		if !resourceScope.needsApply() {
-			continue
+			resourceBinding := resourceSetBinding.GetResource(resource)
+			if resourceBinding == nil {
+				continue
+			}
+			// Track the objects applied from the resource if they are not tracked yet, e.g. because prune has been enabled
+			// after the resource has been applied, so they are pruned when they are removed from the ClusterResourceSet.
+			if ptr.Deref(clusterResourceSet.Spec.Prune, false) && len(resourceBinding.Objects) == 0 {
+				resourceBinding.Objects = resourceBindingObjects(resourceScope.objs())
+				resourceSetBinding.SetBinding(*resourceBinding)
+			}
+
+			if clusterResourceSet.Spec.DriftCorrectionIntervalSeconds != nil {
+				driftedObjects = resourceBinding.DriftedObjects
+				lastDriftCheckTime = resourceBinding.LastDriftCheckTime
+			}
+			if needsDriftCheck(clusterResourceSet, resourceBinding) {
+				driftedObjs, err := resourceScope.driftedObjs(ctx, remoteClient)
+				if err != nil {
+					log.Error(err, "Failed to check drift of ClusterResourceSet resource", resource.Kind, klog.KRef(clusterResourceSet.Namespace, resource.Name))
+					errList = append(errList, err)
+					continue
+				}
+				driftedObjects = resourceBindingObjects(driftedObjs)
+				lastDriftCheckTime = metav1.Time{Time: time.Now().UTC()}
+			}
+
+			if len(driftedObjects) == 0 || !needsDriftCorrection(clusterResourceSet, resourceBinding) {
+				resourceBinding.DriftedObjects = driftedObjects
+				resourceBinding.LastDriftCheckTime = lastDriftCheckTime
+				if err := setResourceBindingHealth(ctx, remoteClient, clusterResourceSet, resourceBinding, resourceScope.objs()); err != nil {
+					log.Error(err, "Failed to check health of ClusterResourceSet resource", resource.Kind, klog.KRef(clusterResourceSet.Namespace, resource.Name))
+					errList = append(errList, err)
+				}
+				resourceSetBinding.SetBinding(*resourceBinding)
+				continue
+			}
+			log.Info(fmt.Sprintf("Correcting drift of objects applied from %s %s", resource.Kind, klog.KRef(clusterResourceSet.Namespace, resource.Name)))
+		}
+
+		// Get the objects previously applied from the resource, so objects removed from the resource can be pruned.
+		// Note: The objects are not kept in the ResourceBinding while applying, so they are not considered in use
+		// by the resource itself when pruning.
+		var previousObjects []addonsv1.ResourceBindingObject
+		if resourceBinding := resourceSetBinding.GetResource(resource); resourceBinding != nil {
+			previousObjects = resourceBinding.Objects
 		}
 
 		// Set status in ClusterResourceSetBinding in case of early continue due to a failure.
@@ -442,17 +635,77 @@ func (r *Reconciler) ApplyClusterResourceSet(ctx context.Context, cluster *clust
 			errList = append(errList, err)
 		}
 
-		resourceSetBinding.SetBinding(addonsv1.ResourceBinding{
-			ResourceRef:     resource,
-			Hash:            resourceScope.hash(),
-			Applied:         ptr.To(isSuccessful),
-			LastAppliedTime: metav1.Time{Time: time.Now().UTC()},
-		})
+		// Objects applied successfully are not drifted anymore.
+		if isSuccessful {
+			driftedObjects = nil
+			if clusterResourceSet.Spec.DriftCorrectionIntervalSeconds != nil {
+				lastDriftCheckTime = metav1.Time{Time: time.Now().UTC()}
+			}
+		}
+
+		// Track the objects applied from the resource and delete the ones that have been removed from the resource.
+		// Note: If applying the resource failed, previous objects are still tracked so they can be pruned later.
+		var objects []addonsv1.ResourceBindingObject
+		if ptr.Deref(clusterResourceSet.Spec.Prune, false) {
+			objects = resourceBindingObjects(resourceScope.objs())
+			if isSuccessful {
+				keep := trackedResourceBindingObjects(clusterResourceSet, resourceSetBinding).
+					Union(otherClusterResourceSetsObjects(clusterResourceSetBinding, clusterResourceSet, resourceSetBinding)).
+					Insert(objects...)
+				notPruned, err := pruneObjects(ctx, remoteClient, previousObjects, keep)
+				if err != nil {
+					isSuccessful = false
+					log.Error(err, "Failed to prune objects of ClusterResourceSet resource", resource.Kind, klog.KRef(clusterResourceSet.Namespace, resource.Name))
+					setPruneFailedConditions(clusterResourceSet, err)
+					errList = append(errList, err)
+				}
+				objects = mergeResourceBindingObjects(objects, notPruned)
+			} else {
+				objects = mergeResourceBindingObjects(previousObjects, objects)
+			}
+		}
+
+		resourceBinding := addonsv1.ResourceBinding{
+			ResourceRef:        resource,
+			Hash:               resourceScope.hash(),
+			Applied:            ptr.To(isSuccessful),
+			LastAppliedTime:    metav1.Time{Time: time.Now().UTC()},
+			Objects:            objects,
+			DriftedObjects:     driftedObjects,
+			LastDriftCheckTime: lastDriftCheckTime,
+		}
+
+		// Check the health of the objects applied from the resource.
+		if isSuccessful {
+			if err := setResourceBindingHealth(ctx, remoteClient, clusterResourceSet, &resourceBinding, resourceScope.objs()); err != nil {
+				log.Error(err, "Failed to check health of ClusterResourceSet resource", resource.Kind, klog.KRef(clusterResourceSet.Namespace, resource.Name))
+				errList = append(errList, err)
+			}
+		}
+
+		resourceSetBinding.SetBinding(resourceBinding)
 	}
+
+	// Delete the objects applied from resources that have been removed from the ClusterResourceSet.
+	if ptr.Deref(clusterResourceSet.Spec.Prune, false) {
+		if err := pruneRemovedResources(ctx, remoteClient, clusterResourceSet, clusterResourceSetBinding, resourceSetBinding); err != nil {
+			log.Error(err, "Failed to prune objects of resources removed from ClusterResourceSet")
+			setPruneFailedConditions(clusterResourceSet, err)
+			errList = append(errList, err)
+		}
+	}
+
 	if len(errList) > 0 {
 		return kerrors.NewAggregate(errList)
 	}
 
+	// Wait for the objects applied to the Cluster to be healthy before reporting the resources as applied.
+	// Note: The ResourcesApplied condition is set by the caller after all the Clusters have been reconciled.
+	if unhealthy := unhealthyResources(clusterResourceSet, resourceSetBinding); len(unhealthy) > 0 {
+		log.V(4).Info(fmt.Sprintf("Waiting for objects applied from %s to be healthy", strings.Join(unhealthy, ", ")))
+		return errors.Wrapf(errResourcesNotHealthy, "failed to apply ClusterResourceSet %s to Cluster %s", klog.KObj(clusterResourceSet), klog.KObj(cluster))
+	}
+
 	v1beta1conditions.MarkTrue(clusterResourceSet, addonsv1.ResourcesAppliedV1Beta1Condition)
 	conditions.Set(clusterResourceSet, metav1.Condition{
 		Type:   addonsv1.ClusterResourceSetResourcesAppliedCondition,
@@ -463,6 +716,16 @@ func (r *Reconciler) ApplyClusterResourceSet(ctx context.Context, cluster *clust
 	return nil
 }
 
+func setPruneFailedConditions(clusterResourceSet *addonsv1.ClusterResourceSet, err error) {
+	v1beta1conditions.MarkFalse(clusterResourceSet, addonsv1.ResourcesAppliedV1Beta1Condition, addonsv1.ApplyFailedV1Beta1Reason, clusterv1.ConditionSeverityWarning, "%s", err.Error())
+	conditions.Set(clusterResourceSet, metav1.Condition{
+		Type:    addonsv1.ClusterResourceSetResourcesAppliedCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  addonsv1.ClusterResourceSetResourcesNotAppliedReason,
+		Message: "Failed to delete objects that are not part of the ClusterResourceSet anymore from Cluster",
+	})
+}
+
 // getResource retrieves the requested resource and convert it to unstructured type.
 // Unsupported resource kinds are not denied by validation webhook, hence no need to check here.
 // Only supports Secrets/Configmaps as resource types and allow using resources in the same namespace with the cluster.
@@ -749,6 +749,72 @@ metadata:
 		g.Eventually(configMapHasBeenUpdated(env, resourceConfigMap2Key, resourceConfigMap2), timeout).Should(Succeed())
 	})
 
+	t.Run("Should delete the objects of a resource removed from a ClusterResourceSet after prune has been enabled", func(t *testing.T) {
+		g := NewWithT(t)
+		ns := setup(t, g)
+		defer teardown(t, g, ns)
+
+		t.Log("Updating the cluster with labels")
+		testCluster.SetLabels(labels)
+		g.Expect(env.Update(ctx, testCluster)).To(Succeed())
+
+		t.Log("Creating a ClusterResourceSet instance with Reconcile strategy and without prune")
+		clusterResourceSet := &addonsv1.ClusterResourceSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      clusterResourceSetName,
+				Namespace: ns.Name,
+			},
+			Spec: addonsv1.ClusterResourceSetSpec{
+				Strategy: string(addonsv1.ClusterResourceSetStrategyReconcile),
+				ClusterSelector: metav1.LabelSelector{
+					MatchLabels: labels,
+				},
+				Resources: []addonsv1.ResourceRef{{Name: configmapName, Kind: "ConfigMap"}, {Name: secretName, Kind: "Secret"}},
+			},
+		}
+		g.Expect(env.Create(ctx, clusterResourceSet)).To(Succeed())
+
+		t.Log("Verifying resource ConfigMaps have been created")
+		resourceConfigMap1Key := client.ObjectKey{Namespace: resourceConfigMapsNamespace, Name: resourceConfigMap1Name}
+		resourceConfigMap2Key := client.ObjectKey{Namespace: resourceConfigMapsNamespace, Name: resourceConfigMap2Name}
+		g.Eventually(func() error {
+			return env.Get(ctx, resourceConfigMap1Key, &corev1.ConfigMap{})
+		}, timeout).Should(Succeed())
+		g.Eventually(func() error {
+			return env.Get(ctx, resourceConfigMap2Key, &corev1.ConfigMap{})
+		}, timeout).Should(Succeed())
+
+		t.Log("Enabling prune on the ClusterResourceSet")
+		g.Expect(env.Get(ctx, client.ObjectKeyFromObject(clusterResourceSet), clusterResourceSet)).To(Succeed())
+		crsPatch := client.MergeFrom(clusterResourceSet.DeepCopy())
+		clusterResourceSet.Spec.Prune = ptr.To(true)
+		g.Expect(env.Patch(ctx, clusterResourceSet, crsPatch)).To(Succeed())
+
+		t.Log("Verifying the objects applied from the resources are tracked in the ClusterResourceSetBinding")
+		clusterResourceSetBindingKey := client.ObjectKey{Namespace: testCluster.Namespace, Name: testCluster.Name}
+		g.Eventually(func(g Gomega) {
+			binding := &addonsv1.ClusterResourceSetBinding{}
+			g.Expect(env.Get(ctx, clusterResourceSetBindingKey, binding)).To(Succeed())
+			g.Expect(binding.Spec.Bindings).To(HaveLen(1))
+			g.Expect(binding.Spec.Bindings[0].Resources).To(HaveLen(2))
+			for _, r := range binding.Spec.Bindings[0].Resources {
+				g.Expect(r.Objects).To(HaveLen(1))
+			}
+		}, timeout).Should(Succeed())
+
+		t.Log("Removing the Secret from the resources of the ClusterResourceSet")
+		g.Expect(env.Get(ctx, client.ObjectKeyFromObject(clusterResourceSet), clusterResourceSet)).To(Succeed())
+		crsPatch = client.MergeFrom(clusterResourceSet.DeepCopy())
+		clusterResourceSet.Spec.Resources = []addonsv1.ResourceRef{{Name: configmapName, Kind: "ConfigMap"}}
+		g.Expect(env.Patch(ctx, clusterResourceSet, crsPatch)).To(Succeed())
+
+		t.Log("Verifying resource ConfigMap 2 has been deleted and resource ConfigMap 1 has been kept")
+		g.Eventually(func() bool {
+			return apierrors.IsNotFound(env.Get(ctx, resourceConfigMap2Key, &corev1.ConfigMap{}))
+		}, timeout).Should(BeTrue())
+		g.Expect(env.Get(ctx, resourceConfigMap1Key, &corev1.ConfigMap{})).To(Succeed())
+	})
+
 	t.Run("Should reconcile a ClusterResourceSet with ApplyOnce strategy even when one of the resources already exist", func(t *testing.T) {
 		g := NewWithT(t)
 		ns := setup(t, g)
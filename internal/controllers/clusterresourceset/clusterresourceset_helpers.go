@@ -24,27 +24,54 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"reflect"
+	"slices"
 	"sort"
+	"strings"
+	"text/template"
+	"time"
 	"unicode"
 
+	"github.com/Masterminds/sprig/v3"
 	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	addonsv1 "sigs.k8s.io/cluster-api/api/addons/v1beta2"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
+	"sigs.k8s.io/cluster-api/internal/topology/builtins"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	clog "sigs.k8s.io/cluster-api/util/log"
 	utilresource "sigs.k8s.io/cluster-api/util/resource"
 	utilyaml "sigs.k8s.io/cluster-api/util/yaml"
 )
 
 var jsonListPrefix = []byte("[")
 
+const (
+	// clusterResourceSetFieldManagerPrefix is the prefix of the field manager used to apply the objects of a ClusterResourceSet.
+	clusterResourceSetFieldManagerPrefix = "capi-clusterresourceset-"
+
+	// maxFieldManagerLength is the maximum length of a field manager.
+	maxFieldManagerLength = 128
+)
+
 // objsFromYamlData parses a collection of yaml documents into Unstructured objects.
 // The returned objects are sorted for creation priority within the objects defined
 // in the same document. The flattening of the documents preserves the original order.
@@ -106,6 +133,396 @@ func createUnstructured(ctx context.Context, c client.Client, obj *unstructured.
 	return nil
 }
 
+// clusterResourceSetFieldManager returns the field manager used to apply the objects of a ClusterResourceSet, so each
+// ClusterResourceSet owns the fields of the objects it applies.
+// Note: If the name of the ClusterResourceSet is too long, it is truncated and a hash of the name is added to keep the field manager unique.
+func clusterResourceSetFieldManager(clusterResourceSet *addonsv1.ClusterResourceSet) string {
+	fieldManager := clusterResourceSetFieldManagerPrefix + clusterResourceSet.Name
+	if len(fieldManager) <= maxFieldManagerLength {
+		return fieldManager
+	}
+	hash := fmt.Sprintf("%x", sha256.Sum256([]byte(clusterResourceSet.Name)))[:8]
+	return fmt.Sprintf("%s-%s", fieldManager[:maxFieldManagerLength-len(hash)-1], hash)
+}
+
+// isAppliedBy returns true if the object exists in the cluster and fields of the object are owned by the given
+// field manager via server-side apply.
+func isAppliedBy(ctx context.Context, c client.Client, fieldManager string, obj *unstructured.Unstructured) (bool, error) {
+	currentObj := &unstructured.Unstructured{}
+	currentObj.SetAPIVersion(obj.GetAPIVersion())
+	currentObj.SetKind(obj.GetKind())
+	if err := c.Get(ctx, client.ObjectKeyFromObject(obj), currentObj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "reading object %s %s", obj.GroupVersionKind(), klog.KObj(obj))
+	}
+
+	for _, managedField := range currentObj.GetManagedFields() {
+		if managedField.Manager == fieldManager && managedField.Operation == metav1.ManagedFieldsOperationApply {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// hasDrifted returns true if the object has been changed or deleted in the cluster after being applied, i.e. if
+// applying the object again would change the object in the cluster.
+// Note: A server-side apply dry-run is used to compare the object in the cluster with the result of applying the object;
+// conflicts with other field managers are considered drift, given that they are caused by fields owned by the
+// ClusterResourceSet being changed in the cluster.
+func hasDrifted(ctx context.Context, c client.Client, fieldManager string, obj *unstructured.Unstructured) (bool, error) {
+	currentObj := &unstructured.Unstructured{}
+	currentObj.SetAPIVersion(obj.GetAPIVersion())
+	currentObj.SetKind(obj.GetKind())
+	if err := c.Get(ctx, client.ObjectKeyFromObject(obj), currentObj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, errors.Wrapf(err, "reading object %s %s", obj.GroupVersionKind(), klog.KObj(obj))
+	}
+
+	dryRunObj := obj.DeepCopy()
+	if err := c.Apply(ctx, client.ApplyConfigurationFromUnstructured(dryRunObj), client.FieldOwner(fieldManager), client.DryRunAll); err != nil {
+		if apierrors.IsConflict(err) {
+			return true, nil
+		}
+		return false, errors.Wrapf(err, "applying object %s %s with dry-run", obj.GroupVersionKind(), klog.KObj(obj))
+	}
+
+	// Drop fields that are expected to change when applying the object, even if the object didn't change.
+	for _, u := range []*unstructured.Unstructured{currentObj, dryRunObj} {
+		unstructured.RemoveNestedField(u.Object, "metadata", "managedFields")
+		unstructured.RemoveNestedField(u.Object, "metadata", "resourceVersion")
+		unstructured.RemoveNestedField(u.Object, "metadata", "generation")
+	}
+	return !reflect.DeepEqual(currentObj.Object, dryRunObj.Object), nil
+}
+
+// needsDriftCheck returns true if objects applied from a resource must be checked for drift, i.e. if the drift
+// correction interval of the ClusterResourceSet is expired since the objects have been last checked.
+func needsDriftCheck(clusterResourceSet *addonsv1.ClusterResourceSet, resourceBinding *addonsv1.ResourceBinding) bool {
+	if clusterResourceSet.Spec.DriftCorrectionIntervalSeconds == nil {
+		return false
+	}
+	interval := time.Duration(*clusterResourceSet.Spec.DriftCorrectionIntervalSeconds) * time.Second
+	return time.Since(resourceBinding.LastDriftCheckTime.Time) >= interval
+}
+
+// needsDriftCorrection returns true if objects applied from a resource that have been changed or deleted in the Cluster
+// must be re-applied, i.e. if the drift correction interval of the ClusterResourceSet is expired since the resource
+// has been last applied.
+func needsDriftCorrection(clusterResourceSet *addonsv1.ClusterResourceSet, resourceBinding *addonsv1.ResourceBinding) bool {
+	if clusterResourceSet.Spec.DriftCorrectionIntervalSeconds == nil {
+		return false
+	}
+	interval := time.Duration(*clusterResourceSet.Spec.DriftCorrectionIntervalSeconds) * time.Second
+	return time.Since(resourceBinding.LastAppliedTime.Time) >= interval
+}
+
+// setDriftedCondition sets the Drifted condition on the ClusterResourceSetBinding, surfacing the objects applied
+// by ClusterResourceSets that have been changed or deleted in the Cluster.
+func setDriftedCondition(clusterResourceSetBinding *addonsv1.ClusterResourceSetBinding) {
+	messages := []string{}
+	for _, binding := range clusterResourceSetBinding.Spec.Bindings {
+		driftedObjects := []addonsv1.ResourceBindingObject{}
+		for _, resource := range binding.Resources {
+			driftedObjects = append(driftedObjects, resource.DriftedObjects...)
+		}
+		if len(driftedObjects) == 0 {
+			continue
+		}
+		messages = append(messages, fmt.Sprintf("* ClusterResourceSet %s: %s", binding.ClusterResourceSetName, clog.ListToString(driftedObjects, func(o addonsv1.ResourceBindingObject) string {
+			return fmt.Sprintf("%s %s", o.Kind, klog.KRef(o.Namespace, o.Name))
+		}, 5)))
+	}
+
+	if len(messages) == 0 {
+		conditions.Set(clusterResourceSetBinding, metav1.Condition{
+			Type:   addonsv1.ClusterResourceSetBindingDriftedCondition,
+			Status: metav1.ConditionFalse,
+			Reason: addonsv1.ClusterResourceSetBindingNotDriftedReason,
+		})
+		return
+	}
+
+	conditions.Set(clusterResourceSetBinding, metav1.Condition{
+		Type:    addonsv1.ClusterResourceSetBindingDriftedCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  addonsv1.ClusterResourceSetBindingDriftedReason,
+		Message: "Objects changed or deleted in the Cluster after being applied:\n" + strings.Join(messages, "\n"),
+	})
+}
+
+// setResourceBindingHealth sets the health of the objects applied from a resource in the ResourceBinding.
+// Note: Health is only tracked if waitForHealthy is enabled in the ClusterResourceSet.
+func setResourceBindingHealth(ctx context.Context, c client.Client, clusterResourceSet *addonsv1.ClusterResourceSet, resourceBinding *addonsv1.ResourceBinding, objs []unstructured.Unstructured) error {
+	if !ptr.Deref(clusterResourceSet.Spec.WaitForHealthy, false) {
+		resourceBinding.Healthy = nil
+		resourceBinding.UnhealthyObjects = nil
+		return nil
+	}
+
+	unhealthyObjs, err := unhealthyObjects(ctx, c, objs)
+	if err != nil {
+		return err
+	}
+	resourceBinding.Healthy = ptr.To(len(unhealthyObjs) == 0)
+	resourceBinding.UnhealthyObjects = resourceBindingObjects(unhealthyObjs)
+	return nil
+}
+
+// unhealthyObjects returns the objects that are not healthy in the Cluster, i.e. Deployments and DaemonSets
+// that are not available and CustomResourceDefinitions that are not established.
+// Note: Objects of other kinds are always considered healthy.
+func unhealthyObjects(ctx context.Context, c client.Client, objs []unstructured.Unstructured) ([]unstructured.Unstructured, error) {
+	unhealthyObjs := []unstructured.Unstructured{}
+	for i := range objs {
+		healthy, err := isHealthy(ctx, c, &objs[i])
+		if err != nil {
+			return nil, err
+		}
+		if !healthy {
+			unhealthyObjs = append(unhealthyObjs, objs[i])
+		}
+	}
+	return unhealthyObjs, nil
+}
+
+var (
+	deploymentGroupKind               = schema.GroupKind{Group: appsv1.GroupName, Kind: "Deployment"}
+	daemonSetGroupKind                = schema.GroupKind{Group: appsv1.GroupName, Kind: "DaemonSet"}
+	customResourceDefinitionGroupKind = schema.GroupKind{Group: apiextensionsv1.GroupName, Kind: "CustomResourceDefinition"}
+)
+
+// isHealthy returns true if an object applied to the Cluster is healthy.
+func isHealthy(ctx context.Context, c client.Client, obj *unstructured.Unstructured) (bool, error) {
+	groupKind := obj.GroupVersionKind().GroupKind()
+	if groupKind != deploymentGroupKind && groupKind != daemonSetGroupKind && groupKind != customResourceDefinitionGroupKind {
+		return true, nil
+	}
+
+	currentObj := &unstructured.Unstructured{}
+	currentObj.SetAPIVersion(obj.GetAPIVersion())
+	currentObj.SetKind(obj.GetKind())
+	if err := c.Get(ctx, client.ObjectKeyFromObject(obj), currentObj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "reading object %s %s", obj.GroupVersionKind(), klog.KObj(obj))
+	}
+
+	switch groupKind {
+	case deploymentGroupKind:
+		deployment := &appsv1.Deployment{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(currentObj.Object, deployment); err != nil {
+			return false, errors.Wrapf(err, "converting object %s %s", obj.GroupVersionKind(), klog.KObj(obj))
+		}
+		// Note: This is the same check used by kubectl rollout status.
+		replicas := ptr.Deref(deployment.Spec.Replicas, 1)
+		return deployment.Status.ObservedGeneration >= deployment.Generation &&
+			deployment.Status.UpdatedReplicas >= replicas &&
+			deployment.Status.Replicas <= deployment.Status.UpdatedReplicas &&
+			deployment.Status.AvailableReplicas >= deployment.Status.UpdatedReplicas, nil
+	case daemonSetGroupKind:
+		daemonSet := &appsv1.DaemonSet{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(currentObj.Object, daemonSet); err != nil {
+			return false, errors.Wrapf(err, "converting object %s %s", obj.GroupVersionKind(), klog.KObj(obj))
+		}
+		return daemonSet.Status.ObservedGeneration >= daemonSet.Generation &&
+			daemonSet.Status.UpdatedNumberScheduled >= daemonSet.Status.DesiredNumberScheduled &&
+			daemonSet.Status.NumberAvailable >= daemonSet.Status.DesiredNumberScheduled, nil
+	default:
+		crd := &apiextensionsv1.CustomResourceDefinition{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(currentObj.Object, crd); err != nil {
+			return false, errors.Wrapf(err, "converting object %s %s", obj.GroupVersionKind(), klog.KObj(obj))
+		}
+		for _, condition := range crd.Status.Conditions {
+			if condition.Type == apiextensionsv1.Established {
+				return condition.Status == apiextensionsv1.ConditionTrue, nil
+			}
+		}
+		return false, nil
+	}
+}
+
+// unhealthyResources returns the resources of a ClusterResourceSet whose applied objects are not healthy yet.
+// Note: Health is only tracked if waitForHealthy is enabled in the ClusterResourceSet.
+func unhealthyResources(clusterResourceSet *addonsv1.ClusterResourceSet, resourceSetBinding *addonsv1.ResourceSetBinding) []string {
+	if !ptr.Deref(clusterResourceSet.Spec.WaitForHealthy, false) {
+		return nil
+	}
+
+	unhealthy := []string{}
+	for _, resourceRef := range clusterResourceSet.Spec.Resources {
+		resourceBinding := resourceSetBinding.GetResource(resourceRef)
+		if resourceBinding == nil || !ptr.Deref(resourceBinding.Healthy, false) {
+			unhealthy = append(unhealthy, fmt.Sprintf("%s %s", resourceRef.Kind, klog.KRef(clusterResourceSet.Namespace, resourceRef.Name)))
+		}
+	}
+	return unhealthy
+}
+
+// setResourcesHealthyCondition sets the ResourcesHealthy condition on the Cluster, surfacing the health of the objects
+// applied by all the ClusterResourceSets with waitForHealthy enabled.
+// Note: The condition is removed if no ClusterResourceSet applied to the Cluster has waitForHealthy enabled.
+func setResourcesHealthyCondition(cluster *clusterv1.Cluster, clusterResourceSetBinding *addonsv1.ClusterResourceSetBinding) {
+	tracked := false
+	messages := []string{}
+	for _, binding := range clusterResourceSetBinding.Spec.Bindings {
+		unhealthyObjects := []addonsv1.ResourceBindingObject{}
+		for _, resource := range binding.Resources {
+			if resource.Healthy == nil {
+				continue
+			}
+			tracked = true
+			unhealthyObjects = append(unhealthyObjects, resource.UnhealthyObjects...)
+		}
+		if len(unhealthyObjects) == 0 {
+			continue
+		}
+		messages = append(messages, fmt.Sprintf("* ClusterResourceSet %s: %s", binding.ClusterResourceSetName, clog.ListToString(unhealthyObjects, func(o addonsv1.ResourceBindingObject) string {
+			return fmt.Sprintf("%s %s", o.Kind, klog.KRef(o.Namespace, o.Name))
+		}, 5)))
+	}
+
+	if !tracked {
+		conditions.Delete(cluster, clusterv1.ClusterResourcesHealthyCondition)
+		return
+	}
+
+	if len(messages) == 0 {
+		conditions.Set(cluster, metav1.Condition{
+			Type:   clusterv1.ClusterResourcesHealthyCondition,
+			Status: metav1.ConditionTrue,
+			Reason: clusterv1.ClusterResourcesHealthyReason,
+		})
+		return
+	}
+
+	conditions.Set(cluster, metav1.Condition{
+		Type:    clusterv1.ClusterResourcesHealthyCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  clusterv1.ClusterResourcesNotHealthyReason,
+		Message: "Objects applied by ClusterResourceSets are not healthy:\n" + strings.Join(messages, "\n"),
+	})
+}
+
+// resourceBindingObjects returns references to the given objects, to be tracked in a ResourceBinding.
+func resourceBindingObjects(objs []unstructured.Unstructured) []addonsv1.ResourceBindingObject {
+	objects := make([]addonsv1.ResourceBindingObject, 0, len(objs))
+	for _, obj := range objs {
+		objects = append(objects, addonsv1.ResourceBindingObject{
+			APIVersion: obj.GetAPIVersion(),
+			Kind:       obj.GetKind(),
+			Namespace:  obj.GetNamespace(),
+			Name:       obj.GetName(),
+		})
+	}
+	return objects
+}
+
+// mergeResourceBindingObjects returns the union of the given lists of objects, preserving order.
+func mergeResourceBindingObjects(objectLists ...[]addonsv1.ResourceBindingObject) []addonsv1.ResourceBindingObject {
+	seen := sets.Set[addonsv1.ResourceBindingObject]{}
+	objects := []addonsv1.ResourceBindingObject{}
+	for _, objectList := range objectLists {
+		for _, object := range objectList {
+			if seen.Has(object) {
+				continue
+			}
+			seen.Insert(object)
+			objects = append(objects, object)
+		}
+	}
+	return objects
+}
+
+// pruneObjects deletes the given objects from the cluster, except the ones in keep.
+// It returns the objects that could not be deleted.
+func pruneObjects(ctx context.Context, c client.Client, objects []addonsv1.ResourceBindingObject, keep sets.Set[addonsv1.ResourceBindingObject]) ([]addonsv1.ResourceBindingObject, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	notPruned := []addonsv1.ResourceBindingObject{}
+	errList := []error{}
+	for _, object := range objects {
+		if keep.Has(object) {
+			continue
+		}
+
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion(object.APIVersion)
+		obj.SetKind(object.Kind)
+		obj.SetNamespace(object.Namespace)
+		obj.SetName(object.Name)
+		if err := c.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+			notPruned = append(notPruned, object)
+			errList = append(errList, errors.Wrapf(err, "deleting object %s %s", obj.GroupVersionKind(), klog.KObj(obj)))
+			continue
+		}
+		log.Info(fmt.Sprintf("Deleted %s %s that is not part of the ClusterResourceSet anymore", object.Kind, klog.KObj(obj)))
+	}
+	return notPruned, kerrors.NewAggregate(errList)
+}
+
+// pruneRemovedResources deletes from the cluster the objects applied from resources that are not part of the
+// ClusterResourceSet anymore, and removes those resources from the ResourceSetBinding once all their objects are deleted.
+// Note: Objects that are still applied from other resources of the ClusterResourceSet, or from other ClusterResourceSets
+// bound to the same Cluster, are not deleted.
+func pruneRemovedResources(ctx context.Context, c client.Client, clusterResourceSet *addonsv1.ClusterResourceSet, clusterResourceSetBinding *addonsv1.ClusterResourceSetBinding, resourceSetBinding *addonsv1.ResourceSetBinding) error {
+	keep := trackedResourceBindingObjects(clusterResourceSet, resourceSetBinding).
+		Union(otherClusterResourceSetsObjects(clusterResourceSetBinding, clusterResourceSet, resourceSetBinding))
+
+	errList := []error{}
+	for _, resourceBinding := range slices.Clone(resourceSetBinding.Resources) {
+		if slices.Contains(clusterResourceSet.Spec.Resources, resourceBinding.ResourceRef) {
+			continue
+		}
+
+		notPruned, err := pruneObjects(ctx, c, resourceBinding.Objects, keep)
+		if err != nil {
+			errList = append(errList, err)
+			resourceBinding.Objects = notPruned
+			resourceSetBinding.SetBinding(resourceBinding)
+			continue
+		}
+		resourceSetBinding.RemoveResource(resourceBinding.ResourceRef)
+	}
+	return kerrors.NewAggregate(errList)
+}
+
+// trackedResourceBindingObjects returns the objects tracked in the ResourceSetBinding for the resources
+// that are part of the ClusterResourceSet.
+func trackedResourceBindingObjects(clusterResourceSet *addonsv1.ClusterResourceSet, resourceSetBinding *addonsv1.ResourceSetBinding) sets.Set[addonsv1.ResourceBindingObject] {
+	objects := sets.Set[addonsv1.ResourceBindingObject]{}
+	for _, resourceBinding := range resourceSetBinding.Resources {
+		if slices.Contains(clusterResourceSet.Spec.Resources, resourceBinding.ResourceRef) {
+			objects.Insert(resourceBinding.Objects...)
+		}
+	}
+	return objects
+}
+
+// otherClusterResourceSetsObjects returns the objects applied to the Cluster by the other ClusterResourceSets
+// in the ClusterResourceSetBinding.
+// Note: Objects are tracked only for ClusterResourceSets with prune enabled, so the objects of resources
+// shared with another ClusterResourceSet are considered in use as long as the other ClusterResourceSet applies the resource.
+func otherClusterResourceSetsObjects(clusterResourceSetBinding *addonsv1.ClusterResourceSetBinding, clusterResourceSet *addonsv1.ClusterResourceSet, resourceSetBinding *addonsv1.ResourceSetBinding) sets.Set[addonsv1.ResourceBindingObject] {
+	objects := sets.Set[addonsv1.ResourceBindingObject]{}
+	for _, binding := range clusterResourceSetBinding.Spec.Bindings {
+		if binding.ClusterResourceSetName == clusterResourceSet.Name {
+			continue
+		}
+		for _, resourceBinding := range binding.Resources {
+			objects.Insert(resourceBinding.Objects...)
+			if sharedResourceBinding := resourceSetBinding.GetResource(resourceBinding.ResourceRef); sharedResourceBinding != nil {
+				objects.Insert(sharedResourceBinding.Objects...)
+			}
+		}
+	}
+	return objects
+}
+
 // getOrCreateClusterResourceSetBinding retrieves ClusterResourceSetBinding resource owned by the cluster or create a new one if not found.
 func (r *Reconciler) getOrCreateClusterResourceSetBinding(ctx context.Context, cluster *clusterv1.Cluster, clusterResourceSet *addonsv1.ClusterResourceSet) (*addonsv1.ClusterResourceSetBinding, error) {
 	clusterResourceSetBinding := &addonsv1.ClusterResourceSetBinding{
@@ -143,6 +560,116 @@ func (r *Reconciler) getOrCreateClusterResourceSetBinding(ctx context.Context, c
 	return clusterResourceSetBinding, nil
 }
 
+// invalidDependencies returns the reasons why the ClusterResourceSets in dependsOn can never be applied, i.e.
+// ClusterResourceSets in dependsOn that do not exist and ClusterResourceSets depending on each other in a cycle.
+// Note: ClusterResourceSets in dependsOn of dependencies that do not exist are reported by the dependencies themselves.
+func (r *Reconciler) invalidDependencies(ctx context.Context, clusterResourceSet *addonsv1.ClusterResourceSet) ([]string, error) {
+	invalid := []string{}
+	var cycle []string
+	visiting := sets.Set[string]{}
+	visited := sets.Set[string]{}
+
+	// visit walks the dependsOn graph depth-first, starting from the last ClusterResourceSet in path.
+	var visit func(path []string) error
+	visit = func(path []string) error {
+		name := path[len(path)-1]
+		if visiting.Has(name) {
+			cycle = path[slices.Index(path, name):]
+			return nil
+		}
+		if visited.Has(name) {
+			return nil
+		}
+
+		dependsOn := clusterResourceSet.Spec.DependsOn
+		if name != clusterResourceSet.Name {
+			dependency := &addonsv1.ClusterResourceSet{}
+			if err := r.Client.Get(ctx, client.ObjectKey{Namespace: clusterResourceSet.Namespace, Name: name}, dependency); err != nil {
+				if !apierrors.IsNotFound(err) {
+					return errors.Wrapf(err, "failed to get ClusterResourceSet %s", klog.KRef(clusterResourceSet.Namespace, name))
+				}
+				if len(path) == 2 {
+					invalid = append(invalid, fmt.Sprintf("ClusterResourceSet %s does not exist", klog.KRef(clusterResourceSet.Namespace, name)))
+				}
+			}
+			dependsOn = dependency.Spec.DependsOn
+		}
+
+		visiting.Insert(name)
+		for _, dependencyName := range dependsOn {
+			if err := visit(append(slices.Clone(path), dependencyName)); err != nil {
+				return err
+			}
+			if cycle != nil {
+				return nil
+			}
+		}
+		visiting.Delete(name)
+		visited.Insert(name)
+		return nil
+	}
+
+	if err := visit([]string{clusterResourceSet.Name}); err != nil {
+		return nil, err
+	}
+	if cycle != nil {
+		invalid = append(invalid, fmt.Sprintf("ClusterResourceSets depend on each other in a cycle: %s", strings.Join(cycle, " -> ")))
+	}
+	return invalid, nil
+}
+
+// dependenciesNotApplied returns the names of the ClusterResourceSets in dependsOn that are not applied to the Cluster yet,
+// or whose applied objects are not healthy yet if they have waitForHealthy enabled.
+// Note: ClusterResourceSets in dependsOn that do not match the Cluster are ignored.
+func (r *Reconciler) dependenciesNotApplied(ctx context.Context, cluster *clusterv1.Cluster, clusterResourceSet *addonsv1.ClusterResourceSet, clusterResourceSetBinding *addonsv1.ClusterResourceSetBinding) ([]string, error) {
+	notApplied := []string{}
+	for _, name := range clusterResourceSet.Spec.DependsOn {
+		dependency := &addonsv1.ClusterResourceSet{}
+		if err := r.Client.Get(ctx, client.ObjectKey{Namespace: clusterResourceSet.Namespace, Name: name}, dependency); err != nil {
+			if apierrors.IsNotFound(err) {
+				notApplied = append(notApplied, name)
+				continue
+			}
+			return nil, errors.Wrapf(err, "failed to get ClusterResourceSet %s", klog.KRef(clusterResourceSet.Namespace, name))
+		}
+
+		selector, err := metav1.LabelSelectorAsSelector(&dependency.Spec.ClusterSelector)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to build selector of ClusterResourceSet %s", klog.KObj(dependency))
+		}
+		if selector.Empty() || !selector.Matches(labels.Set(cluster.GetLabels())) {
+			continue
+		}
+
+		if !isClusterResourceSetApplied(dependency, clusterResourceSetBinding) {
+			notApplied = append(notApplied, name)
+		}
+	}
+	return notApplied, nil
+}
+
+// isClusterResourceSetApplied returns true if all the resources of a ClusterResourceSet are applied to the Cluster,
+// and if the objects applied to the Cluster are healthy when the ClusterResourceSet has waitForHealthy enabled.
+func isClusterResourceSetApplied(clusterResourceSet *addonsv1.ClusterResourceSet, clusterResourceSetBinding *addonsv1.ClusterResourceSetBinding) bool {
+	for i := range clusterResourceSetBinding.Spec.Bindings {
+		binding := &clusterResourceSetBinding.Spec.Bindings[i]
+		if binding.ClusterResourceSetName != clusterResourceSet.Name {
+			continue
+		}
+		for _, resourceRef := range clusterResourceSet.Spec.Resources {
+			resourceBinding := binding.GetResource(resourceRef)
+			if resourceBinding == nil || !ptr.Deref(resourceBinding.Applied, false) {
+				return false
+			}
+			if ptr.Deref(clusterResourceSet.Spec.WaitForHealthy, false) && !ptr.Deref(resourceBinding.Healthy, false) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
 // getConfigMap retrieves any ConfigMap from the given name and namespace.
 func getConfigMap(ctx context.Context, c client.Client, configMapName types.NamespacedName) (*corev1.ConfigMap, error) {
 	configMap := &corev1.ConfigMap{}
@@ -214,6 +741,60 @@ func normalizeData(resource *unstructured.Unstructured) ([][]byte, error) {
 	return dataList, nil
 }
 
+// renderData renders the data of a resource as Go templates using values from the Cluster.
+// The template data has the same format of the variables available in ClusterClass inline patches,
+// e.g. {{ .builtin.cluster.name }} or {{ .builtin.cluster.network.pods }}; variables defined in
+// Cluster.spec.topology.variables are available by name.
+func renderData(data [][]byte, cluster *clusterv1.Cluster) ([][]byte, error) {
+	templateData, err := calculateTemplateData(cluster)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to calculate template data for Cluster %s", klog.KObj(cluster))
+	}
+
+	renderedData := make([][]byte, 0, len(data))
+	for _, d := range data {
+		// Note: Rendering fails on missing keys, so typos in variable names do not end up in the applied objects.
+		tpl, err := template.New("tpl").Option("missingkey=error").Funcs(sprig.HermeticTxtFuncMap()).Parse(string(d))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse template")
+		}
+
+		var buf bytes.Buffer
+		if err := tpl.Execute(&buf, templateData); err != nil {
+			return nil, errors.Wrapf(err, "failed to render template for Cluster %s", klog.KObj(cluster))
+		}
+		renderedData = append(renderedData, buf.Bytes())
+	}
+	return renderedData, nil
+}
+
+// calculateTemplateData calculates the data for rendering templates from the Cluster, by converting
+// the builtin variable and the Cluster topology variables to their Go types.
+func calculateTemplateData(cluster *clusterv1.Cluster) (map[string]interface{}, error) {
+	variablesMap := make(map[string]interface{}, len(cluster.Spec.Topology.Variables)+1)
+	for _, variable := range cluster.Spec.Topology.Variables {
+		// Don't add user-defined "builtin" variable.
+		if variable.Name == runtimehooksv1.BuiltinsName {
+			continue
+		}
+		variablesMap[variable.Name] = variable.Value
+	}
+	variablesMap[runtimehooksv1.BuiltinsName] = runtimehooksv1.Builtins{
+		Cluster: builtins.Cluster(cluster),
+	}
+
+	// Marshal the variables and unmarshal them back to convert values from apiextensionsv1.JSON to their Go types.
+	tmp, err := json.Marshal(variablesMap)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal variables")
+	}
+	res := map[string]interface{}{}
+	if err := json.Unmarshal(tmp, &res); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal variables")
+	}
+	return res, nil
+}
+
 // ensureKubernetesServiceCreated ensures that the Service for Kubernetes API Server has been created.
 func ensureKubernetesServiceCreated(ctx context.Context, client client.Client) error {
 	err := client.Get(ctx, types.NamespacedName{
@@ -18,20 +18,26 @@ package clusterresourceset
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
 	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	addonsv1 "sigs.k8s.io/cluster-api/api/addons/v1beta2"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util/conditions"
 )
 
 const (
@@ -269,3 +275,846 @@ func TestEnsureKubernetesServiceCreated(t *testing.T) {
 		})
 	}
 }
+
+func TestMergeResourceBindingObjects(t *testing.T) {
+	g := NewWithT(t)
+
+	cm1 := addonsv1.ResourceBindingObject{APIVersion: "v1", Kind: "ConfigMap", Namespace: metav1.NamespaceDefault, Name: "cm1"}
+	cm2 := addonsv1.ResourceBindingObject{APIVersion: "v1", Kind: "ConfigMap", Namespace: metav1.NamespaceDefault, Name: "cm2"}
+	ns := addonsv1.ResourceBindingObject{APIVersion: "v1", Kind: "Namespace", Name: "ns"}
+
+	g.Expect(mergeResourceBindingObjects()).To(BeEmpty())
+	g.Expect(mergeResourceBindingObjects([]addonsv1.ResourceBindingObject{cm1, cm2}, []addonsv1.ResourceBindingObject{ns, cm1})).
+		To(Equal([]addonsv1.ResourceBindingObject{cm1, cm2, ns}))
+}
+
+func TestPruneObjects(t *testing.T) {
+	cm1 := addonsv1.ResourceBindingObject{APIVersion: "v1", Kind: "ConfigMap", Namespace: metav1.NamespaceDefault, Name: "cm1"}
+	cm2 := addonsv1.ResourceBindingObject{APIVersion: "v1", Kind: "ConfigMap", Namespace: metav1.NamespaceDefault, Name: "cm2"}
+	cmNotExist := addonsv1.ResourceBindingObject{APIVersion: "v1", Kind: "ConfigMap", Namespace: metav1.NamespaceDefault, Name: "not-exist"}
+
+	tests := []struct {
+		name              string
+		objects           []addonsv1.ResourceBindingObject
+		keep              []addonsv1.ResourceBindingObject
+		expectedRemaining []string
+	}{
+		{
+			name:              "should delete objects",
+			objects:           []addonsv1.ResourceBindingObject{cm1, cm2},
+			expectedRemaining: []string{},
+		},
+		{
+			name:              "should not delete objects to keep",
+			objects:           []addonsv1.ResourceBindingObject{cm1, cm2},
+			keep:              []addonsv1.ResourceBindingObject{cm2},
+			expectedRemaining: []string{"cm2"},
+		},
+		{
+			name:              "should ignore objects that do not exist anymore",
+			objects:           []addonsv1.ResourceBindingObject{cm1, cmNotExist},
+			expectedRemaining: []string{"cm2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			c := fake.NewClientBuilder().
+				WithObjects(
+					configMap("cm1", metav1.NamespaceDefault, nil),
+					configMap("cm2", metav1.NamespaceDefault, nil),
+				).
+				Build()
+
+			notPruned, err := pruneObjects(ctx, c, tt.objects, sets.New(tt.keep...))
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(notPruned).To(BeEmpty())
+
+			configMaps := &corev1.ConfigMapList{}
+			g.Expect(c.List(ctx, configMaps)).To(Succeed())
+			remaining := []string{}
+			for _, cm := range configMaps.Items {
+				remaining = append(remaining, cm.Name)
+			}
+			g.Expect(remaining).To(ConsistOf(tt.expectedRemaining))
+		})
+	}
+}
+
+func TestPruneRemovedResources(t *testing.T) {
+	g := NewWithT(t)
+
+	cm1 := addonsv1.ResourceBindingObject{APIVersion: "v1", Kind: "ConfigMap", Namespace: metav1.NamespaceDefault, Name: "cm1"}
+	cm2 := addonsv1.ResourceBindingObject{APIVersion: "v1", Kind: "ConfigMap", Namespace: metav1.NamespaceDefault, Name: "cm2"}
+	cm3 := addonsv1.ResourceBindingObject{APIVersion: "v1", Kind: "ConfigMap", Namespace: metav1.NamespaceDefault, Name: "cm3"}
+
+	keptResource := addonsv1.ResourceRef{Name: "kept", Kind: "ConfigMap"}
+	removedResource := addonsv1.ResourceRef{Name: "removed", Kind: "Secret"}
+
+	clusterResourceSet := &addonsv1.ClusterResourceSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-clusterresourceset",
+			Namespace: metav1.NamespaceDefault,
+		},
+		Spec: addonsv1.ClusterResourceSetSpec{
+			Strategy:  string(addonsv1.ClusterResourceSetStrategyReconcile),
+			Prune:     ptr.To(true),
+			Resources: []addonsv1.ResourceRef{keptResource},
+		},
+	}
+	resourceSetBinding := &addonsv1.ResourceSetBinding{
+		ClusterResourceSetName: clusterResourceSet.Name,
+		Resources: []addonsv1.ResourceBinding{
+			{
+				ResourceRef: keptResource,
+				Applied:     ptr.To(true),
+				Objects:     []addonsv1.ResourceBindingObject{cm1},
+			},
+			{
+				ResourceRef: removedResource,
+				Applied:     ptr.To(true),
+				Objects:     []addonsv1.ResourceBindingObject{cm1, cm2},
+			},
+		},
+	}
+
+	clusterResourceSetBinding := &addonsv1.ClusterResourceSetBinding{
+		Spec: addonsv1.ClusterResourceSetBindingSpec{
+			Bindings: []addonsv1.ResourceSetBinding{*resourceSetBinding},
+		},
+	}
+
+	c := fake.NewClientBuilder().
+		WithObjects(
+			configMap(cm1.Name, metav1.NamespaceDefault, nil),
+			configMap(cm2.Name, metav1.NamespaceDefault, nil),
+			configMap(cm3.Name, metav1.NamespaceDefault, nil),
+		).
+		Build()
+
+	g.Expect(pruneRemovedResources(ctx, c, clusterResourceSet, clusterResourceSetBinding, resourceSetBinding)).To(Succeed())
+
+	// The resource removed from the ClusterResourceSet is removed from the binding.
+	g.Expect(resourceSetBinding.Resources).To(HaveLen(1))
+	g.Expect(resourceSetBinding.Resources[0].ResourceRef).To(Equal(keptResource))
+
+	// Only objects of the removed resource that are not applied by other resources are deleted.
+	configMaps := &corev1.ConfigMapList{}
+	g.Expect(c.List(ctx, configMaps)).To(Succeed())
+	remaining := []string{}
+	for _, cm := range configMaps.Items {
+		remaining = append(remaining, cm.Name)
+	}
+	g.Expect(remaining).To(ConsistOf(cm1.Name, cm3.Name))
+}
+
+func TestPruneRemovedResourcesSharedWithOtherClusterResourceSets(t *testing.T) {
+	cm1 := addonsv1.ResourceBindingObject{APIVersion: "v1", Kind: "ConfigMap", Namespace: metav1.NamespaceDefault, Name: "cm1"}
+	cm2 := addonsv1.ResourceBindingObject{APIVersion: "v1", Kind: "ConfigMap", Namespace: metav1.NamespaceDefault, Name: "cm2"}
+
+	sharedResource := addonsv1.ResourceRef{Name: "shared", Kind: "ConfigMap"}
+	otherResource := addonsv1.ResourceRef{Name: "other", Kind: "Secret"}
+
+	tests := []struct {
+		name              string
+		otherBinding      addonsv1.ResourceSetBinding
+		expectedRemaining []string
+	}{
+		{
+			name: "objects of a resource still applied by another ClusterResourceSet without prune are not deleted",
+			otherBinding: addonsv1.ResourceSetBinding{
+				ClusterResourceSetName: "other-clusterresourceset",
+				Resources: []addonsv1.ResourceBinding{
+					{ResourceRef: sharedResource, Applied: ptr.To(true)},
+				},
+			},
+			expectedRemaining: []string{cm1.Name, cm2.Name},
+		},
+		{
+			name: "objects applied by another ClusterResourceSet from a different resource are not deleted",
+			otherBinding: addonsv1.ResourceSetBinding{
+				ClusterResourceSetName: "other-clusterresourceset",
+				Resources: []addonsv1.ResourceBinding{
+					{ResourceRef: otherResource, Applied: ptr.To(true), Objects: []addonsv1.ResourceBindingObject{cm2}},
+				},
+			},
+			expectedRemaining: []string{cm2.Name},
+		},
+		{
+			name: "objects not applied by another ClusterResourceSet are deleted",
+			otherBinding: addonsv1.ResourceSetBinding{
+				ClusterResourceSetName: "other-clusterresourceset",
+				Resources: []addonsv1.ResourceBinding{
+					{ResourceRef: otherResource, Applied: ptr.To(true)},
+				},
+			},
+			expectedRemaining: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			clusterResourceSet := &addonsv1.ClusterResourceSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-clusterresourceset",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: addonsv1.ClusterResourceSetSpec{
+					Strategy: string(addonsv1.ClusterResourceSetStrategyReconcile),
+					Prune:    ptr.To(true),
+				},
+			}
+			clusterResourceSetBinding := &addonsv1.ClusterResourceSetBinding{
+				Spec: addonsv1.ClusterResourceSetBindingSpec{
+					Bindings: []addonsv1.ResourceSetBinding{
+						{
+							ClusterResourceSetName: clusterResourceSet.Name,
+							Resources: []addonsv1.ResourceBinding{
+								{
+									ResourceRef: sharedResource,
+									Applied:     ptr.To(true),
+									Objects:     []addonsv1.ResourceBindingObject{cm1, cm2},
+								},
+							},
+						},
+						tt.otherBinding,
+					},
+				},
+			}
+			resourceSetBinding := clusterResourceSetBinding.GetOrCreateBinding(clusterResourceSet)
+
+			c := fake.NewClientBuilder().
+				WithObjects(
+					configMap(cm1.Name, metav1.NamespaceDefault, nil),
+					configMap(cm2.Name, metav1.NamespaceDefault, nil),
+				).
+				Build()
+
+			g.Expect(pruneRemovedResources(ctx, c, clusterResourceSet, clusterResourceSetBinding, resourceSetBinding)).To(Succeed())
+			g.Expect(resourceSetBinding.Resources).To(BeEmpty())
+
+			configMaps := &corev1.ConfigMapList{}
+			g.Expect(c.List(ctx, configMaps)).To(Succeed())
+			remaining := []string{}
+			for _, cm := range configMaps.Items {
+				remaining = append(remaining, cm.Name)
+			}
+			g.Expect(remaining).To(ConsistOf(tt.expectedRemaining))
+		})
+	}
+}
+
+func TestClusterResourceSetFieldManager(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(clusterResourceSetFieldManager(&addonsv1.ClusterResourceSet{ObjectMeta: metav1.ObjectMeta{Name: "my-crs"}})).
+		To(Equal("capi-clusterresourceset-my-crs"))
+
+	longName := strings.Repeat("a", 253)
+	fieldManager := clusterResourceSetFieldManager(&addonsv1.ClusterResourceSet{ObjectMeta: metav1.ObjectMeta{Name: longName}})
+	g.Expect(fieldManager).To(HaveLen(maxFieldManagerLength))
+	g.Expect(fieldManager).To(HavePrefix("capi-clusterresourceset-aaa"))
+	g.Expect(fieldManager).ToNot(Equal(clusterResourceSetFieldManager(&addonsv1.ClusterResourceSet{ObjectMeta: metav1.ObjectMeta{Name: longName + "b"}})))
+}
+
+func TestNeedsDriftCorrection(t *testing.T) {
+	tests := []struct {
+		name                           string
+		driftCorrectionIntervalSeconds *int32
+		lastAppliedTime                time.Time
+		want                           bool
+	}{
+		{
+			name:            "should not correct drift if driftCorrectionIntervalSeconds is not set",
+			lastAppliedTime: time.Now().Add(-time.Hour),
+			want:            false,
+		},
+		{
+			name:                           "should not correct drift before the interval expires",
+			driftCorrectionIntervalSeconds: ptr.To[int32](600),
+			lastAppliedTime:                time.Now().Add(-time.Minute),
+			want:                           false,
+		},
+		{
+			name:                           "should correct drift after the interval expires",
+			driftCorrectionIntervalSeconds: ptr.To[int32](600),
+			lastAppliedTime:                time.Now().Add(-time.Hour),
+			want:                           true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			clusterResourceSet := &addonsv1.ClusterResourceSet{
+				Spec: addonsv1.ClusterResourceSetSpec{
+					DriftCorrectionIntervalSeconds: tt.driftCorrectionIntervalSeconds,
+				},
+			}
+			resourceBinding := &addonsv1.ResourceBinding{
+				LastAppliedTime: metav1.Time{Time: tt.lastAppliedTime},
+			}
+			g.Expect(needsDriftCorrection(clusterResourceSet, resourceBinding)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestNeedsDriftCheck(t *testing.T) {
+	tests := []struct {
+		name                           string
+		driftCorrectionIntervalSeconds *int32
+		lastDriftCheckTime             time.Time
+		want                           bool
+	}{
+		{
+			name:               "should not check drift if driftCorrectionIntervalSeconds is not set",
+			lastDriftCheckTime: time.Now().Add(-time.Hour),
+			want:               false,
+		},
+		{
+			name:                           "should check drift if drift has never been checked",
+			driftCorrectionIntervalSeconds: ptr.To[int32](600),
+			want:                           true,
+		},
+		{
+			name:                           "should not check drift before the interval expires",
+			driftCorrectionIntervalSeconds: ptr.To[int32](600),
+			lastDriftCheckTime:             time.Now().Add(-time.Minute),
+			want:                           false,
+		},
+		{
+			name:                           "should check drift after the interval expires",
+			driftCorrectionIntervalSeconds: ptr.To[int32](600),
+			lastDriftCheckTime:             time.Now().Add(-time.Hour),
+			want:                           true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			clusterResourceSet := &addonsv1.ClusterResourceSet{
+				Spec: addonsv1.ClusterResourceSetSpec{
+					DriftCorrectionIntervalSeconds: tt.driftCorrectionIntervalSeconds,
+				},
+			}
+			resourceBinding := &addonsv1.ResourceBinding{
+				LastDriftCheckTime: metav1.Time{Time: tt.lastDriftCheckTime},
+			}
+			g.Expect(needsDriftCheck(clusterResourceSet, resourceBinding)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestSetDriftedCondition(t *testing.T) {
+	tests := []struct {
+		name              string
+		bindings          []addonsv1.ResourceSetBinding
+		expectedCondition metav1.Condition
+	}{
+		{
+			name: "should set Drifted false if no objects have drifted",
+			bindings: []addonsv1.ResourceSetBinding{
+				{
+					ClusterResourceSetName: "crs1",
+					Resources:              []addonsv1.ResourceBinding{{ResourceRef: addonsv1.ResourceRef{Name: "cm1", Kind: "ConfigMap"}}},
+				},
+			},
+			expectedCondition: metav1.Condition{
+				Type:   addonsv1.ClusterResourceSetBindingDriftedCondition,
+				Status: metav1.ConditionFalse,
+				Reason: addonsv1.ClusterResourceSetBindingNotDriftedReason,
+			},
+		},
+		{
+			name: "should set Drifted true if objects have drifted",
+			bindings: []addonsv1.ResourceSetBinding{
+				{
+					ClusterResourceSetName: "crs1",
+					Resources: []addonsv1.ResourceBinding{
+						{
+							ResourceRef: addonsv1.ResourceRef{Name: "cm1", Kind: "ConfigMap"},
+							DriftedObjects: []addonsv1.ResourceBindingObject{
+								{APIVersion: "v1", Kind: "ConfigMap", Namespace: "ns1", Name: "foo"},
+								{APIVersion: "v1", Kind: "Namespace", Name: "ns1"},
+							},
+						},
+					},
+				},
+				{
+					ClusterResourceSetName: "crs2",
+					Resources:              []addonsv1.ResourceBinding{{ResourceRef: addonsv1.ResourceRef{Name: "cm2", Kind: "ConfigMap"}}},
+				},
+				{
+					ClusterResourceSetName: "crs3",
+					Resources: []addonsv1.ResourceBinding{
+						{
+							ResourceRef: addonsv1.ResourceRef{Name: "secret1", Kind: "Secret"},
+							DriftedObjects: []addonsv1.ResourceBindingObject{
+								{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "ns2", Name: "bar"},
+							},
+						},
+					},
+				},
+			},
+			expectedCondition: metav1.Condition{
+				Type:   addonsv1.ClusterResourceSetBindingDriftedCondition,
+				Status: metav1.ConditionTrue,
+				Reason: addonsv1.ClusterResourceSetBindingDriftedReason,
+				Message: "Objects changed or deleted in the Cluster after being applied:\n" +
+					"* ClusterResourceSet crs1: ConfigMap ns1/foo, Namespace ns1\n" +
+					"* ClusterResourceSet crs3: Deployment ns2/bar",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			clusterResourceSetBinding := &addonsv1.ClusterResourceSetBinding{
+				Spec: addonsv1.ClusterResourceSetBindingSpec{
+					Bindings: tt.bindings,
+				},
+			}
+			setDriftedCondition(clusterResourceSetBinding)
+
+			condition := conditions.Get(clusterResourceSetBinding, addonsv1.ClusterResourceSetBindingDriftedCondition)
+			g.Expect(condition).ToNot(BeNil())
+			g.Expect(*condition).To(conditions.MatchCondition(tt.expectedCondition, conditions.IgnoreLastTransitionTime(true)))
+		})
+	}
+}
+
+func TestRenderData(t *testing.T) {
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cluster",
+			Namespace: metav1.NamespaceDefault,
+			Labels:    map[string]string{"region": "eu-west"},
+		},
+		Spec: clusterv1.ClusterSpec{
+			ClusterNetwork: clusterv1.ClusterNetwork{
+				Pods: clusterv1.NetworkRanges{CIDRBlocks: []string{"192.168.0.0/16"}},
+			},
+			Topology: clusterv1.Topology{
+				Variables: []clusterv1.ClusterVariable{
+					{Name: "replicas", Value: apiextensionsv1.JSON{Raw: []byte(`3`)}},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		data    [][]byte
+		want    [][]byte
+		wantErr bool
+	}{
+		{
+			name: "render builtin and topology variables",
+			data: [][]byte{
+				[]byte("name: {{ .builtin.cluster.name }}-config\nnamespace: {{ .builtin.cluster.namespace }}"),
+				[]byte("region: {{ index .builtin.cluster.metadata.labels \"region\" }}\npodCIDR: {{ index .builtin.cluster.network.pods 0 }}\nreplicas: {{ .replicas }}"),
+			},
+			want: [][]byte{
+				[]byte("name: test-cluster-config\nnamespace: default"),
+				[]byte("region: eu-west\npodCIDR: 192.168.0.0/16\nreplicas: 3"),
+			},
+		},
+		{
+			name: "render with sprig functions",
+			data: [][]byte{[]byte(`name: {{ .builtin.cluster.name | upper }}`)},
+			want: [][]byte{[]byte(`name: TEST-CLUSTER`)},
+		},
+		{
+			name: "data without templates is not changed",
+			data: [][]byte{[]byte("kind: ConfigMap\napiVersion: v1")},
+			want: [][]byte{[]byte("kind: ConfigMap\napiVersion: v1")},
+		},
+		{
+			name:    "fail for invalid templates",
+			data:    [][]byte{[]byte(`name: {{ .builtin.cluster.name`)},
+			wantErr: true,
+		},
+		{
+			name:    "fail for undefined variables",
+			data:    [][]byte{[]byte(`replicas: {{ .replica }}`)},
+			wantErr: true,
+		},
+		{
+			name:    "fail for undefined builtin variables",
+			data:    [][]byte{[]byte(`serviceDomain: {{ .builtin.cluster.network.serviceDomain }}`)},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			got, err := renderData(tt.data, cluster)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestUnhealthyObjects(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(appsv1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(apiextensionsv1.AddToScheme(scheme)).To(Succeed())
+
+	deployment := func(name string, replicas, availableReplicas int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: metav1.NamespaceDefault},
+			Spec:       appsv1.DeploymentSpec{Replicas: ptr.To(replicas)},
+			Status: appsv1.DeploymentStatus{
+				Replicas:          replicas,
+				UpdatedReplicas:   replicas,
+				AvailableReplicas: availableReplicas,
+			},
+		}
+	}
+	daemonSet := func(name string, desired, available int32) *appsv1.DaemonSet {
+		return &appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: metav1.NamespaceDefault},
+			Status: appsv1.DaemonSetStatus{
+				DesiredNumberScheduled: desired,
+				UpdatedNumberScheduled: desired,
+				NumberAvailable:        available,
+			},
+		}
+	}
+	crd := func(name string, established apiextensionsv1.ConditionStatus) *apiextensionsv1.CustomResourceDefinition {
+		return &apiextensionsv1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: apiextensionsv1.CustomResourceDefinitionStatus{
+				Conditions: []apiextensionsv1.CustomResourceDefinitionCondition{
+					{Type: apiextensionsv1.Established, Status: established},
+				},
+			},
+		}
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			deployment("available", 2, 2),
+			deployment("not-available", 2, 1),
+			daemonSet("available", 3, 3),
+			daemonSet("not-available", 3, 0),
+			crd("established.example.com", apiextensionsv1.ConditionTrue),
+			crd("not-established.example.com", apiextensionsv1.ConditionFalse),
+		).
+		Build()
+
+	obj := func(apiVersion, kind, namespace, name string) unstructured.Unstructured {
+		u := unstructured.Unstructured{}
+		u.SetAPIVersion(apiVersion)
+		u.SetKind(kind)
+		u.SetNamespace(namespace)
+		u.SetName(name)
+		return u
+	}
+	objs := []unstructured.Unstructured{
+		obj("v1", "ConfigMap", metav1.NamespaceDefault, "not-checked"),
+		obj("apps/v1", "Deployment", metav1.NamespaceDefault, "available"),
+		obj("apps/v1", "Deployment", metav1.NamespaceDefault, "not-available"),
+		obj("apps/v1", "Deployment", metav1.NamespaceDefault, "not-found"),
+		obj("apps/v1", "DaemonSet", metav1.NamespaceDefault, "available"),
+		obj("apps/v1", "DaemonSet", metav1.NamespaceDefault, "not-available"),
+		obj("apiextensions.k8s.io/v1", "CustomResourceDefinition", "", "established.example.com"),
+		obj("apiextensions.k8s.io/v1", "CustomResourceDefinition", "", "not-established.example.com"),
+	}
+
+	unhealthyObjs, err := unhealthyObjects(ctx, c, objs)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(resourceBindingObjects(unhealthyObjs)).To(Equal([]addonsv1.ResourceBindingObject{
+		{APIVersion: "apps/v1", Kind: "Deployment", Namespace: metav1.NamespaceDefault, Name: "not-available"},
+		{APIVersion: "apps/v1", Kind: "Deployment", Namespace: metav1.NamespaceDefault, Name: "not-found"},
+		{APIVersion: "apps/v1", Kind: "DaemonSet", Namespace: metav1.NamespaceDefault, Name: "not-available"},
+		{APIVersion: "apiextensions.k8s.io/v1", Kind: "CustomResourceDefinition", Name: "not-established.example.com"},
+	}))
+}
+
+func TestUnhealthyResources(t *testing.T) {
+	resourceSetBinding := &addonsv1.ResourceSetBinding{
+		ClusterResourceSetName: "crs1",
+		Resources: []addonsv1.ResourceBinding{
+			{ResourceRef: addonsv1.ResourceRef{Name: "cm1", Kind: "ConfigMap"}, Applied: ptr.To(true), Healthy: ptr.To(true)},
+			{ResourceRef: addonsv1.ResourceRef{Name: "cm2", Kind: "ConfigMap"}, Applied: ptr.To(true), Healthy: ptr.To(false)},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		waitForHealthy *bool
+		resources      []addonsv1.ResourceRef
+		want           []string
+	}{
+		{
+			name:      "should not return resources if health is not tracked",
+			resources: []addonsv1.ResourceRef{{Name: "cm1", Kind: "ConfigMap"}, {Name: "cm2", Kind: "ConfigMap"}},
+			want:      nil,
+		},
+		{
+			name:           "should not return resources if all objects are healthy",
+			waitForHealthy: ptr.To(true),
+			resources:      []addonsv1.ResourceRef{{Name: "cm1", Kind: "ConfigMap"}},
+			want:           []string{},
+		},
+		{
+			name:           "should return resources with objects that are not healthy or whose health is not known yet",
+			waitForHealthy: ptr.To(true),
+			resources:      []addonsv1.ResourceRef{{Name: "cm1", Kind: "ConfigMap"}, {Name: "cm2", Kind: "ConfigMap"}, {Name: "cm3", Kind: "ConfigMap"}},
+			want:           []string{"ConfigMap default/cm2", "ConfigMap default/cm3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			clusterResourceSet := &addonsv1.ClusterResourceSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "crs1", Namespace: metav1.NamespaceDefault},
+				Spec: addonsv1.ClusterResourceSetSpec{
+					WaitForHealthy: tt.waitForHealthy,
+					Resources:      tt.resources,
+				},
+			}
+			g.Expect(unhealthyResources(clusterResourceSet, resourceSetBinding)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestSetResourcesHealthyCondition(t *testing.T) {
+	tests := []struct {
+		name              string
+		bindings          []addonsv1.ResourceSetBinding
+		expectedCondition *metav1.Condition
+	}{
+		{
+			name: "should not set ResourcesHealthy if health is not tracked",
+			bindings: []addonsv1.ResourceSetBinding{
+				{
+					ClusterResourceSetName: "crs1",
+					Resources:              []addonsv1.ResourceBinding{{ResourceRef: addonsv1.ResourceRef{Name: "cm1", Kind: "ConfigMap"}}},
+				},
+			},
+			expectedCondition: nil,
+		},
+		{
+			name: "should set ResourcesHealthy true if all objects are healthy",
+			bindings: []addonsv1.ResourceSetBinding{
+				{
+					ClusterResourceSetName: "crs1",
+					Resources:              []addonsv1.ResourceBinding{{ResourceRef: addonsv1.ResourceRef{Name: "cm1", Kind: "ConfigMap"}, Healthy: ptr.To(true)}},
+				},
+			},
+			expectedCondition: &metav1.Condition{
+				Type:   clusterv1.ClusterResourcesHealthyCondition,
+				Status: metav1.ConditionTrue,
+				Reason: clusterv1.ClusterResourcesHealthyReason,
+			},
+		},
+		{
+			name: "should set ResourcesHealthy false if objects are not healthy",
+			bindings: []addonsv1.ResourceSetBinding{
+				{
+					ClusterResourceSetName: "crs1",
+					Resources: []addonsv1.ResourceBinding{
+						{
+							ResourceRef: addonsv1.ResourceRef{Name: "cm1", Kind: "ConfigMap"},
+							Healthy:     ptr.To(false),
+							UnhealthyObjects: []addonsv1.ResourceBindingObject{
+								{APIVersion: "apps/v1", Kind: "DaemonSet", Namespace: "kube-system", Name: "cni"},
+							},
+						},
+					},
+				},
+				{
+					ClusterResourceSetName: "crs2",
+					Resources:              []addonsv1.ResourceBinding{{ResourceRef: addonsv1.ResourceRef{Name: "cm2", Kind: "ConfigMap"}, Healthy: ptr.To(true)}},
+				},
+			},
+			expectedCondition: &metav1.Condition{
+				Type:    clusterv1.ClusterResourcesHealthyCondition,
+				Status:  metav1.ConditionFalse,
+				Reason:  clusterv1.ClusterResourcesNotHealthyReason,
+				Message: "Objects applied by ClusterResourceSets are not healthy:\n* ClusterResourceSet crs1: DaemonSet kube-system/cni",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			cluster := &clusterv1.Cluster{}
+			clusterResourceSetBinding := &addonsv1.ClusterResourceSetBinding{
+				Spec: addonsv1.ClusterResourceSetBindingSpec{
+					Bindings: tt.bindings,
+				},
+			}
+			setResourcesHealthyCondition(cluster, clusterResourceSetBinding)
+
+			condition := conditions.Get(cluster, clusterv1.ClusterResourcesHealthyCondition)
+			if tt.expectedCondition == nil {
+				g.Expect(condition).To(BeNil())
+				return
+			}
+			g.Expect(condition).ToNot(BeNil())
+			g.Expect(*condition).To(conditions.MatchCondition(*tt.expectedCondition, conditions.IgnoreLastTransitionTime(true)))
+		})
+	}
+}
+
+func TestInvalidDependencies(t *testing.T) {
+	scheme := runtime.NewScheme()
+	g := NewWithT(t)
+	g.Expect(addonsv1.AddToScheme(scheme)).To(Succeed())
+
+	crs := func(name string, dependsOn ...string) *addonsv1.ClusterResourceSet {
+		return &addonsv1.ClusterResourceSet{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: metav1.NamespaceDefault},
+			Spec: addonsv1.ClusterResourceSetSpec{
+				DependsOn: dependsOn,
+			},
+		}
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			crs("crds"),
+			crs("cni", "crds"),
+			crs("cycle-a", "cycle-b"),
+			crs("cycle-b", "crds", "cycle-a"),
+			crs("missing-dependency", "not-found"),
+		).
+		Build()
+	r := &Reconciler{
+		Client: c,
+	}
+
+	tests := []struct {
+		name               string
+		clusterResourceSet *addonsv1.ClusterResourceSet
+		want               []string
+	}{
+		{
+			name:               "should not return reasons for valid dependencies",
+			clusterResourceSet: crs("addon", "cni", "crds"),
+			want:               []string{},
+		},
+		{
+			name:               "should return dependencies that do not exist",
+			clusterResourceSet: crs("addon", "cni", "not-found"),
+			want:               []string{"ClusterResourceSet default/not-found does not exist"},
+		},
+		{
+			name:               "should not return dependencies of dependencies that do not exist",
+			clusterResourceSet: crs("addon", "missing-dependency"),
+			want:               []string{},
+		},
+		{
+			name:               "should return cycles including the ClusterResourceSet",
+			clusterResourceSet: crs("cycle-a", "cycle-b"),
+			want:               []string{"ClusterResourceSets depend on each other in a cycle: cycle-a -> cycle-b -> cycle-a"},
+		},
+		{
+			name:               "should return cycles between dependencies",
+			clusterResourceSet: crs("addon", "cni", "cycle-a"),
+			want:               []string{"ClusterResourceSets depend on each other in a cycle: cycle-a -> cycle-b -> cycle-a"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			invalid, err := r.invalidDependencies(ctx, tt.clusterResourceSet)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(invalid).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestDependenciesNotApplied(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(addonsv1.AddToScheme(scheme)).To(Succeed())
+
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cluster",
+			Namespace: metav1.NamespaceDefault,
+			Labels:    map[string]string{"cni": "calico"},
+		},
+	}
+	crs := func(name string, clusterSelector map[string]string, waitForHealthy bool) *addonsv1.ClusterResourceSet {
+		return &addonsv1.ClusterResourceSet{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: metav1.NamespaceDefault},
+			Spec: addonsv1.ClusterResourceSetSpec{
+				ClusterSelector: metav1.LabelSelector{MatchLabels: clusterSelector},
+				Resources:       []addonsv1.ResourceRef{{Name: name, Kind: "ConfigMap"}},
+				WaitForHealthy:  ptr.To(waitForHealthy),
+			},
+		}
+	}
+	resourceSetBinding := func(name string, applied bool, healthy *bool) addonsv1.ResourceSetBinding {
+		return addonsv1.ResourceSetBinding{
+			ClusterResourceSetName: name,
+			Resources: []addonsv1.ResourceBinding{
+				{ResourceRef: addonsv1.ResourceRef{Name: name, Kind: "ConfigMap"}, Applied: ptr.To(applied), Healthy: healthy},
+			},
+		}
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			crs("applied", map[string]string{"cni": "calico"}, false),
+			crs("not-applied", map[string]string{"cni": "calico"}, false),
+			crs("not-in-binding", map[string]string{"cni": "calico"}, false),
+			crs("healthy", map[string]string{"cni": "calico"}, true),
+			crs("not-healthy", map[string]string{"cni": "calico"}, true),
+			crs("not-matching", map[string]string{"cni": "cilium"}, false),
+		).
+		Build()
+	r := &Reconciler{
+		Client: c,
+	}
+
+	clusterResourceSetBinding := &addonsv1.ClusterResourceSetBinding{
+		Spec: addonsv1.ClusterResourceSetBindingSpec{
+			Bindings: []addonsv1.ResourceSetBinding{
+				resourceSetBinding("applied", true, nil),
+				resourceSetBinding("not-applied", false, nil),
+				resourceSetBinding("healthy", true, ptr.To(true)),
+				resourceSetBinding("not-healthy", true, ptr.To(false)),
+			},
+		},
+	}
+
+	clusterResourceSet := crs("test-crs", map[string]string{"cni": "calico"}, false)
+	clusterResourceSet.Spec.DependsOn = []string{"applied", "not-applied", "not-in-binding", "healthy", "not-healthy", "not-matching", "not-found"}
+
+	notApplied, err := r.dependenciesNotApplied(ctx, cluster, clusterResourceSet, clusterResourceSetBinding)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(notApplied).To(Equal([]string{"not-applied", "not-in-binding", "not-healthy", "not-found"}))
+}
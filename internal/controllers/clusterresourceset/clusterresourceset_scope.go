@@ -28,6 +28,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	addonsv1 "sigs.k8s.io/cluster-api/api/addons/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 )
 
 // resourceReconcileScope contains the scope for a CRS's resource
@@ -41,6 +42,11 @@ type resourceReconcileScope interface {
 	// hash returns a computed hash of the defined objects in the resource. It is consistent
 	// between runs.
 	hash() string
+	// objs returns the objects defined by the resource.
+	objs() []unstructured.Unstructured
+	// driftedObjs returns the objects defined by the resource that have been changed or deleted in the
+	// target cluster after being applied.
+	driftedObjs(ctx context.Context, c client.Client) ([]unstructured.Unstructured, error)
 }
 
 func reconcileScopeForResource(
@@ -48,12 +54,23 @@ func reconcileScopeForResource(
 	resourceRef addonsv1.ResourceRef,
 	resourceSetBinding *addonsv1.ResourceSetBinding,
 	resource *unstructured.Unstructured,
+	cluster *clusterv1.Cluster,
 ) (resourceReconcileScope, error) {
 	normalizedData, err := normalizeData(resource)
 	if err != nil {
 		return nil, err
 	}
 
+	// Render the data with values from the Cluster if a template engine is set.
+	// Note: The hash is computed from the rendered data, so changes to the Cluster that change
+	// the rendered data are applied to the Cluster when using the Reconcile strategy.
+	if crs.Spec.TemplateEngine == addonsv1.ClusterResourceSetTemplateEngineGoTemplate {
+		normalizedData, err = renderData(normalizedData, cluster)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to render %s %s", resource.GetKind(), klog.KObj(resource))
+		}
+	}
+
 	objs, err := objsFromYamlData(normalizedData)
 	if err != nil {
 		return nil, err
@@ -120,28 +137,29 @@ func (r *reconcileStrategyScope) apply(ctx context.Context, c client.Client) err
 }
 
 func (r *reconcileStrategyScope) applyObj(ctx context.Context, c client.Client, obj *unstructured.Unstructured) error {
-	currentObj := &unstructured.Unstructured{}
-	currentObj.SetAPIVersion(obj.GetAPIVersion())
-	currentObj.SetKind(obj.GetKind())
-	err := c.Get(ctx, client.ObjectKeyFromObject(obj), currentObj)
-	if apierrors.IsNotFound(err) {
-		return createUnstructured(ctx, c, obj)
-	}
-	if err != nil {
-		return errors.Wrapf(
-			err,
-			"reading object %s %s",
-			obj.GroupVersionKind(),
-			klog.KObj(obj),
-		)
+	fieldManager := clusterResourceSetFieldManager(r.clusterResourceSet)
+
+	// Note: Ownership of fields is forced if drift correction is enabled, so fields changed in the cluster by other
+	// field managers are corrected, or if the object has not been applied by the ClusterResourceSet yet, so objects
+	// that already exist in the cluster are adopted (e.g. objects created by previous versions of Cluster API).
+	// Otherwise, applying fails if fields are owned by other field managers.
+	forceOwnership := r.clusterResourceSet.Spec.DriftCorrectionIntervalSeconds != nil
+	if !forceOwnership {
+		applied, err := isAppliedBy(ctx, c, fieldManager, obj)
+		if err != nil {
+			return err
+		}
+		forceOwnership = !applied
 	}
 
-	patch := client.MergeFrom(currentObj.DeepCopy())
-	obj.SetResourceVersion(currentObj.GetResourceVersion())
-	if err = c.Patch(ctx, obj, patch); err != nil {
+	opts := []client.ApplyOption{client.FieldOwner(fieldManager)}
+	if forceOwnership {
+		opts = append(opts, client.ForceOwnership)
+	}
+	if err := c.Apply(ctx, client.ApplyConfigurationFromUnstructured(obj.DeepCopy()), opts...); err != nil {
 		return errors.Wrapf(
 			err,
-			"patching object %s %s",
+			"applying object %s %s",
 			obj.GroupVersionKind(),
 			klog.KObj(obj),
 		)
@@ -150,6 +168,23 @@ func (r *reconcileStrategyScope) applyObj(ctx context.Context, c client.Client,
 	return nil
 }
 
+func (r *reconcileStrategyScope) driftedObjs(ctx context.Context, c client.Client) ([]unstructured.Unstructured, error) {
+	driftedObjs := []unstructured.Unstructured{}
+	errList := []error{}
+	for _, obj := range r.objs() {
+		drifted, err := hasDrifted(ctx, c, clusterResourceSetFieldManager(r.clusterResourceSet), &obj)
+		if err != nil {
+			errList = append(errList, err)
+			continue
+		}
+		if drifted {
+			driftedObjs = append(driftedObjs, obj)
+		}
+	}
+
+	return driftedObjs, kerrors.NewAggregate(errList)
+}
+
 type reconcileApplyOnceScope struct {
 	baseResourceReconcileScope
 }
@@ -162,6 +197,11 @@ func (r *reconcileApplyOnceScope) apply(ctx context.Context, c client.Client) er
 	return apply(ctx, c, r.applyObj, r.objs())
 }
 
+func (r *reconcileApplyOnceScope) driftedObjs(_ context.Context, _ client.Client) ([]unstructured.Unstructured, error) {
+	// Objects applied with the ApplyOnce strategy are never re-applied, so changes in the cluster are not tracked.
+	return nil, nil
+}
+
 func (r *reconcileApplyOnceScope) applyObj(ctx context.Context, c client.Client, obj *unstructured.Unstructured) error {
 	// The create call is idempotent, so if the object already exists
 	// then do not consider it to be an error.
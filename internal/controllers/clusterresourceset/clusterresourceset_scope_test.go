@@ -250,3 +250,202 @@ func TestReconcileApplyOnceScopeApplyObj(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileStrategyScopeApplyObj(t *testing.T) {
+	clusterResourceSet := &addonsv1.ClusterResourceSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-crs",
+			Namespace: "crs-ns",
+		},
+	}
+
+	tests := []struct {
+		name         string
+		existingObjs []client.Object
+		obj          *unstructured.Unstructured
+		wantData     map[string]string
+	}{
+		{
+			name: "object doesn't exist",
+			obj: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"apiVersion": "v1",
+					"kind":       "ConfigMap",
+					"metadata": map[string]interface{}{
+						"name":      "my-cm",
+						"namespace": "that-ns",
+					},
+					"data": map[string]interface{}{
+						"key": "value",
+					},
+				},
+			},
+			wantData: map[string]string{"key": "value"},
+		},
+		{
+			name: "object exists and has been changed by another field manager",
+			existingObjs: []client.Object{
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "my-cm",
+						Namespace: "that-ns",
+					},
+					Data: map[string]string{"key": "changed-value", "other-key": "other-value"},
+				},
+			},
+			obj: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"apiVersion": "v1",
+					"kind":       "ConfigMap",
+					"metadata": map[string]interface{}{
+						"name":      "my-cm",
+						"namespace": "that-ns",
+					},
+					"data": map[string]interface{}{
+						"key": "value",
+					},
+				},
+			},
+			wantData: map[string]string{"key": "value", "other-key": "other-value"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gs := NewWithT(t)
+			ctx := context.Background()
+			c := fake.NewClientBuilder().WithObjects(tt.existingObjs...).Build()
+			scope := &reconcileStrategyScope{baseResourceReconcileScope{clusterResourceSet: clusterResourceSet}}
+			gs.Expect(scope.applyObj(ctx, c, tt.obj)).To(Succeed())
+
+			cm := &corev1.ConfigMap{}
+			gs.Expect(c.Get(ctx, client.ObjectKey{Namespace: "that-ns", Name: "my-cm"}, cm)).To(Succeed())
+			gs.Expect(cm.Data).To(Equal(tt.wantData))
+		})
+	}
+}
+
+func TestReconcileStrategyScopeApplyObjOwnership(t *testing.T) {
+	obj := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]interface{}{
+				"name":      "my-cm",
+				"namespace": "that-ns",
+			},
+			"data": map[string]interface{}{
+				"key":       "value",
+				"other-key": "other-value",
+			},
+		},
+	}
+
+	tests := []struct {
+		name                           string
+		driftCorrectionIntervalSeconds *int32
+		wantErr                        bool
+		wantData                       map[string]string
+	}{
+		{
+			name:     "should not force ownership of fields changed by another field manager if drift correction is not enabled",
+			wantErr:  true,
+			wantData: map[string]string{"key": "changed-value", "other-key": "other-value"},
+		},
+		{
+			name:                           "should force ownership of fields changed by another field manager if drift correction is enabled",
+			driftCorrectionIntervalSeconds: ptr.To[int32](600),
+			wantData:                       map[string]string{"key": "value", "other-key": "other-value"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gs := NewWithT(t)
+			ctx := context.Background()
+			c := fake.NewClientBuilder().WithReturnManagedFields().Build()
+			scope := &reconcileStrategyScope{baseResourceReconcileScope{clusterResourceSet: &addonsv1.ClusterResourceSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-crs",
+					Namespace: "crs-ns",
+				},
+				Spec: addonsv1.ClusterResourceSetSpec{
+					DriftCorrectionIntervalSeconds: tt.driftCorrectionIntervalSeconds,
+				},
+			}}}
+			gs.Expect(scope.applyObj(ctx, c, obj.DeepCopy())).To(Succeed())
+
+			// Change a field of the object with another field manager.
+			cm := &corev1.ConfigMap{}
+			gs.Expect(c.Get(ctx, client.ObjectKey{Namespace: "that-ns", Name: "my-cm"}, cm)).To(Succeed())
+			cm.Data["key"] = "changed-value"
+			gs.Expect(c.Update(ctx, cm, client.FieldOwner("another-manager"))).To(Succeed())
+
+			err := scope.applyObj(ctx, c, obj.DeepCopy())
+			if tt.wantErr {
+				gs.Expect(err).To(HaveOccurred())
+			} else {
+				gs.Expect(err).ToNot(HaveOccurred())
+			}
+
+			gs.Expect(c.Get(ctx, client.ObjectKey{Namespace: "that-ns", Name: "my-cm"}, cm)).To(Succeed())
+			gs.Expect(cm.Data).To(Equal(tt.wantData))
+		})
+	}
+}
+
+func TestReconcileStrategyScopeDriftedObjs(t *testing.T) {
+	clusterResourceSet := &addonsv1.ClusterResourceSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-crs",
+			Namespace: "crs-ns",
+		},
+		Spec: addonsv1.ClusterResourceSetSpec{
+			DriftCorrectionIntervalSeconds: ptr.To[int32](600),
+		},
+	}
+	configMap := func(name string, data map[string]interface{}) unstructured.Unstructured {
+		return unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"metadata": map[string]interface{}{
+					"name":      name,
+					"namespace": "that-ns",
+				},
+				"data": data,
+			},
+		}
+	}
+	notChanged := configMap("not-changed", map[string]interface{}{"key": "value"})
+	changed := configMap("changed", map[string]interface{}{"key": "value"})
+	deleted := configMap("deleted", map[string]interface{}{"key": "value"})
+
+	gs := NewWithT(t)
+	ctx := context.Background()
+	c := fake.NewClientBuilder().Build()
+	scope := &reconcileStrategyScope{baseResourceReconcileScope{
+		clusterResourceSet: clusterResourceSet,
+		normalizedObjs:     []unstructured.Unstructured{notChanged, changed, deleted},
+	}}
+	gs.Expect(scope.apply(ctx, c)).To(Succeed())
+
+	driftedObjs, err := scope.driftedObjs(ctx, c)
+	gs.Expect(err).ToNot(HaveOccurred())
+	gs.Expect(driftedObjs).To(BeEmpty())
+
+	// Change and delete objects in the cluster.
+	cm := &corev1.ConfigMap{}
+	gs.Expect(c.Get(ctx, client.ObjectKey{Namespace: "that-ns", Name: "changed"}, cm)).To(Succeed())
+	cm.Data["key"] = "changed-value"
+	gs.Expect(c.Update(ctx, cm, client.FieldOwner("another-manager"))).To(Succeed())
+	gs.Expect(c.Delete(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "that-ns", Name: "deleted"}})).To(Succeed())
+
+	driftedObjs, err = scope.driftedObjs(ctx, c)
+	gs.Expect(err).ToNot(HaveOccurred())
+	gs.Expect(resourceBindingObjects(driftedObjs)).To(Equal(resourceBindingObjects([]unstructured.Unstructured{changed, deleted})))
+
+	// Re-applying objects corrects the drift.
+	gs.Expect(scope.apply(ctx, c)).To(Succeed())
+	driftedObjs, err = scope.driftedObjs(ctx, c)
+	gs.Expect(err).ToNot(HaveOccurred())
+	gs.Expect(driftedObjs).To(BeEmpty())
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package drain
+
+import (
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// budgetEntryTTL is the duration after which a Machine that did not renew its drain budget entry
+// (e.g. because it has been deleted in the meantime) is not considered draining anymore.
+const budgetEntryTTL = 10 * time.Minute
+
+// Budget limits Node drains across all the Machines of a workload cluster.
+// Note: The Budget is kept in memory, so it only limits drains performed by the current controller instance.
+type Budget struct {
+	// MaxConcurrentDrains is the maximum number of Nodes that can be drained concurrently in a workload cluster.
+	// 0 means no limit.
+	MaxConcurrentDrains int
+
+	// MaxEvictionsPerSecond is the maximum number of Pod evictions per second in a workload cluster.
+	// 0 means no limit.
+	MaxEvictionsPerSecond float64
+
+	lock     sync.Mutex
+	clusters map[client.ObjectKey]*clusterBudget
+}
+
+type clusterBudget struct {
+	// drainingMachines maps the names of the Machines currently draining to the last time they renewed their entry.
+	drainingMachines    map[string]time.Time
+	evictionRateLimiter *rate.Limiter
+}
+
+// TryAcquire tries to acquire the drain budget for a Machine of a workload cluster.
+// If the Machine already started draining its Node, the drain budget is always acquired, so a drain in progress
+// is never interrupted (e.g. after a controller restart).
+// If the drain budget can't be acquired, TryAcquire returns the names of the Machines currently draining.
+// Note: TryAcquire has to be called on every reconcile while the Node is drained to renew the entry of the Machine.
+func (b *Budget) TryAcquire(cluster client.ObjectKey, machineName string, drainStarted bool) (bool, []string) {
+	if b == nil || b.MaxConcurrentDrains <= 0 {
+		return true, nil
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	cb := b.getClusterBudget(cluster)
+	now := time.Now()
+	for name, lastSeen := range cb.drainingMachines {
+		if now.Sub(lastSeen) > budgetEntryTTL {
+			delete(cb.drainingMachines, name)
+		}
+	}
+
+	if _, ok := cb.drainingMachines[machineName]; ok || drainStarted || len(cb.drainingMachines) < b.MaxConcurrentDrains {
+		cb.drainingMachines[machineName] = now
+		return true, nil
+	}
+
+	drainingMachines := make([]string, 0, len(cb.drainingMachines))
+	for name := range cb.drainingMachines {
+		drainingMachines = append(drainingMachines, name)
+	}
+	slices.Sort(drainingMachines)
+	return false, drainingMachines
+}
+
+// Release releases the drain budget of a Machine of a workload cluster.
+func (b *Budget) Release(cluster client.ObjectKey, machineName string) {
+	if b == nil {
+		return
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if cb, ok := b.clusters[cluster]; ok {
+		delete(cb.drainingMachines, machineName)
+	}
+}
+
+// EvictionRateLimiter returns the rate limiter for Pod evictions in a workload cluster.
+// If there is no limit, EvictionRateLimiter returns nil.
+func (b *Budget) EvictionRateLimiter(cluster client.ObjectKey) *rate.Limiter {
+	if b == nil || b.MaxEvictionsPerSecond <= 0 {
+		return nil
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	return b.getClusterBudget(cluster).evictionRateLimiter
+}
+
+func (b *Budget) getClusterBudget(cluster client.ObjectKey) *clusterBudget {
+	if b.clusters == nil {
+		b.clusters = map[client.ObjectKey]*clusterBudget{}
+	}
+	cb, ok := b.clusters[cluster]
+	if !ok {
+		cb = &clusterBudget{
+			drainingMachines: map[string]time.Time{},
+		}
+		if b.MaxEvictionsPerSecond > 0 {
+			// Allow bursts of up to one second worth of evictions.
+			burst := int(math.Max(1, math.Ceil(b.MaxEvictionsPerSecond)))
+			cb.evictionRateLimiter = rate.NewLimiter(rate.Limit(b.MaxEvictionsPerSecond), burst)
+		}
+		b.clusters[cluster] = cb
+	}
+	return cb
+}
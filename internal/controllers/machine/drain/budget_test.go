@@ -0,0 +1,100 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package drain
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestBudget_TryAcquire(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster1 := client.ObjectKey{Namespace: "default", Name: "cluster-1"}
+	cluster2 := client.ObjectKey{Namespace: "default", Name: "cluster-2"}
+
+	b := &Budget{MaxConcurrentDrains: 2}
+
+	// Machines can acquire the budget until the limit is reached.
+	ok, _ := b.TryAcquire(cluster1, "machine-1", false)
+	g.Expect(ok).To(BeTrue())
+	ok, _ = b.TryAcquire(cluster1, "machine-2", false)
+	g.Expect(ok).To(BeTrue())
+	ok, drainingMachines := b.TryAcquire(cluster1, "machine-3", false)
+	g.Expect(ok).To(BeFalse())
+	g.Expect(drainingMachines).To(Equal([]string{"machine-1", "machine-2"}))
+
+	// Machines that already acquired the budget can acquire it again.
+	ok, _ = b.TryAcquire(cluster1, "machine-1", false)
+	g.Expect(ok).To(BeTrue())
+
+	// Machines that already started draining always acquire the budget.
+	ok, _ = b.TryAcquire(cluster1, "machine-4", true)
+	g.Expect(ok).To(BeTrue())
+	b.Release(cluster1, "machine-4")
+
+	// The budget is per cluster.
+	ok, _ = b.TryAcquire(cluster2, "machine-1", false)
+	g.Expect(ok).To(BeTrue())
+
+	// Releasing the budget allows other Machines to acquire it.
+	b.Release(cluster1, "machine-1")
+	ok, _ = b.TryAcquire(cluster1, "machine-3", false)
+	g.Expect(ok).To(BeTrue())
+
+	// Entries that have not been renewed expire.
+	b.clusters[cluster1].drainingMachines["machine-2"] = time.Now().Add(-2 * budgetEntryTTL)
+	ok, _ = b.TryAcquire(cluster1, "machine-5", false)
+	g.Expect(ok).To(BeTrue())
+}
+
+func TestBudget_NoLimits(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster := client.ObjectKey{Namespace: "default", Name: "cluster-1"}
+
+	for _, b := range []*Budget{nil, {}} {
+		for i := range 10 {
+			ok, _ := b.TryAcquire(cluster, string(rune('a'+i)), false)
+			g.Expect(ok).To(BeTrue())
+		}
+		b.Release(cluster, "a")
+		g.Expect(b.EvictionRateLimiter(cluster)).To(BeNil())
+	}
+}
+
+func TestBudget_EvictionRateLimiter(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster1 := client.ObjectKey{Namespace: "default", Name: "cluster-1"}
+	cluster2 := client.ObjectKey{Namespace: "default", Name: "cluster-2"}
+
+	b := &Budget{MaxEvictionsPerSecond: 0.5}
+
+	// The rate limiter is shared by all the Machines of a cluster.
+	limiter := b.EvictionRateLimiter(cluster1)
+	g.Expect(limiter).ToNot(BeNil())
+	g.Expect(b.EvictionRateLimiter(cluster1)).To(BeIdenticalTo(limiter))
+	g.Expect(b.EvictionRateLimiter(cluster2)).ToNot(BeIdenticalTo(limiter))
+
+	g.Expect(limiter.Burst()).To(Equal(1))
+	g.Expect(limiter.Allow()).To(BeTrue())
+	g.Expect(limiter.Allow()).To(BeFalse())
+}
@@ -24,14 +24,17 @@ import (
 	"math"
 	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/pkg/errors"
+	"golang.org/x/time/rate"
 	corev1 "k8s.io/api/core/v1"
 	policyv1 "k8s.io/api/policy/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/ptr"
@@ -61,6 +64,19 @@ type Helper struct {
 	// DeletionTimeStamp > N seconds. This can be used e.g. when a Node is unreachable
 	// and the Pods won't drain because of that.
 	SkipWaitForDeleteTimeoutSeconds int
+
+	// EvictionRateLimiter limits the rate of Pod evictions, e.g. across all the Nodes of a workload cluster.
+	// If nil, evictions are not rate limited.
+	EvictionRateLimiter *rate.Limiter
+
+	// NodeDrainGroups contains the times when the drain of groups of Pods with the same drain order started.
+	// NodeDrainGroups is used to determine if the timeouts of MachineDrainRules are exceeded.
+	NodeDrainGroups []clusterv1.MachineNodeDrainGroup
+
+	// EvictionBlockedTracker tracks when the eviction of Pods has been blocked by PodDisruptionBudgets for the first time.
+	// EvictionBlockedTracker is used to determine if the deadlines of eviction fallbacks of MachineDrainRules are reached.
+	// If nil, the deadlines of eviction fallbacks are never reached.
+	EvictionBlockedTracker *EvictionBlockedTracker
 }
 
 // CordonNode cordons a Node.
@@ -165,6 +181,84 @@ func (d *Helper) GetPodsForEviction(ctx context.Context, cluster *clusterv1.Clus
 	return list, nil
 }
 
+// NodeDisruption describes the disruption caused by draining a Node.
+type NodeDisruption struct {
+	// Pods is the number of Pods that have to go away before the Node can be considered completely drained.
+	// Note: DaemonSet Pods, static Pods and Pods skipped via drain label or MachineDrainRules are not included.
+	Pods int
+
+	// PDBProtectedPods is the number of Pods that have to go away and are selected by at least one PodDisruptionBudget.
+	PDBProtectedPods int
+
+	// LocalStoragePods is the number of Pods that have to go away and are using local storage.
+	LocalStoragePods int
+}
+
+// Less returns true if the disruption caused by draining the Node is lower than the other one.
+// PDB-protected Pods are considered first, then Pods using local storage and finally all other Pods.
+func (n NodeDisruption) Less(other NodeDisruption) bool {
+	if n.PDBProtectedPods != other.PDBProtectedPods {
+		return n.PDBProtectedPods < other.PDBProtectedPods
+	}
+	if n.LocalStoragePods != other.LocalStoragePods {
+		return n.LocalStoragePods < other.LocalStoragePods
+	}
+	return n.Pods < other.Pods
+}
+
+// ListPodDisruptionBudgets lists all the PodDisruptionBudgets in the workload cluster.
+// Note: PodDisruptionBudgets should be listed once and then used e.g. for computing the disruption of many Nodes.
+func (d *Helper) ListPodDisruptionBudgets(ctx context.Context) ([]policyv1.PodDisruptionBudget, error) {
+	pdbList := &policyv1.PodDisruptionBudgetList{}
+	if err := d.RemoteClient.List(ctx, pdbList); err != nil {
+		return nil, errors.Wrapf(err, "failed to list PodDisruptionBudgets")
+	}
+	return pdbList.Items, nil
+}
+
+// GetNodeDisruption gets Pods running on a Node, filters them like GetPodsForEviction does and returns
+// the disruption that would be caused by draining the Node, using the given PodDisruptionBudgets.
+func (d *Helper) GetNodeDisruption(ctx context.Context, cluster *clusterv1.Cluster, machine *clusterv1.Machine, nodeName string, pdbs []policyv1.PodDisruptionBudget) (*NodeDisruption, error) {
+	podDeleteList, err := d.GetPodsForEviction(ctx, cluster, machine, nodeName)
+	if err != nil {
+		return nil, err
+	}
+
+	pods := podDeleteList.Pods()
+	disruption := &NodeDisruption{
+		Pods: len(pods),
+	}
+	for _, pod := range pods {
+		if hasLocalStorage(pod) {
+			disruption.LocalStoragePods++
+		}
+		if len(podDisruptionBudgetsForPod(pod, pdbs)) > 0 {
+			disruption.PDBProtectedPods++
+		}
+	}
+	return disruption, nil
+}
+
+// podDisruptionBudgetsForPod returns the PodDisruptionBudgets selecting the Pod.
+// Note: A PodDisruptionBudget with a nil selector selects no Pods, while an empty selector selects all Pods in the Namespace.
+func podDisruptionBudgetsForPod(pod *corev1.Pod, pdbs []policyv1.PodDisruptionBudget) []*policyv1.PodDisruptionBudget {
+	var matching []*policyv1.PodDisruptionBudget
+	for i := range pdbs {
+		pdb := &pdbs[i]
+		if pdb.Namespace != pod.Namespace {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil {
+			continue
+		}
+		if selector.Matches(labels.Set(pod.Labels)) {
+			matching = append(matching, pdb)
+		}
+	}
+	return matching
+}
+
 func (d *Helper) getMatchingMachineDrainRules(ctx context.Context, cluster *clusterv1.Cluster, machine *clusterv1.Machine) ([]*clusterv1.MachineDrainRule, error) {
 	// List all MachineDrainRules.
 	machineDrainRuleList := &clusterv1.MachineDrainRuleList{}
@@ -273,12 +367,36 @@ func (d *Helper) EvictPods(ctx context.Context, podDeleteList *PodDeleteList) Ev
 			fmt.Sprintf("%s/%s", podDeleteList.items[j].Pod.GetNamespace(), podDeleteList.items[j].Pod.GetName())
 	})
 
+	// Apply the timeouts of MachineDrainRules to Pods of groups for which the timeout has been reached.
+	now := time.Now()
+	for i := range podDeleteList.items {
+		pd := &podDeleteList.items[i]
+		if !drainTimeoutReached(pd.Status, d.NodeDrainGroups, now) {
+			continue
+		}
+
+		log := ctrl.LoggerFrom(ctx, "Pod", klog.KObj(pd.Pod))
+		log.V(4).Info(fmt.Sprintf("Drain timeout of %ds reached, applying timeout behavior %s",
+			ptr.Deref(pd.Status.DrainTimeout.Seconds, 0), pd.Status.DrainTimeout.Behavior))
+		pd.Status.DrainTimeoutReached = true
+		switch pd.Status.DrainTimeout.Behavior {
+		case clusterv1.MachineDrainRuleDrainTimeoutBehaviorSkip:
+			pd.Status.DrainBehavior = clusterv1.MachineDrainRuleDrainBehaviorSkip
+		case clusterv1.MachineDrainRuleDrainTimeoutBehaviorDrain, clusterv1.MachineDrainRuleDrainTimeoutBehaviorDelete:
+			pd.Status.DrainBehavior = clusterv1.MachineDrainRuleDrainBehaviorDrain
+		}
+	}
+
 	// Get the minimum order of all existing Pods.
 	// Note: We are only going to evict or wait for termination of Pods with the minimum order.
 	// This could also mean that we don't evict any additional Pods in this call, if there are still Pods with
 	// deletionTimestamps that have a lower order.
 	minDrainOrder := minDrainOrderOfPodsToDrain(podDeleteList.items)
 
+	// Track when the drain of the group of Pods with the minimum order started, so the timeouts
+	// of MachineDrainRules can be computed.
+	nodeDrainGroups := startNodeDrainGroup(d.NodeDrainGroups, podDeleteList.items, minDrainOrder, now)
+
 	var podsToTriggerEvictionNow []PodDelete
 	var podsToTriggerEvictionLater []PodDelete
 	var podsWithDeletionTimestamp []PodDelete
@@ -321,6 +439,7 @@ func (d *Helper) EvictPods(ctx context.Context, podDeleteList *PodDeleteList) Ev
 
 	res := EvictionResult{
 		PodsFailedEviction: map[string][]*corev1.Pod{},
+		NodeDrainGroups:    nodeDrainGroups,
 	}
 
 	for _, pd := range podsToBeIgnored {
@@ -358,9 +477,22 @@ evictionLoop:
 		default:
 		}
 
-		log.V(4).Info("Evicting Pod")
+		if d.EvictionRateLimiter != nil && !d.EvictionRateLimiter.Allow() {
+			// Skip eviction if the eviction rate limit is reached; this is not an eviction failure, the eviction is retried on the next reconcile.
+			log.V(4).Info(fmt.Sprintf("Skip triggering Pod eviction because the eviction rate limit of %s evictions per second is reached",
+				strconv.FormatFloat(float64(d.EvictionRateLimiter.Limit()), 'f', -1, 64)))
+			res.PodsEvictionRateLimited = append(res.PodsEvictionRateLimited, pd.Pod)
+			continue evictionLoop
+		}
 
-		err := d.evictPod(ctx, pd.Pod)
+		var err error
+		if pd.Status.DrainTimeoutReached && pd.Status.DrainTimeout.Behavior == clusterv1.MachineDrainRuleDrainTimeoutBehaviorDelete {
+			log.V(4).Info("Deleting Pod")
+			err = d.deletePod(ctx, pd.Pod, d.GracePeriodSeconds)
+		} else {
+			log.V(4).Info("Evicting Pod")
+			err = d.evictPod(ctx, pd.Pod)
+		}
 		switch {
 		case err == nil:
 			log.V(4).Info("Pod eviction successfully triggered")
@@ -387,6 +519,26 @@ evictionLoop:
 				err = errors.New(errorMessage)
 			}
 
+			// Delete the Pod instead if its eviction is blocked for longer than the eviction fallback allows.
+			// Note: Contrary to evictions, deletions do not respect PodDisruptionBudgets.
+			if pd.Status.EvictionFallback != nil && evictionFallbackReached(pd.Status, d.EvictionBlockedTracker.Blocked(pd.Pod, now), now) {
+				log.V(4).Info("Deleting Pod, because eviction fallback deadline has been reached", "evictionErr", err)
+				deleteErr := d.deletePod(ctx, pd.Pod, evictionFallbackGracePeriodSeconds(pd.Status.EvictionFallback, d.GracePeriodSeconds))
+				switch {
+				case deleteErr == nil:
+					log.V(4).Info("Pod deletion successfully triggered")
+					res.PodsDeletionTimestampSet = append(res.PodsDeletionTimestampSet, pd.Pod)
+					res.PodsDeletedAfterEvictionBlocked = append(res.PodsDeletedAfterEvictionBlocked, pd.Pod)
+					continue evictionLoop
+				case apierrors.IsNotFound(deleteErr):
+					log.V(4).Info("Deletion not needed, Pod doesn't exist anymore")
+					res.PodsNotFound = append(res.PodsNotFound, pd.Pod)
+					continue evictionLoop
+				default:
+					log.V(4).Info("Error when deleting Pod", "err", deleteErr)
+				}
+			}
+
 			log.V(4).Info("Error when evicting Pod", "err", err)
 			res.PodsFailedEviction[err.Error()] = append(res.PodsFailedEviction[err.Error()], pd.Pod)
 		case apierrors.IsForbidden(err) && apierrors.HasStatusCause(err, corev1.NamespaceTerminatingCause):
@@ -430,6 +582,80 @@ func minDrainOrderOfPodsToDrain(pds []PodDelete) int32 {
 	return minOrder
 }
 
+// drainTimeoutReached returns true if the drain timeout of a Pod has been reached.
+func drainTimeoutReached(status PodDeleteStatus, nodeDrainGroups []clusterv1.MachineNodeDrainGroup, now time.Time) bool {
+	if status.DrainTimeout == nil || status.DrainTimeout.Seconds == nil {
+		return false
+	}
+	if status.DrainBehavior != clusterv1.MachineDrainRuleDrainBehaviorDrain &&
+		status.DrainBehavior != clusterv1.MachineDrainRuleDrainBehaviorWaitCompleted {
+		return false
+	}
+
+	for _, group := range nodeDrainGroups {
+		if ptr.Deref(group.Order, 0) != ptr.Deref(status.DrainOrder, 0) {
+			continue
+		}
+		timeout := time.Duration(*status.DrainTimeout.Seconds) * time.Second
+		return now.Sub(group.StartTime.Time) >= timeout
+	}
+	// The drain of the group of the Pod didn't start yet.
+	return false
+}
+
+// evictionFallbackReached returns true if the deadline of the eviction fallback of a Pod has been reached,
+// i.e. if the Pod should be deleted because its eviction is blocked by PodDisruptionBudgets since blockedSince.
+func evictionFallbackReached(status PodDeleteStatus, blockedSince, now time.Time) bool {
+	if status.EvictionFallback == nil || status.EvictionFallback.DeleteAfterSeconds == nil {
+		return false
+	}
+
+	deleteAfter := time.Duration(*status.EvictionFallback.DeleteAfterSeconds) * time.Second
+	return now.Sub(blockedSince) >= deleteAfter
+}
+
+// evictionFallbackGracePeriodSeconds returns the grace period that should be used when deleting a Pod
+// because of its eviction fallback.
+func evictionFallbackGracePeriodSeconds(fallback *clusterv1.MachineDrainRuleEvictionFallback, defaultGracePeriodSeconds int) int {
+	if fallback == nil || fallback.GracePeriodSeconds == nil {
+		return defaultGracePeriodSeconds
+	}
+	return int(*fallback.GracePeriodSeconds)
+}
+
+// maxNodeDrainGroups is the maximum number of groups tracked in Machine.status.deletion.nodeDrainGroups.
+const maxNodeDrainGroups = 100
+
+// startNodeDrainGroup returns nodeDrainGroups including the group of Pods with the given order, if the group
+// was not already started and at least one Pod of the group has a drain timeout.
+func startNodeDrainGroup(nodeDrainGroups []clusterv1.MachineNodeDrainGroup, pds []PodDelete, order int32, now time.Time) []clusterv1.MachineNodeDrainGroup {
+	if order == math.MaxInt32 || len(nodeDrainGroups) >= maxNodeDrainGroups {
+		return nodeDrainGroups
+	}
+	for _, group := range nodeDrainGroups {
+		if ptr.Deref(group.Order, 0) == order {
+			return nodeDrainGroups
+		}
+	}
+
+	hasDrainTimeout := false
+	for _, pd := range pds {
+		if pd.Status.DrainTimeout != nil && !pd.Status.DrainTimeoutReached && ptr.Deref(pd.Status.DrainOrder, 0) == order &&
+			(pd.Status.DrainBehavior == clusterv1.MachineDrainRuleDrainBehaviorDrain || pd.Status.DrainBehavior == clusterv1.MachineDrainRuleDrainBehaviorWaitCompleted) {
+			hasDrainTimeout = true
+			break
+		}
+	}
+	if !hasDrainTimeout {
+		return nodeDrainGroups
+	}
+
+	return append(slices.Clone(nodeDrainGroups), clusterv1.MachineNodeDrainGroup{
+		Order:     ptr.To(order),
+		StartTime: metav1.NewTime(now),
+	})
+}
+
 // evictPod evicts the given Pod, or return an error if it couldn't.
 func (d *Helper) evictPod(ctx context.Context, pod *corev1.Pod) error {
 	delOpts := metav1.DeleteOptions{}
@@ -449,6 +675,18 @@ func (d *Helper) evictPod(ctx context.Context, pod *corev1.Pod) error {
 	return d.RemoteClient.SubResource("eviction").Create(ctx, pod, eviction)
 }
 
+// deletePod deletes the given Pod with the given grace period, or return an error if it couldn't.
+// If gracePeriodSeconds is negative, the terminationGracePeriodSeconds of the Pod is used.
+// Note: Contrary to evictions, deletions do not respect PodDisruptionBudgets.
+func (d *Helper) deletePod(ctx context.Context, pod *corev1.Pod, gracePeriodSeconds int) error {
+	var opts []client.DeleteOption
+	if gracePeriodSeconds >= 0 {
+		opts = append(opts, client.GracePeriodSeconds(int64(gracePeriodSeconds)))
+	}
+
+	return d.RemoteClient.Delete(ctx, pod, opts...)
+}
+
 // EvictionResult contains the results of an eviction.
 type EvictionResult struct {
 	PodsDeletionTimestampSet   []*corev1.Pod
@@ -458,12 +696,25 @@ type EvictionResult struct {
 	PodsToWaitCompletedLater   []*corev1.Pod
 	PodsNotFound               []*corev1.Pod
 	PodsIgnored                []*corev1.Pod
+
+	// PodsEvictionRateLimited contains the Pods whose eviction has not been triggered because the eviction
+	// rate limit has been reached; their eviction is triggered on the next reconcile.
+	PodsEvictionRateLimited []*corev1.Pod
+
+	// PodsDeletedAfterEvictionBlocked contains the Pods that have been deleted because their eviction
+	// was blocked by PodDisruptionBudgets past the deadline of their eviction fallback.
+	// Note: These Pods are also included in PodsDeletionTimestampSet.
+	PodsDeletedAfterEvictionBlocked []*corev1.Pod
+
+	// NodeDrainGroups contains the times when the drain of groups of Pods with the same drain order started,
+	// including the group started by this eviction.
+	NodeDrainGroups []clusterv1.MachineNodeDrainGroup
 }
 
 // DrainCompleted returns if a Node is entirely drained, i.e. if all relevant Pods have gone away.
 func (r EvictionResult) DrainCompleted() bool {
 	return len(r.PodsDeletionTimestampSet) == 0 && len(r.PodsFailedEviction) == 0 &&
-		len(r.PodsToTriggerEvictionLater) == 0 && len(r.PodsToWaitCompletedLater) == 0 &&
+		len(r.PodsEvictionRateLimited) == 0 && len(r.PodsToTriggerEvictionLater) == 0 && len(r.PodsToWaitCompletedLater) == 0 &&
 		len(r.PodsToWaitCompletedNow) == 0
 }
 
@@ -520,6 +771,16 @@ func (r EvictionResult) ConditionMessage(nodeDrainStartTime metav1.Time) string
 			}
 		}
 	}
+	if len(r.PodsEvictionRateLimited) > 0 {
+		kind := "Pod"
+		if len(r.PodsEvictionRateLimited) > 1 {
+			kind = "Pods"
+		}
+		// Note: the code computing stale warning for the machine deleting condition is making assumptions on the format/content of this message.
+		// Same applies for other conditions where deleting is involved, e.g. MachineSet's Deleting and ScalingDown condition.
+		conditionMessage = fmt.Sprintf("%s\n* %s %s: waiting for eviction, eviction rate limit reached",
+			conditionMessage, kind, PodListToString(r.PodsEvictionRateLimited, 3))
+	}
 	if len(r.PodsToWaitCompletedNow) > 0 {
 		kind := "Pod"
 		if len(r.PodsToWaitCompletedNow) > 1 {
@@ -19,13 +19,16 @@ package drain
 import (
 	"context"
 	"fmt"
+	"math"
 	"net/http"
 	"testing"
 	"time"
 
 	. "github.com/onsi/gomega"
+	"golang.org/x/time/rate"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -910,6 +913,132 @@ func TestGetPodsForEviction(t *testing.T) {
 	}
 }
 
+func TestNodeDisruption_Less(t *testing.T) {
+	tests := []struct {
+		name string
+		a    NodeDisruption
+		b    NodeDisruption
+		want bool
+	}{
+		{
+			name: "fewer PDB-protected Pods is less disruptive",
+			a:    NodeDisruption{Pods: 10, PDBProtectedPods: 0, LocalStoragePods: 5},
+			b:    NodeDisruption{Pods: 1, PDBProtectedPods: 1, LocalStoragePods: 0},
+			want: true,
+		},
+		{
+			name: "fewer Pods using local storage is less disruptive",
+			a:    NodeDisruption{Pods: 10, PDBProtectedPods: 1, LocalStoragePods: 0},
+			b:    NodeDisruption{Pods: 1, PDBProtectedPods: 1, LocalStoragePods: 1},
+			want: true,
+		},
+		{
+			name: "fewer Pods is less disruptive",
+			a:    NodeDisruption{Pods: 1, PDBProtectedPods: 1, LocalStoragePods: 1},
+			b:    NodeDisruption{Pods: 2, PDBProtectedPods: 1, LocalStoragePods: 1},
+			want: true,
+		},
+		{
+			name: "same disruption is not less disruptive",
+			a:    NodeDisruption{Pods: 1, PDBProtectedPods: 1, LocalStoragePods: 1},
+			b:    NodeDisruption{Pods: 1, PDBProtectedPods: 1, LocalStoragePods: 1},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			g.Expect(tt.a.Less(tt.b)).To(Equal(tt.want))
+		})
+	}
+}
+
+func Test_podDisruptionBudgetsForPod(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pod",
+			Namespace: "test-namespace",
+			Labels:    map[string]string{"app": "critical"},
+		},
+	}
+
+	tests := []struct {
+		name string
+		pdbs []policyv1.PodDisruptionBudget
+		want bool
+	}{
+		{
+			name: "no PodDisruptionBudgets",
+			want: false,
+		},
+		{
+			name: "PodDisruptionBudget selecting the Pod",
+			pdbs: []policyv1.PodDisruptionBudget{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "pdb", Namespace: "test-namespace"},
+					Spec: policyv1.PodDisruptionBudgetSpec{
+						Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "critical"}},
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "PodDisruptionBudget selecting the Pod in another Namespace",
+			pdbs: []policyv1.PodDisruptionBudget{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "pdb", Namespace: "other-namespace"},
+					Spec: policyv1.PodDisruptionBudgetSpec{
+						Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "critical"}},
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "PodDisruptionBudget selecting other Pods",
+			pdbs: []policyv1.PodDisruptionBudget{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "pdb", Namespace: "test-namespace"},
+					Spec: policyv1.PodDisruptionBudgetSpec{
+						Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "other"}},
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "PodDisruptionBudget with empty selector selects all Pods in the Namespace",
+			pdbs: []policyv1.PodDisruptionBudget{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "pdb", Namespace: "test-namespace"},
+					Spec: policyv1.PodDisruptionBudgetSpec{
+						Selector: &metav1.LabelSelector{},
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "PodDisruptionBudget with nil selector selects no Pods",
+			pdbs: []policyv1.PodDisruptionBudget{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "pdb", Namespace: "test-namespace"},
+				},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			g.Expect(len(podDisruptionBudgetsForPod(pod, tt.pdbs)) > 0).To(Equal(tt.want))
+		})
+	}
+}
+
 func Test_getMatchingMachineDrainRules(t *testing.T) {
 	cluster := &clusterv1.Cluster{
 		ObjectMeta: metav1.ObjectMeta{
@@ -1259,10 +1388,26 @@ func Test_machineDrainRuleAppliesToMachine(t *testing.T) {
 }
 
 func TestEvictPods(t *testing.T) {
+	deletionTimestamp := metav1.Now()
+	nodeDrainGroups := []clusterv1.MachineNodeDrainGroup{
+		{
+			Order:     ptr.To[int32](0),
+			StartTime: metav1.NewTime(time.Now().Add(-2 * time.Minute)),
+		},
+	}
+	// The eviction of some Pods with eviction fallbacks has been blocked by PodDisruptionBudgets for the first time 2m ago.
+	evictionBlockedTracker := &EvictionBlockedTracker{}
+	for _, name := range []string{"pod-5-to-trigger-eviction-pdb-violated-1", "pod-5-to-trigger-eviction-pdb-violated-2"} {
+		evictionBlockedTracker.Blocked(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name}}, time.Now().Add(-2*time.Minute))
+	}
+
 	tests := []struct {
-		name               string
-		podDeleteList      *PodDeleteList
-		wantEvictionResult EvictionResult
+		name                   string
+		podDeleteList          *PodDeleteList
+		evictionRateLimiter    *rate.Limiter
+		nodeDrainGroups        []clusterv1.MachineNodeDrainGroup
+		evictionBlockedTracker *EvictionBlockedTracker
+		wantEvictionResult     EvictionResult
 	}{
 		{
 			name: "EvictPods correctly",
@@ -1637,6 +1782,260 @@ func TestEvictPods(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "EvictPods with drain timeouts",
+			podDeleteList: &PodDeleteList{items: []PodDelete{
+				{
+					Pod: &corev1.Pod{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:              "pod-1-drain-timeout-skip",
+							DeletionTimestamp: &deletionTimestamp,
+						},
+					},
+					Status: PodDeleteStatus{
+						DrainBehavior: clusterv1.MachineDrainRuleDrainBehaviorDrain,
+						DrainOrder:    ptr.To[int32](0),
+						DrainTimeout: &clusterv1.MachineDrainRuleDrainTimeout{
+							Seconds:  ptr.To[int32](60), // Timeout reached, group 0 started 2m ago => will be skipped
+							Behavior: clusterv1.MachineDrainRuleDrainTimeoutBehaviorSkip,
+						},
+						Reason: PodDeleteStatusTypeOkay,
+					},
+				},
+				{
+					Pod: &corev1.Pod{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "pod-2-wait-completed-drain-timeout-drain",
+						},
+					},
+					Status: PodDeleteStatus{
+						DrainBehavior: clusterv1.MachineDrainRuleDrainBehaviorWaitCompleted,
+						DrainOrder:    ptr.To[int32](0),
+						DrainTimeout: &clusterv1.MachineDrainRuleDrainTimeout{
+							Seconds:  ptr.To[int32](60), // Timeout reached, group 0 started 2m ago => will be evicted
+							Behavior: clusterv1.MachineDrainRuleDrainTimeoutBehaviorDrain,
+						},
+						Reason: PodDeleteStatusTypeWaitCompleted,
+					},
+				},
+				{
+					Pod: &corev1.Pod{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "pod-3-drain-timeout-delete",
+						},
+					},
+					Status: PodDeleteStatus{
+						DrainBehavior: clusterv1.MachineDrainRuleDrainBehaviorDrain,
+						DrainOrder:    ptr.To[int32](0),
+						DrainTimeout: &clusterv1.MachineDrainRuleDrainTimeout{
+							Seconds:  ptr.To[int32](60), // Timeout reached, group 0 started 2m ago => will be deleted
+							Behavior: clusterv1.MachineDrainRuleDrainTimeoutBehaviorDelete,
+						},
+						Reason: PodDeleteStatusTypeOkay,
+					},
+				},
+				{
+					Pod: &corev1.Pod{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "pod-4-wait-completed-drain-timeout-not-reached",
+						},
+					},
+					Status: PodDeleteStatus{
+						DrainBehavior: clusterv1.MachineDrainRuleDrainBehaviorWaitCompleted,
+						DrainOrder:    ptr.To[int32](0),
+						DrainTimeout: &clusterv1.MachineDrainRuleDrainTimeout{
+							Seconds:  ptr.To[int32](600), // Timeout not reached => will wait for completion
+							Behavior: clusterv1.MachineDrainRuleDrainTimeoutBehaviorDrain,
+						},
+						Reason: PodDeleteStatusTypeWaitCompleted,
+					},
+				},
+				{
+					Pod: &corev1.Pod{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "pod-5-to-trigger-eviction-later-drain-timeout",
+						},
+					},
+					Status: PodDeleteStatus{
+						DrainBehavior: clusterv1.MachineDrainRuleDrainBehaviorDrain,
+						DrainOrder:    ptr.To[int32](1),
+						DrainTimeout: &clusterv1.MachineDrainRuleDrainTimeout{
+							Seconds:  ptr.To[int32](60), // Group 1 not started yet => will be evicted later
+							Behavior: clusterv1.MachineDrainRuleDrainTimeoutBehaviorSkip,
+						},
+						Reason: PodDeleteStatusTypeOkay,
+					},
+				},
+			}},
+			nodeDrainGroups: nodeDrainGroups,
+			wantEvictionResult: EvictionResult{
+				PodsIgnored: []*corev1.Pod{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:              "pod-1-drain-timeout-skip",
+							DeletionTimestamp: &deletionTimestamp,
+						},
+					},
+				},
+				PodsDeletionTimestampSet: []*corev1.Pod{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "pod-2-wait-completed-drain-timeout-drain",
+						},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "pod-3-drain-timeout-delete",
+						},
+					},
+				},
+				PodsFailedEviction: map[string][]*corev1.Pod{},
+				PodsToWaitCompletedNow: []*corev1.Pod{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "pod-4-wait-completed-drain-timeout-not-reached",
+						},
+					},
+				},
+				PodsToTriggerEvictionLater: []*corev1.Pod{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "pod-5-to-trigger-eviction-later-drain-timeout",
+						},
+					},
+				},
+				NodeDrainGroups: nodeDrainGroups,
+			},
+		},
+		{
+			name: "EvictPods with eviction fallbacks",
+			podDeleteList: &PodDeleteList{items: []PodDelete{
+				{
+					Pod: &corev1.Pod{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "pod-5-to-trigger-eviction-pdb-violated-1",
+						},
+					},
+					Status: PodDeleteStatus{
+						DrainBehavior: clusterv1.MachineDrainRuleDrainBehaviorDrain,
+						DrainOrder:    ptr.To[int32](0),
+						EvictionFallback: &clusterv1.MachineDrainRuleEvictionFallback{
+							DeleteAfterSeconds: ptr.To[int32](60), // Deadline reached, eviction first blocked 2m ago => will be deleted
+							GracePeriodSeconds: ptr.To[int32](30),
+						},
+						Reason: PodDeleteStatusTypeOkay,
+					},
+				},
+				{
+					Pod: &corev1.Pod{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "pod-5-to-trigger-eviction-pdb-violated-2",
+						},
+					},
+					Status: PodDeleteStatus{
+						DrainBehavior: clusterv1.MachineDrainRuleDrainBehaviorDrain,
+						DrainOrder:    ptr.To[int32](0),
+						EvictionFallback: &clusterv1.MachineDrainRuleEvictionFallback{
+							DeleteAfterSeconds: ptr.To[int32](600), // Deadline not reached => eviction will be retried
+						},
+						Reason: PodDeleteStatusTypeOkay,
+					},
+				},
+				{
+					Pod: &corev1.Pod{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "pod-5-to-trigger-eviction-pdb-violated-3",
+						},
+					},
+					Status: PodDeleteStatus{
+						DrainBehavior: clusterv1.MachineDrainRuleDrainBehaviorDrain,
+						DrainOrder:    ptr.To[int32](0),
+						EvictionFallback: &clusterv1.MachineDrainRuleEvictionFallback{
+							DeleteAfterSeconds: ptr.To[int32](60), // Deadline not reached, eviction blocked for the first time => eviction will be retried
+						},
+						Reason: PodDeleteStatusTypeOkay,
+					},
+				},
+			}},
+			nodeDrainGroups:        nodeDrainGroups,
+			evictionBlockedTracker: evictionBlockedTracker,
+			wantEvictionResult: EvictionResult{
+				PodsDeletionTimestampSet: []*corev1.Pod{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "pod-5-to-trigger-eviction-pdb-violated-1",
+						},
+					},
+				},
+				PodsDeletedAfterEvictionBlocked: []*corev1.Pod{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "pod-5-to-trigger-eviction-pdb-violated-1",
+						},
+					},
+				},
+				PodsFailedEviction: map[string][]*corev1.Pod{
+					"Cannot evict pod as it would violate the pod's disruption budget. The disruption budget pod-5-pdb needs 3 healthy pods and has 2 currently": {
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "pod-5-to-trigger-eviction-pdb-violated-2",
+							},
+						},
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "pod-5-to-trigger-eviction-pdb-violated-3",
+							},
+						},
+					},
+				},
+				NodeDrainGroups: nodeDrainGroups,
+			},
+		},
+		{
+			name: "EvictPods with eviction rate limit",
+			podDeleteList: &PodDeleteList{items: []PodDelete{
+				{
+					Pod: &corev1.Pod{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "pod-1-to-trigger-eviction-successfully",
+						},
+					},
+					Status: PodDeleteStatus{
+						DrainBehavior: clusterv1.MachineDrainRuleDrainBehaviorDrain,
+						Reason:        PodDeleteStatusTypeOkay,
+					},
+				},
+				{
+					Pod: &corev1.Pod{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "pod-3-to-trigger-eviction-rate-limited",
+						},
+					},
+					Status: PodDeleteStatus{
+						DrainBehavior: clusterv1.MachineDrainRuleDrainBehaviorDrain,
+						Reason:        PodDeleteStatusTypeOkay,
+					},
+				},
+			}},
+			evictionRateLimiter: rate.NewLimiter(0.5, 1), // Only the first eviction is allowed.
+			wantEvictionResult: EvictionResult{
+				PodsDeletionTimestampSet: []*corev1.Pod{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "pod-1-to-trigger-eviction-successfully",
+						},
+					},
+				},
+				PodsFailedEviction: map[string][]*corev1.Pod{},
+				PodsEvictionRateLimited: []*corev1.Pod{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "pod-3-to-trigger-eviction-rate-limited",
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -1651,11 +2050,11 @@ func TestEvictPods(t *testing.T) {
 				SubResourceCreate: func(_ context.Context, _ client.Client, subResourceName string, obj client.Object, _ client.Object, _ ...client.SubResourceCreateOption) error {
 					g.Expect(subResourceName).To(Equal("eviction"))
 					switch name := obj.GetName(); name {
-					case "pod-3-to-trigger-eviction-successfully":
+					case "pod-1-to-trigger-eviction-successfully", "pod-3-to-trigger-eviction-successfully", "pod-2-wait-completed-drain-timeout-drain":
 						return nil // Successful eviction.
 					case "pod-4-to-trigger-eviction-pod-not-found":
 						return apierrors.NewNotFound(podResource, name)
-					case "pod-5-to-trigger-eviction-pdb-violated-1", "pod-5-to-trigger-eviction-pdb-violated-2":
+					case "pod-5-to-trigger-eviction-pdb-violated-1", "pod-5-to-trigger-eviction-pdb-violated-2", "pod-5-to-trigger-eviction-pdb-violated-3":
 						return &apierrors.StatusError{
 							ErrStatus: metav1.Status{
 								Status:  metav1.StatusFailure,
@@ -1699,10 +2098,28 @@ func TestEvictPods(t *testing.T) {
 					g.Fail(fmt.Sprintf("eviction behavior for Pod %q not implemented", obj.GetName()))
 					return nil
 				},
+				Delete: func(_ context.Context, _ client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+					switch name := obj.GetName(); name {
+					case "pod-3-drain-timeout-delete":
+						return nil // Successful deletion.
+					case "pod-5-to-trigger-eviction-pdb-violated-1":
+						// Successful deletion with the grace period of the eviction fallback.
+						deleteOpts := &client.DeleteOptions{}
+						deleteOpts.ApplyOptions(opts)
+						g.Expect(deleteOpts.GracePeriodSeconds).To(Equal(ptr.To[int64](30)))
+						return nil
+					}
+
+					g.Fail(fmt.Sprintf("deletion behavior for Pod %q not implemented", obj.GetName()))
+					return nil
+				},
 			})
 
 			drainer := &Helper{
-				RemoteClient: fakeClient,
+				RemoteClient:           fakeClient,
+				EvictionRateLimiter:    tt.evictionRateLimiter,
+				NodeDrainGroups:        tt.nodeDrainGroups,
+				EvictionBlockedTracker: tt.evictionBlockedTracker,
 			}
 
 			gotEvictionResult := drainer.EvictPods(context.Background(), tt.podDeleteList)
@@ -1763,6 +2180,13 @@ func TestEvictionResult_ConditionMessage(t *testing.T) {
 						},
 					},
 				},
+				PodsEvictionRateLimited: []*corev1.Pod{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "pod-9-eviction-rate-limited",
+						},
+					},
+				},
 				PodsToTriggerEvictionLater: []*corev1.Pod{
 					{
 						ObjectMeta: metav1.ObjectMeta{
@@ -1780,6 +2204,7 @@ func TestEvictionResult_ConditionMessage(t *testing.T) {
 * Pods pod-2-deletionTimestamp-set-1, pod-3-to-trigger-eviction-successfully-1: deletionTimestamp set, but still not removed from the Node
 * Pod pod-5-to-trigger-eviction-pdb-violated-1: cannot evict pod as it would violate the pod's disruption budget. The disruption budget pod-5-pdb needs 20 healthy pods and has 20 currently
 * Pod pod-6-to-trigger-eviction-some-other-error: failed to evict Pod, some other error 1
+* Pod pod-9-eviction-rate-limited: waiting for eviction, eviction rate limit reached
 After above Pods have been removed from the Node, the following Pods will be evicted: pod-7-eviction-later, pod-8-eviction-later`,
 		},
 		{
@@ -2058,3 +2483,95 @@ func podByNodeName(o client.Object) []string {
 
 	return []string{pod.Spec.NodeName}
 }
+
+func Test_startNodeDrainGroup(t *testing.T) {
+	now := time.Now()
+	startedGroup := clusterv1.MachineNodeDrainGroup{
+		Order:     ptr.To[int32](0),
+		StartTime: metav1.NewTime(now.Add(-time.Minute)),
+	}
+	podWithDrainTimeout := func(order int32) PodDelete {
+		return PodDelete{
+			Pod: &corev1.Pod{},
+			Status: PodDeleteStatus{
+				DrainBehavior: clusterv1.MachineDrainRuleDrainBehaviorDrain,
+				DrainOrder:    ptr.To(order),
+				DrainTimeout: &clusterv1.MachineDrainRuleDrainTimeout{
+					Seconds:  ptr.To[int32](60),
+					Behavior: clusterv1.MachineDrainRuleDrainTimeoutBehaviorSkip,
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name                string
+		nodeDrainGroups     []clusterv1.MachineNodeDrainGroup
+		pds                 []PodDelete
+		order               int32
+		wantNodeDrainGroups []clusterv1.MachineNodeDrainGroup
+	}{
+		{
+			name:                "Don't start a group if there are no Pods to drain",
+			order:               math.MaxInt32,
+			wantNodeDrainGroups: nil,
+		},
+		{
+			name: "Don't start a group if no Pod of the group has a drain timeout",
+			pds: []PodDelete{
+				{
+					Pod:    &corev1.Pod{},
+					Status: MakePodDeleteStatusOkay(),
+				},
+				podWithDrainTimeout(1),
+			},
+			order:               0,
+			wantNodeDrainGroups: nil,
+		},
+		{
+			name: "Don't start a group if Pods of the group only have an eviction fallback",
+			pds: []PodDelete{
+				{
+					Pod: &corev1.Pod{},
+					Status: PodDeleteStatus{
+						DrainBehavior: clusterv1.MachineDrainRuleDrainBehaviorDrain,
+						DrainOrder:    ptr.To[int32](0),
+						EvictionFallback: &clusterv1.MachineDrainRuleEvictionFallback{
+							DeleteAfterSeconds: ptr.To[int32](60),
+						},
+					},
+				},
+			},
+			order:               0,
+			wantNodeDrainGroups: nil,
+		},
+		{
+			name:                "Don't start a group that is already started",
+			nodeDrainGroups:     []clusterv1.MachineNodeDrainGroup{startedGroup},
+			pds:                 []PodDelete{podWithDrainTimeout(0)},
+			order:               0,
+			wantNodeDrainGroups: []clusterv1.MachineNodeDrainGroup{startedGroup},
+		},
+		{
+			name:            "Start a group if at least one Pod of the group has a drain timeout",
+			nodeDrainGroups: []clusterv1.MachineNodeDrainGroup{startedGroup},
+			pds:             []PodDelete{podWithDrainTimeout(0), podWithDrainTimeout(1)},
+			order:           1,
+			wantNodeDrainGroups: []clusterv1.MachineNodeDrainGroup{
+				startedGroup,
+				{
+					Order:     ptr.To[int32](1),
+					StartTime: metav1.NewTime(now),
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			g.Expect(startNodeDrainGroup(tt.nodeDrainGroups, tt.pds, tt.order, now)).To(BeComparableTo(tt.wantNodeDrainGroups))
+		})
+	}
+}
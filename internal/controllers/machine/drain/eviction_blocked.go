@@ -0,0 +1,82 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package drain
+
+import (
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// evictionBlockedEntryTTL is the duration after which a Pod for which eviction was not blocked anymore
+// (e.g. because it has been evicted in the meantime) is forgotten.
+const evictionBlockedEntryTTL = 10 * time.Minute
+
+// EvictionBlockedTracker tracks when the eviction of Pods has been blocked by PodDisruptionBudgets for the first time,
+// so the deadlines of eviction fallbacks of MachineDrainRules can be computed.
+// Note: The EvictionBlockedTracker is kept in memory, so the deadlines of eviction fallbacks start again
+// after a controller restart; this can only delay the deletion of Pods.
+type EvictionBlockedTracker struct {
+	lock sync.Mutex
+	pods map[evictionBlockedKey]evictionBlockedEntry
+}
+
+type evictionBlockedKey struct {
+	namespace string
+	name      string
+	uid       types.UID
+}
+
+type evictionBlockedEntry struct {
+	// firstBlocked is the time when the eviction of the Pod has been blocked for the first time.
+	firstBlocked time.Time
+	// lastBlocked is the last time when the eviction of the Pod has been blocked.
+	lastBlocked time.Time
+}
+
+// Blocked records that the eviction of a Pod has been blocked by PodDisruptionBudgets and returns
+// the time when the eviction of the Pod has been blocked for the first time.
+// Note: If the eviction of a Pod has not been blocked for longer than evictionBlockedEntryTTL, Blocked
+// considers the eviction of the Pod as blocked for the first time.
+func (t *EvictionBlockedTracker) Blocked(pod *corev1.Pod, now time.Time) time.Time {
+	if t == nil {
+		return now
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.pods == nil {
+		t.pods = map[evictionBlockedKey]evictionBlockedEntry{}
+	}
+	for key, entry := range t.pods {
+		if now.Sub(entry.lastBlocked) > evictionBlockedEntryTTL {
+			delete(t.pods, key)
+		}
+	}
+
+	key := evictionBlockedKey{namespace: pod.Namespace, name: pod.Name, uid: pod.UID}
+	entry, ok := t.pods[key]
+	if !ok {
+		entry.firstBlocked = now
+	}
+	entry.lastBlocked = now
+	t.pods[key] = entry
+	return entry.firstBlocked
+}
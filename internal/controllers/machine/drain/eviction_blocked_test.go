@@ -0,0 +1,53 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package drain
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestEvictionBlockedTracker_Blocked(t *testing.T) {
+	g := NewWithT(t)
+
+	now := time.Now()
+	pod1 := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pod-1", UID: "uid-1"}}
+	pod1Recreated := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pod-1", UID: "uid-2"}}
+	pod2 := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pod-2", UID: "uid-3"}}
+
+	tracker := &EvictionBlockedTracker{}
+
+	// The first blocked eviction of a Pod is tracked.
+	g.Expect(tracker.Blocked(pod1, now)).To(Equal(now))
+	g.Expect(tracker.Blocked(pod1, now.Add(time.Minute))).To(Equal(now))
+
+	// Pods are tracked independently, also if they are recreated with the same name.
+	g.Expect(tracker.Blocked(pod2, now.Add(time.Minute))).To(Equal(now.Add(time.Minute)))
+	g.Expect(tracker.Blocked(pod1Recreated, now.Add(time.Minute))).To(Equal(now.Add(time.Minute)))
+
+	// A Pod for which eviction was not blocked for longer than the TTL is forgotten.
+	later := now.Add(time.Minute + evictionBlockedEntryTTL + time.Second)
+	g.Expect(tracker.Blocked(pod1, later)).To(Equal(later))
+
+	// Without a tracker, evictions are always blocked for the first time.
+	var nilTracker *EvictionBlockedTracker
+	g.Expect(nilTracker.Blocked(pod1, now)).To(Equal(now))
+}
@@ -113,6 +113,17 @@ type PodDeleteStatus struct {
 	// DrainOrder is only used if DrainBehavior is "Drain".
 	DrainOrder *int32
 
+	// DrainTimeout defines the timeout of the MachineDrainRule that applies to the Pod.
+	// DrainTimeout is only set if the MachineDrainRule has a timeout.
+	DrainTimeout *clusterv1.MachineDrainRuleDrainTimeout
+
+	// DrainTimeoutReached is true if the DrainTimeout has been reached.
+	DrainTimeoutReached bool
+
+	// EvictionFallback defines the eviction fallback of the MachineDrainRule that applies to the Pod.
+	// EvictionFallback is only set if the MachineDrainRule has an eviction fallback.
+	EvictionFallback *clusterv1.MachineDrainRuleEvictionFallback
+
 	Reason  string
 	Message string
 }
@@ -316,13 +327,18 @@ func (d *Helper) machineDrainRulesFilter(machineDrainRules []*clusterv1.MachineD
 			log := ctrl.LoggerFrom(ctx, "Pod", klog.KObj(pod))
 			switch mdr.Spec.Drain.Behavior {
 			case clusterv1.MachineDrainRuleDrainBehaviorDrain:
-				return MakePodDeleteStatusOkayWithOrder(mdr.Spec.Drain.Order)
+				status := MakePodDeleteStatusOkayWithOrder(mdr.Spec.Drain.Order)
+				status.DrainTimeout = drainTimeout(mdr)
+				status.EvictionFallback = evictionFallback(mdr)
+				return status
 			case clusterv1.MachineDrainRuleDrainBehaviorSkip:
 				log.V(4).Info(fmt.Sprintf("Skip evicting Pod, because MachineDrainRule %s with behavior %s applies to the Pod", mdr.Name, clusterv1.MachineDrainRuleDrainBehaviorSkip))
 				return MakePodDeleteStatusSkip()
 			case clusterv1.MachineDrainRuleDrainBehaviorWaitCompleted:
 				log.V(4).Info(fmt.Sprintf("Skip evicting Pod, because MachineDrainRule %s with behavior %s applies to the Pod", mdr.Name, clusterv1.MachineDrainRuleDrainBehaviorWaitCompleted))
-				return MakePodDeleteStatusWaitCompleted()
+				status := MakePodDeleteStatusWaitCompleted()
+				status.DrainTimeout = drainTimeout(mdr)
+				return status
 			default:
 				return MakePodDeleteStatusWithError(
 					fmt.Sprintf("MachineDrainRule %q has unknown spec.drain.behavior: %q",
@@ -335,6 +351,22 @@ func (d *Helper) machineDrainRulesFilter(machineDrainRules []*clusterv1.MachineD
 	}
 }
 
+// drainTimeout returns the timeout of a MachineDrainRule, or nil if the MachineDrainRule has no timeout.
+func drainTimeout(mdr *clusterv1.MachineDrainRule) *clusterv1.MachineDrainRuleDrainTimeout {
+	if mdr.Spec.Drain.Timeout.Seconds == nil {
+		return nil
+	}
+	return mdr.Spec.Drain.Timeout.DeepCopy()
+}
+
+// evictionFallback returns the eviction fallback of a MachineDrainRule, or nil if the MachineDrainRule has no eviction fallback.
+func evictionFallback(mdr *clusterv1.MachineDrainRule) *clusterv1.MachineDrainRuleEvictionFallback {
+	if mdr.Spec.Drain.EvictionFallback.DeleteAfterSeconds == nil {
+		return nil
+	}
+	return mdr.Spec.Drain.EvictionFallback.DeepCopy()
+}
+
 // machineDrainRuleAppliesToPod evaluates if a MachineDrainRule applies to a Pod.
 func machineDrainRuleAppliesToPod(mdr *clusterv1.MachineDrainRule, pod *corev1.Pod, namespace *corev1.Namespace) bool {
 	// If pods is empty, the MachineDrainRule applies to all Pods.
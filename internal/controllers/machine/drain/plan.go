@@ -0,0 +1,144 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package drain
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/utils/ptr"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+)
+
+// Plan describes what draining a Node would do.
+type Plan struct {
+	// Groups contains the groups of Pods with the same drain order that have to go away before the Node
+	// is considered completely drained, in the order in which they are drained.
+	Groups []PlanGroup
+
+	// PodsSkipped contains the Pods that are not drained, e.g. DaemonSet Pods, static Pods and Pods
+	// skipped via drain label or MachineDrainRules.
+	PodsSkipped []PlanPod
+}
+
+// PlanGroup is a group of Pods with the same drain order.
+type PlanGroup struct {
+	// Order is the drain order of the Pods of the group.
+	Order int32
+
+	// Pods contains the Pods of the group.
+	Pods []PlanPod
+}
+
+// PlanPod describes how a Pod is handled when draining a Node.
+type PlanPod struct {
+	// Pod is the Pod.
+	Pod *corev1.Pod
+
+	// DrainBehavior is the drain behavior of the Pod, it is either "Drain", "WaitCompleted" or "Skip".
+	DrainBehavior clusterv1.MachineDrainRuleDrainBehavior
+
+	// Warning contains a warning about the Pod, e.g. if the Pod is using local storage.
+	Warning string
+
+	// BlockingPodDisruptionBudgets contains the names of the PodDisruptionBudgets that currently
+	// do not allow any disruption of the Pod, i.e. an eviction of the Pod would be blocked right now.
+	// BlockingPodDisruptionBudgets is only computed for Pods with drain behavior "Drain".
+	BlockingPodDisruptionBudgets []string
+}
+
+// GetPlan gets Pods running on a Node, filters them like GetPodsForEviction does and returns what draining
+// the Node would do.
+// Note: GetPlan never evicts or deletes Pods.
+func (d *Helper) GetPlan(ctx context.Context, cluster *clusterv1.Cluster, machine *clusterv1.Machine, nodeName string) (*Plan, error) {
+	podDeleteList, err := d.GetPodsForEviction(ctx, cluster, machine, nodeName)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &Plan{}
+	if len(podDeleteList.items) == 0 {
+		return plan, nil
+	}
+
+	pdbs, err := d.ListPodDisruptionBudgets(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get drain plan")
+	}
+
+	// Sort Pods, so the plan is deterministic and lists Pods in the same order they are evicted.
+	items := podDeleteList.items
+	sort.Slice(items, func(i, j int) bool {
+		return fmt.Sprintf("%s/%s", items[i].Pod.GetNamespace(), items[i].Pod.GetName()) <
+			fmt.Sprintf("%s/%s", items[j].Pod.GetNamespace(), items[j].Pod.GetName())
+	})
+
+	groups := map[int32]*PlanGroup{}
+	for _, pd := range items {
+		planPod := PlanPod{
+			Pod:           pd.Pod,
+			DrainBehavior: pd.Status.DrainBehavior,
+		}
+		if pd.Status.Reason == PodDeleteStatusTypeWarning {
+			planPod.Warning = pd.Status.Message
+		}
+
+		switch pd.Status.DrainBehavior {
+		case clusterv1.MachineDrainRuleDrainBehaviorDrain, clusterv1.MachineDrainRuleDrainBehaviorWaitCompleted:
+			if pd.Status.DrainBehavior == clusterv1.MachineDrainRuleDrainBehaviorDrain {
+				planPod.BlockingPodDisruptionBudgets = blockingPodDisruptionBudgets(pd.Pod, pdbs)
+			}
+			order := ptr.Deref(pd.Status.DrainOrder, 0)
+			group, ok := groups[order]
+			if !ok {
+				group = &PlanGroup{Order: order}
+				groups[order] = group
+			}
+			group.Pods = append(group.Pods, planPod)
+		default:
+			plan.PodsSkipped = append(plan.PodsSkipped, planPod)
+		}
+	}
+
+	for _, group := range groups {
+		plan.Groups = append(plan.Groups, *group)
+	}
+	// Pods are drained in batches from the lowest to the highest order.
+	sort.Slice(plan.Groups, func(i, j int) bool {
+		return plan.Groups[i].Order < plan.Groups[j].Order
+	})
+	return plan, nil
+}
+
+// blockingPodDisruptionBudgets returns the names of the PodDisruptionBudgets selecting the Pod that currently
+// do not allow any disruption.
+func blockingPodDisruptionBudgets(pod *corev1.Pod, pdbs []policyv1.PodDisruptionBudget) []string {
+	var names []string
+	for _, pdb := range podDisruptionBudgetsForPod(pod, pdbs) {
+		if pdb.Status.DisruptionsAllowed > 0 {
+			continue
+		}
+		names = append(names, pdb.Name)
+	}
+	sort.Strings(names)
+	return names
+}
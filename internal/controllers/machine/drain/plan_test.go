@@ -0,0 +1,162 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package drain
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+)
+
+func TestGetPlan(t *testing.T) {
+	g := NewWithT(t)
+
+	mdr := &clusterv1.MachineDrainRule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "mdr-behavior-drain",
+			Namespace: "test-namespace",
+		},
+		Spec: clusterv1.MachineDrainRuleSpec{
+			Drain: clusterv1.MachineDrainRuleDrainConfig{
+				Behavior: clusterv1.MachineDrainRuleDrainBehaviorDrain,
+				Order:    ptr.To[int32](5),
+			},
+			Pods: []clusterv1.MachineDrainRulePodSelector{
+				{
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							"app": "drain-later",
+						},
+					},
+				},
+			},
+		},
+	}
+	pod := func(name string, podLabels map[string]string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "test-namespace",
+				Labels:    podLabels,
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						Kind:       "ReplicaSet",
+						Controller: ptr.To(true),
+					},
+				},
+			},
+			Spec: corev1.PodSpec{
+				NodeName: "node-1",
+			},
+		}
+	}
+	pdb := func(name string, disruptionsAllowed int32) *policyv1.PodDisruptionBudget {
+		return &policyv1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "test-namespace",
+			},
+			Spec: policyv1.PodDisruptionBudgetSpec{
+				Selector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						"pdb": name,
+					},
+				},
+			},
+			Status: policyv1.PodDisruptionBudgetStatus{
+				DisruptionsAllowed: disruptionsAllowed,
+			},
+		}
+	}
+
+	fakeRemoteClient := fake.NewClientBuilder().
+		WithObjects(
+			&corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-namespace",
+					Labels: map[string]string{
+						"kubernetes.io/metadata.name": "test-namespace",
+					},
+				},
+			},
+			pod("pod-1-drain-pdb-blocked", map[string]string{"pdb": "pdb-blocking"}),
+			pod("pod-2-drain-pdb-not-blocked", map[string]string{"pdb": "pdb-not-blocking"}),
+			pod("pod-3-drain-later", map[string]string{"app": "drain-later"}),
+			pod("pod-4-skip", map[string]string{clusterv1.PodDrainLabel: "skip"}),
+			pod("pod-5-wait-completed", map[string]string{clusterv1.PodDrainLabel: "wait-completed"}),
+			pdb("pdb-blocking", 0),
+			pdb("pdb-not-blocking", 1),
+		).
+		WithIndex(&corev1.Pod{}, "spec.nodeName", podByNodeName).
+		Build()
+
+	scheme := runtime.NewScheme()
+	g.Expect(clusterv1.AddToScheme(scheme)).To(Succeed())
+	fakeClient := fake.NewClientBuilder().
+		WithObjects(mdr).
+		WithScheme(scheme).
+		Build()
+
+	drainer := &Helper{
+		Client:       fakeClient,
+		RemoteClient: fakeRemoteClient,
+	}
+
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cluster",
+			Namespace: "test-namespace",
+		},
+	}
+	machine := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-machine",
+			Namespace: "test-namespace",
+		},
+	}
+
+	plan, err := drainer.GetPlan(context.Background(), cluster, machine, "node-1")
+	g.Expect(err).ToNot(HaveOccurred())
+
+	names := func(pods []PlanPod) []string {
+		res := []string{}
+		for _, p := range pods {
+			res = append(res, p.Pod.Name)
+		}
+		return res
+	}
+
+	g.Expect(plan.Groups).To(HaveLen(2))
+	g.Expect(plan.Groups[0].Order).To(Equal(int32(0)))
+	g.Expect(names(plan.Groups[0].Pods)).To(Equal([]string{"pod-1-drain-pdb-blocked", "pod-2-drain-pdb-not-blocked", "pod-5-wait-completed"}))
+	g.Expect(plan.Groups[0].Pods[0].DrainBehavior).To(Equal(clusterv1.MachineDrainRuleDrainBehaviorDrain))
+	g.Expect(plan.Groups[0].Pods[0].BlockingPodDisruptionBudgets).To(Equal([]string{"pdb-blocking"}))
+	g.Expect(plan.Groups[0].Pods[1].BlockingPodDisruptionBudgets).To(BeEmpty())
+	g.Expect(plan.Groups[0].Pods[2].DrainBehavior).To(Equal(clusterv1.MachineDrainRuleDrainBehaviorWaitCompleted))
+	g.Expect(plan.Groups[1].Order).To(Equal(int32(5)))
+	g.Expect(names(plan.Groups[1].Pods)).To(Equal([]string{"pod-3-drain-later"}))
+	g.Expect(names(plan.PodsSkipped)).To(Equal([]string{"pod-4-skip"}))
+}
@@ -107,6 +107,14 @@ type Reconciler struct {
 	AdditionalSyncMachineLabels      []*regexp.Regexp
 	AdditionalSyncMachineAnnotations []*regexp.Regexp
 
+	// MaxConcurrentDrainsPerCluster is the maximum number of Nodes that are drained concurrently in a Cluster.
+	// 0 means no limit.
+	MaxConcurrentDrainsPerCluster int
+
+	// MaxEvictionsPerSecondPerCluster is the maximum number of Pod evictions per second during Node drains in a Cluster.
+	// 0 means no limit.
+	MaxEvictionsPerSecondPerCluster float64
+
 	controller      capicontrollerutil.Controller
 	recorder        record.EventRecorder
 	externalTracker external.ObjectTracker
@@ -117,6 +125,10 @@ type Reconciler struct {
 
 	hookCache cache.Cache[cache.HookEntry]
 
+	drainBudget *drain.Budget
+
+	evictionBlockedTracker *drain.EvictionBlockedTracker
+
 	predicateLog *logr.Logger
 }
 
@@ -176,6 +188,11 @@ func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, opt
 	}
 
 	r.hookCache = cache.New[cache.HookEntry](cache.HookCacheDefaultTTL)
+	r.drainBudget = &drain.Budget{
+		MaxConcurrentDrains:   r.MaxConcurrentDrainsPerCluster,
+		MaxEvictionsPerSecond: r.MaxEvictionsPerSecondPerCluster,
+	}
+	r.evictionBlockedTracker = &drain.EvictionBlockedTracker{}
 	r.controller = c
 	r.recorder = mgr.GetEventRecorderFor("machine-controller")
 	r.externalTracker = external.ObjectTracker{
@@ -509,6 +526,22 @@ func (r *Reconciler) reconcileDelete(ctx context.Context, s *scope) (ctrl.Result
 
 		// Drain node before deletion and issue a patch in order to make this operation visible to the users.
 		if r.isNodeDrainAllowed(m) {
+			// Wait until the Node can be drained without exceeding the drain budget of the Cluster.
+			// Note: Machines that already started to drain their Node always acquire the drain budget.
+			drainStarted := m.Status.Deletion != nil && !m.Status.Deletion.NodeDrainStartTime.IsZero()
+			if acquired, drainingMachines := r.drainBudget.TryAcquire(util.ObjectKey(cluster), m.Name, drainStarted); !acquired {
+				log.Info(fmt.Sprintf("Waiting for drain budget, requeuing in %s", drainRetryInterval),
+					"drainingMachines", clog.StringListToString(drainingMachines))
+				kind := "Machine is"
+				if len(drainingMachines) > 1 {
+					kind = "Machines are"
+				}
+				s.deletingReason = clusterv1.MachineDeletingDrainingNodeReason
+				s.deletingMessage = fmt.Sprintf("Waiting for drain budget, %d %s already draining Nodes in the Cluster: %s",
+					len(drainingMachines), kind, clog.StringListToString(drainingMachines))
+				return ctrl.Result{RequeueAfter: drainRetryInterval}, nil
+			}
+
 			patchHelper, err := patch.NewHelper(m, r.Client)
 			if err != nil {
 				s.deletingReason = clusterv1.MachineDeletingInternalErrorReason
@@ -552,6 +585,8 @@ func (r *Reconciler) reconcileDelete(ctx context.Context, s *scope) (ctrl.Result
 			v1beta1conditions.MarkTrue(m, clusterv1.DrainingSucceededV1Beta1Condition)
 			r.recorder.Eventf(m, corev1.EventTypeNormal, "SuccessfulDrainNode", "success draining Machine's node %q", m.Status.NodeRef.Name)
 		}
+		// Drain is completed or skipped, release the drain budget so other Machines of the Cluster can drain their Nodes.
+		r.drainBudget.Release(util.ObjectKey(cluster), m.Name)
 
 		// After node draining is completed, and if isNodeVolumeDetachingAllowed returns True, make sure all
 		// volumes are detached before proceeding to delete the Node.
@@ -862,9 +897,14 @@ func (r *Reconciler) drainNode(ctx context.Context, s *scope) (ctrl.Result, erro
 	}
 
 	drainer := &drain.Helper{
-		Client:             r.Client,
-		RemoteClient:       remoteClient,
-		GracePeriodSeconds: -1,
+		Client:                 r.Client,
+		RemoteClient:           remoteClient,
+		GracePeriodSeconds:     -1,
+		EvictionRateLimiter:    r.drainBudget.EvictionRateLimiter(util.ObjectKey(cluster)),
+		EvictionBlockedTracker: r.evictionBlockedTracker,
+	}
+	if machine.Status.Deletion != nil {
+		drainer.NodeDrainGroups = machine.Status.Deletion.NodeDrainGroups
 	}
 
 	if noderefutil.IsNodeUnreachable(node) {
@@ -909,6 +949,16 @@ func (r *Reconciler) drainNode(ctx context.Context, s *scope) (ctrl.Result, erro
 	log.Info("Draining Node")
 
 	evictionResult := drainer.EvictPods(ctx, podDeleteList)
+	if machine.Status.Deletion != nil {
+		// Note: The start times of drain groups are persisted so the timeouts of MachineDrainRules
+		// are computed correctly across reconciles.
+		machine.Status.Deletion.NodeDrainGroups = evictionResult.NodeDrainGroups
+	}
+	if len(evictionResult.PodsDeletedAfterEvictionBlocked) > 0 {
+		r.recorder.Eventf(machine, corev1.EventTypeWarning, "PodsDeletedAfterEvictionBlocked",
+			"Deleted Pods %s on Machine's node %q, because their eviction was blocked by PodDisruptionBudgets past the deadline of their eviction fallback",
+			drain.PodListToString(evictionResult.PodsDeletedAfterEvictionBlocked, 5), nodeName)
+	}
 
 	if evictionResult.DrainCompleted() {
 		log.Info("Drain completed, remaining Pods on the Node have been evicted")
@@ -928,6 +978,7 @@ func (r *Reconciler) drainNode(ctx context.Context, s *scope) (ctrl.Result, erro
 	}
 	log.Info(fmt.Sprintf("Drain not completed yet, requeuing in %s", drainRetryInterval),
 		"podsFailedEviction", drain.PodListToString(podsFailedEviction, 5),
+		"podsEvictionRateLimited", drain.PodListToString(evictionResult.PodsEvictionRateLimited, 5),
 		"podsWithDeletionTimestamp", drain.PodListToString(evictionResult.PodsDeletionTimestampSet, 5),
 		"podsToTriggerEvictionLater", drain.PodListToString(evictionResult.PodsToTriggerEvictionLater, 5),
 		"podsToWaitCompletedNow", drain.PodListToString(evictionResult.PodsToWaitCompletedNow, 5),
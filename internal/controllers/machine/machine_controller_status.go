@@ -830,6 +830,9 @@ func calculateDeletingConditionForSummary(machine *clusterv1.Machine) conditions
 				if strings.Contains(deletingCondition.Message, "failed to evict Pod") {
 					delayReasons = append(delayReasons, "Pod eviction errors")
 				}
+				if strings.Contains(deletingCondition.Message, "eviction rate limit reached") {
+					delayReasons = append(delayReasons, "Pod eviction rate limit")
+				}
 				if strings.Contains(deletingCondition.Message, "waiting for completion") {
 					delayReasons = append(delayReasons, "Pods not completed yet")
 				}
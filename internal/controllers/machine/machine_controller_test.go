@@ -38,6 +38,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -50,6 +51,7 @@ import (
 	externalfake "sigs.k8s.io/cluster-api/controllers/external/fake"
 	"sigs.k8s.io/cluster-api/feature"
 	"sigs.k8s.io/cluster-api/internal/contract"
+	"sigs.k8s.io/cluster-api/internal/controllers/machine/drain"
 	capicontrollerutil "sigs.k8s.io/cluster-api/internal/util/controller"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/conditions"
@@ -1856,6 +1858,107 @@ func TestDrainNode(t *testing.T) {
 	}
 }
 
+func TestReconcileDeleteDrainBudget(t *testing.T) {
+	g := NewWithT(t)
+
+	testCluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "test-cluster",
+		},
+	}
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node-1",
+		},
+	}
+	testMachine := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         metav1.NamespaceDefault,
+			Name:              "test-machine",
+			Finalizers:        []string{clusterv1.MachineFinalizer},
+			DeletionTimestamp: &metav1.Time{Time: time.Now()},
+		},
+		Spec: clusterv1.MachineSpec{
+			ClusterName: "test-cluster",
+		},
+		Status: clusterv1.MachineStatus{
+			NodeRef: clusterv1.MachineNodeReference{
+				Name: "node-1",
+			},
+		},
+	}
+	cpMachine := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "cp1",
+			Labels: map[string]string{
+				clusterv1.ClusterNameLabel:         "test-cluster",
+				clusterv1.MachineControlPlaneLabel: "",
+			},
+		},
+		Spec: clusterv1.MachineSpec{
+			ClusterName: "test-cluster",
+		},
+		Status: clusterv1.MachineStatus{
+			NodeRef: clusterv1.MachineNodeReference{
+				Name: "cp1",
+			},
+		},
+	}
+
+	c := fake.NewClientBuilder().
+		WithObjects(testCluster, testMachine, cpMachine).
+		WithStatusSubresource(&clusterv1.Machine{}).
+		Build()
+	remoteClient := fake.NewClientBuilder().
+		WithIndex(&corev1.Pod{}, "spec.nodeName", podByNodeName).
+		WithObjects(node).
+		Build()
+
+	r := &Reconciler{
+		Client:       c,
+		ClusterCache: clustercache.NewFakeClusterCache(remoteClient, client.ObjectKeyFromObject(testCluster)),
+		recorder:     record.NewFakeRecorder(10),
+		drainBudget:  &drain.Budget{MaxConcurrentDrains: 1},
+	}
+
+	// Another Machine of the Cluster is draining its Node.
+	acquired, _ := r.drainBudget.TryAcquire(client.ObjectKeyFromObject(testCluster), "other-machine", false)
+	g.Expect(acquired).To(BeTrue())
+
+	s := &scope{
+		cluster:                   testCluster,
+		machine:                   testMachine,
+		infraMachineIsNotFound:    true,
+		bootstrapConfigIsNotFound: true,
+	}
+	res, err := r.reconcileDelete(ctx, s)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(res).To(BeComparableTo(ctrl.Result{RequeueAfter: drainRetryInterval}))
+	g.Expect(s.deletingReason).To(Equal(clusterv1.MachineDeletingDrainingNodeReason))
+	g.Expect(s.deletingMessage).To(Equal("Waiting for drain budget, 1 Machine is already draining Nodes in the Cluster: other-machine"))
+	g.Expect(testMachine.Status.Deletion).To(BeNil())
+
+	// After the other Machine released the drain budget, the Node is drained.
+	r.drainBudget.Release(client.ObjectKeyFromObject(testCluster), "other-machine")
+	s = &scope{
+		cluster:                   testCluster,
+		machine:                   testMachine,
+		infraMachineIsNotFound:    true,
+		bootstrapConfigIsNotFound: true,
+	}
+	_, err = r.reconcileDelete(ctx, s)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(testMachine.Status.Deletion).ToNot(BeNil())
+	g.Expect(testMachine.Status.Deletion.NodeDrainStartTime.IsZero()).To(BeFalse())
+	g.Expect(v1beta1conditions.IsTrue(testMachine, clusterv1.DrainingSucceededV1Beta1Condition)).To(BeTrue())
+
+	// The drain budget is released after the drain is completed.
+	acquired, _ = r.drainBudget.TryAcquire(client.ObjectKeyFromObject(testCluster), "other-machine", false)
+	g.Expect(acquired).To(BeTrue())
+}
+
 func TestDrainNode_withCaching(t *testing.T) {
 	g := NewWithT(t)
 
@@ -1984,6 +2087,243 @@ func TestDrainNode_withCaching(t *testing.T) {
 	)
 }
 
+func TestDrainNode_withMachineDrainRuleTimeout(t *testing.T) {
+	g := NewWithT(t)
+
+	testCluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "test-cluster",
+		},
+	}
+	testMachine := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "test-machine",
+		},
+		Status: clusterv1.MachineStatus{
+			NodeRef: clusterv1.MachineNodeReference{
+				Name: "node-1",
+			},
+			Deletion: &clusterv1.MachineDeletionStatus{
+				NodeDrainStartTime: metav1.Now(),
+			},
+		},
+	}
+	mdr := &clusterv1.MachineDrainRule{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "mdr-timeout",
+		},
+		Spec: clusterv1.MachineDrainRuleSpec{
+			Drain: clusterv1.MachineDrainRuleDrainConfig{
+				Behavior: clusterv1.MachineDrainRuleDrainBehaviorDrain,
+				Timeout: clusterv1.MachineDrainRuleDrainTimeout{
+					Seconds:  ptr.To[int32](60),
+					Behavior: clusterv1.MachineDrainRuleDrainTimeoutBehaviorSkip,
+				},
+			},
+		},
+	}
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node-1",
+		},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pod-stuck-terminating",
+			Namespace: "test-namespace",
+			Finalizers: []string{
+				// Add a finalizer so the Pod doesn't go away after eviction.
+				"cluster.x-k8s.io/block",
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					Kind:       "Deployment",
+					Controller: ptr.To(true),
+				},
+			},
+		},
+		Spec: corev1.PodSpec{
+			NodeName: "node-1",
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+		},
+	}
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-namespace",
+			Labels: map[string]string{
+				"kubernetes.io/metadata.name": "test-namespace",
+			},
+		},
+	}
+
+	c := fake.NewClientBuilder().
+		WithObjects(testCluster, testMachine, mdr).
+		Build()
+	remoteClient := fake.NewClientBuilder().
+		WithIndex(&corev1.Pod{}, "spec.nodeName", podByNodeName).
+		WithObjects(node, pod, ns).
+		Build()
+
+	r := &Reconciler{
+		Client:       c,
+		ClusterCache: clustercache.NewFakeClusterCache(remoteClient, client.ObjectKeyFromObject(testCluster)),
+		controller:   capicontrollerutil.NewFakeController(),
+	}
+
+	s := &scope{
+		cluster: testCluster,
+		machine: testMachine,
+	}
+
+	// The first reconcile will evict the Pod and start the drain group with order 0.
+	res, err := r.drainNode(ctx, s)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(res).To(BeComparableTo(ctrl.Result{RequeueAfter: drainRetryInterval}))
+	g.Expect(testMachine.Status.Deletion.NodeDrainGroups).To(HaveLen(1))
+	g.Expect(testMachine.Status.Deletion.NodeDrainGroups[0].Order).To(Equal(ptr.To[int32](0)))
+
+	// The Pod is stuck terminating, as long as the timeout of the MachineDrainRule is not reached the drain is not completed.
+	res, err = r.drainNode(ctx, s)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(res).To(BeComparableTo(ctrl.Result{RequeueAfter: drainRetryInterval}))
+	g.Expect(s.deletingMessage).To(ContainSubstring("deletionTimestamp set, but still not removed from the Node"))
+
+	// After the timeout of the MachineDrainRule is reached, the Pod is skipped and the drain is completed.
+	testMachine.Status.Deletion.NodeDrainGroups[0].StartTime = metav1.NewTime(time.Now().Add(-2 * time.Minute))
+	res, err = r.drainNode(ctx, s)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(res).To(BeComparableTo(ctrl.Result{}))
+}
+
+func TestDrainNode_withMachineDrainRuleEvictionFallback(t *testing.T) {
+	g := NewWithT(t)
+
+	testCluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "test-cluster",
+		},
+	}
+	testMachine := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "test-machine",
+		},
+		Status: clusterv1.MachineStatus{
+			NodeRef: clusterv1.MachineNodeReference{
+				Name: "node-1",
+			},
+			Deletion: &clusterv1.MachineDeletionStatus{
+				NodeDrainStartTime: metav1.Now(),
+			},
+		},
+	}
+	mdr := &clusterv1.MachineDrainRule{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "mdr-eviction-fallback",
+		},
+		Spec: clusterv1.MachineDrainRuleSpec{
+			Drain: clusterv1.MachineDrainRuleDrainConfig{
+				Behavior: clusterv1.MachineDrainRuleDrainBehaviorDrain,
+				EvictionFallback: clusterv1.MachineDrainRuleEvictionFallback{
+					DeleteAfterSeconds: ptr.To[int32](60),
+				},
+			},
+		},
+	}
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node-1",
+		},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pod-blocked-by-pdb",
+			Namespace: "test-namespace",
+			Finalizers: []string{
+				// Add a finalizer so the Pod doesn't go away after deletion.
+				"cluster.x-k8s.io/block",
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					Kind:       "Deployment",
+					Controller: ptr.To(true),
+				},
+			},
+		},
+		Spec: corev1.PodSpec{
+			NodeName: "node-1",
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+		},
+	}
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-namespace",
+			Labels: map[string]string{
+				"kubernetes.io/metadata.name": "test-namespace",
+			},
+		},
+	}
+
+	c := fake.NewClientBuilder().
+		WithObjects(testCluster, testMachine, mdr).
+		Build()
+	remoteClient := fake.NewClientBuilder().
+		WithIndex(&corev1.Pod{}, "spec.nodeName", podByNodeName).
+		WithObjects(node, pod, ns).
+		WithInterceptorFuncs(interceptor.Funcs{
+			SubResourceCreate: func(_ context.Context, _ client.Client, _ string, _ client.Object, _ client.Object, _ ...client.SubResourceCreateOption) error {
+				// Evictions are always blocked by a PodDisruptionBudget.
+				return apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 0)
+			},
+		}).
+		Build()
+
+	recorder := record.NewFakeRecorder(10)
+	r := &Reconciler{
+		Client:                 c,
+		ClusterCache:           clustercache.NewFakeClusterCache(remoteClient, client.ObjectKeyFromObject(testCluster)),
+		controller:             capicontrollerutil.NewFakeController(),
+		recorder:               recorder,
+		evictionBlockedTracker: &drain.EvictionBlockedTracker{},
+	}
+
+	s := &scope{
+		cluster: testCluster,
+		machine: testMachine,
+	}
+
+	// The first reconcile fails to evict the Pod, the deadline of the eviction fallback is not reached yet.
+	res, err := r.drainNode(ctx, s)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(res).To(BeComparableTo(ctrl.Result{RequeueAfter: drainRetryInterval}))
+	g.Expect(s.deletingMessage).To(ContainSubstring("cannot evict pod as it would violate the pod's disruption budget"))
+	g.Expect(recorder.Events).To(BeEmpty())
+
+	// After the deadline of the eviction fallback is reached, the Pod is deleted.
+	// Note: Simulate that the eviction of the Pod has been blocked for the first time 2m ago.
+	gotPod := &corev1.Pod{}
+	g.Expect(remoteClient.Get(ctx, client.ObjectKeyFromObject(pod), gotPod)).To(Succeed())
+	r.evictionBlockedTracker = &drain.EvictionBlockedTracker{}
+	r.evictionBlockedTracker.Blocked(gotPod, time.Now().Add(-2*time.Minute))
+	res, err = r.drainNode(ctx, s)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(res).To(BeComparableTo(ctrl.Result{RequeueAfter: drainRetryInterval}))
+	g.Expect(s.deletingMessage).To(ContainSubstring("deletionTimestamp set, but still not removed from the Node"))
+	g.Expect(recorder.Events).To(Receive(ContainSubstring("PodsDeletedAfterEvictionBlocked")))
+
+	g.Expect(remoteClient.Get(ctx, client.ObjectKeyFromObject(pod), gotPod)).To(Succeed())
+	g.Expect(gotPod.DeletionTimestamp.IsZero()).To(BeFalse())
+}
+
 func TestIsNodeVolumeDetachingAllowed(t *testing.T) {
 	testCluster := &clusterv1.Cluster{
 		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "test-cluster"},
@@ -317,6 +317,10 @@ func (r *Reconciler) reconcile(ctx context.Context, s *scope) error {
 		return r.rolloutOnDelete(ctx, md, s.machineSets, s.machines, templateExists)
 	}
 
+	if md.Spec.Rollout.Strategy.Type == clusterv1.CanaryMachineDeploymentStrategyType {
+		return r.rolloutCanary(ctx, md, s.machineSets, s.machines, templateExists)
+	}
+
 	return errors.Errorf("unexpected deployment strategy type: %s", md.Spec.Rollout.Strategy.Type)
 }
 
@@ -0,0 +1,222 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinedeployment
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/internal/controllers/machinedeployment/mdutil"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/collections"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// rolloutCanary reconcile machine sets controlled by a MachineDeployment that is using the Canary strategy.
+func (r *Reconciler) rolloutCanary(ctx context.Context, md *clusterv1.MachineDeployment, msList []*clusterv1.MachineSet, machines collections.Machines, templateExists bool) error {
+	planner := newRolloutPlanner(r.Client, r.RuntimeClient, r.canUpdateMachineSetCache)
+	if err := planner.init(ctx, md, msList, machines.UnsortedList(), true, templateExists); err != nil {
+		return err
+	}
+
+	if err := planner.planCanary(ctx); err != nil {
+		return err
+	}
+
+	if err := r.createOrUpdateMachineSetsAndSyncMachineDeploymentRevision(ctx, planner); err != nil {
+		return err
+	}
+
+	newMS := planner.newMS
+	oldMSs := planner.oldMSs
+	allMSs := append(oldMSs, newMS)
+
+	if err := r.syncDeploymentStatus(allMSs, newMS, md); err != nil {
+		return err
+	}
+
+	if mdutil.DeploymentComplete(md, &md.Status) {
+		if err := r.cleanupDeployment(ctx, oldMSs, md); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// planCanary determine how to proceed with the rollout when using the Canary strategy if the system is not yet at the desired state.
+// Note: A canary rollout is a rolling update where the new MachineSet cannot be scaled up above the current canary step.
+func (p *rolloutPlanner) planCanary(ctx context.Context) error {
+	// Adjust the replica count for the newMS after a move operation has been completed.
+	p.reconcileReplicasPendingAcknowledgeMove(ctx)
+
+	// Scale up, if we can.
+	if err := p.reconcileNewMachineSet(ctx); err != nil {
+		return err
+	}
+
+	// Scale down, if we can.
+	if err := p.reconcileOldMachineSetsRollingUpdate(ctx); err != nil {
+		return err
+	}
+
+	// Limit scale up and scale down to the current canary step.
+	// Note: This func must be called after computing scale up/down intent for all the MachineSets, but before
+	// computing in-place update intent, so in-place updates are limited to the current canary step too.
+	p.reconcileCanaryStep(ctx)
+
+	// Ensures CAPI rolls out changes by performing in-place updates whenever possible.
+	if err := p.reconcileInPlaceUpdateIntent(ctx); err != nil {
+		return err
+	}
+
+	// This func tries to detect and address the case when a rollout is not making progress because both scaling down and scaling up are blocked.
+	// See planRollingUpdate for more details.
+	p.reconcileDeadlockBreaker(ctx)
+	return nil
+}
+
+// reconcileCanaryStep limits scale up intent for the newMS and scale down intent for the oldMSs to the current canary step.
+//
+// The current canary step is the first step with more replicas than the replicas on the newMS passing the analysis;
+// as a consequence, the rollout proceeds to the next step only when all the replicas for the current step pass the analysis.
+// NOTE: The current canary step is computed from the current state of the system only, so the rollout never goes back
+// to a previous step e.g. when a new Machine stops passing the analysis; instead the rollout will stop at the current step.
+func (p *rolloutPlanner) reconcileCanaryStep(ctx context.Context) {
+	log := ctrl.LoggerFrom(ctx)
+
+	// If there are no replicas on the old MS, there is no rollout in progress (e.g. the MachineDeployment is scaling up or down).
+	if mdutil.GetReplicaCountForMachineSets(p.oldMSs) == 0 {
+		return
+	}
+
+	replicas := ptr.Deref(p.md.Spec.Replicas, 0)
+	passingReplicas := p.canaryAnalysisPassingReplicas()
+
+	step := int32(0)
+	for _, stepReplicas := range canarySteps(p.md) {
+		step = stepReplicas
+		if passingReplicas < stepReplicas {
+			break
+		}
+	}
+
+	// Never scale down the newMS because of the canary step (the canary step only limits scale up).
+	step = max(step, ptr.Deref(p.newMS.Spec.Replicas, 0))
+
+	// Limit scale up of the newMS to the current canary step.
+	if scaleIntent, ok := p.scaleIntents[p.newMS.Name]; ok && scaleIntent > step {
+		p.addNote(p.newMS, "scale up limited by canary step %d replicas, %d replicas passed analysis", step, passingReplicas)
+		log.V(5).Info(fmt.Sprintf("Revisited scale up intent for MachineSet %s to %d replicas to respect the current canary step", p.newMS.Name, step), "MachineSet", klog.KObj(p.newMS))
+		if step == ptr.Deref(p.newMS.Spec.Replicas, 0) {
+			delete(p.scaleIntents, p.newMS.Name)
+		} else {
+			p.scaleIntents[p.newMS.Name] = step
+		}
+	}
+
+	// Limit scale down of the oldMSs so the overall number of replicas does not drop below spec.replicas
+	// when the newMS cannot scale up to compensate replicas deleted from the oldMSs.
+	minOldReplicas := max(replicas-step, 0)
+	oldReplicas := int32(0)
+	for _, oldMS := range p.oldMSs {
+		oldReplicas += p.scaleIntentOrReplicas(oldMS)
+	}
+	for _, oldMS := range p.oldMSs {
+		if oldReplicas >= minOldReplicas {
+			break
+		}
+		scaleIntent, ok := p.scaleIntents[oldMS.Name]
+		if !ok {
+			continue
+		}
+		restore := min(ptr.Deref(oldMS.Spec.Replicas, 0)-scaleIntent, minOldReplicas-oldReplicas)
+		if restore <= 0 {
+			continue
+		}
+		newScaleIntent := scaleIntent + restore
+		oldReplicas += restore
+		p.addNote(oldMS, "scale down limited by canary step %d replicas, %d replicas passed analysis", step, passingReplicas)
+		log.V(5).Info(fmt.Sprintf("Revisited scale down intent for MachineSet %s to %d replicas to respect the current canary step", oldMS.Name, newScaleIntent), "MachineSet", klog.KObj(oldMS))
+		if newScaleIntent == ptr.Deref(oldMS.Spec.Replicas, 0) {
+			delete(p.scaleIntents, oldMS.Name)
+		} else {
+			p.scaleIntents[oldMS.Name] = newScaleIntent
+		}
+	}
+}
+
+// canaryAnalysisPassingReplicas returns the number of replicas on the newMS passing the canary analysis,
+// which requires a Machine to be available and to have all the analysis conditions set to true.
+func (p *rolloutPlanner) canaryAnalysisPassingReplicas() int32 {
+	passingReplicas := int32(0)
+	for _, m := range p.machines {
+		if !util.IsControlledBy(m, p.newMS, clusterv1.GroupVersion.WithKind("MachineSet").GroupKind()) {
+			continue
+		}
+		if !m.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if !conditions.IsTrue(m, clusterv1.MachineAvailableCondition) {
+			continue
+		}
+		passing := true
+		for _, conditionType := range p.md.Spec.Rollout.Strategy.Canary.Analysis.Conditions {
+			if !conditions.IsTrue(m, conditionType) {
+				passing = false
+				break
+			}
+		}
+		if passing {
+			passingReplicas++
+		}
+	}
+	return passingReplicas
+}
+
+// scaleIntentOrReplicas returns the scale intent for a MachineSet, if any, otherwise its spec.replicas.
+func (p *rolloutPlanner) scaleIntentOrReplicas(ms *clusterv1.MachineSet) int32 {
+	if scaleIntent, ok := p.scaleIntents[ms.Name]; ok {
+		return scaleIntent
+	}
+	return ptr.Deref(ms.Spec.Replicas, 0)
+}
+
+// canarySteps returns the number of replicas for each canary step, in increasing order.
+// Note: The first step is for the canary replicas, and the last step is always for spec.replicas.
+func canarySteps(md *clusterv1.MachineDeployment) []int32 {
+	replicas := ptr.Deref(md.Spec.Replicas, 0)
+	canary := md.Spec.Rollout.Strategy.Canary
+
+	steps := []int32{min(ptr.Deref(canary.Replicas, 1), replicas)}
+	for _, percentage := range canary.Steps {
+		// Note: Round up, so each step always rolls out at least one replica.
+		stepReplicas := min((replicas*percentage+99)/100, replicas)
+		if stepReplicas > steps[len(steps)-1] {
+			steps = append(steps, stepReplicas)
+		}
+	}
+	if steps[len(steps)-1] < replicas {
+		steps = append(steps, replicas)
+	}
+	return steps
+}
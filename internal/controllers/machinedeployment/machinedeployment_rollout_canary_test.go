@@ -0,0 +1,194 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinedeployment
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+)
+
+func Test_reconcileCanaryStep(t *testing.T) {
+	var ctx = context.Background()
+
+	available := withCondition(metav1.Condition{Type: clusterv1.MachineAvailableCondition, Status: metav1.ConditionTrue})
+	healthy := withCondition(metav1.Condition{Type: "Healthy", Status: metav1.ConditionTrue})
+	notHealthy := withCondition(metav1.Condition{Type: "Healthy", Status: metav1.ConditionFalse})
+
+	tests := []struct {
+		name              string
+		md                *clusterv1.MachineDeployment
+		scaleIntent       map[string]int32
+		newMS             *clusterv1.MachineSet
+		oldMSs            []*clusterv1.MachineSet
+		machines          []*clusterv1.Machine
+		expectScaleIntent map[string]int32
+	}{
+		{
+			name:        "no op if there are no replicas on old machinesets",
+			md:          createMD("v2", 3, withCanaryStrategy(1, 0, 1, nil)),
+			scaleIntent: map[string]int32{},
+			newMS:       createMS("ms2", "v2", 3),
+			oldMSs: []*clusterv1.MachineSet{
+				createMS("ms1", "v1", 0),
+			},
+			expectScaleIntent: map[string]int32{},
+		},
+		{
+			name:        "limit scale up of the new MachineSet to canary replicas",
+			md:          createMD("v2", 6, withCanaryStrategy(3, 0, 2, nil)),
+			scaleIntent: map[string]int32{"ms2": 3},
+			newMS:       createMS("ms2", "v2", 0),
+			oldMSs: []*clusterv1.MachineSet{
+				createMS("ms1", "v1", 6),
+			},
+			expectScaleIntent: map[string]int32{"ms2": 2},
+		},
+		{
+			name:        "limit scale down of old MachineSets while canary replicas do not pass analysis",
+			md:          createMD("v2", 3, withCanaryStrategy(1, 1, 1, nil, "Healthy")),
+			scaleIntent: map[string]int32{"ms1": 1, "ms2": 2},
+			newMS:       createMS("ms2", "v2", 1),
+			oldMSs: []*clusterv1.MachineSet{
+				createMS("ms1", "v1", 3),
+			},
+			machines: []*clusterv1.Machine{
+				createM("m4", "ms2", "v2", available, notHealthy),
+			},
+			expectScaleIntent: map[string]int32{"ms1": 2},
+		},
+		{
+			name:        "proceed to the next step when canary replicas pass analysis",
+			md:          createMD("v2", 4, withCanaryStrategy(3, 0, 1, []int32{50}, "Healthy")),
+			scaleIntent: map[string]int32{"ms2": 4},
+			newMS:       createMS("ms2", "v2", 1),
+			oldMSs: []*clusterv1.MachineSet{
+				createMS("ms1", "v1", 3),
+			},
+			machines: []*clusterv1.Machine{
+				createM("m4", "ms2", "v2", available, healthy),
+			},
+			expectScaleIntent: map[string]int32{"ms2": 2},
+		},
+		{
+			name:        "proceed to spec.replicas when replicas for the last step pass analysis",
+			md:          createMD("v2", 4, withCanaryStrategy(3, 0, 1, []int32{50}, "Healthy")),
+			scaleIntent: map[string]int32{"ms2": 4, "ms1": 1},
+			newMS:       createMS("ms2", "v2", 2),
+			oldMSs: []*clusterv1.MachineSet{
+				createMS("ms1", "v1", 2),
+			},
+			machines: []*clusterv1.Machine{
+				createM("m4", "ms2", "v2", available, healthy),
+				createM("m5", "ms2", "v2", available, healthy),
+			},
+			expectScaleIntent: map[string]int32{"ms2": 4, "ms1": 1},
+		},
+		{
+			name:        "do not count unavailable or deleting replicas as passing analysis",
+			md:          createMD("v2", 4, withCanaryStrategy(3, 0, 1, []int32{50})),
+			scaleIntent: map[string]int32{"ms2": 4},
+			newMS:       createMS("ms2", "v2", 2),
+			oldMSs: []*clusterv1.MachineSet{
+				createMS("ms1", "v1", 2),
+			},
+			machines: []*clusterv1.Machine{
+				createM("m4", "ms2", "v2", available, withStaleDeletion()),
+				createM("m5", "ms2", "v2"),
+			},
+			// Note: the new MachineSet is never scaled down below its current replicas.
+			expectScaleIntent: map[string]int32{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			p := &rolloutPlanner{
+				md:           tt.md,
+				newMS:        tt.newMS,
+				oldMSs:       tt.oldMSs,
+				machines:     tt.machines,
+				scaleIntents: tt.scaleIntent,
+				notes:        make(map[string][]string),
+			}
+			p.reconcileCanaryStep(ctx)
+			g.Expect(p.scaleIntents).To(Equal(tt.expectScaleIntent), "unexpected scaleIntents")
+		})
+	}
+}
+
+func Test_canarySteps(t *testing.T) {
+	tests := []struct {
+		name     string
+		md       *clusterv1.MachineDeployment
+		expected []int32
+	}{
+		{
+			name:     "canary replicas then spec.replicas",
+			md:       createMD("v1", 10, withCanaryStrategy(1, 0, 1, nil)),
+			expected: []int32{1, 10},
+		},
+		{
+			name:     "canary replicas then percentage steps then spec.replicas",
+			md:       createMD("v1", 10, withCanaryStrategy(1, 0, 2, []int32{25, 50})),
+			expected: []int32{2, 3, 5, 10},
+		},
+		{
+			name:     "percentage steps not adding replicas are dropped",
+			md:       createMD("v1", 4, withCanaryStrategy(1, 0, 1, []int32{10, 25, 100})),
+			expected: []int32{1, 4},
+		},
+		{
+			name:     "canary replicas are limited to spec.replicas",
+			md:       createMD("v1", 3, withCanaryStrategy(1, 0, 5, nil)),
+			expected: []int32{3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			g.Expect(canarySteps(tt.md)).To(Equal(tt.expected))
+		})
+	}
+}
+
+func withCanaryStrategy(maxSurge, maxUnavailable, canaryReplicas int32, steps []int32, analysisConditions ...string) func(md *clusterv1.MachineDeployment) {
+	return func(md *clusterv1.MachineDeployment) {
+		md.Spec.Rollout.Strategy = clusterv1.MachineDeploymentRolloutStrategy{
+			Type: clusterv1.CanaryMachineDeploymentStrategyType,
+			RollingUpdate: clusterv1.MachineDeploymentRolloutStrategyRollingUpdate{
+				MaxSurge:       ptr.To(intstr.FromInt32(maxSurge)),
+				MaxUnavailable: ptr.To(intstr.FromInt32(maxUnavailable)),
+			},
+			Canary: clusterv1.MachineDeploymentRolloutStrategyCanary{
+				Replicas: ptr.To(canaryReplicas),
+				Steps:    steps,
+				Analysis: clusterv1.MachineDeploymentRolloutStrategyCanaryAnalysis{
+					Conditions: analysisConditions,
+				},
+			},
+		}
+	}
+}
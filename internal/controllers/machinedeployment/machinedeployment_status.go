@@ -558,6 +558,9 @@ func aggregateStaleMachines(machines collections.Machines) string {
 				if strings.Contains(deletingCondition.Message, "failed to evict Pod") {
 					delayReasons.Insert("Pod eviction errors")
 				}
+				if strings.Contains(deletingCondition.Message, "eviction rate limit reached") {
+					delayReasons.Insert("Pod eviction rate limit")
+				}
 				if strings.Contains(deletingCondition.Message, "waiting for completion") {
 					delayReasons.Insert("Pods not completed yet")
 				}
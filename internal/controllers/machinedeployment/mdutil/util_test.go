@@ -822,6 +822,16 @@ func TestNewMSNewReplicas(t *testing.T) {
 			expected:           4, // +2 (6 MachineDeployment spec.replicas + 3 maxSurge - 7 current Machines)
 			expectedNote:       "7 current Machines < 6 MachineDeployment spec.replicas + 3 maxSurge",
 		},
+		{
+			Name:               "Canary strategy scale up to deploymentReplicas",
+			strategyType:       clusterv1.CanaryMachineDeploymentStrategyType,
+			deploymentReplicas: 6,
+			maxSurge:           3,
+			oldMSReplicas:      5,
+			newMSReplicas:      2,
+			expected:           4, // +2 (6 MachineDeployment spec.replicas + 3 maxSurge - 7 current Machines)
+			expectedNote:       "7 current Machines < 6 MachineDeployment spec.replicas + 3 maxSurge",
+		},
 		{
 			Name:               "OnDeleteMachine strategy can not scale up",
 			strategyType:       clusterv1.OnDeleteMachineDeploymentStrategyType,
@@ -48,6 +48,7 @@ import (
 	"sigs.k8s.io/cluster-api/controllers/clustercache"
 	"sigs.k8s.io/cluster-api/controllers/external"
 	"sigs.k8s.io/cluster-api/controllers/noderefutil"
+	runtimeclient "sigs.k8s.io/cluster-api/exp/runtime/client"
 	"sigs.k8s.io/cluster-api/internal/contract"
 	"sigs.k8s.io/cluster-api/internal/controllers/machine"
 	"sigs.k8s.io/cluster-api/internal/hooks"
@@ -89,9 +90,10 @@ const (
 
 // Reconciler reconciles a MachineSet object.
 type Reconciler struct {
-	Client       client.Client
-	APIReader    client.Reader
-	ClusterCache clustercache.ClusterCache
+	Client        client.Client
+	APIReader     client.Reader
+	ClusterCache  clustercache.ClusterCache
+	RuntimeClient runtimeclient.Client
 
 	PreflightChecks sets.Set[clusterv1.MachineSetPreflightCheck]
 
@@ -797,7 +799,7 @@ func (r *Reconciler) createMachines(ctx context.Context, s *scope, machinesToAdd
 	ms := s.machineSet
 	cluster := s.cluster
 
-	preflightCheckErrMessages, err := r.runPreflightChecks(ctx, cluster, ms, "Scale up")
+	preflightCheckErrMessages, err := r.runPreflightChecks(ctx, cluster, ms, scaleUpAction, runtimehooksv1.BeforeMachineCreateOperationScaleUp)
 	if err != nil || len(preflightCheckErrMessages) > 0 {
 		if err != nil {
 			// If err is not nil use that as the preflightCheckErrMessage
@@ -907,7 +909,7 @@ func (r *Reconciler) deleteMachines(ctx context.Context, s *scope, machinesToDel
 	//   - Move old machines (m1, m2, m3)
 	// - Resulting new MS at this point has 4 replicas m1, m2, m3 (updating in place) and (m4).
 	// - The system scales down MS, and the system does this getting rid of m3 - the last replica that started in place.
-	deletePriorityFunc, err := getDeletePriorityFunc(ms)
+	deletePriorityFunc, err := r.getDeletePriorityFunc(ctx, s)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
@@ -968,7 +970,7 @@ func (r *Reconciler) startMoveMachines(ctx context.Context, s *scope, targetMSNa
 
 	// Sort to Move machine in deterministic and predictable order.
 	// Note: For convenience we sort machine using the ordering criteria defined in ms.Spec.Deletion.Order.
-	deletePriorityFunc, err := getDeletePriorityFunc(ms)
+	deletePriorityFunc, err := r.getDeletePriorityFunc(ctx, s)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
@@ -1604,7 +1606,7 @@ func (r *Reconciler) reconcileUnhealthyMachines(ctx context.Context, s *scope) (
 	}
 
 	// Run preflight checks.
-	preflightCheckErrMessages, err := r.runPreflightChecks(ctx, cluster, ms, "Machine remediation")
+	preflightCheckErrMessages, err := r.runPreflightChecks(ctx, cluster, ms, machineRemediationAction, runtimehooksv1.BeforeMachineCreateOperationRemediation)
 	if err != nil || len(preflightCheckErrMessages) > 0 {
 		if err != nil {
 			// If err is not nil use that as the preflightCheckErrMessage
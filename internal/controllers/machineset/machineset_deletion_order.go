@@ -17,14 +17,24 @@ limitations under the License.
 package machineset
 
 import (
+	"context"
+	"fmt"
 	"math"
 	"sort"
+	"strings"
 
 	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
+	"sigs.k8s.io/cluster-api/controllers/clustercache"
+	"sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/cluster-api/internal/controllers/machine/drain"
 	"sigs.k8s.io/cluster-api/internal/util/inplace"
+	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/conditions"
 )
 
@@ -121,6 +131,46 @@ func randomDeletionOrder(machine *clusterv1.Machine) deletePriority {
 	return couldDelete
 }
 
+// rankedDeletionOrder returns a deletePriorityFunc that maps the position of a Machine in a list of
+// ranked Machine names (e.g. returned by the RankMachinesForDeletion Runtime Extension) onto the 0-50 priority range.
+// Machines not included in the ranked list get the lowest priority.
+func rankedDeletionOrder(rankedMachineNames []string) deletePriorityFunc {
+	rank := make(map[string]int, len(rankedMachineNames))
+	for i, name := range rankedMachineNames {
+		if _, ok := rank[name]; !ok {
+			rank[name] = i
+		}
+	}
+
+	return func(machine *clusterv1.Machine) deletePriority {
+		// Deleting machines must go first, otherwise deletion code will delete more machines while previously deleted machines
+		// are still deleting.
+		if !machine.DeletionTimestamp.IsZero() {
+			return mustDelete
+		}
+		// If user expressed the intent to delete a machines, respect it by deleting this machine first when scaling down.
+		if _, ok := machine.Annotations[clusterv1.DeleteMachineAnnotation]; ok {
+			return shouldDeleteFirst
+		}
+		// If there is machine still updating in progress and the MS is scaling down, consider this machine next
+		// so the system avoids to complete unnecessary in-place updates (drop machines not at the desired state first).
+		if inplace.IsUpdateInProgress(machine) {
+			return shouldDelete
+		}
+		// If there are machines not healthy, get rid of them next, because this will unblock the rollout
+		// while respecting the maxUnhealthy requirement.
+		if !isMachineHealthy(machine) {
+			return betterDelete
+		}
+		i, ok := rank[machine.Name]
+		if !ok {
+			return mustNotDelete
+		}
+		n := float64(len(rankedMachineNames))
+		return deletePriority(float64(betterDelete) * (n - float64(i)) / (n + 1))
+	}
+}
+
 type sortableMachines struct {
 	machines []*clusterv1.Machine
 	priority deletePriorityFunc
@@ -159,7 +209,8 @@ func getMachinesToDeletePrioritized(filteredMachines []*clusterv1.Machine, diff
 	return sortable.machines[:diff]
 }
 
-func getDeletePriorityFunc(ms *clusterv1.MachineSet) (deletePriorityFunc, error) {
+func (r *Reconciler) getDeletePriorityFunc(ctx context.Context, s *scope) (deletePriorityFunc, error) {
+	ms := s.machineSet
 	// Map the Spec.Order value to the appropriate delete priority function
 	switch ms.Spec.Deletion.Order {
 	case clusterv1.RandomMachineSetDeletionOrder:
@@ -168,11 +219,139 @@ func getDeletePriorityFunc(ms *clusterv1.MachineSet) (deletePriorityFunc, error)
 		return newestDeletionOrder, nil
 	case clusterv1.OldestMachineSetDeletionOrder:
 		return oldestDeletionOrder, nil
+	case clusterv1.LeastDisruptiveMachineSetDeletionOrder:
+		rankedMachineNames, err := r.rankMachinesByDisruption(ctx, s.cluster, s.machines)
+		if err != nil {
+			return nil, err
+		}
+		return rankedDeletionOrder(rankedMachineNames), nil
+	case clusterv1.ExternalMachineSetDeletionOrder:
+		rankedMachineNames, err := r.rankMachinesForDeletion(ctx, ms, s.machines)
+		if err != nil {
+			return nil, err
+		}
+		return rankedDeletionOrder(rankedMachineNames), nil
 	case "":
 		return randomDeletionOrder, nil
 	default:
-		return nil, errors.Errorf("Unsupported deletion order %s. Must be one of 'Random', 'Newest', or 'Oldest'", ms.Spec.Deletion.Order)
+		return nil, errors.Errorf("Unsupported deletion order %s. Must be one of 'Random', 'Newest', 'Oldest', 'LeastDisruptive', or 'External'", ms.Spec.Deletion.Order)
+	}
+}
+
+// rankMachinesByDisruption ranks Machines by the disruption that draining their Nodes would cause in the workload cluster,
+// the Machine causing the lowest disruption goes first.
+// Note: Machines without a Node or for which it is not possible to compute the disruption are not ranked.
+func (r *Reconciler) rankMachinesByDisruption(ctx context.Context, cluster *clusterv1.Cluster, machines []*clusterv1.Machine) ([]string, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	remoteClient, err := r.ClusterCache.GetClient(ctx, util.ObjectKey(cluster))
+	if err != nil {
+		if errors.Is(err, clustercache.ErrClusterNotConnected) {
+			// Note: Do not block scale down if the workload cluster is not reachable, Machines are then picked by name.
+			log.V(5).Info(fmt.Sprintf("Unable to rank Machines by disruption, connection to the workload cluster is down: %v", err))
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to rank Machines by disruption")
+	}
+
+	drainer := &drain.Helper{
+		Client:       r.Client,
+		RemoteClient: remoteClient,
+	}
+
+	// Note: PodDisruptionBudgets are listed once for all the Machines, because they are not cached.
+	pdbs, err := drainer.ListPodDisruptionBudgets(ctx)
+	if err != nil {
+		// Note: Do not block scale down if it is not possible to compute the disruption, Machines are then picked by name.
+		log.V(5).Info(fmt.Sprintf("Unable to rank Machines by disruption: %v", err))
+		return nil, nil
+	}
+
+	type machineDisruption struct {
+		name       string
+		disruption drain.NodeDisruption
+	}
+	disruptions := []machineDisruption{}
+	for _, machine := range machines {
+		if !machine.Status.NodeRef.IsDefined() {
+			continue
+		}
+		disruption, err := drainer.GetNodeDisruption(ctx, cluster, machine, machine.Status.NodeRef.Name, pdbs)
+		if err != nil {
+			log.V(5).Info(fmt.Sprintf("Unable to compute disruption for Machine %s: %v", machine.Name, err), "Machine", klog.KObj(machine))
+			continue
+		}
+		disruptions = append(disruptions, machineDisruption{name: machine.Name, disruption: *disruption})
+	}
+
+	sort.SliceStable(disruptions, func(i, j int) bool {
+		if disruptions[i].disruption != disruptions[j].disruption {
+			return disruptions[i].disruption.Less(disruptions[j].disruption)
+		}
+		return disruptions[i].name < disruptions[j].name
+	})
+
+	rankedMachineNames := make([]string, 0, len(disruptions))
+	for _, d := range disruptions {
+		rankedMachineNames = append(rankedMachineNames, d.name)
+	}
+	return rankedMachineNames, nil
+}
+
+// rankMachinesForDeletion calls the RankMachinesForDeletion Runtime Extension to get the names of the Machines
+// ranked for deletion.
+func (r *Reconciler) rankMachinesForDeletion(ctx context.Context, ms *clusterv1.MachineSet, machines []*clusterv1.Machine) ([]string, error) {
+	if !feature.Gates.Enabled(feature.RuntimeSDK) || r.RuntimeClient == nil {
+		return nil, errors.Errorf("deletion order %s can be used only if the RuntimeSDK feature flag is enabled", clusterv1.ExternalMachineSetDeletionOrder)
+	}
+
+	extensionHandlers, err := r.RuntimeClient.GetAllExtensions(ctx, runtimehooksv1.RankMachinesForDeletion, ms)
+	if err != nil {
+		return nil, err
+	}
+	if len(extensionHandlers) == 0 {
+		return nil, errors.Errorf("deletion order %s requires a RankMachinesForDeletion hook, but no hook is registered", clusterv1.ExternalMachineSetDeletionOrder)
+	}
+	if len(extensionHandlers) > 1 {
+		return nil, errors.Errorf("found multiple RankMachinesForDeletion hooks (%s): only one hook is supported", strings.Join(extensionHandlers, ","))
+	}
+
+	req := &runtimehooksv1.RankMachinesForDeletionRequest{
+		MachineSet: *cleanupMachineSet(ms),
+		Machines:   make([]clusterv1.Machine, 0, len(machines)),
+	}
+	for _, machine := range machines {
+		req.Machines = append(req.Machines, *cleanupMachine(machine))
+	}
+	resp := &runtimehooksv1.RankMachinesForDeletionResponse{}
+	if err := r.RuntimeClient.CallExtension(ctx, runtimehooksv1.RankMachinesForDeletion, ms, extensionHandlers[0], req, resp); err != nil {
+		return nil, err
 	}
+	return resp.MachineNames, nil
+}
+
+func cleanupMachineSet(ms *clusterv1.MachineSet) *clusterv1.MachineSet {
+	ms = ms.DeepCopy()
+	// Set GVK because object is later marshalled with json.Marshal.
+	ms.SetGroupVersionKind(clusterv1.GroupVersion.WithKind("MachineSet"))
+	ms.SetManagedFields(nil)
+	return ms
+}
+
+func cleanupCluster(cluster *clusterv1.Cluster) *clusterv1.Cluster {
+	cluster = cluster.DeepCopy()
+	// Set GVK because object is later marshalled with json.Marshal.
+	cluster.SetGroupVersionKind(clusterv1.GroupVersion.WithKind("Cluster"))
+	cluster.SetManagedFields(nil)
+	return cluster
+}
+
+func cleanupMachine(machine *clusterv1.Machine) *clusterv1.Machine {
+	machine = machine.DeepCopy()
+	// Set GVK because object is later marshalled with json.Marshal.
+	machine.SetGroupVersionKind(clusterv1.GroupVersion.WithKind("Machine"))
+	machine.SetManagedFields(nil)
+	return machine
 }
 
 func isMachineHealthy(machine *clusterv1.Machine) bool {
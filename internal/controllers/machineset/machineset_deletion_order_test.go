@@ -21,10 +21,22 @@ import (
 	"testing"
 
 	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/rand"
+	utilfeature "k8s.io/component-base/featuregate/testing"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
+	"sigs.k8s.io/cluster-api/controllers/clustercache"
+	runtimecatalog "sigs.k8s.io/cluster-api/exp/runtime/catalog"
+	"sigs.k8s.io/cluster-api/feature"
+	fakeruntimeclient "sigs.k8s.io/cluster-api/internal/runtime/client/fake"
 )
 
 func TestMachineRandomDelete(t *testing.T) {
@@ -678,6 +690,284 @@ func TestMachineOldestDelete(t *testing.T) {
 	}
 }
 
+func TestMachineRankedDelete(t *testing.T) {
+	now := metav1.Now()
+	nodeRef := clusterv1.MachineNodeReference{Name: "some-node"}
+	machineA := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{Name: "machine-a"},
+		Status:     clusterv1.MachineStatus{NodeRef: nodeRef},
+	}
+	machineB := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{Name: "machine-b"},
+		Status:     clusterv1.MachineStatus{NodeRef: nodeRef},
+	}
+	machineC := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{Name: "machine-c"},
+		Status:     clusterv1.MachineStatus{NodeRef: nodeRef},
+	}
+	mustDeleteMachine := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{Name: "machine-z", DeletionTimestamp: &now},
+		Status:     clusterv1.MachineStatus{NodeRef: nodeRef},
+	}
+	deleteMachineWithMachineAnnotation := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{Name: "machine-y", Annotations: map[string]string{clusterv1.DeleteMachineAnnotation: ""}},
+		Status:     clusterv1.MachineStatus{NodeRef: nodeRef},
+	}
+	unhealthyMachine := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{Name: "machine-x"},
+		Status: clusterv1.MachineStatus{
+			NodeRef: nodeRef,
+			Conditions: []metav1.Condition{
+				{
+					Type:   clusterv1.MachineNodeHealthyCondition,
+					Status: metav1.ConditionFalse,
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		desc               string
+		diff               int
+		rankedMachineNames []string
+		machines           []*clusterv1.Machine
+		expect             []*clusterv1.Machine
+	}{
+		{
+			desc:               "func=rankedDeletionOrder, diff=1",
+			diff:               1,
+			rankedMachineNames: []string{"machine-b", "machine-c", "machine-a"},
+			machines:           []*clusterv1.Machine{machineA, machineB, machineC},
+			expect:             []*clusterv1.Machine{machineB},
+		},
+		{
+			desc:               "func=rankedDeletionOrder, diff=3",
+			diff:               3,
+			rankedMachineNames: []string{"machine-c", "machine-a", "machine-b"},
+			machines:           []*clusterv1.Machine{machineA, machineB, machineC},
+			expect:             []*clusterv1.Machine{machineC, machineA, machineB},
+		},
+		{
+			desc:               "func=rankedDeletionOrder, diff=3 (Machines not ranked go last)",
+			diff:               3,
+			rankedMachineNames: []string{"machine-c"},
+			machines:           []*clusterv1.Machine{machineA, machineB, machineC},
+			expect:             []*clusterv1.Machine{machineC, machineA, machineB},
+		},
+		{
+			desc:               "func=rankedDeletionOrder, diff=2 (unknown and duplicated Machines are ignored)",
+			diff:               2,
+			rankedMachineNames: []string{"machine-unknown", "machine-b", "machine-a", "machine-b"},
+			machines:           []*clusterv1.Machine{machineA, machineB, machineC},
+			expect:             []*clusterv1.Machine{machineB, machineA},
+		},
+		{
+			desc:               "func=rankedDeletionOrder, diff=4 (deleting, DeleteMachineAnnotation and unhealthy go first)",
+			diff:               4,
+			rankedMachineNames: []string{"machine-a", "machine-b", "machine-c"},
+			machines:           []*clusterv1.Machine{machineA, machineB, machineC, unhealthyMachine, deleteMachineWithMachineAnnotation, mustDeleteMachine},
+			expect:             []*clusterv1.Machine{mustDeleteMachine, deleteMachineWithMachineAnnotation, unhealthyMachine, machineA},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			g := NewWithT(t)
+
+			result := getMachinesToDeletePrioritized(test.machines, test.diff, rankedDeletionOrder(test.rankedMachineNames))
+			g.Expect(result).To(BeComparableTo(test.expect))
+		})
+	}
+}
+
+func TestGetDeletePriorityFuncExternal(t *testing.T) {
+	catalog := runtimecatalog.New()
+	_ = runtimehooksv1.AddToCatalog(catalog)
+	rankMachinesForDeletionGVH, err := catalog.GroupVersionHook(runtimehooksv1.RankMachinesForDeletion)
+	if err != nil {
+		panic("unable to compute GVH")
+	}
+
+	nodeRef := clusterv1.MachineNodeReference{Name: "some-node"}
+	machineA := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{Name: "machine-a"},
+		Status:     clusterv1.MachineStatus{NodeRef: nodeRef},
+	}
+	machineB := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{Name: "machine-b"},
+		Status:     clusterv1.MachineStatus{NodeRef: nodeRef},
+	}
+	ms := &clusterv1.MachineSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "ms", Namespace: "default"},
+		Spec: clusterv1.MachineSetSpec{
+			Deletion: clusterv1.MachineSetDeletionSpec{
+				Order: clusterv1.ExternalMachineSetDeletionOrder,
+			},
+		},
+	}
+
+	tests := []struct {
+		name                      string
+		runtimeSDKEnabled         bool
+		getAllExtensionsResponses map[runtimecatalog.GroupVersionHook][]string
+		callExtensionResponses    map[string]runtimehooksv1.ResponseObject
+		expect                    []*clusterv1.Machine
+		wantErr                   bool
+	}{
+		{
+			name:              "Fail if the RuntimeSDK feature flag is disabled",
+			runtimeSDKEnabled: false,
+			wantErr:           true,
+		},
+		{
+			name:              "Fail if no RankMachinesForDeletion hook is registered",
+			runtimeSDKEnabled: true,
+			wantErr:           true,
+		},
+		{
+			name:              "Fail if multiple RankMachinesForDeletion hooks are registered",
+			runtimeSDKEnabled: true,
+			getAllExtensionsResponses: map[runtimecatalog.GroupVersionHook][]string{
+				rankMachinesForDeletionGVH: {"test-extension-1", "test-extension-2"},
+			},
+			wantErr: true,
+		},
+		{
+			name:              "Fail if the RankMachinesForDeletion hook fails",
+			runtimeSDKEnabled: true,
+			getAllExtensionsResponses: map[runtimecatalog.GroupVersionHook][]string{
+				rankMachinesForDeletionGVH: {"test-extension"},
+			},
+			callExtensionResponses: map[string]runtimehooksv1.ResponseObject{
+				"test-extension": &runtimehooksv1.RankMachinesForDeletionResponse{
+					CommonResponse: runtimehooksv1.CommonResponse{Status: runtimehooksv1.ResponseStatusFailure},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:              "Prioritize Machines according to the ranking returned by the RankMachinesForDeletion hook",
+			runtimeSDKEnabled: true,
+			getAllExtensionsResponses: map[runtimecatalog.GroupVersionHook][]string{
+				rankMachinesForDeletionGVH: {"test-extension"},
+			},
+			callExtensionResponses: map[string]runtimehooksv1.ResponseObject{
+				"test-extension": &runtimehooksv1.RankMachinesForDeletionResponse{
+					CommonResponse: runtimehooksv1.CommonResponse{Status: runtimehooksv1.ResponseStatusSuccess},
+					MachineNames:   []string{"machine-b", "machine-a"},
+				},
+			},
+			expect: []*clusterv1.Machine{machineB, machineA},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.RuntimeSDK, tt.runtimeSDKEnabled)
+
+			g := NewWithT(t)
+
+			r := &Reconciler{
+				RuntimeClient: fakeruntimeclient.NewRuntimeClientBuilder().
+					WithCatalog(catalog).
+					WithGetAllExtensionResponses(tt.getAllExtensionsResponses).
+					WithCallExtensionResponses(tt.callExtensionResponses).
+					WithCallExtensionValidations(func(_ string, object runtimehooksv1.RequestObject) error {
+						req := object.(*runtimehooksv1.RankMachinesForDeletionRequest)
+						g.Expect(req.MachineSet.Name).To(Equal(ms.Name))
+						g.Expect(req.Machines).To(HaveLen(2))
+						return nil
+					}).
+					Build(),
+			}
+
+			machines := []*clusterv1.Machine{machineA, machineB}
+			deletePriorityFunc, err := r.getDeletePriorityFunc(ctx, &scope{machineSet: ms, machines: machines})
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(getMachinesToMovePrioritized(machines, deletePriorityFunc)).To(BeComparableTo(tt.expect))
+		})
+	}
+}
+
+func TestRankMachinesByDisruption(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: metav1.NamespaceDefault},
+	}
+	newMachine := func(name, nodeName string) *clusterv1.Machine {
+		m := &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: metav1.NamespaceDefault},
+		}
+		if nodeName != "" {
+			m.Status.NodeRef = clusterv1.MachineNodeReference{Name: nodeName}
+		}
+		return m
+	}
+	newPod := func(name, nodeName string, labels map[string]string, controllerKind string) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: metav1.NamespaceDefault,
+				Labels:    labels,
+				OwnerReferences: []metav1.OwnerReference{
+					{Kind: controllerKind, Name: "owner", Controller: ptr.To(true)},
+				},
+			},
+			Spec: corev1.PodSpec{NodeName: nodeName},
+		}
+		return pod
+	}
+
+	// node-1 is hosting a Pod protected by a PodDisruptionBudget.
+	pdbProtectedPod := newPod("pdb-protected-pod", "node-1", map[string]string{"app": "critical"}, "ReplicaSet")
+	// node-2 is hosting a Pod using local storage.
+	localStoragePod := newPod("local-storage-pod", "node-2", nil, "ReplicaSet")
+	localStoragePod.Spec.Volumes = []corev1.Volume{{Name: "data", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}}
+	// node-3 is hosting two Pods and a DaemonSet Pod (which is ignored).
+	pod1 := newPod("pod-1", "node-3", nil, "ReplicaSet")
+	pod2 := newPod("pod-2", "node-3", nil, "ReplicaSet")
+	daemonSetPod := newPod("daemonset-pod", "node-3", nil, "DaemonSet")
+	// node-4 is hosting a single Pod.
+	pod3 := newPod("pod-3", "node-4", nil, "ReplicaSet")
+
+	remoteClient := fake.NewClientBuilder().
+		WithObjects(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: metav1.NamespaceDefault}},
+			&appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "owner", Namespace: metav1.NamespaceDefault}},
+			&policyv1.PodDisruptionBudget{
+				ObjectMeta: metav1.ObjectMeta{Name: "pdb", Namespace: metav1.NamespaceDefault},
+				Spec: policyv1.PodDisruptionBudgetSpec{
+					Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "critical"}},
+				},
+			},
+			pdbProtectedPod, localStoragePod, pod1, pod2, daemonSetPod, pod3,
+		).
+		WithIndex(&corev1.Pod{}, "spec.nodeName", func(o client.Object) []string {
+			return []string{o.(*corev1.Pod).Spec.NodeName}
+		}).
+		Build()
+
+	r := &Reconciler{
+		Client:       fake.NewClientBuilder().WithScheme(fakeScheme).Build(),
+		ClusterCache: clustercache.NewFakeClusterCache(remoteClient, client.ObjectKeyFromObject(cluster)),
+	}
+
+	machines := []*clusterv1.Machine{
+		newMachine("machine-1", "node-1"),
+		newMachine("machine-2", "node-2"),
+		newMachine("machine-3", "node-3"),
+		newMachine("machine-4", "node-4"),
+		newMachine("machine-5", ""),
+	}
+	rankedMachineNames, err := r.rankMachinesByDisruption(ctx, cluster, machines)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(rankedMachineNames).To(Equal([]string{"machine-4", "machine-3", "machine-2", "machine-1"}))
+}
+
 func TestMachineDeleteMultipleSamePriority(t *testing.T) {
 	machines := make([]*clusterv1.Machine, 0, 10)
 	// All of these machines will have the same delete priority because they all have the "must delete" annotation.
@@ -33,7 +33,9 @@ import (
 
 	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
 	"sigs.k8s.io/cluster-api/controllers/external"
+	runtimecatalog "sigs.k8s.io/cluster-api/exp/runtime/catalog"
 	"sigs.k8s.io/cluster-api/feature"
 	"sigs.k8s.io/cluster-api/internal/contract"
 )
@@ -44,7 +46,17 @@ type preflightCheckErrorMessage *string
 // the preflight checks fail.
 const preflightFailedRequeueAfter = 15 * time.Second
 
-func (r *Reconciler) runPreflightChecks(ctx context.Context, cluster *clusterv1.Cluster, ms *clusterv1.MachineSet, action string) ([]string, error) {
+const (
+	// scaleUpAction is the action used when running preflight checks before scaling up.
+	scaleUpAction = "Scale up"
+
+	// machineRemediationAction is the action used when running preflight checks before remediating Machines.
+	machineRemediationAction = "Machine remediation"
+)
+
+// runPreflightChecks runs the preflight checks before performing action; operation is the operation Machines are
+// created for, which is passed to the BeforeMachineCreate hook.
+func (r *Reconciler) runPreflightChecks(ctx context.Context, cluster *clusterv1.Cluster, ms *clusterv1.MachineSet, action string, operation runtimehooksv1.BeforeMachineCreateOperation) ([]string, error) {
 	log := ctrl.LoggerFrom(ctx)
 	// If the MachineSetPreflightChecks feature gate is disabled return early.
 	if !feature.Gates.Enabled(feature.MachineSetPreflightChecks) {
@@ -57,6 +69,36 @@ func (r *Reconciler) runPreflightChecks(ctx context.Context, cluster *clusterv1.
 		return nil, nil
 	}
 
+	preflightCheckErrs, err := r.runControlPlanePreflightChecks(ctx, cluster, ms, action, skipped)
+	if err != nil {
+		return nil, err
+	}
+
+	// Run the BeforeMachineCreate hook preflight check.
+	// Note: Contrary to the other preflight checks, this check does not depend on the ControlPlane.
+	if shouldRun(r.PreflightChecks, skipped, clusterv1.MachineSetPreflightCheckBeforeMachineCreateHook) {
+		preflightCheckErr, err := r.beforeMachineCreateHookPreflightCheck(ctx, cluster, ms, operation)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to perform %q: failed to perform preflight checks", action)
+		}
+		if preflightCheckErr != nil {
+			preflightCheckErrs = append(preflightCheckErrs, preflightCheckErr)
+		}
+	}
+
+	if len(preflightCheckErrs) > 0 {
+		preflightCheckErrStrings := []string{}
+		for _, v := range preflightCheckErrs {
+			preflightCheckErrStrings = append(preflightCheckErrStrings, *v)
+		}
+		log.Info(fmt.Sprintf("%s on hold because %s. The operation will continue after the preflight check(s) pass", action, strings.Join(preflightCheckErrStrings, "; ")))
+		return preflightCheckErrStrings, nil
+	}
+	return nil, nil
+}
+
+// runControlPlanePreflightChecks runs the preflight checks that depend on the ControlPlane.
+func (r *Reconciler) runControlPlanePreflightChecks(ctx context.Context, cluster *clusterv1.Cluster, ms *clusterv1.MachineSet, action string, skipped sets.Set[clusterv1.MachineSetPreflightCheck]) ([]preflightCheckErrorMessage, error) {
 	// If the cluster does not have a control plane reference then there is nothing to do. Return early.
 	if !cluster.Spec.ControlPlaneRef.IsDefined() {
 		return nil, nil
@@ -130,15 +172,7 @@ func (r *Reconciler) runPreflightChecks(ctx context.Context, cluster *clusterv1.
 	if len(errList) > 0 {
 		return nil, errors.Wrapf(kerrors.NewAggregate(errList), "failed to perform %q: failed to perform preflight checks", action)
 	}
-	if len(preflightCheckErrs) > 0 {
-		preflightCheckErrStrings := []string{}
-		for _, v := range preflightCheckErrs {
-			preflightCheckErrStrings = append(preflightCheckErrStrings, *v)
-		}
-		log.Info(fmt.Sprintf("%s on hold because %s. The operation will continue after the preflight check(s) pass", action, strings.Join(preflightCheckErrStrings, "; ")))
-		return preflightCheckErrStrings, nil
-	}
-	return nil, nil
+	return preflightCheckErrs, nil
 }
 
 func shouldRun(preflightChecks, skippedPreflightChecks sets.Set[clusterv1.MachineSetPreflightCheck], preflightCheck clusterv1.MachineSetPreflightCheck) bool {
@@ -187,6 +221,33 @@ func (r *Reconciler) controlPlaneStablePreflightCheck(controlPlane *unstructured
 	return nil, nil
 }
 
+func (r *Reconciler) beforeMachineCreateHookPreflightCheck(ctx context.Context, cluster *clusterv1.Cluster, ms *clusterv1.MachineSet, operation runtimehooksv1.BeforeMachineCreateOperation) (preflightCheckErrorMessage, error) {
+	// If the RuntimeSDK feature gate is disabled there are no Runtime Extensions to call.
+	if !feature.Gates.Enabled(feature.RuntimeSDK) || r.RuntimeClient == nil {
+		return nil, nil
+	}
+
+	hookRequest := &runtimehooksv1.BeforeMachineCreateRequest{
+		Cluster:    *cleanupCluster(cluster),
+		MachineSet: *cleanupMachineSet(ms),
+		Operation:  operation,
+	}
+	hookResponse := &runtimehooksv1.BeforeMachineCreateResponse{}
+	if err := r.RuntimeClient.CallAllExtensions(ctx, runtimehooksv1.BeforeMachineCreate, ms, hookRequest, hookResponse); err != nil {
+		return nil, errors.Wrapf(err, "failed to perform %q preflight check", clusterv1.MachineSetPreflightCheckBeforeMachineCreateHook)
+	}
+
+	if hookResponse.RetryAfterSeconds != 0 {
+		message := hookResponse.GetMessage()
+		if message == "" {
+			message = fmt.Sprintf("Machine creation is blocked by %s hook", runtimecatalog.HookName(runtimehooksv1.BeforeMachineCreate))
+		}
+		return ptr.To(fmt.Sprintf("%s (%q preflight check failed)", message, clusterv1.MachineSetPreflightCheckBeforeMachineCreateHook)), nil
+	}
+
+	return nil, nil
+}
+
 func (r *Reconciler) kubernetesVersionPreflightCheck(cpSemver, msSemver semver.Version) preflightCheckErrorMessage {
 	// Check the Kubernetes version skew policy.
 	// => MS minor version cannot be greater than the Control Plane minor version.
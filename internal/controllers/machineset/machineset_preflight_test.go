@@ -29,8 +29,11 @@ import (
 
 	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
+	runtimecatalog "sigs.k8s.io/cluster-api/exp/runtime/catalog"
 	"sigs.k8s.io/cluster-api/feature"
 	"sigs.k8s.io/cluster-api/internal/contract"
+	fakeruntimeclient "sigs.k8s.io/cluster-api/internal/runtime/client/fake"
 	"sigs.k8s.io/cluster-api/util/test/builder"
 )
 
@@ -702,7 +705,7 @@ func TestMachineSetReconciler_runPreflightChecks(t *testing.T) {
 					Client:          fakeClient,
 					PreflightChecks: sets.Set[clusterv1.MachineSetPreflightCheck]{}.Insert(clusterv1.MachineSetPreflightCheckAll),
 				}
-				preflightCheckErrMessage, err := r.runPreflightChecks(ctx, tt.cluster, tt.machineSet, "")
+				preflightCheckErrMessage, err := r.runPreflightChecks(ctx, tt.cluster, tt.machineSet, "", runtimehooksv1.BeforeMachineCreateOperationScaleUp)
 				if tt.wantErr {
 					g.Expect(err).To(HaveOccurred())
 				} else {
@@ -713,6 +716,156 @@ func TestMachineSetReconciler_runPreflightChecks(t *testing.T) {
 		}
 	})
 
+	t.Run("should run the BeforeMachineCreate hook preflight check", func(t *testing.T) {
+		catalog := runtimecatalog.New()
+		_ = runtimehooksv1.AddToCatalog(catalog)
+		beforeMachineCreateGVH, err := catalog.GroupVersionHook(runtimehooksv1.BeforeMachineCreate)
+		if err != nil {
+			panic("unable to compute GVH")
+		}
+
+		// Note: The Cluster has no ControlPlane, the BeforeMachineCreate hook preflight check has to run anyway.
+		cluster := &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: ns,
+				Name:      "cluster1",
+			},
+		}
+		machineSet := &clusterv1.MachineSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: ns,
+				Name:      "ms1",
+			},
+		}
+		machineSetWithSkipAnnotation := machineSet.DeepCopy()
+		machineSetWithSkipAnnotation.Annotations = map[string]string{clusterv1.MachineSetSkipPreflightChecksAnnotation: string(clusterv1.MachineSetPreflightCheckBeforeMachineCreateHook)}
+
+		tests := []struct {
+			name              string
+			runtimeSDKEnabled bool
+			machineSet        *clusterv1.MachineSet
+			action            string
+			operation         runtimehooksv1.BeforeMachineCreateOperation
+			hookResponse      *runtimehooksv1.BeforeMachineCreateResponse
+			wantOperation     runtimehooksv1.BeforeMachineCreateOperation
+			wantMessages      []string
+			wantErr           bool
+		}{
+			{
+				name:              "should pass if the RuntimeSDK feature gate is disabled",
+				runtimeSDKEnabled: false,
+				machineSet:        machineSet,
+				action:            scaleUpAction,
+				operation:         runtimehooksv1.BeforeMachineCreateOperationScaleUp,
+				wantMessages:      nil,
+			},
+			{
+				name:              "should pass if the hook does not block",
+				runtimeSDKEnabled: true,
+				machineSet:        machineSet,
+				action:            scaleUpAction,
+				operation:         runtimehooksv1.BeforeMachineCreateOperationScaleUp,
+				hookResponse: &runtimehooksv1.BeforeMachineCreateResponse{
+					CommonRetryResponse: runtimehooksv1.CommonRetryResponse{
+						CommonResponse: runtimehooksv1.CommonResponse{Status: runtimehooksv1.ResponseStatusSuccess},
+					},
+				},
+				wantOperation: runtimehooksv1.BeforeMachineCreateOperationScaleUp,
+				wantMessages:  nil,
+			},
+			{
+				name:              "should fail if the hook blocks",
+				runtimeSDKEnabled: true,
+				machineSet:        machineSet,
+				action:            scaleUpAction,
+				operation:         runtimehooksv1.BeforeMachineCreateOperationScaleUp,
+				hookResponse: &runtimehooksv1.BeforeMachineCreateResponse{
+					CommonRetryResponse: runtimehooksv1.CommonRetryResponse{
+						CommonResponse:    runtimehooksv1.CommonResponse{Status: runtimehooksv1.ResponseStatusSuccess, Message: "Quota exceeded"},
+						RetryAfterSeconds: 30,
+					},
+				},
+				wantOperation: runtimehooksv1.BeforeMachineCreateOperationScaleUp,
+				wantMessages: []string{
+					"Quota exceeded (\"BeforeMachineCreateHook\" preflight check failed)",
+				},
+			},
+			{
+				name:              "should fail if the hook blocks Machine remediation without a message",
+				runtimeSDKEnabled: true,
+				machineSet:        machineSet,
+				action:            machineRemediationAction,
+				operation:         runtimehooksv1.BeforeMachineCreateOperationRemediation,
+				hookResponse: &runtimehooksv1.BeforeMachineCreateResponse{
+					CommonRetryResponse: runtimehooksv1.CommonRetryResponse{
+						CommonResponse:    runtimehooksv1.CommonResponse{Status: runtimehooksv1.ResponseStatusSuccess},
+						RetryAfterSeconds: 30,
+					},
+				},
+				wantOperation: runtimehooksv1.BeforeMachineCreateOperationRemediation,
+				wantMessages: []string{
+					"Machine creation is blocked by BeforeMachineCreate hook (\"BeforeMachineCreateHook\" preflight check failed)",
+				},
+			},
+			{
+				name:              "should pass if the hook blocks but the preflight check is skipped",
+				runtimeSDKEnabled: true,
+				machineSet:        machineSetWithSkipAnnotation,
+				action:            scaleUpAction,
+				operation:         runtimehooksv1.BeforeMachineCreateOperationScaleUp,
+				wantMessages:      nil,
+			},
+			{
+				name:              "should error if the hook fails",
+				runtimeSDKEnabled: true,
+				machineSet:        machineSet,
+				action:            scaleUpAction,
+				operation:         runtimehooksv1.BeforeMachineCreateOperationScaleUp,
+				hookResponse: &runtimehooksv1.BeforeMachineCreateResponse{
+					CommonRetryResponse: runtimehooksv1.CommonRetryResponse{
+						CommonResponse: runtimehooksv1.CommonResponse{Status: runtimehooksv1.ResponseStatusFailure},
+					},
+				},
+				wantOperation: runtimehooksv1.BeforeMachineCreateOperationScaleUp,
+				wantErr:       true,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.RuntimeSDK, tt.runtimeSDKEnabled)
+
+				g := NewWithT(t)
+				fakeRuntimeClient := fakeruntimeclient.NewRuntimeClientBuilder().
+					WithCatalog(catalog).
+					WithCallAllExtensionResponses(map[runtimecatalog.GroupVersionHook]runtimehooksv1.ResponseObject{
+						beforeMachineCreateGVH: tt.hookResponse,
+					}).
+					WithCallAllExtensionValidations(func(object runtimehooksv1.RequestObject) error {
+						req := object.(*runtimehooksv1.BeforeMachineCreateRequest)
+						g.Expect(req.Cluster.Name).To(Equal(cluster.Name))
+						g.Expect(req.MachineSet.Name).To(Equal(tt.machineSet.Name))
+						g.Expect(req.Operation).To(Equal(tt.wantOperation))
+						return nil
+					}).
+					Build()
+				r := &Reconciler{
+					Client:          fake.NewClientBuilder().Build(),
+					RuntimeClient:   fakeRuntimeClient,
+					PreflightChecks: sets.Set[clusterv1.MachineSetPreflightCheck]{}.Insert(clusterv1.MachineSetPreflightCheckAll),
+				}
+				preflightCheckErrMessage, err := r.runPreflightChecks(ctx, cluster, tt.machineSet, tt.action, tt.operation)
+				if tt.wantErr {
+					g.Expect(err).To(HaveOccurred())
+				} else {
+					g.Expect(err).ToNot(HaveOccurred())
+				}
+				g.Expect(preflightCheckErrMessage).To(BeComparableTo(tt.wantMessages))
+				g.Expect(fakeRuntimeClient.CallAllCount(runtimehooksv1.BeforeMachineCreate) > 0).To(Equal(tt.hookResponse != nil))
+			})
+		}
+	})
+
 	t.Run("should not run the preflight checks if the feature gate is disabled", func(t *testing.T) {
 		utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.MachineSetPreflightChecks, false)
 
@@ -744,7 +897,7 @@ func TestMachineSetReconciler_runPreflightChecks(t *testing.T) {
 		}
 		fakeClient := fake.NewClientBuilder().WithObjects(controlPlane).Build()
 		r := &Reconciler{Client: fakeClient}
-		messages, err := r.runPreflightChecks(ctx, cluster, machineSet, "")
+		messages, err := r.runPreflightChecks(ctx, cluster, machineSet, "", runtimehooksv1.BeforeMachineCreateOperationScaleUp)
 		g.Expect(err).ToNot(HaveOccurred())
 		g.Expect(messages).To(BeNil())
 	})
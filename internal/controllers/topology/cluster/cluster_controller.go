@@ -170,6 +170,48 @@ func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, opt
 	return nil
 }
 
+// SetupForDryRun prepares the Reconciler for computing the desired state of a Cluster
+// topology without a manager, e.g. to preview changes from clusterctl.
+func (r *Reconciler) SetupForDryRun() error {
+	if r.Client == nil || r.ClusterCache == nil {
+		return errors.New("Client and ClusterCache must not be nil")
+	}
+
+	var err error
+	r.hookCache = cache.New[cache.HookEntry](cache.HookCacheDefaultTTL)
+	r.desiredStateGenerator, err = desiredstate.NewGenerator(
+		r.Client,
+		r.ClusterCache,
+		r.RuntimeClient,
+		r.hookCache,
+		cache.New[desiredstate.GenerateUpgradePlanCacheEntry](10*time.Minute),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed creating desired state generator")
+	}
+	return nil
+}
+
+// ComputeDesiredState returns a scope with the blueprint, the current state and the desired state of a Cluster topology.
+// NOTE: Differently from Reconcile, this func does not call the BeforeClusterCreate hook and does not change any object.
+func (r *Reconciler) ComputeDesiredState(ctx context.Context, cluster *clusterv1.Cluster) (*scope.Scope, error) {
+	if !cluster.Spec.Topology.IsDefined() {
+		return nil, errors.Errorf("Cluster %s does not have a managed topology", klog.KObj(cluster))
+	}
+
+	s := scope.New(cluster)
+	if err := r.getBlueprintAndCurrentState(ctx, s); err != nil {
+		return nil, err
+	}
+
+	var err error
+	s.Desired, err = r.desiredStateGenerator.Generate(ctx, s)
+	if err != nil {
+		return nil, errors.Wrap(err, "error computing the desired state of the Cluster topology")
+	}
+	return s, nil
+}
+
 func clusterChangeIsRelevant(scheme *runtime.Scheme, logger logr.Logger) predicate.Funcs {
 	dropNotRelevant := func(cluster *clusterv1.Cluster) *clusterv1.Cluster {
 		c := cluster.DeepCopy()
@@ -329,44 +371,9 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 func (r *Reconciler) reconcile(ctx context.Context, s *scope.Scope) (ctrl.Result, error) {
 	var err error
 
-	// Get ClusterClass.
-	clusterClass := &clusterv1.ClusterClass{}
-	key := s.Current.Cluster.GetClassKey()
-	if err := r.Client.Get(ctx, key, clusterClass); err != nil {
-		return ctrl.Result{}, errors.Wrapf(err, "failed to retrieve ClusterClass %s", key)
-	}
-
-	s.Blueprint.ClusterClass = clusterClass
-	// If the ClusterClass `metadata.Generation` doesn't match the `status.ObservedGeneration` return as the ClusterClass
-	// is not up to date.
-	// Note: This doesn't require requeue as a change to ClusterClass observedGeneration will cause an additional reconcile
-	// in the Cluster.
-	if !conditions.Has(clusterClass, clusterv1.ClusterClassVariablesReadyCondition) ||
-		conditions.IsFalse(clusterClass, clusterv1.ClusterClassVariablesReadyCondition) {
-		return ctrl.Result{}, errors.Errorf("ClusterClass is not successfully reconciled: status of %s condition on ClusterClass must be \"True\"", clusterv1.ClusterClassVariablesReadyCondition)
-	}
-	if clusterClass.GetGeneration() != clusterClass.Status.ObservedGeneration {
-		return ctrl.Result{}, errors.Errorf("ClusterClass is not successfully reconciled: ClusterClass.status.observedGeneration must be %d, but is %d", clusterClass.GetGeneration(), clusterClass.Status.ObservedGeneration)
-	}
-
-	// Default and Validate the Cluster variables based on information from the ClusterClass.
-	// This step is needed as if the ClusterClass does not exist at Cluster creation some fields may not be defaulted or
-	// validated in the webhook.
-	if errs := webhooks.DefaultAndValidateVariables(ctx, s.Current.Cluster, nil, clusterClass); len(errs) > 0 {
-		return ctrl.Result{}, apierrors.NewInvalid(clusterv1.GroupVersion.WithKind("Cluster").GroupKind(), s.Current.Cluster.Name, errs)
-	}
-
-	// Gets the blueprint with the ClusterClass and the referenced templates
-	// and store it in the request scope.
-	s.Blueprint, err = r.getBlueprint(ctx, s.Current.Cluster, s.Blueprint.ClusterClass)
-	if err != nil {
-		return ctrl.Result{}, errors.Wrap(err, "error reading the ClusterClass")
-	}
-
-	// Gets the current state of the Cluster and store it in the request scope.
-	s.Current, err = r.getCurrentState(ctx, s)
-	if err != nil {
-		return ctrl.Result{}, errors.Wrap(err, "error reading current state of the Cluster topology")
+	// Gets the blueprint and the current state of the Cluster and store them in the request scope.
+	if err := r.getBlueprintAndCurrentState(ctx, s); err != nil {
+		return ctrl.Result{}, err
 	}
 
 	// The cluster topology is yet to be created. Call the BeforeClusterCreate hook before proceeding.
@@ -405,6 +412,52 @@ func (r *Reconciler) reconcile(ctx context.Context, s *scope.Scope) (ctrl.Result
 	return ctrl.Result{}, nil
 }
 
+// getBlueprintAndCurrentState gets the ClusterClass, the blueprint and the current state of a Cluster and stores them in the scope.
+func (r *Reconciler) getBlueprintAndCurrentState(ctx context.Context, s *scope.Scope) error {
+	var err error
+
+	// Get ClusterClass.
+	clusterClass := &clusterv1.ClusterClass{}
+	key := s.Current.Cluster.GetClassKey()
+	if err := r.Client.Get(ctx, key, clusterClass); err != nil {
+		return errors.Wrapf(err, "failed to retrieve ClusterClass %s", key)
+	}
+
+	s.Blueprint.ClusterClass = clusterClass
+	// If the ClusterClass `metadata.Generation` doesn't match the `status.ObservedGeneration` return as the ClusterClass
+	// is not up to date.
+	// Note: This doesn't require requeue as a change to ClusterClass observedGeneration will cause an additional reconcile
+	// in the Cluster.
+	if !conditions.Has(clusterClass, clusterv1.ClusterClassVariablesReadyCondition) ||
+		conditions.IsFalse(clusterClass, clusterv1.ClusterClassVariablesReadyCondition) {
+		return errors.Errorf("ClusterClass is not successfully reconciled: status of %s condition on ClusterClass must be \"True\"", clusterv1.ClusterClassVariablesReadyCondition)
+	}
+	if clusterClass.GetGeneration() != clusterClass.Status.ObservedGeneration {
+		return errors.Errorf("ClusterClass is not successfully reconciled: ClusterClass.status.observedGeneration must be %d, but is %d", clusterClass.GetGeneration(), clusterClass.Status.ObservedGeneration)
+	}
+
+	// Default and Validate the Cluster variables based on information from the ClusterClass.
+	// This step is needed as if the ClusterClass does not exist at Cluster creation some fields may not be defaulted or
+	// validated in the webhook.
+	if errs := webhooks.DefaultAndValidateVariables(ctx, s.Current.Cluster, nil, clusterClass); len(errs) > 0 {
+		return apierrors.NewInvalid(clusterv1.GroupVersion.WithKind("Cluster").GroupKind(), s.Current.Cluster.Name, errs)
+	}
+
+	// Gets the blueprint with the ClusterClass and the referenced templates
+	// and store it in the request scope.
+	s.Blueprint, err = r.getBlueprint(ctx, s.Current.Cluster, s.Blueprint.ClusterClass)
+	if err != nil {
+		return errors.Wrap(err, "error reading the ClusterClass")
+	}
+
+	// Gets the current state of the Cluster and store it in the request scope.
+	s.Current, err = r.getCurrentState(ctx, s)
+	if err != nil {
+		return errors.Wrap(err, "error reading current state of the Cluster topology")
+	}
+	return nil
+}
+
 // setupDynamicWatches create watches for InfrastructureCluster and ControlPlane CRs when they exist.
 func (r *Reconciler) setupDynamicWatches(ctx context.Context, s *scope.Scope) error {
 	scheme := r.Client.Scheme()
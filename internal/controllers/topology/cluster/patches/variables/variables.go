@@ -19,10 +19,8 @@ package variables
 
 import (
 	"encoding/json"
-	"maps"
 
 	"github.com/pkg/errors"
-	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/utils/ptr"
@@ -31,7 +29,7 @@ import (
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
 	"sigs.k8s.io/cluster-api/internal/contract"
-	"sigs.k8s.io/cluster-api/util/conversion"
+	"sigs.k8s.io/cluster-api/internal/topology/builtins"
 )
 
 // Global returns variables that apply to all the templates, including user provided variables
@@ -53,44 +51,7 @@ func Global(clusterTopology clusterv1.Topology, cluster *clusterv1.Cluster, patc
 
 	// Construct builtin variable.
 	builtin := runtimehooksv1.Builtins{
-		Cluster: &runtimehooksv1.ClusterBuiltins{
-			Name:      cluster.Name,
-			Namespace: cluster.Namespace,
-			UID:       cluster.UID,
-			Topology: &runtimehooksv1.ClusterTopologyBuiltins{
-				Version:        cluster.Spec.Topology.Version,
-				Class:          cluster.GetClassKey().Name,
-				ClassNamespace: cluster.GetClassKey().Namespace,
-				ClassRef: runtimehooksv1.ClusterTopologyClusterClassRefBuiltins{
-					Name:      cluster.GetClassKey().Name,
-					Namespace: cluster.GetClassKey().Namespace,
-				},
-			},
-		},
-	}
-	if cluster.Labels != nil || cluster.Annotations != nil {
-		builtin.Cluster.Metadata = &clusterv1beta1.ObjectMeta{
-			Labels:      cluster.Labels,
-			Annotations: cleanupAnnotations(cluster.Annotations),
-		}
-	}
-	if cluster.Spec.ClusterNetwork.ServiceDomain != "" {
-		if builtin.Cluster.Network == nil {
-			builtin.Cluster.Network = &runtimehooksv1.ClusterNetworkBuiltins{}
-		}
-		builtin.Cluster.Network.ServiceDomain = &cluster.Spec.ClusterNetwork.ServiceDomain
-	}
-	if cluster.Spec.ClusterNetwork.Services.CIDRBlocks != nil {
-		if builtin.Cluster.Network == nil {
-			builtin.Cluster.Network = &runtimehooksv1.ClusterNetworkBuiltins{}
-		}
-		builtin.Cluster.Network.Services = cluster.Spec.ClusterNetwork.Services.CIDRBlocks
-	}
-	if cluster.Spec.ClusterNetwork.Pods.CIDRBlocks != nil {
-		if builtin.Cluster.Network == nil {
-			builtin.Cluster.Network = &runtimehooksv1.ClusterNetworkBuiltins{}
-		}
-		builtin.Cluster.Network.Pods = cluster.Spec.ClusterNetwork.Pods.CIDRBlocks
+		Cluster: builtins.Cluster(cluster),
 	}
 
 	// Add builtin variables derived from the cluster object.
@@ -135,7 +96,7 @@ func ControlPlane(cpTopology *clusterv1.ControlPlaneTopology, cp, cpInfrastructu
 	}
 	if cp.GetLabels() != nil || cp.GetAnnotations() != nil {
 		builtin.ControlPlane.Metadata = &clusterv1beta1.ObjectMeta{
-			Annotations: cleanupAnnotations(cp.GetAnnotations()),
+			Annotations: builtins.CleanupAnnotations(cp.GetAnnotations()),
 			Labels:      cp.GetLabels(),
 		}
 	}
@@ -189,7 +150,7 @@ func MachineDeployment(mdTopology *clusterv1.MachineDeploymentTopology, md *clus
 	}
 	if md.Labels != nil || md.Annotations != nil {
 		builtin.MachineDeployment.Metadata = &clusterv1beta1.ObjectMeta{
-			Annotations: cleanupAnnotations(md.Annotations),
+			Annotations: builtins.CleanupAnnotations(md.Annotations),
 			Labels:      md.Labels,
 		}
 	}
@@ -243,7 +204,7 @@ func MachinePool(mpTopology *clusterv1.MachinePoolTopology, mp *clusterv1.Machin
 	}
 	if mp.Labels != nil || mp.Annotations != nil {
 		builtin.MachinePool.Metadata = &clusterv1beta1.ObjectMeta{
-			Annotations: cleanupAnnotations(mp.Annotations),
+			Annotations: builtins.CleanupAnnotations(mp.Annotations),
 			Labels:      mp.Labels,
 		}
 	}
@@ -283,15 +244,3 @@ func toVariable(name string, value interface{}) (*runtimehooksv1.Variable, error
 		Value: apiextensionsv1.JSON{Raw: marshalledValue},
 	}, nil
 }
-
-func cleanupAnnotations(annotations map[string]string) map[string]string {
-	if annotations == nil {
-		return nil
-	}
-
-	// Optimize size of GeneratePatchesRequest and ValidateTopologyRequest by not sending the last-applied annotation.
-	annotations = maps.Clone(annotations)
-	delete(annotations, corev1.LastAppliedConfigAnnotation)
-	delete(annotations, conversion.DataAnnotation)
-	return annotations
-}
@@ -0,0 +1,86 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package builtins calculates the values of builtin variables.
+package builtins
+
+import (
+	"maps"
+
+	corev1 "k8s.io/api/core/v1"
+
+	clusterv1beta1 "sigs.k8s.io/cluster-api/api/core/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
+	"sigs.k8s.io/cluster-api/util/conversion"
+)
+
+// Cluster returns the builtin variables for the Cluster object.
+func Cluster(cluster *clusterv1.Cluster) *runtimehooksv1.ClusterBuiltins {
+	builtin := &runtimehooksv1.ClusterBuiltins{
+		Name:      cluster.Name,
+		Namespace: cluster.Namespace,
+		UID:       cluster.UID,
+		Topology: &runtimehooksv1.ClusterTopologyBuiltins{
+			Version:        cluster.Spec.Topology.Version,
+			Class:          cluster.GetClassKey().Name,
+			ClassNamespace: cluster.GetClassKey().Namespace,
+			ClassRef: runtimehooksv1.ClusterTopologyClusterClassRefBuiltins{
+				Name:      cluster.GetClassKey().Name,
+				Namespace: cluster.GetClassKey().Namespace,
+			},
+		},
+	}
+	if cluster.Labels != nil || cluster.Annotations != nil {
+		builtin.Metadata = &clusterv1beta1.ObjectMeta{
+			Labels:      cluster.Labels,
+			Annotations: CleanupAnnotations(cluster.Annotations),
+		}
+	}
+	if cluster.Spec.ClusterNetwork.ServiceDomain != "" {
+		if builtin.Network == nil {
+			builtin.Network = &runtimehooksv1.ClusterNetworkBuiltins{}
+		}
+		builtin.Network.ServiceDomain = &cluster.Spec.ClusterNetwork.ServiceDomain
+	}
+	if cluster.Spec.ClusterNetwork.Services.CIDRBlocks != nil {
+		if builtin.Network == nil {
+			builtin.Network = &runtimehooksv1.ClusterNetworkBuiltins{}
+		}
+		builtin.Network.Services = cluster.Spec.ClusterNetwork.Services.CIDRBlocks
+	}
+	if cluster.Spec.ClusterNetwork.Pods.CIDRBlocks != nil {
+		if builtin.Network == nil {
+			builtin.Network = &runtimehooksv1.ClusterNetworkBuiltins{}
+		}
+		builtin.Network.Pods = cluster.Spec.ClusterNetwork.Pods.CIDRBlocks
+	}
+	return builtin
+}
+
+// CleanupAnnotations returns a copy of the annotations without the annotations that should not be
+// exposed in builtin variables.
+func CleanupAnnotations(annotations map[string]string) map[string]string {
+	if annotations == nil {
+		return nil
+	}
+
+	// Optimize size of GeneratePatchesRequest and ValidateTopologyRequest by not sending the last-applied annotation.
+	annotations = maps.Clone(annotations)
+	delete(annotations, corev1.LastAppliedConfigAnnotation)
+	delete(annotations, conversion.DataAnnotation)
+	return annotations
+}
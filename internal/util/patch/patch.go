@@ -39,7 +39,7 @@ import (
 )
 
 // ApplyPatchToTypedObject applies the patch to a typed obj.
-func ApplyPatchToTypedObject[T any](ctx context.Context, currentMachine *T, machinePath runtimehooksv1.Patch, patchPath string) error {
+func ApplyPatchToTypedObject[T any](ctx context.Context, currentMachine *T, machinePath runtimehooksv1.Patch, patchPaths ...string) error {
 	// Note: Machine needs special handling because it is not a runtime.RawExtension. Simply converting it here to
 	//       a runtime.RawExtension so we can avoid making the code in applyPatchToObject more complex.
 	currentMachineRaw, err := ConvertToRawExtension(currentMachine)
@@ -47,7 +47,7 @@ func ApplyPatchToTypedObject[T any](ctx context.Context, currentMachine *T, mach
 		return err
 	}
 
-	machineChanged, err := ApplyPatchToObject(ctx, &currentMachineRaw, machinePath, patchPath)
+	machineChanged, err := ApplyPatchToObject(ctx, &currentMachineRaw, machinePath, patchPaths...)
 	if err != nil {
 		return err
 	}
@@ -65,10 +65,10 @@ func ApplyPatchToTypedObject[T any](ctx context.Context, currentMachine *T, mach
 	return nil
 }
 
-// ApplyPatchToObject applies the patch to the obj.
+// ApplyPatchToObject applies the patch to the obj; only changes to the fields at patchPaths are picked up.
 // Note: This is following the same general structure that is used in the applyPatchToRequest func in
 // internal/controllers/topology/cluster/patches/engine.go.
-func ApplyPatchToObject(ctx context.Context, obj *runtime.RawExtension, patch runtimehooksv1.Patch, patchPath string) (objChanged bool, reterr error) {
+func ApplyPatchToObject(ctx context.Context, obj *runtime.RawExtension, patch runtimehooksv1.Patch, patchPaths ...string) (objChanged bool, reterr error) {
 	log := ctrl.LoggerFrom(ctx)
 
 	if patch.PatchType == "" {
@@ -124,7 +124,7 @@ func ApplyPatchToObject(ctx context.Context, obj *runtime.RawExtension, patch ru
 
 	// Overwrite the spec of obj with the spec of the patchedObject,
 	// to ensure that we only pick up changes to the spec.
-	if err := Patch(obj, patchedObject, patchPath); err != nil {
+	if err := Patch(obj, patchedObject, patchPaths...); err != nil {
 		return false, errors.Wrap(err, "failed to apply patch to object")
 	}
 
@@ -155,8 +155,8 @@ func ConvertToRawExtension(object any) (runtime.RawExtension, error) {
 	}, nil
 }
 
-// Patch overwrites spec in object with spec of patchedObjectBytes.
-func Patch(object *runtime.RawExtension, patchedObjectBytes []byte, patchPath string) error {
+// Patch overwrites spec in object with spec of patchedObjectBytes; spec is identified by one or more patchPaths.
+func Patch(object *runtime.RawExtension, patchedObjectBytes []byte, patchPaths ...string) error {
 	objectUnstructured, err := bytesToUnstructured(object.Raw)
 	if err != nil {
 		return errors.Wrap(err, "failed to convert object to Unstructured")
@@ -167,13 +167,15 @@ func Patch(object *runtime.RawExtension, patchedObjectBytes []byte, patchPath st
 	}
 
 	// Copy spec from patchedObjectUnstructured to objectUnstructured.
-	if err := CopySpec(CopySpecInput{
-		Src:          patchedObjectUnstructured,
-		Dest:         objectUnstructured,
-		SrcSpecPath:  patchPath,
-		DestSpecPath: patchPath,
-	}); err != nil {
-		return errors.Wrap(err, "failed to apply patch to object")
+	for _, patchPath := range patchPaths {
+		if err := CopySpec(CopySpecInput{
+			Src:          patchedObjectUnstructured,
+			Dest:         objectUnstructured,
+			SrcSpecPath:  patchPath,
+			DestSpecPath: patchPath,
+		}); err != nil {
+			return errors.Wrap(err, "failed to apply patch to object")
+		}
 	}
 
 	// Marshal objectUnstructured and store it in object.
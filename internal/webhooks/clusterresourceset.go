@@ -25,6 +25,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -116,6 +117,29 @@ func (webhook *ClusterResourceSet) validate(oldCRS, newCRS *addonsv1.ClusterReso
 		)
 	}
 
+	if ptr.Deref(newCRS.Spec.Prune, false) && newCRS.Spec.Strategy != string(addonsv1.ClusterResourceSetStrategyReconcile) {
+		allErrs = append(
+			allErrs,
+			field.Forbidden(field.NewPath("spec", "prune"), fmt.Sprintf("prune can only be set if strategy is %q", addonsv1.ClusterResourceSetStrategyReconcile)),
+		)
+	}
+
+	if newCRS.Spec.DriftCorrectionIntervalSeconds != nil && newCRS.Spec.Strategy != string(addonsv1.ClusterResourceSetStrategyReconcile) {
+		allErrs = append(
+			allErrs,
+			field.Forbidden(field.NewPath("spec", "driftCorrectionIntervalSeconds"), fmt.Sprintf("driftCorrectionIntervalSeconds can only be set if strategy is %q", addonsv1.ClusterResourceSetStrategyReconcile)),
+		)
+	}
+
+	for i, name := range newCRS.Spec.DependsOn {
+		if name == newCRS.Name {
+			allErrs = append(
+				allErrs,
+				field.Invalid(field.NewPath("spec", "dependsOn").Index(i), name, "a ClusterResourceSet cannot depend on itself"),
+			)
+		}
+	}
+
 	if oldCRS != nil && !reflect.DeepEqual(oldCRS.Spec.ClusterSelector, newCRS.Spec.ClusterSelector) {
 		allErrs = append(
 			allErrs,
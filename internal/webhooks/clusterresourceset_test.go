@@ -21,6 +21,7 @@ import (
 
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 
 	addonsv1 "sigs.k8s.io/cluster-api/api/addons/v1beta2"
 	"sigs.k8s.io/cluster-api/internal/webhooks/util"
@@ -148,6 +149,167 @@ func TestClusterResourceSetStrategyImmutable(t *testing.T) {
 	}
 }
 
+func TestClusterResourceSetPruneValidation(t *testing.T) {
+	tests := []struct {
+		name      string
+		strategy  string
+		prune     *bool
+		expectErr bool
+	}{
+		{
+			name:      "should allow prune with Reconcile strategy",
+			strategy:  string(addonsv1.ClusterResourceSetStrategyReconcile),
+			prune:     ptr.To(true),
+			expectErr: false,
+		},
+		{
+			name:      "should allow prune set to false with ApplyOnce strategy",
+			strategy:  string(addonsv1.ClusterResourceSetStrategyApplyOnce),
+			prune:     ptr.To(false),
+			expectErr: false,
+		},
+		{
+			name:      "should not allow prune with ApplyOnce strategy",
+			strategy:  string(addonsv1.ClusterResourceSetStrategyApplyOnce),
+			prune:     ptr.To(true),
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			clusterResourceSet := &addonsv1.ClusterResourceSet{
+				Spec: addonsv1.ClusterResourceSetSpec{
+					ClusterSelector: metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							"test": "test",
+						},
+					},
+					Strategy: tt.strategy,
+					Prune:    tt.prune,
+				},
+			}
+			webhook := ClusterResourceSet{}
+
+			warnings, err := webhook.ValidateCreate(ctx, clusterResourceSet)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(warnings).To(BeEmpty())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(warnings).To(BeEmpty())
+		})
+	}
+}
+
+func TestClusterResourceSetDriftCorrectionIntervalValidation(t *testing.T) {
+	tests := []struct {
+		name                           string
+		strategy                       string
+		driftCorrectionIntervalSeconds *int32
+		expectErr                      bool
+	}{
+		{
+			name:                           "should allow driftCorrectionIntervalSeconds with Reconcile strategy",
+			strategy:                       string(addonsv1.ClusterResourceSetStrategyReconcile),
+			driftCorrectionIntervalSeconds: ptr.To[int32](600),
+			expectErr:                      false,
+		},
+		{
+			name:                           "should allow ApplyOnce strategy without driftCorrectionIntervalSeconds",
+			strategy:                       string(addonsv1.ClusterResourceSetStrategyApplyOnce),
+			driftCorrectionIntervalSeconds: nil,
+			expectErr:                      false,
+		},
+		{
+			name:                           "should not allow driftCorrectionIntervalSeconds with ApplyOnce strategy",
+			strategy:                       string(addonsv1.ClusterResourceSetStrategyApplyOnce),
+			driftCorrectionIntervalSeconds: ptr.To[int32](600),
+			expectErr:                      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			clusterResourceSet := &addonsv1.ClusterResourceSet{
+				Spec: addonsv1.ClusterResourceSetSpec{
+					ClusterSelector: metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							"test": "test",
+						},
+					},
+					Strategy:                       tt.strategy,
+					DriftCorrectionIntervalSeconds: tt.driftCorrectionIntervalSeconds,
+				},
+			}
+			webhook := ClusterResourceSet{}
+
+			warnings, err := webhook.ValidateCreate(ctx, clusterResourceSet)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(warnings).To(BeEmpty())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(warnings).To(BeEmpty())
+		})
+	}
+}
+
+func TestClusterResourceSetDependsOnValidation(t *testing.T) {
+	tests := []struct {
+		name      string
+		dependsOn []string
+		expectErr bool
+	}{
+		{
+			name:      "should allow depending on other ClusterResourceSets",
+			dependsOn: []string{"crds", "cni"},
+			expectErr: false,
+		},
+		{
+			name:      "should not allow depending on itself",
+			dependsOn: []string{"crds", "test-crs"},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			clusterResourceSet := &addonsv1.ClusterResourceSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-crs",
+				},
+				Spec: addonsv1.ClusterResourceSetSpec{
+					ClusterSelector: metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							"test": "test",
+						},
+					},
+					DependsOn: tt.dependsOn,
+				},
+			}
+			webhook := ClusterResourceSet{}
+
+			warnings, err := webhook.ValidateCreate(ctx, clusterResourceSet)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(warnings).To(BeEmpty())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(warnings).To(BeEmpty())
+		})
+	}
+}
+
 func TestClusterResourceSetClusterSelectorImmutable(t *testing.T) {
 	tests := []struct {
 		name               string
@@ -19,6 +19,7 @@ package webhooks
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"strconv"
 	"strings"
 
@@ -112,8 +113,9 @@ func (webhook *MachineDeployment) Default(ctx context.Context, obj runtime.Objec
 		m.Spec.Template.Labels = make(map[string]string)
 	}
 
-	// Default RollingUpdate strategy only if strategy type is RollingUpdate.
-	if m.Spec.Rollout.Strategy.Type == clusterv1.RollingUpdateMachineDeploymentStrategyType {
+	// Default RollingUpdate strategy only if strategy type is RollingUpdate or Canary.
+	if m.Spec.Rollout.Strategy.Type == clusterv1.RollingUpdateMachineDeploymentStrategyType ||
+		m.Spec.Rollout.Strategy.Type == clusterv1.CanaryMachineDeploymentStrategyType {
 		if m.Spec.Rollout.Strategy.RollingUpdate.MaxSurge == nil {
 			m.Spec.Rollout.Strategy.RollingUpdate.MaxSurge = ptr.To(intstr.FromInt32(1))
 		}
@@ -122,6 +124,13 @@ func (webhook *MachineDeployment) Default(ctx context.Context, obj runtime.Objec
 		}
 	}
 
+	// Default Canary strategy only if strategy type is Canary.
+	if m.Spec.Rollout.Strategy.Type == clusterv1.CanaryMachineDeploymentStrategyType {
+		if m.Spec.Rollout.Strategy.Canary.Replicas == nil {
+			m.Spec.Rollout.Strategy.Canary.Replicas = ptr.To[int32](1)
+		}
+	}
+
 	// If no selector has been provided, add label and selector for the
 	// MachineDeployment's name as a default way of providing uniqueness.
 	if len(m.Spec.Selector.MatchLabels) == 0 && len(m.Spec.Selector.MatchExpressions) == 0 {
@@ -222,6 +231,10 @@ func (webhook *MachineDeployment) validate(oldMD, newMD *clusterv1.MachineDeploy
 		}
 	}
 
+	if err := validateMachineSetDeletionOrder(newMD.Spec.Deletion.Order, specPath.Child("deletion", "order")); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
 	if oldMD != nil && oldMD.Spec.ClusterName != newMD.Spec.ClusterName {
 		allErrs = append(
 			allErrs,
@@ -244,6 +257,7 @@ func (webhook *MachineDeployment) validate(oldMD, newMD *clusterv1.MachineDeploy
 	}
 
 	allErrs = append(allErrs, validateRolloutStrategy(specPath.Child("rollout", "strategy"), newMD.Spec.Rollout.Strategy.RollingUpdate.MaxUnavailable, newMD.Spec.Rollout.Strategy.RollingUpdate.MaxSurge)...)
+	allErrs = append(allErrs, validateCanaryRolloutStrategy(specPath.Child("rollout", "strategy"), newMD.Spec.Rollout.Strategy)...)
 	allErrs = append(allErrs, validateRemediationMaxInFlight(specPath.Child("remediation"), newMD.Spec.Remediation.MaxInFlight)...)
 
 	if newMD.Spec.Template.Spec.Version != "" {
@@ -301,6 +315,29 @@ func validateRolloutStrategy(fldPath *field.Path, maxUnavailable, maxSurge *ints
 	return allErrs
 }
 
+func validateCanaryRolloutStrategy(fldPath *field.Path, strategy clusterv1.MachineDeploymentRolloutStrategy) field.ErrorList {
+	var allErrs field.ErrorList
+	if strategy.Type != clusterv1.CanaryMachineDeploymentStrategyType {
+		if !reflect.DeepEqual(strategy.Canary, clusterv1.MachineDeploymentRolloutStrategyCanary{}) {
+			allErrs = append(
+				allErrs,
+				field.Forbidden(fldPath.Child("canary"), fmt.Sprintf("can only be set if type is %s", clusterv1.CanaryMachineDeploymentStrategyType)),
+			)
+		}
+		return allErrs
+	}
+
+	for i := 1; i < len(strategy.Canary.Steps); i++ {
+		if strategy.Canary.Steps[i] <= strategy.Canary.Steps[i-1] {
+			allErrs = append(
+				allErrs,
+				field.Invalid(fldPath.Child("canary", "steps").Index(i), strategy.Canary.Steps[i], "steps must be in increasing order"),
+			)
+		}
+	}
+	return allErrs
+}
+
 func validateRemediationMaxInFlight(fldPath *field.Path, maxInFlight *intstr.IntOrString) field.ErrorList {
 	var allErrs field.ErrorList
 	if maxInFlight != nil {
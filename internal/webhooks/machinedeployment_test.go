@@ -88,6 +88,52 @@ func TestMachineDeploymentDefault(t *testing.T) {
 	g.Expect(md.Spec.Template.Spec.Version).To(Equal("v1.19.10"))
 }
 
+func TestMachineDeploymentDefaultCanaryStrategy(t *testing.T) {
+	g := NewWithT(t)
+	md := &clusterv1.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-md",
+		},
+		Spec: clusterv1.MachineDeploymentSpec{
+			ClusterName: "test-cluster",
+			Rollout: clusterv1.MachineDeploymentRolloutSpec{
+				Strategy: clusterv1.MachineDeploymentRolloutStrategy{
+					Type: clusterv1.CanaryMachineDeploymentStrategyType,
+				},
+			},
+			Template: clusterv1.MachineTemplateSpec{
+				Spec: clusterv1.MachineSpec{
+					ClusterName: "test-cluster",
+					Version:     "v1.19.10",
+					Bootstrap: clusterv1.Bootstrap{
+						DataSecretName: ptr.To("data-secret"),
+					},
+				},
+			},
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	g.Expect(clusterv1.AddToScheme(scheme)).To(Succeed())
+	webhook := &MachineDeployment{
+		decoder: admission.NewDecoder(scheme),
+	}
+
+	reqCtx := admission.NewContextWithRequest(ctx, admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: admissionv1.Create,
+		},
+	})
+	t.Run("for MachineDeployment", util.CustomDefaultValidateTest(reqCtx, md, webhook))
+
+	g.Expect(webhook.Default(reqCtx, md)).To(Succeed())
+
+	g.Expect(md.Spec.Rollout.Strategy.Type).To(Equal(clusterv1.CanaryMachineDeploymentStrategyType))
+	g.Expect(md.Spec.Rollout.Strategy.RollingUpdate.MaxSurge.IntValue()).To(Equal(1))
+	g.Expect(md.Spec.Rollout.Strategy.RollingUpdate.MaxUnavailable.IntValue()).To(Equal(0))
+	g.Expect(md.Spec.Rollout.Strategy.Canary.Replicas).To(Equal(ptr.To[int32](1)))
+}
+
 func TestMachineDeploymentBootstrapValidation(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -511,6 +557,43 @@ func TestMachineDeploymentValidation(t *testing.T) {
 			},
 			expectErr: false,
 		},
+		{
+			name:      "should not return error for valid canary steps",
+			selectors: map[string]string{"foo": "bar"},
+			labels:    map[string]string{"foo": "bar"},
+			strategy: clusterv1.MachineDeploymentRolloutStrategy{
+				Type: clusterv1.CanaryMachineDeploymentStrategyType,
+				Canary: clusterv1.MachineDeploymentRolloutStrategyCanary{
+					Replicas: ptr.To[int32](2),
+					Steps:    []int32{25, 50, 100},
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name:      "should return error for canary steps not in increasing order",
+			selectors: map[string]string{"foo": "bar"},
+			labels:    map[string]string{"foo": "bar"},
+			strategy: clusterv1.MachineDeploymentRolloutStrategy{
+				Type: clusterv1.CanaryMachineDeploymentStrategyType,
+				Canary: clusterv1.MachineDeploymentRolloutStrategyCanary{
+					Steps: []int32{50, 25},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name:      "should return error for canary config params with a strategy type other than Canary",
+			selectors: map[string]string{"foo": "bar"},
+			labels:    map[string]string{"foo": "bar"},
+			strategy: clusterv1.MachineDeploymentRolloutStrategy{
+				Type: clusterv1.RollingUpdateMachineDeploymentStrategyType,
+				Canary: clusterv1.MachineDeploymentRolloutStrategyCanary{
+					Replicas: ptr.To[int32](2),
+				},
+			},
+			expectErr: true,
+		},
 		{
 			name: "should not return error when MachineNamingSpec have {{ .random }}",
 			machineNaming: clusterv1.MachineNamingSpec{
@@ -19,6 +19,7 @@ package webhooks
 import (
 	"context"
 	"fmt"
+	"reflect"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -86,6 +87,35 @@ func (webhook *MachineDrainRule) validate(newMDR *clusterv1.MachineDrainRule) er
 		}
 	}
 
+	if !reflect.DeepEqual(newMDR.Spec.Drain.Timeout, clusterv1.MachineDrainRuleDrainTimeout{}) {
+		if newMDR.Spec.Drain.Behavior == clusterv1.MachineDrainRuleDrainBehaviorSkip {
+			allErrs = append(allErrs,
+				field.Forbidden(field.NewPath("spec", "drain", "timeout"),
+					fmt.Sprintf("timeout must not be set if drain behavior is %q", clusterv1.MachineDrainRuleDrainBehaviorSkip),
+				),
+			)
+		}
+		if newMDR.Spec.Drain.Timeout.Behavior == clusterv1.MachineDrainRuleDrainTimeoutBehaviorDrain &&
+			newMDR.Spec.Drain.Behavior != clusterv1.MachineDrainRuleDrainBehaviorWaitCompleted {
+			allErrs = append(allErrs,
+				field.Invalid(field.NewPath("spec", "drain", "timeout", "behavior"),
+					newMDR.Spec.Drain.Timeout.Behavior,
+					fmt.Sprintf("timeout behavior can only be %q if drain behavior is %q",
+						clusterv1.MachineDrainRuleDrainTimeoutBehaviorDrain, clusterv1.MachineDrainRuleDrainBehaviorWaitCompleted),
+				),
+			)
+		}
+	}
+
+	if !reflect.DeepEqual(newMDR.Spec.Drain.EvictionFallback, clusterv1.MachineDrainRuleEvictionFallback{}) &&
+		newMDR.Spec.Drain.Behavior != clusterv1.MachineDrainRuleDrainBehaviorDrain {
+		allErrs = append(allErrs,
+			field.Forbidden(field.NewPath("spec", "drain", "evictionFallback"),
+				fmt.Sprintf("evictionFallback can only be set if drain behavior is %q", clusterv1.MachineDrainRuleDrainBehaviorDrain),
+			),
+		)
+	}
+
 	allErrs = append(allErrs, ValidateMachineDrainRulesSelectors(newMDR)...)
 
 	if len(allErrs) == 0 {
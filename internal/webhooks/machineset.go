@@ -195,6 +195,10 @@ func (webhook *MachineSet) validate(oldMS, newMS *clusterv1.MachineSet) error {
 		}
 	}
 
+	if err := validateMachineSetDeletionOrder(newMS.Spec.Deletion.Order, specPath.Child("deletion", "order")); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
 	if oldMS != nil && oldMS.Spec.ClusterName != newMS.Spec.ClusterName {
 		allErrs = append(
 			allErrs,
@@ -294,6 +298,7 @@ func validateSkippedMachineSetPreflightChecks(o client.Object) *field.Error {
 		clusterv1.MachineSetPreflightCheckKubernetesVersionSkew,
 		clusterv1.MachineSetPreflightCheckControlPlaneIsStable,
 		clusterv1.MachineSetPreflightCheckControlPlaneVersionSkew,
+		clusterv1.MachineSetPreflightCheckBeforeMachineCreateHook,
 	)
 
 	skippedList := strings.Split(skip, ",")
@@ -314,6 +319,16 @@ func validateSkippedMachineSetPreflightChecks(o client.Object) *field.Error {
 	return nil
 }
 
+func validateMachineSetDeletionOrder(order clusterv1.MachineSetDeletionOrder, fldPath *field.Path) *field.Error {
+	if order == clusterv1.ExternalMachineSetDeletionOrder && !feature.Gates.Enabled(feature.RuntimeSDK) {
+		return field.Forbidden(
+			fldPath,
+			fmt.Sprintf("deletion order %s can be used only if the RuntimeSDK feature flag is enabled", order),
+		)
+	}
+	return nil
+}
+
 // calculateMachineSetReplicas calculates the default value of the replicas field.
 // The value will be calculated based on the following logic:
 // * if replicas is already set on newMS, keep the current value
@@ -25,6 +25,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	utilfeature "k8s.io/component-base/featuregate/testing"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -625,6 +626,54 @@ func TestValidateSkippedMachineSetPreflightChecks(t *testing.T) {
 	}
 }
 
+func TestValidateMachineSetDeletionOrder(t *testing.T) {
+	tests := []struct {
+		name              string
+		order             clusterv1.MachineSetDeletionOrder
+		runtimeSDKEnabled bool
+		expectErr         bool
+	}{
+		{
+			name:              "should pass if deletion order is not set",
+			order:             "",
+			runtimeSDKEnabled: false,
+			expectErr:         false,
+		},
+		{
+			name:              "should pass if deletion order is Oldest",
+			order:             clusterv1.OldestMachineSetDeletionOrder,
+			runtimeSDKEnabled: false,
+			expectErr:         false,
+		},
+		{
+			name:              "should pass if deletion order is External and the RuntimeSDK feature flag is enabled",
+			order:             clusterv1.ExternalMachineSetDeletionOrder,
+			runtimeSDKEnabled: true,
+			expectErr:         false,
+		},
+		{
+			name:              "should fail if deletion order is External and the RuntimeSDK feature flag is disabled",
+			order:             clusterv1.ExternalMachineSetDeletionOrder,
+			runtimeSDKEnabled: false,
+			expectErr:         true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.RuntimeSDK, tt.runtimeSDKEnabled)
+
+			g := NewWithT(t)
+			err := validateMachineSetDeletionOrder(tt.order, field.NewPath("spec", "deletion", "order"))
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+		})
+	}
+}
+
 func TestMachineSetTemplateMetadataValidation(t *testing.T) {
 	tests := []struct {
 		name        string
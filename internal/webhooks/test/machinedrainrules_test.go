@@ -119,6 +119,105 @@ func Test_validate(t *testing.T) {
 				"MachineDrainRule.cluster.x-k8s.io \"mdr\" is invalid: " +
 				"spec.drain.order: Invalid value: 5: order must not be set if drain behavior is \"Skip\" or \"WaitCompleted\"",
 		},
+		{
+			name: "Return error if timeout is set with drain behavior Skip",
+			machineDrainRule: &clusterv1.MachineDrainRule{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "mdr",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: clusterv1.MachineDrainRuleSpec{
+					Drain: clusterv1.MachineDrainRuleDrainConfig{
+						Behavior: clusterv1.MachineDrainRuleDrainBehaviorSkip,
+						Timeout: clusterv1.MachineDrainRuleDrainTimeout{
+							Seconds:  ptr.To[int32](600),
+							Behavior: clusterv1.MachineDrainRuleDrainTimeoutBehaviorSkip,
+						},
+					},
+				},
+			},
+			wantErr: "admission webhook \"validation.machinedrainrule.cluster.x-k8s.io\" denied the request: " +
+				"MachineDrainRule.cluster.x-k8s.io \"mdr\" is invalid: " +
+				"spec.drain.timeout: Forbidden: timeout must not be set if drain behavior is \"Skip\"",
+		},
+		{
+			name: "Return error if timeout behavior is Drain with drain behavior Drain",
+			machineDrainRule: &clusterv1.MachineDrainRule{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "mdr",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: clusterv1.MachineDrainRuleSpec{
+					Drain: clusterv1.MachineDrainRuleDrainConfig{
+						Behavior: clusterv1.MachineDrainRuleDrainBehaviorDrain,
+						Timeout: clusterv1.MachineDrainRuleDrainTimeout{
+							Seconds:  ptr.To[int32](600),
+							Behavior: clusterv1.MachineDrainRuleDrainTimeoutBehaviorDrain,
+						},
+					},
+				},
+			},
+			wantErr: "admission webhook \"validation.machinedrainrule.cluster.x-k8s.io\" denied the request: " +
+				"MachineDrainRule.cluster.x-k8s.io \"mdr\" is invalid: " +
+				"spec.drain.timeout.behavior: Invalid value: \"Drain\": timeout behavior can only be \"Drain\" if drain behavior is \"WaitCompleted\"",
+		},
+		{
+			name: "Return no error if timeout behavior is Drain with drain behavior WaitCompleted",
+			machineDrainRule: &clusterv1.MachineDrainRule{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "mdr",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: clusterv1.MachineDrainRuleSpec{
+					Drain: clusterv1.MachineDrainRuleDrainConfig{
+						Behavior: clusterv1.MachineDrainRuleDrainBehaviorWaitCompleted,
+						Timeout: clusterv1.MachineDrainRuleDrainTimeout{
+							Seconds:  ptr.To[int32](7200),
+							Behavior: clusterv1.MachineDrainRuleDrainTimeoutBehaviorDrain,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Return error if evictionFallback is set with drain behavior WaitCompleted",
+			machineDrainRule: &clusterv1.MachineDrainRule{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "mdr",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: clusterv1.MachineDrainRuleSpec{
+					Drain: clusterv1.MachineDrainRuleDrainConfig{
+						Behavior: clusterv1.MachineDrainRuleDrainBehaviorWaitCompleted,
+						EvictionFallback: clusterv1.MachineDrainRuleEvictionFallback{
+							DeleteAfterSeconds: ptr.To[int32](600),
+						},
+					},
+				},
+			},
+			wantErr: "admission webhook \"validation.machinedrainrule.cluster.x-k8s.io\" denied the request: " +
+				"MachineDrainRule.cluster.x-k8s.io \"mdr\" is invalid: " +
+				"spec.drain.evictionFallback: Forbidden: evictionFallback can only be set if drain behavior is \"Drain\"",
+		},
+		{
+			name: "Return no error if evictionFallback is set with drain behavior Drain",
+			machineDrainRule: &clusterv1.MachineDrainRule{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "mdr",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: clusterv1.MachineDrainRuleSpec{
+					Drain: clusterv1.MachineDrainRuleDrainConfig{
+						Behavior: clusterv1.MachineDrainRuleDrainBehaviorDrain,
+						Order:    ptr.To[int32](5),
+						EvictionFallback: clusterv1.MachineDrainRuleEvictionFallback{
+							DeleteAfterSeconds: ptr.To[int32](600),
+							GracePeriodSeconds: ptr.To[int32](30),
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "Return error for MachineDrainRules with invalid selector",
 			machineDrainRule: &clusterv1.MachineDrainRule{
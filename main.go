@@ -30,6 +30,7 @@ import (
 	"github.com/spf13/pflag"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	storagev1 "k8s.io/api/storage/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -132,6 +133,8 @@ var (
 	skipCRDMigrationPhases           []string
 	additionalSyncMachineLabels      []string
 	additionalSyncMachineAnnotations []string
+	machineDrainMaxConcurrentNodes   int
+	machineDrainMaxEvictionsPerSec   float64
 )
 
 func init() {
@@ -196,6 +199,12 @@ func InitFlags(fs *pflag.FlagSet) {
 		"Grace period after which remote conditions (e.g. `NodeHealthy`) are set to `Unknown`, "+
 			"the grace period starts from the last successful health probe to the workload cluster")
 
+	fs.IntVar(&machineDrainMaxConcurrentNodes, "machine-drain-max-concurrent-nodes-per-cluster", 0,
+		"Maximum number of Nodes that are drained concurrently in a workload cluster, 0 means no limit")
+
+	fs.Float64Var(&machineDrainMaxEvictionsPerSec, "machine-drain-max-evictions-per-second-per-cluster", 0,
+		"Maximum number of Pod evictions per second during Node drains in a workload cluster, 0 means no limit")
+
 	fs.IntVar(&clusterTopologyConcurrency, "clustertopology-concurrency", 10,
 		"Number of clusters to process simultaneously")
 
@@ -234,7 +243,7 @@ func InitFlags(fs *pflag.FlagSet) {
 		"List of MachineSet preflight checks that should be run. Per default all of them are enabled."+
 			"Set this flag to only enable a subset of them. The MachineSet preflight checks can be then also disabled"+
 			"on MachineSets via the 'machineset.cluster.x-k8s.io/skip-preflight-checks' annotation."+
-			"Valid values are: All or a list of KubeadmVersionSkew, KubernetesVersionSkew, ControlPlaneIsStable, ControlPlaneVersionSkew")
+			"Valid values are: All or a list of KubeadmVersionSkew, KubernetesVersionSkew, ControlPlaneIsStable, ControlPlaneVersionSkew, BeforeMachineCreateHook")
 
 	fs.StringSliceVar(&skipCRDMigrationPhases, "skip-crd-migration-phases", []string{},
 		"List of CRD migration phases to skip. Valid values are: StorageVersionMigration, CleanupManagedFields.")
@@ -334,6 +343,10 @@ func main() {
 		setupLog.Error(errors.Errorf("--remote-conditions-grace-period must be greater than --remote-connection-grace-period"), "Unable to start manager")
 		os.Exit(1)
 	}
+	if machineDrainMaxConcurrentNodes < 0 || machineDrainMaxEvictionsPerSec < 0 {
+		setupLog.Error(errors.Errorf("--machine-drain-max-concurrent-nodes-per-cluster and --machine-drain-max-evictions-per-second-per-cluster must not be negative"), "Unable to start manager")
+		os.Exit(1)
+	}
 	if remoteConditionsGracePeriod < 2*time.Minute {
 		// A minimum of 2m is enforced to ensure the ClusterCache always drops the connection before the grace period is reached.
 		// In the worst case the ClusterCache will take FailureThreshold x (Interval + Timeout) = 5x(10s+5s) = 75s to drop a
@@ -487,6 +500,8 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager, watchNamespaces map
 					// Don't cache PersistentVolumes and VolumeAttachments (we get/list them e.g. during wait for volumes to detach)
 					&storagev1.VolumeAttachment{},
 					&corev1.PersistentVolume{},
+					// Don't cache PodDisruptionBudgets (we list them e.g. to compute the LeastDisruptive MachineSet deletion order).
+					&policyv1.PodDisruptionBudget{},
 				},
 			},
 		},
@@ -677,6 +692,8 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager, watchNamespaces map
 		RemoteConditionsGracePeriod:      remoteConditionsGracePeriod,
 		AdditionalSyncMachineLabels:      additionalSyncMachineLabelRegexes,
 		AdditionalSyncMachineAnnotations: additionalSyncMachineAnnotationRegexes,
+		MaxConcurrentDrainsPerCluster:    machineDrainMaxConcurrentNodes,
+		MaxEvictionsPerSecondPerCluster:  machineDrainMaxEvictionsPerSec,
 	}).SetupWithManager(ctx, mgr, concurrency(machineConcurrency)); err != nil {
 		setupLog.Error(err, "Unable to create controller", "controller", "Machine")
 		os.Exit(1)
@@ -688,6 +705,7 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager, watchNamespaces map
 		clusterv1.MachineSetPreflightCheckKubernetesVersionSkew,
 		clusterv1.MachineSetPreflightCheckControlPlaneIsStable,
 		clusterv1.MachineSetPreflightCheckControlPlaneVersionSkew,
+		clusterv1.MachineSetPreflightCheckBeforeMachineCreateHook,
 	)
 	for _, c := range machineSetPreflightChecks {
 		if c == "" {
@@ -704,6 +722,7 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager, watchNamespaces map
 		Client:           mgr.GetClient(),
 		APIReader:        mgr.GetAPIReader(),
 		ClusterCache:     clusterCache,
+		RuntimeClient:    runtimeClient,
 		PreflightChecks:  machineSetPreflightChecksSet,
 		WatchFilterValue: watchFilterValue,
 	}).SetupWithManager(ctx, mgr, concurrency(machineSetConcurrency)); err != nil {
@@ -189,6 +189,8 @@ func NeedsClientCertRotation(configSecret *corev1.Secret, threshold time.Duratio
 }
 
 // RegenerateSecret creates and stores a new Kubeconfig in the given secret.
+// The new Kubeconfig trusts all the certificate authorities in the cluster CA secret, so it is also
+// used to pick up changes to the cluster CA secret, e.g. when the cluster CA is rotated.
 func RegenerateSecret(ctx context.Context, c client.Client, configSecret *corev1.Secret, options ...KubeConfigOption) error {
 	clusterName, _, err := secret.ParseSecretName(configSecret.Name)
 	if err != nil {
@@ -241,6 +243,13 @@ func generateKubeconfig(ctx context.Context, c client.Client, clusterName client
 		return nil, errors.Wrap(err, "failed to generate a kubeconfig")
 	}
 
+	// Trust all the certificate authorities trusted by the cluster CA, while the client certificate is signed by the
+	// certificate authority currently used for signing.
+	// Note: The cluster CA trusts more than one certificate authority while it is being rotated.
+	if trusted, ok := clusterCA.Data[secret.TrustedTLSCrtDataName]; ok {
+		cfg.Clusters[clusterName.Name].CertificateAuthorityData = trusted
+	}
+
 	out, err := clientcmd.Write(*cfg)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to serialize config to yaml")
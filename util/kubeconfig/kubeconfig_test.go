@@ -472,3 +472,45 @@ func TestRegenerateClientCerts(t *testing.T) {
 
 	g.Expect(newCert.NotAfter).To(BeTemporally(">", oldCert.NotAfter))
 }
+
+func TestRegenerateSecretWithCertificateAuthoritiesBundle(t *testing.T) {
+	g := NewWithT(t)
+	caKey, err := certs.NewPrivateKey()
+	g.Expect(err).ToNot(HaveOccurred())
+	caCert, err := getTestCACert(caKey)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	previousCAKey, err := certs.NewPrivateKey()
+	g.Expect(err).ToNot(HaveOccurred())
+	previousCACert, err := getTestCACert(previousCAKey)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	caBundle := append(certs.EncodeCertPEM(caCert), certs.EncodeCertPEM(previousCACert)...)
+	caSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test1-ca",
+			Namespace: "test",
+		},
+		Data: map[string][]byte{
+			secret.TLSKeyDataName:        certs.EncodePrivateKeyPEM(caKey),
+			secret.TLSCrtDataName:        certs.EncodeCertPEM(caCert),
+			secret.TrustedTLSCrtDataName: caBundle,
+		},
+	}
+
+	kubeconfigSecret := validSecret.DeepCopy()
+	c := fake.NewClientBuilder().WithObjects(kubeconfigSecret, caSecret).Build()
+
+	g.Expect(RegenerateSecret(ctx, c, kubeconfigSecret)).To(Succeed())
+
+	newSecret := &corev1.Secret{}
+	g.Expect(c.Get(ctx, util.ObjectKey(kubeconfigSecret), newSecret)).To(Succeed())
+	newConfig, err := clientcmd.Load(newSecret.Data[secret.KubeconfigDataName])
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// The kubeconfig trusts all the certificate authorities, while the client certificate is signed by the one used for signing.
+	g.Expect(newConfig.Clusters["test1"].CertificateAuthorityData).To(Equal(caBundle))
+	newCert, err := certs.DecodeCertPEM(newConfig.AuthInfos["test1-admin"].ClientCertificateData)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(newCert.CheckSignatureFrom(caCert)).To(Succeed())
+}
@@ -50,6 +50,10 @@ const (
 
 	// DefaultCACertificatesExpiryDays is the default expiry for CA certificates (10 years).
 	DefaultCACertificatesExpiryDays = 3650
+
+	// ClusterCATrustedCertFileName is the name of the file in the certificates directory containing all the
+	// certificate authorities trusted while the cluster CA is being rotated.
+	ClusterCATrustedCertFileName = "ca-bundle.crt"
 )
 
 var (
@@ -88,6 +92,7 @@ func NewCertificatesForInitialControlPlane(config *bootstrapv1.ClusterConfigurat
 			Purpose:                ClusterCA,
 			CertFile:               path.Join(certificatesDir, "ca.crt"),
 			KeyFile:                path.Join(certificatesDir, "ca.key"),
+			TrustedCertFile:        path.Join(certificatesDir, ClusterCATrustedCertFileName),
 			ValidityPeriodDays:     validityPeriodDays,
 			KeyEncryptionAlgorithm: keyEncryptionAlgorithm,
 		},
@@ -144,9 +149,10 @@ func NewControlPlaneJoinCerts(config *bootstrapv1.ClusterConfiguration) Certific
 
 	certificates := Certificates{
 		&Certificate{
-			Purpose:  ClusterCA,
-			CertFile: path.Join(certificatesDir, "ca.crt"),
-			KeyFile:  path.Join(certificatesDir, "ca.key"),
+			Purpose:         ClusterCA,
+			CertFile:        path.Join(certificatesDir, "ca.crt"),
+			KeyFile:         path.Join(certificatesDir, "ca.key"),
+			TrustedCertFile: path.Join(certificatesDir, ClusterCATrustedCertFileName),
 		},
 		&Certificate{
 			Purpose:  ServiceAccount,
@@ -347,11 +353,40 @@ type Certificate struct {
 	Secret                 *corev1.Secret
 	ValidityPeriodDays     int32
 	KeyEncryptionAlgorithm bootstrapv1.EncryptionAlgorithmType
+	// TrustedCertFile is the file where the certificate authorities trusted while the certificate authority is being
+	// rotated are written, if the certificate authority used for signing in CertFile must be a single certificate.
+	// If not set, the trusted certificate authorities are written in CertFile.
+	TrustedCertFile string
 }
 
-// Hashes hashes all the certificates stored in a CA certificate.
+// TrustedCertificates returns the certificate authorities trusted by the certificate.
+// Note: While the certificate authority is being rotated, they include the certificate authority used for
+// signing, which comes first, and the previous or the next certificate authority.
+func (c *Certificate) TrustedCertificates() []byte {
+	if c.Secret != nil {
+		if trusted, ok := c.Secret.Data[TrustedTLSCrtDataName]; ok {
+			return trusted
+		}
+	}
+	if c.KeyPair == nil {
+		return nil
+	}
+	return c.KeyPair.Cert
+}
+
+// IsRotating returns true if the certificate authority is being rotated, i.e. if it trusts other certificate
+// authorities in addition to the one used for signing.
+func (c *Certificate) IsRotating() bool {
+	if c.Secret == nil {
+		return false
+	}
+	_, ok := c.Secret.Data[TrustedTLSCrtDataName]
+	return ok
+}
+
+// Hashes hashes all the certificates trusted by a CA certificate.
 func (c *Certificate) Hashes() ([]string, error) {
-	certificates, err := cert.ParseCertsPEM(c.KeyPair.Cert)
+	certificates, err := cert.ParseCertsPEM(c.TrustedCertificates())
 	if err != nil {
 		return nil, errors.Wrapf(err, "unable to parse %s certificate", c.Purpose)
 	}
@@ -399,12 +434,26 @@ func (c *Certificate) AsFiles() []bootstrapv1.File {
 	}
 
 	if len(c.KeyPair.Cert) > 0 {
+		// Note: While the certificate authority is being rotated, the trusted certificate authorities are written
+		// in a separate file if TrustedCertFile is set, otherwise in CertFile, starting with the one used for signing.
+		certContent := c.TrustedCertificates()
+		if c.TrustedCertFile != "" {
+			certContent = c.KeyPair.Cert
+		}
 		out = append(out, bootstrapv1.File{
 			Path:        c.CertFile,
 			Owner:       rootOwnerValue,
 			Permissions: "0640",
-			Content:     string(c.KeyPair.Cert),
+			Content:     string(certContent),
 		})
+		if c.TrustedCertFile != "" && c.IsRotating() {
+			out = append(out, bootstrapv1.File{
+				Path:        c.TrustedCertFile,
+				Owner:       rootOwnerValue,
+				Permissions: "0640",
+				Content:     string(c.TrustedCertificates()),
+			})
+		}
 	}
 	if len(c.KeyPair.Key) > 0 {
 		out = append(out, bootstrapv1.File{
@@ -424,12 +473,7 @@ func (c *Certificate) Generate() error {
 		return nil
 	}
 
-	generator := generateCACert
-	if c.Purpose == ServiceAccount {
-		generator = generateServiceAccountKeys
-	}
-
-	kp, err := generator(c.ValidityPeriodDays, c.KeyEncryptionAlgorithm)
+	kp, err := c.generateKeyPair()
 	if err != nil {
 		return err
 	}
@@ -439,6 +483,15 @@ func (c *Certificate) Generate() error {
 	return nil
 }
 
+// generateKeyPair generates a key pair for the certificate, i.e. a certificate authority or service account keys.
+func (c *Certificate) generateKeyPair() (*certs.KeyPair, error) {
+	generator := generateCACert
+	if c.Purpose == ServiceAccount {
+		generator = generateServiceAccountKeys
+	}
+	return generator(c.ValidityPeriodDays, c.KeyEncryptionAlgorithm)
+}
+
 // AsFiles converts a slice of certificates into bootstrap files.
 func (c Certificates) AsFiles() []bootstrapv1.File {
 	certFiles := make([]bootstrapv1.File, 0)
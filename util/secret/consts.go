@@ -25,6 +25,18 @@ const (
 
 	// TLSCrtDataName is the key used to store a TLS certificate in the secret's data field.
 	TLSCrtDataName = "tls.crt"
+
+	// NextTLSKeyDataName is the key used to store the private key of the certificate authority
+	// a certificate authority is being rotated to in the secret's data field.
+	NextTLSKeyDataName = "next-tls.key"
+
+	// NextTLSCrtDataName is the key used to store the certificate of the certificate authority
+	// a certificate authority is being rotated to in the secret's data field.
+	NextTLSCrtDataName = "next-tls.crt"
+
+	// TrustedTLSCrtDataName is the key used to store all the certificate authorities trusted
+	// while a certificate authority is being rotated in the secret's data field.
+	TrustedTLSCrtDataName = "trusted-tls.crt"
 )
 
 // Purpose is the name to append to the secret generated for a cluster.
@@ -0,0 +1,168 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secret
+
+import (
+	"bytes"
+	"encoding/pem"
+
+	"github.com/pkg/errors"
+
+	"sigs.k8s.io/cluster-api/util/certs"
+)
+
+// The funcs in this file allow to rotate a certificate authority (or the service account keys) in multiple steps:
+// - AddNextCertificateAuthority generates a new certificate authority and adds it to the trusted ones.
+// - UseNextCertificateAuthority starts using the new certificate authority for signing.
+// - RemovePreviousCertificateAuthorities removes the old certificate authority from the trusted ones.
+// In every step, the TLSCrtDataName key of the Secret contains only the certificate authority used for signing,
+// matching the private key in the TLSKeyDataName key, because some components, e.g. the CSR signer of
+// kube-controller-manager, require a single certificate. While the rotation is in progress, all the trusted
+// certificate authorities are stored in the TrustedTLSCrtDataName key, starting with the one used for signing.
+// Machines created after a step pick up the certificate authorities of that step via bootstrap data.
+
+// AddNextCertificateAuthority generates a new certificate authority and adds it to the certificate authorities
+// trusted by the certificate, while the current certificate authority is still used for signing.
+// The trusted certificate authorities are stored in the TrustedTLSCrtDataName key of the Secret.
+// The new certificate authority is also stored in the NextTLSCrtDataName and NextTLSKeyDataName keys of the Secret.
+// It returns true if the Secret has been changed and must be persisted.
+func (c *Certificate) AddNextCertificateAuthority() (bool, error) {
+	if err := c.validateForRotation(); err != nil {
+		return false, err
+	}
+
+	// Nothing to do if the next certificate authority already exists.
+	if _, ok := c.Secret.Data[NextTLSKeyDataName]; ok {
+		return false, nil
+	}
+
+	next, err := c.generateKeyPair()
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to generate next certificate authority for %s certificate", c.Purpose)
+	}
+
+	c.Secret.Data[NextTLSCrtDataName] = next.Cert
+	c.Secret.Data[NextTLSKeyDataName] = next.Key
+	c.Secret.Data[TrustedTLSCrtDataName] = appendPEMBlocks(c.TrustedCertificates(), next.Cert)
+	return true, nil
+}
+
+// UseNextCertificateAuthority starts using the certificate authority added by AddNextCertificateAuthority for signing,
+// while the previous certificate authorities are still trusted by the certificate.
+// It returns true if the Secret has been changed and must be persisted.
+func (c *Certificate) UseNextCertificateAuthority() (bool, error) {
+	if err := c.validateForRotation(); err != nil {
+		return false, err
+	}
+
+	// Nothing to do if there is no next certificate authority, e.g. because it is already used for signing.
+	nextCrt, ok := c.Secret.Data[NextTLSCrtDataName]
+	if !ok {
+		return false, nil
+	}
+	nextKey, ok := c.Secret.Data[NextTLSKeyDataName]
+	if !ok {
+		return false, errors.Errorf("failed to use next certificate authority for %s certificate: missing data for key %s", c.Purpose, NextTLSKeyDataName)
+	}
+
+	c.Secret.Data[TrustedTLSCrtDataName] = appendPEMBlocks(nextCrt, removePEMBlocks(c.TrustedCertificates(), nextCrt))
+	c.Secret.Data[TLSCrtDataName] = nextCrt
+	c.Secret.Data[TLSKeyDataName] = nextKey
+	delete(c.Secret.Data, NextTLSCrtDataName)
+	delete(c.Secret.Data, NextTLSKeyDataName)
+	c.setKeyPairFromSecret()
+	return true, nil
+}
+
+// RemovePreviousCertificateAuthorities removes all the certificate authorities trusted by the certificate
+// except the one used for signing.
+// It returns true if the Secret has been changed and must be persisted.
+func (c *Certificate) RemovePreviousCertificateAuthorities() (bool, error) {
+	if err := c.validateForRotation(); err != nil {
+		return false, err
+	}
+
+	// Removing the previous certificate authorities before using the next one for signing would remove the next one.
+	if _, ok := c.Secret.Data[NextTLSKeyDataName]; ok {
+		return false, errors.Errorf("failed to remove previous certificate authorities for %s certificate: next certificate authority is not used for signing yet", c.Purpose)
+	}
+
+	if _, ok := c.Secret.Data[TrustedTLSCrtDataName]; !ok {
+		return false, nil
+	}
+
+	delete(c.Secret.Data, TrustedTLSCrtDataName)
+	return true, nil
+}
+
+func (c *Certificate) validateForRotation() error {
+	if c.External || c.Purpose == APIServerEtcdClient {
+		return errors.Errorf("%s certificate can't be rotated because it is not managed by Cluster API", c.Purpose)
+	}
+	if c.Secret == nil || c.KeyPair == nil || len(c.KeyPair.Key) == 0 {
+		return errors.Wrapf(ErrMissingCertificate, "%s certificate can't be rotated", c.Purpose)
+	}
+	return nil
+}
+
+func (c *Certificate) setKeyPairFromSecret() {
+	c.KeyPair = &certs.KeyPair{
+		Cert: c.Secret.Data[TLSCrtDataName],
+		Key:  c.Secret.Data[TLSKeyDataName],
+	}
+}
+
+// decodePEMBlocks returns all the PEM blocks in data.
+func decodePEMBlocks(data []byte) []*pem.Block {
+	var blocks []*pem.Block
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return blocks
+		}
+		blocks = append(blocks, block)
+	}
+}
+
+// appendPEMBlocks returns the PEM blocks in data followed by the PEM blocks in other.
+func appendPEMBlocks(data, other []byte) []byte {
+	out := []byte{}
+	for _, block := range append(decodePEMBlocks(data), decodePEMBlocks(other)...) {
+		out = append(out, pem.EncodeToMemory(block)...)
+	}
+	return out
+}
+
+// removePEMBlocks returns the PEM blocks in data which are not in other.
+func removePEMBlocks(data, other []byte) []byte {
+	toRemove := decodePEMBlocks(other)
+	out := []byte{}
+	for _, block := range decodePEMBlocks(data) {
+		remove := false
+		for _, r := range toRemove {
+			if bytes.Equal(block.Bytes, r.Bytes) {
+				remove = true
+				break
+			}
+		}
+		if !remove {
+			out = append(out, pem.EncodeToMemory(block)...)
+		}
+	}
+	return out
+}
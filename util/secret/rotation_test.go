@@ -0,0 +1,201 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secret_test
+
+import (
+	"encoding/pem"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	"sigs.k8s.io/cluster-api/util/secret"
+)
+
+func TestCertificateAuthorityRotation(t *testing.T) {
+	tests := []struct {
+		name    string
+		purpose secret.Purpose
+	}{
+		{
+			name:    "Rotate cluster CA",
+			purpose: secret.ClusterCA,
+		},
+		{
+			name:    "Rotate etcd CA",
+			purpose: secret.EtcdCA,
+		},
+		{
+			name:    "Rotate front-proxy CA",
+			purpose: secret.FrontProxyCA,
+		},
+		{
+			name:    "Rotate service account keys",
+			purpose: secret.ServiceAccount,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			certificates := secret.NewCertificatesForInitialControlPlane(&bootstrapv1.ClusterConfiguration{})
+			g.Expect(certificates.Generate()).To(Succeed())
+			c := certificates.GetByPurpose(tt.purpose)
+			c.Secret = c.AsSecret(client.ObjectKey{Namespace: "default", Name: "test"}, metav1.OwnerReference{})
+			oldCrt := c.Secret.Data[secret.TLSCrtDataName]
+			oldKey := c.Secret.Data[secret.TLSKeyDataName]
+
+			// Removing previous certificate authorities before using the next one for signing must fail.
+			_, err := c.AddNextCertificateAuthority()
+			g.Expect(err).ToNot(HaveOccurred())
+			_, err = c.RemovePreviousCertificateAuthorities()
+			g.Expect(err).To(HaveOccurred())
+
+			// The next certificate authority is added to the trusted ones, the old one is still used for signing.
+			nextCrt := c.Secret.Data[secret.NextTLSCrtDataName]
+			nextKey := c.Secret.Data[secret.NextTLSKeyDataName]
+			g.Expect(nextCrt).ToNot(BeEmpty())
+			g.Expect(nextKey).ToNot(BeEmpty())
+			g.Expect(nextKey).ToNot(Equal(oldKey))
+			g.Expect(decodePEMBlocks(c.Secret.Data[secret.TrustedTLSCrtDataName])).To(Equal(append(decodePEMBlocks(oldCrt), decodePEMBlocks(nextCrt)...)))
+			g.Expect(c.Secret.Data[secret.TLSCrtDataName]).To(Equal(oldCrt))
+			g.Expect(c.Secret.Data[secret.TLSKeyDataName]).To(Equal(oldKey))
+			g.Expect(c.KeyPair.Cert).To(Equal(oldCrt))
+			g.Expect(c.TrustedCertificates()).To(Equal(c.Secret.Data[secret.TrustedTLSCrtDataName]))
+			g.Expect(c.IsRotating()).To(BeTrue())
+
+			changed, err := c.AddNextCertificateAuthority()
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(changed).To(BeFalse())
+
+			// The next certificate authority is used for signing, the old one is still trusted.
+			changed, err = c.UseNextCertificateAuthority()
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(changed).To(BeTrue())
+			g.Expect(decodePEMBlocks(c.Secret.Data[secret.TrustedTLSCrtDataName])).To(Equal(append(decodePEMBlocks(nextCrt), decodePEMBlocks(oldCrt)...)))
+			g.Expect(c.Secret.Data[secret.TLSCrtDataName]).To(Equal(nextCrt))
+			g.Expect(c.Secret.Data[secret.TLSKeyDataName]).To(Equal(nextKey))
+			g.Expect(c.Secret.Data).ToNot(HaveKey(secret.NextTLSCrtDataName))
+			g.Expect(c.Secret.Data).ToNot(HaveKey(secret.NextTLSKeyDataName))
+			g.Expect(c.KeyPair.Cert).To(Equal(nextCrt))
+			g.Expect(c.KeyPair.Key).To(Equal(nextKey))
+
+			changed, err = c.UseNextCertificateAuthority()
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(changed).To(BeFalse())
+
+			// The old certificate authority is removed.
+			changed, err = c.RemovePreviousCertificateAuthorities()
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(changed).To(BeTrue())
+			g.Expect(c.Secret.Data).ToNot(HaveKey(secret.TrustedTLSCrtDataName))
+			g.Expect(c.Secret.Data[secret.TLSCrtDataName]).To(Equal(nextCrt))
+			g.Expect(c.Secret.Data[secret.TLSKeyDataName]).To(Equal(nextKey))
+			g.Expect(c.TrustedCertificates()).To(Equal(nextCrt))
+			g.Expect(c.IsRotating()).To(BeFalse())
+
+			changed, err = c.RemovePreviousCertificateAuthorities()
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(changed).To(BeFalse())
+		})
+	}
+}
+
+func TestCertificateAuthorityRotationFailsForExternalCertificates(t *testing.T) {
+	g := NewWithT(t)
+
+	certificates := secret.NewCertificatesForInitialControlPlane(&bootstrapv1.ClusterConfiguration{
+		Etcd: bootstrapv1.Etcd{
+			External: bootstrapv1.ExternalEtcd{
+				Endpoints: []string{"1.2.3.4"},
+			},
+		},
+	})
+	c := certificates.GetByPurpose(secret.EtcdCA)
+
+	_, err := c.AddNextCertificateAuthority()
+	g.Expect(err).To(HaveOccurred())
+	_, err = c.UseNextCertificateAuthority()
+	g.Expect(err).To(HaveOccurred())
+	_, err = c.RemovePreviousCertificateAuthorities()
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestCertificateAuthorityRotationAsFiles(t *testing.T) {
+	tests := []struct {
+		name                string
+		purpose             secret.Purpose
+		wantCertFile        string
+		wantTrustedCertFile string
+	}{
+		{
+			name:                "Cluster CA trusted certificate authorities are written in a separate file",
+			purpose:             secret.ClusterCA,
+			wantCertFile:        "/etc/kubernetes/pki/ca.crt",
+			wantTrustedCertFile: "/etc/kubernetes/pki/ca-bundle.crt",
+		},
+		{
+			name:         "Etcd CA trusted certificate authorities are written in the certificate file",
+			purpose:      secret.EtcdCA,
+			wantCertFile: "/etc/kubernetes/pki/etcd/ca.crt",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			certificates := secret.NewControlPlaneJoinCerts(&bootstrapv1.ClusterConfiguration{})
+			c := certificates.GetByPurpose(tt.purpose)
+			g.Expect(c.Generate()).To(Succeed())
+			c.Secret = c.AsSecret(client.ObjectKey{Namespace: "default", Name: "test"}, metav1.OwnerReference{})
+			g.Expect(c.AsFiles()).To(HaveLen(2))
+
+			_, err := c.AddNextCertificateAuthority()
+			g.Expect(err).ToNot(HaveOccurred())
+			_, err = c.UseNextCertificateAuthority()
+			g.Expect(err).ToNot(HaveOccurred())
+
+			files := map[string]string{}
+			for _, f := range c.AsFiles() {
+				files[f.Path] = f.Content
+			}
+			trusted := string(c.Secret.Data[secret.TrustedTLSCrtDataName])
+			if tt.wantTrustedCertFile != "" {
+				g.Expect(files).To(HaveLen(3))
+				g.Expect(files).To(HaveKeyWithValue(tt.wantCertFile, string(c.Secret.Data[secret.TLSCrtDataName])))
+				g.Expect(files).To(HaveKeyWithValue(tt.wantTrustedCertFile, trusted))
+				return
+			}
+			g.Expect(files).To(HaveLen(2))
+			g.Expect(files).To(HaveKeyWithValue(tt.wantCertFile, trusted))
+		})
+	}
+}
+
+func decodePEMBlocks(data []byte) []*pem.Block {
+	var blocks []*pem.Block
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return blocks
+		}
+		blocks = append(blocks, block)
+	}
+}